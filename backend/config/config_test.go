@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 )
 
 func TestLoad_Defaults(t *testing.T) {
@@ -41,3 +42,226 @@ func TestLoad_FromEnv(t *testing.T) {
 	}
 }
 
+
+func TestLoad_AutoEscalationActions(t *testing.T) {
+	os.Setenv("AUTO_ESCALATION_ACTIONS", "true")
+	defer os.Unsetenv("AUTO_ESCALATION_ACTIONS")
+
+	if cfg := Load(); !cfg.AutoEscalationActions {
+		t.Error("expected AutoEscalationActions=true")
+	}
+
+	os.Setenv("AUTO_ESCALATION_ACTIONS", "not-a-bool")
+	if cfg := Load(); cfg.AutoEscalationActions {
+		t.Error("expected invalid value to fall back to false")
+	}
+}
+
+func TestLoad_RedactPIIDefaultsOnInProduction(t *testing.T) {
+	os.Setenv("ENVIRONMENT", "production")
+	defer os.Unsetenv("ENVIRONMENT")
+
+	if cfg := Load(); !cfg.RedactPII {
+		t.Error("expected RedactPII=true in production")
+	}
+
+	os.Setenv("REDACT_PII", "false")
+	defer os.Unsetenv("REDACT_PII")
+	if cfg := Load(); cfg.RedactPII {
+		t.Error("expected REDACT_PII=false to override the production default")
+	}
+
+	os.Setenv("ENVIRONMENT", "development")
+	os.Unsetenv("REDACT_PII")
+	if cfg := Load(); cfg.RedactPII {
+		t.Error("expected RedactPII=false in development")
+	}
+}
+
+func TestValidate_Production(t *testing.T) {
+	strongSecret := "0123456789abcdef0123456789abcdef"
+
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{"development allows defaults", Config{Environment: "development", JWTSecret: defaultJWTSecret,
+			CORSOrigins: []string{"http://localhost:5173"}}, false},
+		{"production default secret", Config{Environment: "production", JWTSecret: defaultJWTSecret,
+			CORSOrigins: []string{"https://app.example.com"}}, true},
+		{"production short secret", Config{Environment: "production", JWTSecret: "short",
+			CORSOrigins: []string{"https://app.example.com"}}, true},
+		{"production localhost origin", Config{Environment: "production", JWTSecret: strongSecret,
+			CORSOrigins: []string{"http://localhost:5173"}}, true},
+		{"production secure", Config{Environment: "production", JWTSecret: strongSecret,
+			CORSOrigins: []string{"https://app.example.com"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_GinReleaseModeIsProduction(t *testing.T) {
+	os.Setenv("GIN_MODE", "release")
+	defer os.Unsetenv("GIN_MODE")
+
+	cfg := Config{Environment: "development", JWTSecret: defaultJWTSecret}
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected GIN_MODE=release to enforce production checks")
+	}
+}
+
+func TestLoad_ProductionOmitsLocalhostDevOrigins(t *testing.T) {
+	os.Setenv("ENVIRONMENT", "production")
+	os.Setenv("CORS_ORIGIN", "https://app.example.com")
+	defer func() {
+		os.Unsetenv("ENVIRONMENT")
+		os.Unsetenv("CORS_ORIGIN")
+	}()
+
+	cfg := Load()
+	if len(cfg.CORSOrigins) != 1 || cfg.CORSOrigins[0] != "https://app.example.com" {
+		t.Errorf("expected only the configured origin in production, got %v", cfg.CORSOrigins)
+	}
+}
+
+func TestLoad_EscalationRulesFromEnv(t *testing.T) {
+	if rules := Load().Escalation; rules.CycleLengthDays != 14 || rules.CriticalHighRiskCycles != 3 {
+		t.Errorf("expected default 14-day cycles and critical at 3, got %+v", rules)
+	}
+
+	os.Setenv("ESCALATION_CYCLE_WEEKS", "1")
+	os.Setenv("ESCALATION_CRITICAL_CYCLES", "4")
+	os.Setenv("ESCALATION_GATING_STATUSES", "Regional Legal, Tax Review,")
+	defer func() {
+		os.Unsetenv("ESCALATION_CYCLE_WEEKS")
+		os.Unsetenv("ESCALATION_CRITICAL_CYCLES")
+		os.Unsetenv("ESCALATION_GATING_STATUSES")
+	}()
+
+	rules := Load().Escalation
+	if rules.CycleLengthDays != 7 {
+		t.Errorf("expected 7-day cycles, got %d", rules.CycleLengthDays)
+	}
+	if rules.CriticalHighRiskCycles != 4 {
+		t.Errorf("expected critical at 4 cycles, got %d", rules.CriticalHighRiskCycles)
+	}
+	if rules.ExecSteerCoHighRiskCycles != 2 {
+		t.Errorf("expected unset SteerCo threshold to keep its default, got %d", rules.ExecSteerCoHighRiskCycles)
+	}
+	if got := rules.AutoEscalateGatingStatuses; len(got) != 2 || got[1] != "Tax Review" {
+		t.Errorf("unexpected gating statuses %q", got)
+	}
+}
+
+func TestLoad_TokenTTLs(t *testing.T) {
+	cfg := Load()
+	if cfg.AccessTokenTTL != 15*time.Minute || cfg.RefreshTokenTTL != 30*24*time.Hour {
+		t.Errorf("default TTLs = %v / %v, want 15m / 720h", cfg.AccessTokenTTL, cfg.RefreshTokenTTL)
+	}
+
+	os.Setenv("ACCESS_TOKEN_TTL_MINUTES", "5")
+	os.Setenv("REFRESH_TOKEN_TTL_DAYS", "7")
+	defer func() {
+		os.Unsetenv("ACCESS_TOKEN_TTL_MINUTES")
+		os.Unsetenv("REFRESH_TOKEN_TTL_DAYS")
+	}()
+
+	cfg = Load()
+	if cfg.AccessTokenTTL != 5*time.Minute || cfg.RefreshTokenTTL != 7*24*time.Hour {
+		t.Errorf("TTLs = %v / %v, want 5m / 168h", cfg.AccessTokenTTL, cfg.RefreshTokenTTL)
+	}
+}
+
+func TestLoad_FeedbackThemeAliases(t *testing.T) {
+	if got := Load().FeedbackThemeAliases["on-boarding"]; got != "onboarding" {
+		t.Errorf("expected default on-boarding alias, got %q", got)
+	}
+
+	os.Setenv("FEEDBACK_THEME_ALIASES", "UX=usability, docs = guides, malformed,=empty")
+	defer os.Unsetenv("FEEDBACK_THEME_ALIASES")
+
+	aliases := Load().FeedbackThemeAliases
+	if aliases["UX"] != "usability" || aliases["docs"] != "guides" {
+		t.Errorf("expected env aliases to be added and override defaults, got %v", aliases)
+	}
+	if _, ok := aliases["malformed"]; ok {
+		t.Error("expected a pair without = to be skipped")
+	}
+	if _, ok := aliases[""]; ok {
+		t.Error("expected a pair without a variant to be skipped")
+	}
+}
+
+func TestLoad_ShutdownTimings(t *testing.T) {
+	os.Unsetenv("ENVIRONMENT")
+	cfg := Load()
+	if cfg.ShutdownDrainDelay != 0 || cfg.ShutdownTimeout != 30*time.Second {
+		t.Errorf("development shutdown = %v drain / %v timeout, want 0 / 30s", cfg.ShutdownDrainDelay, cfg.ShutdownTimeout)
+	}
+
+	os.Setenv("ENVIRONMENT", "production")
+	defer os.Unsetenv("ENVIRONMENT")
+	if cfg := Load(); cfg.ShutdownDrainDelay != 5*time.Second {
+		t.Errorf("production drain = %v, want 5s", cfg.ShutdownDrainDelay)
+	}
+
+	os.Setenv("SHUTDOWN_DRAIN_SECONDS", "10")
+	os.Setenv("SHUTDOWN_TIMEOUT_SECONDS", "60")
+	defer func() {
+		os.Unsetenv("SHUTDOWN_DRAIN_SECONDS")
+		os.Unsetenv("SHUTDOWN_TIMEOUT_SECONDS")
+	}()
+	if cfg := Load(); cfg.ShutdownDrainDelay != 10*time.Second || cfg.ShutdownTimeout != time.Minute {
+		t.Errorf("shutdown = %v drain / %v timeout, want 10s / 1m", cfg.ShutdownDrainDelay, cfg.ShutdownTimeout)
+	}
+}
+
+func TestLoad_DBConnectRetry(t *testing.T) {
+	if cfg := Load(); cfg.DBConnectMaxAttempts != 10 || cfg.DBConnectTimeout != time.Minute {
+		t.Errorf("defaults = %d attempts / %v, want 10 / 1m", cfg.DBConnectMaxAttempts, cfg.DBConnectTimeout)
+	}
+
+	os.Setenv("DB_CONNECT_MAX_ATTEMPTS", "3")
+	os.Setenv("DB_CONNECT_TIMEOUT_SECONDS", "20")
+	defer func() {
+		os.Unsetenv("DB_CONNECT_MAX_ATTEMPTS")
+		os.Unsetenv("DB_CONNECT_TIMEOUT_SECONDS")
+	}()
+	if cfg := Load(); cfg.DBConnectMaxAttempts != 3 || cfg.DBConnectTimeout != 20*time.Second {
+		t.Errorf("got %d attempts / %v, want 3 / 20s", cfg.DBConnectMaxAttempts, cfg.DBConnectTimeout)
+	}
+}
+
+func TestLoad_DBLogging(t *testing.T) {
+	if cfg := Load(); cfg.DBLogLevel != "info" || cfg.DBSlowQueryThreshold != 500*time.Millisecond {
+		t.Errorf("defaults = %s / %v, want info / 500ms", cfg.DBLogLevel, cfg.DBSlowQueryThreshold)
+	}
+
+	os.Setenv("ENVIRONMENT", "production")
+	defer os.Unsetenv("ENVIRONMENT")
+	if cfg := Load(); cfg.DBLogLevel != "warn" {
+		t.Errorf("production level = %s, want warn", cfg.DBLogLevel)
+	}
+
+	os.Setenv("DB_LOG_LEVEL", "Error")
+	os.Setenv("DB_SLOW_QUERY_MS", "250")
+	defer func() {
+		os.Unsetenv("DB_LOG_LEVEL")
+		os.Unsetenv("DB_SLOW_QUERY_MS")
+	}()
+	if cfg := Load(); cfg.DBLogLevel != "error" || cfg.DBSlowQueryThreshold != 250*time.Millisecond {
+		t.Errorf("got %s / %v, want error / 250ms", cfg.DBLogLevel, cfg.DBSlowQueryThreshold)
+	}
+
+	os.Setenv("DB_LOG_LEVEL", "verbose")
+	if cfg := Load(); cfg.DBLogLevel != "warn" {
+		t.Errorf("unknown level = %s, want the production default", cfg.DBLogLevel)
+	}
+}
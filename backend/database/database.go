@@ -2,19 +2,50 @@ package database
 
 import (
 	"log"
+	"strings"
+	"sync"
 
 	"github.com/pauly7610/studio-pilot-vision/backend/models"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
 )
 
 var DB *gorm.DB
 
-func Connect(databaseURL string) error {
+// Models are the tables managed by Migrate
+var Models = []interface{}{
+	&models.Product{},
+	&models.ProductReadiness{},
+	&models.ProductMetric{},
+	&models.ProductCompliance{},
+	&models.ProductPartner{},
+	&models.ProductFeedback{},
+	&models.ProductPrediction{},
+	&models.ProductMarketEvidence{},
+	&models.SalesTraining{},
+	&models.ProductAction{},
+	&models.ActionComment{},
+	&models.Profile{},
+	&models.ProductDependency{},
+	&models.ProductReadinessHistory{},
+	&models.ProductEscalation{},
+	&models.TransitionItem{},
+	&models.ProductOwnershipChange{},
+	&models.ProductLifecycleEvent{},
+	&models.PortfolioRiskSnapshot{},
+	&models.RefreshToken{},
+	&models.AuditLog{},
+	&models.WebhookSubscription{},
+	&models.WebhookDelivery{},
+}
+
+// Connect opens the database, logging SQL as opts configure
+func Connect(databaseURL string, opts LogOptions) error {
 	var err error
 	DB, err = gorm.Open(postgres.Open(databaseURL), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+		Logger: newLogger(stdoutWriter(), opts),
 	})
 	if err != nil {
 		return err
@@ -27,32 +58,206 @@ func Connect(databaseURL string) error {
 func Migrate() error {
 	log.Println("Running database migrations...")
 
-	err := DB.AutoMigrate(
-		&models.Product{},
-		&models.ProductReadiness{},
-		&models.ProductMetric{},
-		&models.ProductCompliance{},
-		&models.ProductPartner{},
-		&models.ProductFeedback{},
-		&models.ProductPrediction{},
-		&models.ProductMarketEvidence{},
-		&models.SalesTraining{},
-		&models.ProductAction{},
-		&models.Profile{},
-		&models.ProductDependency{},
-		&models.ProductReadinessHistory{},
-		&models.ProductEscalation{},
-		&models.TransitionItem{},
-	)
+	// Collapse duplicate daily metrics before the (product_id, date) unique
+	// index is created, keeping the most recently written row
+	if DB.Migrator().HasTable(&models.ProductMetric{}) {
+		err := DB.Exec(`DELETE FROM product_metrics a
+			USING product_metrics b
+			WHERE a.product_id = b.product_id AND a.date = b.date
+			AND (a.created_at, a.id) < (b.created_at, b.id)`).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	// Clear references the new foreign keys would reject: comments on
+	// deleted actions, and links to deleted feedback or escalations
+	if DB.Migrator().HasTable(&models.ActionComment{}) {
+		err := DB.Exec(`DELETE FROM action_comments c
+			WHERE NOT EXISTS (SELECT 1 FROM product_actions a WHERE a.id = c.action_id)`).Error
+		if err != nil {
+			return err
+		}
+	}
+	if DB.Migrator().HasTable(&models.ProductAction{}) {
+		for column, table := range map[string]string{
+			"linked_feedback_id":   "product_feedback",
+			"linked_escalation_id": "product_escalations",
+		} {
+			if !DB.Migrator().HasTable(table) {
+				continue
+			}
+			err := DB.Exec(`UPDATE product_actions a SET ` + column + ` = NULL
+				WHERE ` + column + ` IS NOT NULL
+				AND NOT EXISTS (SELECT 1 FROM ` + table + ` t WHERE t.id = a.` + column + `)`).Error
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// Map free-text partner integration statuses onto the enum before the
+	// column is narrowed
+	if DB.Migrator().HasTable(&models.ProductPartner{}) {
+		if err := migrateIntegrationStatuses(DB); err != nil {
+			return err
+		}
+	}
+
+	err := DB.AutoMigrate(Models...)
 
 	if err != nil {
 		return err
 	}
 
+	if err := syncForeignKeyActions(); err != nil {
+		return err
+	}
+
+	// Tag readiness history written before the ISO week and year were
+	// derived on create
+	err = DB.Exec(`UPDATE product_readiness_history
+		SET week_number = EXTRACT(WEEK FROM recorded_at AT TIME ZONE 'UTC'),
+			year = EXTRACT(ISOYEAR FROM recorded_at AT TIME ZONE 'UTC')
+		WHERE (week_number IS NULL OR year IS NULL) AND recorded_at IS NOT NULL`).Error
+	if err != nil {
+		return err
+	}
+
+	// Start updated_at, added for conditional GETs, from when each row was
+	// first written
+	for table, written := range map[string]string{
+		"product_readiness":   "evaluated_at",
+		"product_predictions": "scored_at",
+		"product_feedback":    "created_at",
+		"product_metrics":     "created_at",
+	} {
+		err = DB.Exec(`UPDATE ` + table + ` SET updated_at = ` + written + ` WHERE updated_at IS NULL`).Error
+		if err != nil {
+			return err
+		}
+	}
+
 	log.Println("Database migrations completed")
 	return nil
 }
 
+// migrateIntegrationStatuses rewrites partner integration statuses outside
+// the enum, including unset ones, to their best-effort enum value
+func migrateIntegrationStatuses(db *gorm.DB) error {
+	var legacy []struct {
+		IntegrationStatus *string
+	}
+	err := db.Model(&models.ProductPartner{}).
+		Distinct("integration_status").
+		Where("integration_status IS NULL OR integration_status NOT IN ?", models.IntegrationStatuses).
+		Scan(&legacy).Error
+	if err != nil {
+		return err
+	}
+
+	for _, row := range legacy {
+		query := db.Model(&models.ProductPartner{}).Where("integration_status IS NULL")
+		text := ""
+		if row.IntegrationStatus != nil {
+			text = *row.IntegrationStatus
+			query = db.Model(&models.ProductPartner{}).Where("integration_status = ?", text)
+		}
+		status := models.IntegrationStatusFromText(text)
+		result := query.UpdateColumn("integration_status", status)
+		if result.Error != nil {
+			return result.Error
+		}
+		log.Printf("Mapped %d partners with integration status %q to %s", result.RowsAffected, text, status)
+	}
+	return nil
+}
+
+// pgDeleteActions maps ON DELETE actions to pg_constraint.confdeltype
+var pgDeleteActions = map[string]string{
+	"NO ACTION":   "a",
+	"RESTRICT":    "r",
+	"CASCADE":     "c",
+	"SET NULL":    "n",
+	"SET DEFAULT": "d",
+}
+
+// foreignKeys returns the foreign key constraints declared on Models
+func foreignKeys() ([]*schema.Constraint, error) {
+	var constraints []*schema.Constraint
+	seen := map[string]bool{}
+	for _, model := range Models {
+		s, err := schema.Parse(model, &sync.Map{}, schema.NamingStrategy{})
+		if err != nil {
+			return nil, err
+		}
+		for _, rel := range s.Relationships.Relations {
+			// The same constraint can be reached from more than one model
+			if constraint := rel.ParseConstraint(); constraint != nil && !seen[constraint.Name] {
+				seen[constraint.Name] = true
+				constraints = append(constraints, constraint)
+			}
+		}
+	}
+	return constraints, nil
+}
+
+// syncForeignKeyActions recreates foreign keys whose ON DELETE action
+// differs from the model's. AutoMigrate only adds missing constraints, so
+// ones created before the action was declared are otherwise never updated.
+func syncForeignKeyActions() error {
+	constraints, err := foreignKeys()
+	if err != nil {
+		return err
+	}
+
+	for _, constraint := range constraints {
+		want, ok := pgDeleteActions[strings.ToUpper(constraint.OnDelete)]
+		if !ok {
+			continue
+		}
+
+		var current string
+		err := DB.Raw(`SELECT confdeltype FROM pg_constraint WHERE conname = ? AND conrelid = ?::regclass`,
+			constraint.Name, constraint.Schema.Table).Scan(&current).Error
+		if err != nil {
+			return err
+		}
+		if current == "" || current == want {
+			continue
+		}
+
+		log.Printf("Recreating foreign key %s with ON DELETE %s", constraint.Name, constraint.OnDelete)
+		table := clause.Table{Name: constraint.Schema.Table}
+		sql, vars := constraint.Build()
+		err = DB.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec("ALTER TABLE ? DROP CONSTRAINT ?", table, clause.Column{Name: constraint.Name}).Error; err != nil {
+				return err
+			}
+			return tx.Exec("ALTER TABLE ? ADD "+sql, append([]interface{}{table}, vars...)...).Error
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MissingTables returns the tables of Models that do not exist in the database
+func MissingTables() ([]string, error) {
+	var missing []string
+	for _, model := range Models {
+		stmt := &gorm.Statement{DB: DB}
+		if err := stmt.Parse(model); err != nil {
+			return nil, err
+		}
+		if !DB.Migrator().HasTable(stmt.Table) {
+			missing = append(missing, stmt.Table)
+		}
+	}
+	return missing, nil
+}
+
 func Close() error {
 	sqlDB, err := DB.DB()
 	if err != nil {
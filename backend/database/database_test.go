@@ -0,0 +1,45 @@
+package database
+
+import "testing"
+
+func TestForeignKeyDeleteActions(t *testing.T) {
+	constraints, err := foreignKeys()
+	if err != nil {
+		t.Fatalf("foreignKeys: %v", err)
+	}
+
+	got := map[string]string{}
+	for _, c := range constraints {
+		got[c.Schema.Table+"."+c.ForeignKeys[0].DBName] = c.OnDelete
+	}
+
+	want := map[string]string{
+		"product_readiness.product_id":         "CASCADE",
+		"product_readiness_history.product_id": "CASCADE",
+		"product_metrics.product_id":           "CASCADE",
+		"product_compliances.product_id":       "CASCADE",
+		"product_partners.product_id":          "CASCADE",
+		"product_feedback.product_id":          "CASCADE",
+		"product_predictions.product_id":       "CASCADE",
+		"product_market_evidences.product_id":  "CASCADE",
+		"sales_trainings.product_id":           "CASCADE",
+		"product_actions.product_id":           "CASCADE",
+		"product_dependencies.product_id":      "CASCADE",
+		"product_escalations.product_id":       "CASCADE",
+		"transition_items.product_id":          "CASCADE",
+		"product_ownership_changes.product_id": "CASCADE",
+		"product_lifecycle_events.product_id":  "CASCADE",
+		"action_comments.action_id":            "CASCADE",
+		"product_actions.linked_feedback_id":   "SET NULL",
+		"product_actions.linked_escalation_id": "SET NULL",
+		"webhook_deliveries.subscription_id":   "CASCADE",
+	}
+	for key, action := range want {
+		if got[key] != action {
+			t.Errorf("%s: ON DELETE %q, want %q", key, got[key], action)
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("got %d foreign keys, want %d: %v", len(got), len(want), got)
+	}
+}
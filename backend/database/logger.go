@@ -0,0 +1,78 @@
+package database
+
+import (
+	"context"
+	"log"
+	"os"
+	"regexp"
+	"time"
+
+	"gorm.io/gorm/logger"
+)
+
+// LogOptions configure the SQL log
+type LogOptions struct {
+	// Level is one of config.DBLogLevels; anything else logs every query
+	Level string
+	// SlowThreshold is how long a query may take before it is logged as
+	// slow, at warn level; zero disables the slow query log
+	SlowThreshold time.Duration
+	// RedactPII keeps bound parameters out of the log for PII tables
+	RedactPII bool
+}
+
+var logLevels = map[string]logger.LogLevel{
+	"silent": logger.Silent,
+	"error":  logger.Error,
+	"warn":   logger.Warn,
+	"info":   logger.Info,
+}
+
+// newLogger builds the SQL logger writing to w. It matches GORM's default
+// logger apart from the level and slow query threshold.
+func newLogger(w logger.Writer, opts LogOptions) logger.Interface {
+	level, ok := logLevels[opts.Level]
+	if !ok {
+		level = logger.Info
+	}
+	var l logger.Interface = logger.New(w, logger.Config{
+		SlowThreshold: opts.SlowThreshold,
+		LogLevel:      level,
+		Colorful:      true,
+	})
+	if opts.RedactPII {
+		l = newRedactingLogger(l)
+	}
+	return l
+}
+
+// stdoutWriter is where GORM's default logger writes
+func stdoutWriter() logger.Writer {
+	return log.New(os.Stdout, "\r\n", log.LstdFlags)
+}
+
+// piiTables hold personal data or secrets: products flagged PII, feedback
+// raw_text, user emails in profiles, ownership history and audit logs, the IPs
+// in audit logs, and webhook signing secrets
+var piiTables = regexp.MustCompile(`\b(products|product_feedback|profiles|product_ownership_changes|audit_logs|webhook_subscriptions)\b`)
+
+// redactingLogger drops bound parameters from logged SQL that touches a PII
+// table, leaving the $n placeholders in place of the values
+type redactingLogger struct {
+	logger.Interface
+}
+
+func newRedactingLogger(l logger.Interface) logger.Interface {
+	return redactingLogger{l}
+}
+
+func (l redactingLogger) LogMode(level logger.LogLevel) logger.Interface {
+	return redactingLogger{l.Interface.LogMode(level)}
+}
+
+func (l redactingLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	if piiTables.MatchString(sql) {
+		return sql, nil
+	}
+	return sql, params
+}
@@ -0,0 +1,88 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// bufferWriter collects log lines
+type bufferWriter struct{ lines []string }
+
+func (w *bufferWriter) Printf(format string, args ...interface{}) {
+	w.lines = append(w.lines, fmt.Sprintf(format, args...))
+}
+
+func TestNewLogger(t *testing.T) {
+	trace := func(opts LogOptions, elapsed time.Duration) []string {
+		w := &bufferWriter{}
+		l := newLogger(w, opts)
+		l.Trace(context.Background(), time.Now().Add(-elapsed), func() (string, int64) {
+			return "SELECT * FROM product_actions", 1
+		}, nil)
+		return w.lines
+	}
+
+	t.Run("warn logs only slow queries", func(t *testing.T) {
+		opts := LogOptions{Level: "warn", SlowThreshold: 500 * time.Millisecond}
+		if lines := trace(opts, 10*time.Millisecond); len(lines) != 0 {
+			t.Errorf("fast query logged: %v", lines)
+		}
+		lines := trace(opts, time.Second)
+		if len(lines) != 1 || !strings.Contains(lines[0], "SLOW SQL >= 500ms") {
+			t.Errorf("slow query logged as %v", lines)
+		}
+	})
+
+	t.Run("info logs every query", func(t *testing.T) {
+		if lines := trace(LogOptions{Level: "info", SlowThreshold: time.Second}, 10*time.Millisecond); len(lines) != 1 {
+			t.Errorf("got %v", lines)
+		}
+	})
+
+	t.Run("silent", func(t *testing.T) {
+		if lines := trace(LogOptions{Level: "silent", SlowThreshold: time.Millisecond}, time.Second); len(lines) != 0 {
+			t.Errorf("got %v", lines)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		w := &bufferWriter{}
+		l := newLogger(w, LogOptions{Level: "error"})
+		fc := func() (string, int64) { return "SELECT 1", 0 }
+		l.Trace(context.Background(), time.Now(), fc, gorm.ErrRecordNotFound)
+		l.Trace(context.Background(), time.Now(), fc, gorm.ErrInvalidData)
+		if len(w.lines) != 2 {
+			t.Errorf("got %v, want both errors", w.lines)
+		}
+	})
+}
+
+func TestNewLogger_RedactsPIITables(t *testing.T) {
+	l := newLogger(&bufferWriter{}, LogOptions{Level: "info", RedactPII: true})
+	filter, ok := l.(gorm.ParamsFilter)
+	if !ok {
+		t.Fatal("redacting logger does not filter params")
+	}
+	if _, params := filter.ParamsFilter(context.Background(), "SELECT * FROM products WHERE owner_email = $1", "ana@example.com"); params != nil {
+		t.Errorf("products params = %v, want none", params)
+	}
+	if _, params := filter.ParamsFilter(context.Background(), `SELECT * FROM "profiles" WHERE email = $1`, "ana@example.com"); params != nil {
+		t.Errorf("profiles params = %v, want none", params)
+	}
+	if _, params := filter.ParamsFilter(context.Background(), "SELECT * FROM product_actions WHERE id = $1", "a1"); len(params) != 1 {
+		t.Errorf("product_actions params = %v, want kept", params)
+	}
+
+	plain, ok := newLogger(&bufferWriter{}, LogOptions{Level: "info"}).(gorm.ParamsFilter)
+	if !ok {
+		t.Fatal("logger does not filter params")
+	}
+	if _, params := plain.ParamsFilter(context.Background(), "SELECT * FROM products WHERE owner_email = $1", "ana@example.com"); len(params) != 1 {
+		t.Errorf("params = %v, want kept without RedactPII", params)
+	}
+}
@@ -0,0 +1,45 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestMigrateIntegrationStatuses(t *testing.T) {
+	db := openTestDB(t, `CREATE TABLE product_partners (id TEXT PRIMARY KEY, integration_status TEXT, updated_at DATETIME)`)
+	want := map[string]string{}
+	for raw, status := range map[string]string{
+		"live":                     "live",
+		"In Progress":              "in_progress",
+		"UAT":                      "testing",
+		"Blocked by partner legal": "failed",
+		"something else":           "not_started",
+	} {
+		id := uuid.NewString()
+		db.Exec(`INSERT INTO product_partners (id, integration_status) VALUES (?, ?)`, id, raw)
+		want[id] = status
+	}
+	unset := uuid.NewString()
+	db.Exec(`INSERT INTO product_partners (id) VALUES (?)`, unset)
+	want[unset] = "not_started"
+
+	if err := migrateIntegrationStatuses(db); err != nil {
+		t.Fatalf("migrateIntegrationStatuses: %v", err)
+	}
+
+	var rows []struct {
+		ID                string
+		IntegrationStatus string
+		UpdatedAt         *string
+	}
+	db.Raw(`SELECT id, integration_status, updated_at FROM product_partners`).Scan(&rows)
+	for _, row := range rows {
+		if row.IntegrationStatus != want[row.ID] {
+			t.Errorf("%s: got %q, want %q", row.ID, row.IntegrationStatus, want[row.ID])
+		}
+		if row.UpdatedAt != nil {
+			t.Errorf("%s: migration should not touch updated_at", row.ID)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package database
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+// Backoff between connection attempts starts at initialConnectBackoff and
+// doubles up to maxConnectBackoff
+const (
+	initialConnectBackoff = time.Second
+	maxConnectBackoff     = 15 * time.Second
+)
+
+// RetryPolicy bounds how long ConnectWithRetry waits for a database that is
+// still starting: at most MaxAttempts tries within Timeout
+type RetryPolicy struct {
+	MaxAttempts int
+	Timeout     time.Duration
+}
+
+// ConnectWithRetry calls Connect until it succeeds, backing off exponentially
+// between attempts, so an API container started before its database waits
+// for it rather than exiting. The last error is returned once the policy's
+// attempts or time run out.
+func ConnectWithRetry(databaseURL string, opts LogOptions, policy RetryPolicy) error {
+	return retryWithBackoff(policy, func() error {
+		return Connect(databaseURL, opts)
+	}, time.Now, time.Sleep)
+}
+
+// retryWithBackoff runs attempt under policy, using now and sleep for time
+func retryWithBackoff(policy RetryPolicy, attempt func() error, now func() time.Time, sleep func(time.Duration)) error {
+	deadline := now().Add(policy.Timeout)
+	backoff := initialConnectBackoff
+	for n := 1; ; n++ {
+		err := attempt()
+		if err == nil {
+			return nil
+		}
+		if n >= policy.MaxAttempts {
+			return fmt.Errorf("gave up after %d attempts: %w", n, err)
+		}
+		if now().Add(backoff).After(deadline) {
+			return fmt.Errorf("gave up after %d attempts in %s: %w", n, policy.Timeout, err)
+		}
+
+		log.Printf("Database connection attempt %d/%d failed: %v; retrying in %s", n, policy.MaxAttempts, err, backoff)
+		sleep(backoff)
+		backoff = min(backoff*2, maxConnectBackoff)
+	}
+}
@@ -0,0 +1,81 @@
+package database
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeClock advances only when slept on
+type fakeClock struct {
+	t     time.Time
+	slept []time.Duration
+}
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func (c *fakeClock) sleep(d time.Duration) {
+	c.slept = append(c.slept, d)
+	c.t = c.t.Add(d)
+}
+
+func TestRetryWithBackoff(t *testing.T) {
+	refused := errors.New("connection refused")
+	failing := func(failures int) (func() error, *int) {
+		calls := 0
+		return func() error {
+			calls++
+			if calls <= failures {
+				return refused
+			}
+			return nil
+		}, &calls
+	}
+
+	t.Run("succeeds once the database is up", func(t *testing.T) {
+		clock := &fakeClock{}
+		attempt, calls := failing(3)
+		if err := retryWithBackoff(RetryPolicy{MaxAttempts: 10, Timeout: time.Minute}, attempt, clock.now, clock.sleep); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second}
+		if *calls != 4 || len(clock.slept) != len(want) {
+			t.Fatalf("calls = %d, slept %v; want 4 calls after %v", *calls, clock.slept, want)
+		}
+		for i := range want {
+			if clock.slept[i] != want[i] {
+				t.Errorf("backoff %d = %v, want %v", i, clock.slept[i], want[i])
+			}
+		}
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		clock := &fakeClock{}
+		attempt, calls := failing(100)
+		err := retryWithBackoff(RetryPolicy{MaxAttempts: 3, Timeout: time.Hour}, attempt, clock.now, clock.sleep)
+		if !errors.Is(err, refused) || *calls != 3 {
+			t.Errorf("err = %v after %d calls, want connection refused after 3", err, *calls)
+		}
+	})
+
+	t.Run("gives up when the next wait passes the timeout", func(t *testing.T) {
+		clock := &fakeClock{}
+		attempt, calls := failing(100)
+		err := retryWithBackoff(RetryPolicy{MaxAttempts: 100, Timeout: 10 * time.Second}, attempt, clock.now, clock.sleep)
+		// Waits 1s, 2s and 4s; the next 8s would end past the 10s budget
+		if !errors.Is(err, refused) || *calls != 4 {
+			t.Errorf("err = %v after %d calls, want connection refused after 4", err, *calls)
+		}
+	})
+
+	t.Run("caps the backoff", func(t *testing.T) {
+		clock := &fakeClock{}
+		attempt, _ := failing(6)
+		if err := retryWithBackoff(RetryPolicy{MaxAttempts: 10, Timeout: time.Hour}, attempt, clock.now, clock.sleep); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if last := clock.slept[len(clock.slept)-1]; last != maxConnectBackoff {
+			t.Errorf("last backoff = %v, want capped at %v", last, maxConnectBackoff)
+		}
+	})
+}
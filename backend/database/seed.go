@@ -0,0 +1,270 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// seedNamespace derives the fixed IDs of the demo data. A product with one
+// of those IDs, even soft-deleted, marks the database as already seeded.
+var seedNamespace = uuid.MustParse("6f1c2b7e-3d5a-4c1e-9a8b-5e2f0d4c7a10")
+
+// seedModelVersion tags the demo predictions
+const seedModelVersion = "seed-demo"
+
+// SeedData is the demo portfolio Seed inserts
+type SeedData struct {
+	Products     []models.Product
+	Readiness    []models.ProductReadiness
+	Feedback     []models.ProductFeedback
+	Dependencies []models.ProductDependency
+	Predictions  []models.ProductPrediction
+}
+
+// seedID derives a stable ID for a demo record from its key
+func seedID(key string) uuid.UUID {
+	return uuid.NewSHA1(seedNamespace, []byte(key))
+}
+
+// seedProduct describes one demo product and its satellite records
+type seedProduct struct {
+	name        string
+	productType models.ProductType
+	region      string
+	stage       models.LifecycleStage
+	owner       string
+	launchDays  int // days from now; negative for launched products
+	revenue     float64
+
+	compliance bool
+	training   float64
+	partners   float64
+	onboarding bool
+	docs       float64
+
+	feedback     []seedFeedback
+	dependencies []seedDependency
+	success      float64
+	failure      float64
+}
+
+type seedFeedback struct {
+	source, text, theme, impact string
+	sentiment                   float64
+	volume                      int
+}
+
+type seedDependency struct {
+	name         string
+	depType      models.DependencyType
+	category     models.DependencyCategory
+	status       models.DependencyStatus
+	blockedDays  int
+	resolvedDays int
+}
+
+var seedProducts = []seedProduct{
+	{
+		name: "Demo: Merchant Insights", productType: models.ProductTypeDataServices, region: "North America",
+		stage: models.LifecycleConcept, owner: "maya.chen@example.com", launchDays: 180, revenue: 250000,
+		training: 5, docs: 20,
+		feedback: []seedFeedback{
+			{"interview", "Merchants want daily cohort breakdowns, not weekly", "reporting", "medium", 0.4, 6},
+		},
+		dependencies: []seedDependency{
+			{"Data privacy review", models.DependencyTypeInternal, models.DependencyCategoryPrivacy, models.DependencyStatusPending, 0, 0},
+		},
+		success: 0.45, failure: 0.35,
+	},
+	{
+		name: "Demo: Tap to Pay", productType: models.ProductTypePaymentFlows, region: "EMEA",
+		stage: models.LifecycleEarlyPilot, owner: "luca.romano@example.com", launchDays: 90, revenue: 1200000,
+		training: 30, partners: 20, docs: 45,
+		feedback: []seedFeedback{
+			{"pilot_survey", "Setup took too long on older Android devices", "onboarding", "high", -0.5, 14},
+			{"support", "Contactless acceptance works well once configured", "reliability", "low", 0.6, 4},
+		},
+		dependencies: []seedDependency{
+			{"Acquirer certification", models.DependencyTypeExternal, models.DependencyCategoryPartnerRail, models.DependencyStatusBlocked, 21, 0},
+			{"PCI scope assessment", models.DependencyTypeInternal, models.DependencyCategoryCompliance, models.DependencyStatusPending, 0, 0},
+		},
+		success: 0.55, failure: 0.4,
+	},
+	{
+		name: "Demo: Instant Payouts", productType: models.ProductTypePaymentFlows, region: "LATAM",
+		stage: models.LifecyclePilot, owner: "ana.lima@example.com", launchDays: 30, revenue: 2000000,
+		compliance: true, training: 60, partners: 55, docs: 70,
+		feedback: []seedFeedback{
+			{"pilot_survey", "Payout speed is the main reason we joined the pilot", "speed", "high", 0.8, 22},
+			{"support", "Fees are unclear on the payout confirmation screen", "pricing", "medium", -0.3, 9},
+		},
+		dependencies: []seedDependency{
+			{"Local rails onboarding", models.DependencyTypeExternal, models.DependencyCategoryPartnerRail, models.DependencyStatusResolved, 0, 10},
+			{"Fraud rules tuning", models.DependencyTypeInternal, models.DependencyCategoryEngineering, models.DependencyStatusPending, 0, 0},
+		},
+		success: 0.7, failure: 0.2,
+	},
+	{
+		name: "Demo: Loyalty Partner Hub", productType: models.ProductTypePartnerships, region: "APAC",
+		stage: models.LifecycleScaling, owner: "kenji.sato@example.com", launchDays: -120, revenue: 3500000,
+		compliance: true, training: 85, partners: 75, onboarding: true, docs: 80,
+		feedback: []seedFeedback{
+			{"partner_review", "Partners ask for self-serve offer creation", "self-service", "medium", 0.2, 11},
+		},
+		dependencies: []seedDependency{
+			{"Partner API v2", models.DependencyTypeExternal, models.DependencyCategoryAPI, models.DependencyStatusResolved, 0, 45},
+		},
+		success: 0.82, failure: 0.1,
+	},
+	{
+		name: "Demo: Smart Checkout", productType: models.ProductTypeCoreProducts, region: "North America",
+		stage: models.LifecycleCommercial, owner: "sam.taylor@example.com", launchDays: -365, revenue: 8000000,
+		compliance: true, training: 100, partners: 95, onboarding: true, docs: 95,
+		feedback: []seedFeedback{
+			{"nps", "Checkout conversion improved noticeably", "conversion", "high", 0.9, 40},
+			{"support", "Occasional timeouts during peak sales", "reliability", "medium", -0.4, 7},
+		},
+		dependencies: []seedDependency{
+			{"CDN vendor renewal", models.DependencyTypeExternal, models.DependencyCategoryVendor, models.DependencyStatusPending, 0, 0},
+		},
+		success: 0.9, failure: 0.05,
+	},
+}
+
+// NewSeedData builds the demo portfolio, dated relative to now: a product at
+// each lifecycle stage from concept to commercial, each with readiness,
+// feedback, dependencies and a prediction. IDs are fixed, so repeated builds match.
+func NewSeedData(now time.Time) SeedData {
+	var data SeedData
+	ts := func(days int) *models.Timestamp {
+		t := models.NewTimestamp(now.AddDate(0, 0, days))
+		return &t
+	}
+
+	for _, p := range seedProducts {
+		productID := seedID(p.name)
+		revenue := p.revenue
+		data.Products = append(data.Products, models.Product{
+			ID:             productID,
+			Name:           p.name,
+			ProductType:    p.productType,
+			Region:         p.region,
+			LifecycleStage: p.stage,
+			LaunchDate:     ts(p.launchDays),
+			RevenueTarget:  &revenue,
+			OwnerEmail:     p.owner,
+		})
+
+		readiness := models.ProductReadiness{
+			ID:                 seedID(p.name + "/readiness"),
+			ProductID:          productID,
+			ComplianceComplete: &p.compliance,
+			SalesTrainingPct:   &p.training,
+			PartnerEnabledPct:  &p.partners,
+			OnboardingComplete: &p.onboarding,
+			DocumentationScore: &p.docs,
+		}
+		readiness.ReadinessScore = readiness.ComputeReadinessScore()
+		readiness.RiskBand = models.RiskBandForScore(readiness.ReadinessScore)
+		data.Readiness = append(data.Readiness, readiness)
+
+		for i := range p.feedback {
+			f := p.feedback[i]
+			theme := models.NormalizeTheme(f.theme)
+			data.Feedback = append(data.Feedback, models.ProductFeedback{
+				ID:             seedID(p.name + "/feedback/" + f.text),
+				ProductID:      productID,
+				Source:         f.source,
+				RawText:        f.text,
+				Theme:          &theme,
+				RawTheme:       &f.theme,
+				SentimentScore: &f.sentiment,
+				ImpactLevel:    &f.impact,
+				Volume:         &f.volume,
+			})
+		}
+
+		for _, d := range p.dependencies {
+			dependency := models.ProductDependency{
+				ID:        seedID(p.name + "/dependency/" + d.name),
+				ProductID: productID,
+				Name:      d.name,
+				Type:      d.depType,
+				Category:  d.category,
+				Status:    d.status,
+			}
+			switch d.status {
+			case models.DependencyStatusBlocked:
+				dependency.BlockedSince = ts(-d.blockedDays)
+			case models.DependencyStatusResolved:
+				dependency.ResolvedAt = ts(-d.resolvedDays)
+			}
+			data.Dependencies = append(data.Dependencies, dependency)
+		}
+
+		success, failure := p.success, p.failure
+		revenueProbability := p.success * 0.9
+		features, _ := json.Marshal(map[string]interface{}{
+			"lifecycle_stage": p.stage,
+			"readiness_score": readiness.ReadinessScore,
+			"feedback_count":  len(p.feedback),
+		})
+		data.Predictions = append(data.Predictions, models.ProductPrediction{
+			ID:                 seedID(p.name + "/prediction"),
+			ProductID:          productID,
+			SuccessProbability: &success,
+			RevenueProbability: &revenueProbability,
+			FailureRisk:        &failure,
+			ModelVersion:       seedModelVersion,
+			Features:           features,
+		})
+	}
+	return data
+}
+
+// Seeded reports whether the demo portfolio has already been inserted
+func Seeded(ctx context.Context, db *gorm.DB, data SeedData) (bool, error) {
+	ids := make([]uuid.UUID, 0, len(data.Products))
+	for _, product := range data.Products {
+		ids = append(ids, product.ID)
+	}
+	var count int64
+	err := db.WithContext(ctx).Unscoped().Model(&models.Product{}).Where("id IN ?", ids).Count(&count).Error
+	return count > 0, err
+}
+
+// Seed inserts the demo portfolio into an empty environment so the dashboard
+// has something to show. It does nothing, and returns false, when the
+// database has already been seeded.
+func Seed(ctx context.Context) (bool, error) {
+	return seed(ctx, DB, NewSeedData(time.Now()))
+}
+
+func seed(ctx context.Context, db *gorm.DB, data SeedData) (bool, error) {
+	seeded, err := Seeded(ctx, db, data)
+	if err != nil || seeded {
+		return false, err
+	}
+
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, rows := range []interface{}{&data.Products, &data.Readiness, &data.Feedback, &data.Dependencies, &data.Predictions} {
+			if err := tx.Omit(clause.Associations).Create(rows).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	log.Printf("Seeded %d demo products", len(data.Products))
+	return true, nil
+}
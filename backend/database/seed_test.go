@@ -0,0 +1,82 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+)
+
+func TestNewSeedData(t *testing.T) {
+	now := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	data := NewSeedData(now)
+
+	stages := map[models.LifecycleStage]bool{}
+	products := map[uuid.UUID]bool{}
+	for _, product := range data.Products {
+		stages[product.LifecycleStage] = true
+		products[product.ID] = true
+		if !product.ProductType.IsValid() {
+			t.Errorf("%s: invalid product type %q", product.Name, product.ProductType)
+		}
+	}
+	if len(products) != len(seedProducts) || len(stages) != len(seedProducts) {
+		t.Errorf("got %d products across %d stages, want %d distinct of each", len(products), len(stages), len(seedProducts))
+	}
+
+	if len(data.Readiness) != len(data.Products) || len(data.Predictions) != len(data.Products) {
+		t.Errorf("want one readiness and prediction per product, got %d and %d", len(data.Readiness), len(data.Predictions))
+	}
+	for _, readiness := range data.Readiness {
+		if !products[readiness.ProductID] {
+			t.Errorf("readiness %s points at an unknown product", readiness.ID)
+		}
+		if readiness.RiskBand != models.RiskBandForScore(readiness.ReadinessScore) {
+			t.Errorf("readiness %s: band %s does not match score %v", readiness.ID, readiness.RiskBand, readiness.ReadinessScore)
+		}
+	}
+	for _, feedback := range data.Feedback {
+		if !products[feedback.ProductID] || feedback.Theme == nil || *feedback.Theme != models.NormalizeTheme(*feedback.Theme) {
+			t.Errorf("feedback %s = %+v", feedback.ID, feedback)
+		}
+	}
+	for _, dependency := range data.Dependencies {
+		if !products[dependency.ProductID] || dependency.Type.Check() != nil || dependency.Category.Check() != nil || dependency.Status.Check() != nil {
+			t.Errorf("dependency %s = %+v", dependency.Name, dependency)
+		}
+		if (dependency.Status == models.DependencyStatusBlocked) != (dependency.BlockedSince != nil) {
+			t.Errorf("dependency %s: blocked_since should be set exactly when blocked", dependency.Name)
+		}
+	}
+
+	again := NewSeedData(now.AddDate(0, 1, 0))
+	if again.Products[0].ID != data.Products[0].ID || again.Feedback[0].ID != data.Feedback[0].ID {
+		t.Error("seed IDs should not depend on when the data is built")
+	}
+}
+
+func TestSeeded(t *testing.T) {
+	db := openTestDB(t, `CREATE TABLE products (id TEXT PRIMARY KEY, deleted_at DATETIME)`)
+	ctx := context.Background()
+	data := NewSeedData(time.Now())
+
+	check := func(want bool) {
+		t.Helper()
+		seeded, err := Seeded(ctx, db, data)
+		if err != nil {
+			t.Fatalf("Seeded: %v", err)
+		}
+		if seeded != want {
+			t.Errorf("Seeded = %v, want %v", seeded, want)
+		}
+	}
+
+	db.Exec(`INSERT INTO products (id) VALUES (?)`, uuid.New())
+	check(false)
+
+	// A soft-deleted demo product still counts, so deleting one is not undone
+	db.Exec(`INSERT INTO products (id, deleted_at) VALUES (?, '2025-03-01 00:00:00')`, data.Products[2].ID)
+	check(true)
+}
@@ -0,0 +1,238 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/pauly7610/studio-pilot-vision/backend/database"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+	"github.com/pauly7610/studio-pilot-vision/backend/services/email"
+	"gorm.io/gorm"
+)
+
+// maxReminderDays bounds ?due_within_days= on the reminder run
+const maxReminderDays = 90
+
+// ActionDigest is the reminder for one assignee. Email is empty, and Sent
+// false, when the assignee could not be matched to an address.
+type ActionDigest struct {
+	AssignedTo string      `json:"assigned_to"`
+	Email      string      `json:"email,omitempty"`
+	Overdue    []uuid.UUID `json:"overdue"`
+	DueSoon    []uuid.UUID `json:"due_soon"`
+	Sent       bool        `json:"sent"`
+	Error      string      `json:"error,omitempty"`
+
+	actions []reminderAction
+}
+
+// ActionReminderRun summarizes one reminder run
+type ActionReminderRun struct {
+	DueWithinDays int            `json:"due_within_days"`
+	Sent          int            `json:"sent"`
+	Digests       []ActionDigest `json:"digests"`
+}
+
+// reminderAction is an open, assigned action with its product's name
+type reminderAction struct {
+	models.ProductAction
+	ProductName string
+}
+
+// reminderActions loads the open, assigned actions due before today, or up to
+// dueWithinDays after it, ordered by assignee and due date
+func reminderActions(db *gorm.DB, today models.Date, dueWithinDays int) ([]reminderAction, error) {
+	cutoff := models.NewDate(today.AddDate(0, 0, dueWithinDays+1))
+	if dueWithinDays == 0 {
+		cutoff = today
+	}
+
+	var actions []models.ProductAction
+	if err := db.
+		Where("status IN ?", openActionStatuses).
+		Where("assigned_to IS NOT NULL AND assigned_to <> ''").
+		Where("due_date < ?", cutoff).
+		Order("assigned_to").
+		Order("due_date").
+		Order("title").
+		Find(&actions).Error; err != nil {
+		return nil, err
+	}
+
+	productIDs := make([]uuid.UUID, 0, len(actions))
+	for _, action := range actions {
+		productIDs = append(productIDs, action.ProductID)
+	}
+	var products []struct {
+		ID   uuid.UUID
+		Name string
+	}
+	if len(productIDs) > 0 {
+		if err := db.Model(&models.Product{}).
+			Select("id, name").
+			Where("id IN ?", productIDs).
+			Scan(&products).Error; err != nil {
+			return nil, err
+		}
+	}
+	names := make(map[uuid.UUID]string, len(products))
+	for _, product := range products {
+		names[product.ID] = product.Name
+	}
+
+	result := make([]reminderAction, 0, len(actions))
+	for _, action := range actions {
+		result = append(result, reminderAction{ProductAction: action, ProductName: names[action.ProductID]})
+	}
+	return result, nil
+}
+
+// groupActionDigests splits actions, already ordered by assignee, into one
+// digest per assignee
+func groupActionDigests(actions []reminderAction, today models.Date) []ActionDigest {
+	digests := []ActionDigest{}
+	for _, action := range actions {
+		assignee := *action.AssignedTo
+		if len(digests) == 0 || digests[len(digests)-1].AssignedTo != assignee {
+			digests = append(digests, ActionDigest{AssignedTo: assignee, Overdue: []uuid.UUID{}, DueSoon: []uuid.UUID{}})
+		}
+		digest := &digests[len(digests)-1]
+		if action.DueDate.Before(today.Time) {
+			digest.Overdue = append(digest.Overdue, action.ID)
+		} else {
+			digest.DueSoon = append(digest.DueSoon, action.ID)
+		}
+		digest.actions = append(digest.actions, action)
+	}
+	return digests
+}
+
+// assigneeEmails maps assignees onto email addresses. assigned_to holds
+// either an address or a person's name, so it is matched against profile
+// emails and full names; an unmatched value that looks like an address is
+// used as is.
+func assigneeEmails(db *gorm.DB, assignees []string) (map[string]string, error) {
+	emails := make(map[string]string, len(assignees))
+	if len(assignees) == 0 {
+		return emails, nil
+	}
+
+	var profiles []models.Profile
+	if err := db.Select("email, full_name").
+		Where("email IN ? OR full_name IN ?", assignees, assignees).
+		Find(&profiles).Error; err != nil {
+		return nil, err
+	}
+	for _, profile := range profiles {
+		if profile.FullName != nil {
+			emails[*profile.FullName] = profile.Email
+		}
+	}
+	// An exact email match wins over a shared full name
+	for _, profile := range profiles {
+		emails[profile.Email] = profile.Email
+	}
+
+	for _, assignee := range assignees {
+		if _, ok := emails[assignee]; !ok && strings.Contains(assignee, "@") {
+			emails[assignee] = assignee
+		}
+	}
+	return emails, nil
+}
+
+// digestMessage renders an assignee's digest as a plain-text email
+func digestMessage(digest ActionDigest, today models.Date) email.Message {
+	subject := fmt.Sprintf("[Studio Pilot] Action reminder: %d overdue", len(digest.Overdue))
+	if len(digest.DueSoon) > 0 {
+		subject += fmt.Sprintf(", %d due soon", len(digest.DueSoon))
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "Hi %s,\n\nThese actions assigned to you need attention as of %s:\n", digest.AssignedTo, today)
+	section := ""
+	for _, action := range digest.actions {
+		heading := "Due soon"
+		if action.DueDate.Before(today.Time) {
+			heading = "Overdue"
+		}
+		if heading != section {
+			fmt.Fprintf(&body, "\n%s\n", heading)
+			section = heading
+		}
+		fmt.Fprintf(&body, "- %s (%s, %s priority, due %s)\n", action.Title, action.ProductName, action.Priority, action.DueDate)
+	}
+	body.WriteString("\nPlease update their status or due dates in Studio Pilot Vision.\n")
+
+	return email.Message{To: digest.Email, Subject: subject, Body: body.String()}
+}
+
+// sendActionDigests emails each assignee with overdue actions, or actions due
+// within dueWithinDays, one digest listing them all. A failed send is
+// recorded on its digest and does not stop the others.
+func sendActionDigests(ctx context.Context, db *gorm.DB, mailer email.Notifier, today models.Date, dueWithinDays int) (ActionReminderRun, error) {
+	run := ActionReminderRun{DueWithinDays: dueWithinDays, Digests: []ActionDigest{}}
+
+	actions, err := reminderActions(db, today, dueWithinDays)
+	if err != nil {
+		return run, err
+	}
+	run.Digests = groupActionDigests(actions, today)
+
+	assignees := make([]string, 0, len(run.Digests))
+	for _, digest := range run.Digests {
+		assignees = append(assignees, digest.AssignedTo)
+	}
+	emails, err := assigneeEmails(db, assignees)
+	if err != nil {
+		return run, err
+	}
+
+	for i := range run.Digests {
+		digest := &run.Digests[i]
+		digest.Email = emails[digest.AssignedTo]
+		if digest.Email == "" {
+			digest.Error = "no email address for assignee"
+			log.Printf("Action reminder skipped for %q: no email address", digest.AssignedTo)
+			continue
+		}
+		if err := mailer.Send(ctx, digestMessage(*digest, today)); err != nil {
+			digest.Error = err.Error()
+			log.Printf("Action reminder to %s failed: %v", digest.Email, err)
+			continue
+		}
+		digest.Sent = true
+		run.Sent++
+		log.Printf("Action reminder sent to %s: %d overdue, %d due soon", digest.Email, len(digest.Overdue), len(digest.DueSoon))
+	}
+	return run, nil
+}
+
+// NotifyOverdueActions emails each assignee a digest of their overdue open
+// actions, plus those due within ?due_within_days= days (default 0). Meant
+// to be called by an admin or a cron job.
+func (h *ActionsHandler) NotifyOverdueActions(c *gin.Context) {
+	dueWithinDays := 0
+	if raw := c.Query("due_within_days"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 0 || parsed > maxReminderDays {
+			respondWithError(c, http.StatusBadRequest, fmt.Sprintf("due_within_days must be between 0 and %d", maxReminderDays))
+			return
+		}
+		dueWithinDays = parsed
+	}
+
+	run, err := sendActionDigests(c.Request.Context(), database.DB, h.mailer, models.Today(), dueWithinDays)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithData(c, http.StatusOK, run)
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+	"github.com/pauly7610/studio-pilot-vision/backend/services/email"
+)
+
+// fakeMailer records messages and fails sends to the addresses in fail
+type fakeMailer struct {
+	sent []email.Message
+	fail map[string]bool
+}
+
+func (m *fakeMailer) Send(ctx context.Context, msg email.Message) error {
+	if m.fail[msg.To] {
+		return errors.New("relay refused")
+	}
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+func TestSendActionDigests(t *testing.T) {
+	db := openTestDB(t, productActionsDDL,
+		`CREATE TABLE products (id TEXT PRIMARY KEY, name TEXT, deleted_at DATETIME)`,
+		`CREATE TABLE profiles (id TEXT PRIMARY KEY, email TEXT, full_name TEXT)`,
+	)
+	today, _ := models.ParseDate("2025-03-10")
+	productID := uuid.New()
+	db.Exec(`INSERT INTO products (id, name) VALUES (?, 'Smart Checkout')`, productID)
+	db.Exec(`INSERT INTO profiles (id, email, full_name) VALUES (?, 'ana@example.com', 'Ana Lima')`, uuid.New())
+
+	seed := func(title, assignee, due string, status models.ActionStatus) uuid.UUID {
+		action := models.ProductAction{ProductID: productID, ActionType: models.ActionTypeIntervention, Title: title, Status: status, Priority: models.ActionPriorityHigh}
+		if assignee != "" {
+			action.AssignedTo = &assignee
+		}
+		if due != "" {
+			date, _ := models.ParseDate(due)
+			action.DueDate = &date
+		}
+		db.Create(&action)
+		return action.ID
+	}
+	anaOverdue := seed("Fix KYC copy", "Ana Lima", "2025-03-01", models.ActionStatusPending)
+	anaSoon := seed("Book legal review", "Ana Lima", "2025-03-12", models.ActionStatusInProgress)
+	seed("Later", "Ana Lima", "2025-03-20", models.ActionStatusPending)
+	seed("Done", "Ana Lima", "2025-03-01", models.ActionStatusCompleted)
+	seed("Unassigned", "", "2025-03-01", models.ActionStatusPending)
+	seed("Undated", "Ana Lima", "", models.ActionStatusPending)
+	bobOverdue := seed("Chase partner", "bob@example.com", "2025-03-09", models.ActionStatusPending)
+	seed("Nobody", "Carol", "2025-03-02", models.ActionStatusPending)
+
+	t.Run("overdue only", func(t *testing.T) {
+		mailer := &fakeMailer{}
+		run, err := sendActionDigests(context.Background(), db, mailer, today, 0)
+		if err != nil {
+			t.Fatalf("sendActionDigests: %v", err)
+		}
+		if run.Sent != 2 || len(run.Digests) != 3 {
+			t.Fatalf("sent %d of %d digests, want 2 of 3: %+v", run.Sent, len(run.Digests), run.Digests)
+		}
+
+		byAssignee := map[string]ActionDigest{}
+		for _, digest := range run.Digests {
+			byAssignee[digest.AssignedTo] = digest
+		}
+		if d := byAssignee["Ana Lima"]; d.Email != "ana@example.com" || !d.Sent ||
+			len(d.Overdue) != 1 || d.Overdue[0] != anaOverdue || len(d.DueSoon) != 0 {
+			t.Errorf("Ana's digest = %+v", d)
+		}
+		if d := byAssignee["bob@example.com"]; d.Email != "bob@example.com" || !d.Sent || len(d.Overdue) != 1 || d.Overdue[0] != bobOverdue {
+			t.Errorf("Bob's digest = %+v", d)
+		}
+		if d := byAssignee["Carol"]; d.Sent || d.Error == "" {
+			t.Errorf("Carol has no address and should be skipped: %+v", d)
+		}
+
+		if len(mailer.sent) != 2 {
+			t.Fatalf("sent %d emails, want 2", len(mailer.sent))
+		}
+		msg := mailer.sent[0]
+		if msg.To != "ana@example.com" || msg.Subject != "[Studio Pilot] Action reminder: 1 overdue" {
+			t.Errorf("message = %q to %s", msg.Subject, msg.To)
+		}
+		if !strings.Contains(msg.Body, "- Fix KYC copy (Smart Checkout, high priority, due 2025-03-01)") {
+			t.Errorf("body missing the action:\n%s", msg.Body)
+		}
+	})
+
+	t.Run("due soon", func(t *testing.T) {
+		mailer := &fakeMailer{fail: map[string]bool{"bob@example.com": true}}
+		run, err := sendActionDigests(context.Background(), db, mailer, today, 3)
+		if err != nil {
+			t.Fatalf("sendActionDigests: %v", err)
+		}
+		if run.Sent != 1 || run.DueWithinDays != 3 {
+			t.Errorf("run = %+v", run)
+		}
+		for _, digest := range run.Digests {
+			switch digest.AssignedTo {
+			case "Ana Lima":
+				if len(digest.DueSoon) != 1 || digest.DueSoon[0] != anaSoon {
+					t.Errorf("Ana's due-soon = %v, want [%s]", digest.DueSoon, anaSoon)
+				}
+			case "bob@example.com":
+				if digest.Sent || digest.Error == "" {
+					t.Errorf("failed send should be reported: %+v", digest)
+				}
+			}
+		}
+		if len(mailer.sent) != 1 || mailer.sent[0].Subject != "[Studio Pilot] Action reminder: 1 overdue, 1 due soon" ||
+			!strings.Contains(mailer.sent[0].Body, "Due soon\n- Book legal review") {
+			t.Errorf("unexpected emails: %+v", mailer.sent)
+		}
+	})
+}
@@ -1,24 +1,36 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/pauly7610/studio-pilot-vision/backend/database"
+	"github.com/pauly7610/studio-pilot-vision/backend/middleware"
 	"github.com/pauly7610/studio-pilot-vision/backend/models"
+	"github.com/pauly7610/studio-pilot-vision/backend/services/email"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-type ActionsHandler struct{}
+type ActionsHandler struct {
+	mailer email.Notifier
+}
 
-func NewActionsHandler() *ActionsHandler {
-	return &ActionsHandler{}
+// NewActionsHandler sends action reminders through mailer
+func NewActionsHandler(mailer email.Notifier) *ActionsHandler {
+	return &ActionsHandler{mailer: mailer}
 }
 
 // GetProductActions retrieves all actions for a product
 func (h *ActionsHandler) GetProductActions(c *gin.Context) {
-	productID, err := uuid.Parse(c.Param("productId"))
+	productID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
 		return
@@ -38,30 +50,125 @@ func (h *ActionsHandler) GetProductActions(c *gin.Context) {
 	respondWithData(c, http.StatusOK, actions)
 }
 
-// GetAllActions retrieves all actions
-func (h *ActionsHandler) GetAllActions(c *gin.Context) {
-	var actions []models.ProductAction
+// priorityRankSQL orders priorities low < medium < high < critical
+const priorityRankSQL = "CASE priority WHEN 'low' THEN 1 WHEN 'medium' THEN 2 WHEN 'high' THEN 3 WHEN 'critical' THEN 4 ELSE 0 END"
+
+// actionSorts maps each ?sort= value GetAllActions accepts to its ORDER BY
+// clauses. Order only ever receives these fixed strings, never the raw
+// parameter.
+var actionSorts = map[string][]string{
+	"created_at":  {"created_at ASC"},
+	"-created_at": {"created_at DESC"},
+	"due_date":    {"due_date ASC NULLS LAST", "created_at DESC"},
+	"-due_date":   {"due_date DESC NULLS LAST", "created_at DESC"},
+	"priority":    {priorityRankSQL + " ASC", "created_at DESC"},
+	"-priority":   {priorityRankSQL + " DESC", "created_at DESC"},
+}
+
+// actionDueBounds are the due-date filters, each a date compared against
+// due_date
+var actionDueBounds = []struct {
+	param string
+	op    string
+}{
+	{"due_from", ">="},
+	{"due_to", "<="},
+	{"due_after", ">"},
+	{"due_before", "<"},
+}
+
+// actionFilters are GetAllActions' equality filters
+var actionFilters = []queryFilter{
+	{Param: "status", Column: "status", Multi: true, Check: enumCheck(models.ActionStatus.Check)},
+	{Param: "priority", Column: "priority", Multi: true, Check: enumCheck(models.ActionPriority.Check)},
+	{Param: "action_type", Column: "action_type", Multi: true},
+	{Param: "assigned_to", Column: "assigned_to"},
+}
 
-	query := database.DB.Order("created_at DESC")
+// filterActions applies GetAllActions' filters and sort to query. An error
+// describes a bad parameter.
+func filterActions(query *gorm.DB, params url.Values, today models.Date) (*gorm.DB, *ListMeta, error) {
+	sortBy := params.Get("sort")
+	if sortBy == "" {
+		sortBy = "-created_at"
+	}
+	orders, ok := actionSorts[sortBy]
+	if !ok {
+		names := make([]string, 0, len(actionSorts))
+		for name := range actionSorts {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, nil, fmt.Errorf("sort must be one of %s", strings.Join(names, ", "))
+	}
+	meta := newListMeta(sortBy)
+	meta.accept("sort")
+	for _, order := range orders {
+		query = query.Order(order)
+	}
+
+	query, err := applyFilters(query, params, actionFilters, meta)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	// Optional filtering
-	if status := c.Query("status"); status != "" {
-		query = query.Where("status = ?", status)
+	dates := make(map[string]models.Date)
+	for _, bound := range actionDueBounds {
+		raw := params.Get(bound.param)
+		if raw == "" {
+			continue
+		}
+		date, err := models.ParseDate(raw)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s must be a date (YYYY-MM-DD)", bound.param)
+		}
+		dates[bound.param] = date
+		query = query.Where("due_date "+bound.op+" ?", date)
+		meta.filter(bound.param, date.String())
 	}
-	if priority := c.Query("priority"); priority != "" {
-		query = query.Where("priority = ?", priority)
+	if from, ok := dates["due_from"]; ok {
+		if to, ok := dates["due_to"]; ok && to.Before(from.Time) {
+			return nil, nil, errors.New("due_to must not be before due_from")
+		}
 	}
-	if actionType := c.Query("action_type"); actionType != "" {
-		query = query.Where("action_type = ?", actionType)
+
+	if params.Get("overdue") == "true" {
+		query = query.Where("status IN ? AND due_date < ?", openActionStatuses, today)
+		meta.filter("overdue", "true")
 	}
 
-	result := query.Find(&actions)
+	return query, meta, nil
+}
+
+// GetAllActions retrieves all actions, filtered by ?status=, ?priority=,
+// ?action_type= and ?assigned_to=. Due dates (YYYY-MM-DD) bound the list with
+// ?due_from= and ?due_to= (inclusive) or ?due_after= and ?due_before=
+// (exclusive); ?overdue=true keeps open actions past their due date. ?sort=
+// takes created_at, due_date or priority, prefixed with - for descending
+// (undated actions last).
+func (h *ActionsHandler) GetAllActions(c *gin.Context) {
+	var actions []models.ProductAction
+
+	query, meta, err := filterActions(database.DB, c.Request.URL.Query(), models.Today())
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, pageSize := parsePagination(c, defaultListPageSize, maxListPageSize)
+	pageQuery, total, err := paginate(query, &models.ProductAction{}, page, pageSize)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result := pageQuery.Find(&actions)
 	if result.Error != nil {
 		respondWithError(c, http.StatusInternalServerError, result.Error.Error())
 		return
 	}
 
-	respondWithData(c, http.StatusOK, actions)
+	respondWithPagination(c, actions, total, page, pageSize, meta)
 }
 
 // GetAction retrieves a single action
@@ -83,29 +190,48 @@ func (h *ActionsHandler) GetAction(c *gin.Context) {
 	respondWithData(c, http.StatusOK, action)
 }
 
+// actionLinkError reports a linked feedback or escalation that does not
+// exist, which the foreign keys on product_actions would reject
+func actionLinkError(db *gorm.DB, feedbackID, escalationID *uuid.UUID) (string, error) {
+	links := []struct {
+		id    *uuid.UUID
+		model interface{}
+		msg   string
+	}{
+		{feedbackID, &models.ProductFeedback{}, "Linked feedback not found"},
+		{escalationID, &models.ProductEscalation{}, "Linked escalation not found"},
+	}
+	for _, link := range links {
+		if link.id == nil {
+			continue
+		}
+		var n int64
+		if err := db.Model(link.model).Where("id = ?", *link.id).Count(&n).Error; err != nil {
+			return "", err
+		}
+		if n == 0 {
+			return link.msg, nil
+		}
+	}
+	return "", nil
+}
+
 // CreateAction creates a new action
 func (h *ActionsHandler) CreateAction(c *gin.Context) {
 	var req models.CreateProductActionRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		respondWithError(c, http.StatusBadRequest, err.Error())
-		return
-	}
-
-	// Verify product exists
-	var product models.Product
-	if result := database.DB.First(&product, "id = ?", req.ProductID); result.Error != nil {
-		respondWithError(c, http.StatusNotFound, "Product not found")
+	if !bindRequest(c, &req) {
 		return
 	}
 
 	action := models.ProductAction{
-		ProductID:        req.ProductID,
-		LinkedFeedbackID: req.LinkedFeedbackID,
-		ActionType:       req.ActionType,
-		Title:            req.Title,
-		Description:      req.Description,
-		AssignedTo:       req.AssignedTo,
-		DueDate:          req.DueDate,
+		ProductID:          req.ProductID,
+		LinkedFeedbackID:   req.LinkedFeedbackID,
+		LinkedEscalationID: req.LinkedEscalationID,
+		ActionType:         req.ActionType,
+		Title:              req.Title,
+		Description:        req.Description,
+		AssignedTo:         req.AssignedTo,
+		DueDate:            req.DueDate,
 	}
 
 	if req.Status != nil {
@@ -126,9 +252,25 @@ func (h *ActionsHandler) CreateAction(c *gin.Context) {
 		action.CreatedBy = &userIDStr
 	}
 
-	result := database.DB.Create(&action)
-	if result.Error != nil {
-		respondWithError(c, http.StatusInternalServerError, result.Error.Error())
+	msg, err := actionLinkError(database.DB, req.LinkedFeedbackID, req.LinkedEscalationID)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if msg != "" {
+		respondWithError(c, http.StatusBadRequest, msg)
+		return
+	}
+
+	err = createForProduct(database.DB, req.ProductID, func(tx *gorm.DB) error {
+		return tx.Create(&action).Error
+	})
+	if errors.Is(err, errProductNotFound) {
+		respondWithError(c, http.StatusNotFound, "Product not found")
+		return
+	}
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -150,8 +292,17 @@ func (h *ActionsHandler) UpdateAction(c *gin.Context) {
 	}
 
 	var req models.UpdateProductActionRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		respondWithError(c, http.StatusBadRequest, err.Error())
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	msg, err := actionLinkError(database.DB, req.LinkedFeedbackID, req.LinkedEscalationID)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if msg != "" {
+		respondWithError(c, http.StatusBadRequest, msg)
 		return
 	}
 
@@ -159,6 +310,9 @@ func (h *ActionsHandler) UpdateAction(c *gin.Context) {
 	if req.LinkedFeedbackID != nil {
 		updates["linked_feedback_id"] = *req.LinkedFeedbackID
 	}
+	if req.LinkedEscalationID != nil {
+		updates["linked_escalation_id"] = *req.LinkedEscalationID
+	}
 	if req.ActionType != nil {
 		updates["action_type"] = *req.ActionType
 	}
@@ -172,12 +326,11 @@ func (h *ActionsHandler) UpdateAction(c *gin.Context) {
 		updates["assigned_to"] = *req.AssignedTo
 	}
 	if req.Status != nil {
-		updates["status"] = *req.Status
-		// Auto-set completed_at when status changes to completed
-		if *req.Status == models.ActionStatusCompleted && action.CompletedAt == nil {
-			now := time.Now()
-			updates["completed_at"] = now
+		if !models.CanTransition(action.Status, *req.Status) {
+			respondWithError(c, http.StatusUnprocessableEntity, actionTransitionError(action.Status, *req.Status))
+			return
 		}
+		setActionStatus(updates, action, *req.Status, time.Now())
 	}
 	if req.Priority != nil {
 		updates["priority"] = *req.Priority
@@ -200,6 +353,100 @@ func (h *ActionsHandler) UpdateAction(c *gin.Context) {
 	respondWithData(c, http.StatusOK, action)
 }
 
+// setActionStatus adds the columns for moving the action to status to
+// updates, auto-setting completed_at when it is first completed and
+// clearing it when the action is reopened
+func setActionStatus(updates map[string]interface{}, action models.ProductAction, status models.ActionStatus, now time.Time) {
+	updates["status"] = status
+	switch {
+	case status == models.ActionStatusCompleted && action.CompletedAt == nil:
+		updates["completed_at"] = now
+	case status != models.ActionStatusCompleted && action.Status == models.ActionStatusCompleted:
+		updates["completed_at"] = nil
+	}
+}
+
+func actionTransitionError(from, to models.ActionStatus) string {
+	return fmt.Sprintf("Cannot move action from %s to %s", from, to)
+}
+
+// ActionStatusResult is the outcome of one change in a batch status update
+type ActionStatusResult struct {
+	ID      uuid.UUID             `json:"id"`
+	Success bool                  `json:"success"`
+	Error   string                `json:"error,omitempty"`
+	Action  *models.ProductAction `json:"action,omitempty"`
+}
+
+// applyActionStatusChanges applies the changes in one transaction. A change
+// naming an unknown action, an invalid status or an illegal transition fails
+// on its own; only a database error fails the batch.
+func applyActionStatusChanges(db *gorm.DB, changes []models.ActionStatusChange, now time.Time) ([]ActionStatusResult, error) {
+	results := make([]ActionStatusResult, 0, len(changes))
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, change := range changes {
+			result := ActionStatusResult{ID: change.ID}
+			if err := change.Status.Check(); err != nil {
+				result.Error = err.Error()
+				results = append(results, result)
+				continue
+			}
+
+			var action models.ProductAction
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&action, "id = ?", change.ID).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				result.Error = "Action not found"
+				results = append(results, result)
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			if !models.CanTransition(action.Status, change.Status) {
+				result.Error = actionTransitionError(action.Status, change.Status)
+				results = append(results, result)
+				continue
+			}
+
+			updates := map[string]interface{}{}
+			setActionStatus(updates, action, change.Status, now)
+			if err := tx.Model(&action).Updates(updates).Error; err != nil {
+				return err
+			}
+			if err := tx.First(&action, "id = ?", change.ID).Error; err != nil {
+				return err
+			}
+			result.Success = true
+			result.Action = &action
+			results = append(results, result)
+		}
+		return nil
+	})
+	return results, err
+}
+
+// BatchUpdateActionStatus moves several actions to new statuses at once and
+// reports the outcome of each
+func (h *ActionsHandler) BatchUpdateActionStatus(c *gin.Context) {
+	var changes []models.ActionStatusChange
+	if !bindRequest(c, &changes) {
+		return
+	}
+	if len(changes) == 0 || len(changes) > models.MaxActionBatchSize {
+		respondWithError(c, http.StatusBadRequest, fmt.Sprintf("Send between 1 and %d status changes", models.MaxActionBatchSize))
+		return
+	}
+
+	results, err := applyActionStatusChanges(database.DB, changes, time.Now())
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithData(c, http.StatusOK, results)
+}
+
 // DeleteAction deletes an action
 func (h *ActionsHandler) DeleteAction(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
@@ -221,3 +468,291 @@ func (h *ActionsHandler) DeleteAction(c *gin.Context) {
 
 	respondWithSuccess(c, http.StatusOK, "Action deleted successfully", nil)
 }
+
+var (
+	errActionNotFound   = errors.New("action not found")
+	errCommentNotFound  = errors.New("comment not found")
+	errNotCommentAuthor = errors.New("only the author or an admin can delete this comment")
+)
+
+// actionComments returns one page of an action's comments, oldest first, and
+// their total
+func actionComments(db *gorm.DB, actionID uuid.UUID, page, pageSize int) ([]models.ActionComment, int64, error) {
+	var action models.ProductAction
+	if err := db.Select("id").First(&action, "id = ?", actionID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, 0, errActionNotFound
+		}
+		return nil, 0, err
+	}
+
+	var total int64
+	query := db.Model(&models.ActionComment{}).Where("action_id = ?", actionID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var comments []models.ActionComment
+	err := query.
+		Order("created_at ASC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&comments).Error
+	return comments, total, err
+}
+
+// GetActionComments retrieves the progress notes for an action, oldest first
+func (h *ActionsHandler) GetActionComments(c *gin.Context) {
+	actionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "Invalid action ID")
+		return
+	}
+
+	page, pageSize := parsePagination(c, defaultPageSize, maxPageSize)
+
+	comments, total, err := actionComments(database.DB, actionID, page, pageSize)
+	if errors.Is(err, errActionNotFound) {
+		respondWithError(c, http.StatusNotFound, "Action not found")
+		return
+	}
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithPagination(c, comments, total, page, pageSize, newListMeta("created_at"))
+}
+
+// CreateActionComment adds a progress note to an action
+func (h *ActionsHandler) CreateActionComment(c *gin.Context) {
+	actionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "Invalid action ID")
+		return
+	}
+
+	var action models.ProductAction
+	if result := database.DB.First(&action, "id = ?", actionID); result.Error != nil {
+		respondWithError(c, http.StatusNotFound, "Action not found")
+		return
+	}
+
+	var req models.CreateActionCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	body := middleware.SanitizeInput(req.Body, models.MaxActionCommentLength)
+	if body == "" {
+		respondWithError(c, http.StatusBadRequest, "Comment body cannot be empty")
+		return
+	}
+
+	comment := models.ActionComment{
+		ActionID: actionID,
+		Author:   currentUserID(c),
+		Body:     body,
+	}
+
+	result := database.DB.Create(&comment)
+	if result.Error != nil {
+		respondWithError(c, http.StatusInternalServerError, result.Error.Error())
+		return
+	}
+
+	respondWithData(c, http.StatusCreated, comment)
+}
+
+// deleteActionComment deletes one of an action's comments for userID, who
+// must be its author unless admin is set
+func deleteActionComment(db *gorm.DB, actionID, commentID uuid.UUID, userID string, admin bool) error {
+	var comment models.ActionComment
+	if err := db.First(&comment, "id = ? AND action_id = ?", commentID, actionID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errCommentNotFound
+		}
+		return err
+	}
+
+	if comment.Author != userID && !admin {
+		return errNotCommentAuthor
+	}
+	return db.Delete(&comment).Error
+}
+
+// DeleteActionComment deletes a comment; only its author or an admin may do so
+func (h *ActionsHandler) DeleteActionComment(c *gin.Context) {
+	actionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "Invalid action ID")
+		return
+	}
+
+	commentID, err := uuid.Parse(c.Param("commentId"))
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "Invalid comment ID")
+		return
+	}
+
+	err = deleteActionComment(database.DB, actionID, commentID, currentUserID(c), isAdmin(c))
+	switch {
+	case errors.Is(err, errCommentNotFound):
+		respondWithError(c, http.StatusNotFound, "Comment not found")
+		return
+	case errors.Is(err, errNotCommentAuthor):
+		respondWithError(c, http.StatusForbidden, "Only the author or an admin can delete this comment")
+		return
+	case err != nil:
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithSuccess(c, http.StatusOK, "Comment deleted successfully", nil)
+}
+
+// AssigneeWorkload is one assignee's open actions; a nil AssignedTo groups
+// the unassigned ones
+type AssigneeWorkload struct {
+	AssignedTo     *string      `json:"assigned_to"`
+	OpenCount      int          `json:"open_count"`
+	OverdueCount   int          `json:"overdue_count"`
+	NearestDueDate *models.Date `json:"nearest_due_date,omitempty"`
+}
+
+// openActionStatuses are the statuses still waiting on their assignee
+var openActionStatuses = []models.ActionStatus{models.ActionStatusPending, models.ActionStatusInProgress}
+
+// assigneeWorkload groups open actions by assignee, busiest first. A nil
+// productID covers the whole portfolio.
+func assigneeWorkload(db *gorm.DB, productID *uuid.UUID, today models.Date) ([]AssigneeWorkload, error) {
+	query := db.Model(&models.ProductAction{}).
+		Select(`assigned_to,
+			COUNT(*) AS open_count,
+			SUM(CASE WHEN due_date < ? THEN 1 ELSE 0 END) AS overdue_count,
+			MIN(due_date) AS nearest_due_date`, today).
+		Where("status IN ?", openActionStatuses)
+	if productID != nil {
+		query = query.Where("product_id = ?", *productID)
+	}
+
+	workload := []AssigneeWorkload{}
+	err := query.
+		Group("assigned_to").
+		Order("open_count DESC").
+		Order("overdue_count DESC").
+		Order("assigned_to").
+		Scan(&workload).Error
+	return workload, err
+}
+
+// GetAssigneeWorkload returns open, overdue and nearest-due counts per
+// assignee on a product, or across the portfolio with ?all=true
+func (h *ActionsHandler) GetAssigneeWorkload(c *gin.Context) {
+	var productID *uuid.UUID
+	if c.Query("all") != "true" {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			respondWithError(c, http.StatusBadRequest, "Invalid product ID")
+			return
+		}
+		productID = &id
+	}
+
+	workload, err := assigneeWorkload(database.DB, productID, models.Today())
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithData(c, http.StatusOK, workload)
+}
+
+// BurndownPoint is the number of open actions at the end of a day
+type BurndownPoint struct {
+	Date models.Date `json:"date"`
+	Open int         `json:"open"`
+}
+
+const maxBurndownDays = 366
+
+// actionClosedAt returns when an action stopped being open, or nil if it still is.
+// Cancelled actions carry no completed_at, so their last update is used instead.
+func actionClosedAt(action models.ProductAction) *time.Time {
+	if action.CompletedAt != nil {
+		return &action.CompletedAt.Time
+	}
+	if action.Status == models.ActionStatusCancelled {
+		return &action.UpdatedAt.Time
+	}
+	return nil
+}
+
+// buildBurndown counts, for each day in [from, to], the actions created before
+// the end of that day and not yet closed by then
+func buildBurndown(actions []models.ProductAction, from, to models.Date) []BurndownPoint {
+	series := []BurndownPoint{}
+	for day := from.Time; !day.After(to.Time); day = day.AddDate(0, 0, 1) {
+		endOfDay := day.AddDate(0, 0, 1)
+		open := 0
+		for _, action := range actions {
+			if !action.CreatedAt.Before(endOfDay) {
+				continue
+			}
+			if closedAt := actionClosedAt(action); closedAt != nil && closedAt.Before(endOfDay) {
+				continue
+			}
+			open++
+		}
+		series = append(series, BurndownPoint{Date: models.NewDate(day), Open: open})
+	}
+	return series
+}
+
+// GetProductActionBurndown reconstructs the daily open-action count for a product
+func (h *ActionsHandler) GetProductActionBurndown(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	to := models.Today()
+	if raw := c.Query("to"); raw != "" {
+		if to, err = models.ParseDate(raw); err != nil {
+			respondWithError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	from := models.NewDate(to.AddDate(0, 0, -29))
+	if raw := c.Query("from"); raw != "" {
+		if from, err = models.ParseDate(raw); err != nil {
+			respondWithError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	if from.After(to.Time) {
+		respondWithError(c, http.StatusBadRequest, "from must be on or before to")
+		return
+	}
+	if to.Sub(from.Time) > maxBurndownDays*24*time.Hour {
+		respondWithError(c, http.StatusBadRequest, "Date range cannot exceed 366 days")
+		return
+	}
+
+	var actions []models.ProductAction
+	result := database.DB.
+		Where("product_id = ?", productID).
+		Where("created_at < ?", to.AddDate(0, 0, 1)).
+		Find(&actions)
+
+	if result.Error != nil {
+		respondWithError(c, http.StatusInternalServerError, result.Error.Error())
+		return
+	}
+
+	respondWithData(c, http.StatusOK, buildBurndown(actions, from, to))
+}
@@ -0,0 +1,469 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+)
+
+const productActionsDDL = `CREATE TABLE product_actions (
+	id TEXT PRIMARY KEY,
+	product_id TEXT NOT NULL,
+	linked_feedback_id TEXT,
+	linked_escalation_id TEXT,
+	action_type TEXT NOT NULL,
+	title TEXT NOT NULL,
+	description TEXT,
+	assigned_to TEXT,
+	status TEXT NOT NULL DEFAULT 'pending',
+	priority TEXT NOT NULL DEFAULT 'medium',
+	due_date TEXT,
+	completed_at DATETIME,
+	created_by TEXT,
+	source_ref TEXT,
+	created_at DATETIME,
+	updated_at DATETIME
+)`
+
+func TestAssigneeWorkload(t *testing.T) {
+	db := openTestDB(t, productActionsDDL)
+	productID, otherProduct := uuid.New(), uuid.New()
+	today, _ := models.ParseDate("2025-03-10")
+
+	ana, ben := "ana@example.com", "ben@example.com"
+	date := func(s string) *models.Date {
+		d, _ := models.ParseDate(s)
+		return &d
+	}
+	actions := []models.ProductAction{
+		{ProductID: productID, AssignedTo: &ana, Status: models.ActionStatusPending, DueDate: date("2025-03-01")},
+		{ProductID: productID, AssignedTo: &ana, Status: models.ActionStatusInProgress, DueDate: date("2025-03-20")},
+		{ProductID: productID, AssignedTo: &ana, Status: models.ActionStatusCompleted, DueDate: date("2025-02-01")},
+		{ProductID: productID, AssignedTo: &ben, Status: models.ActionStatusPending},
+		{ProductID: productID, Status: models.ActionStatusPending, DueDate: date("2025-03-05")},
+		{ProductID: otherProduct, AssignedTo: &ben, Status: models.ActionStatusPending, DueDate: date("2025-03-02")},
+		{ProductID: otherProduct, AssignedTo: &ben, Status: models.ActionStatusPending},
+	}
+	for i := range actions {
+		actions[i].ActionType = models.ActionTypeIntervention
+		actions[i].Title = "action"
+		if err := db.Create(&actions[i]).Error; err != nil {
+			t.Fatalf("seed: %v", err)
+		}
+	}
+
+	workload, err := assigneeWorkload(db, &productID, today)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(workload) != 3 {
+		t.Fatalf("got %d assignees, want 3: %+v", len(workload), workload)
+	}
+	first := workload[0]
+	if first.AssignedTo == nil || *first.AssignedTo != ana || first.OpenCount != 2 || first.OverdueCount != 1 {
+		t.Errorf("busiest = %+v, want ana with 2 open, 1 overdue", first)
+	}
+	if first.NearestDueDate == nil || first.NearestDueDate.String() != "2025-03-01" {
+		t.Errorf("nearest due = %v, want 2025-03-01", first.NearestDueDate)
+	}
+
+	portfolio, err := assigneeWorkload(db, nil, today)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if portfolio[0].AssignedTo == nil || *portfolio[0].AssignedTo != ben || portfolio[0].OpenCount != 3 {
+		t.Errorf("portfolio busiest = %+v, want ben with 3 open", portfolio[0])
+	}
+}
+
+func TestFilterActions(t *testing.T) {
+	db := openTestDB(t, productActionsDDL)
+	productID := uuid.New()
+	today := mustDate(t, "2025-03-10")
+	date := func(s string) *models.Date {
+		d := mustDate(t, s)
+		return &d
+	}
+	ana, ben := "ana@example.com", "ben@example.com"
+	seed := []models.ProductAction{
+		{Title: "overdue", AssignedTo: &ana, Status: models.ActionStatusPending, Priority: models.ActionPriorityMedium, DueDate: date("2025-03-01")},
+		{Title: "done late", AssignedTo: &ana, Status: models.ActionStatusCompleted, Priority: models.ActionPriorityCritical, DueDate: date("2025-03-02")},
+		{Title: "upcoming", AssignedTo: &ben, Status: models.ActionStatusInProgress, Priority: models.ActionPriorityHigh, DueDate: date("2025-03-20")},
+		{Title: "undated", AssignedTo: &ben, Status: models.ActionStatusPending, Priority: models.ActionPriorityLow},
+	}
+	for i := range seed {
+		seed[i].ProductID = productID
+		seed[i].ActionType = models.ActionTypeReview
+		if err := db.Create(&seed[i]).Error; err != nil {
+			t.Fatalf("create action: %v", err)
+		}
+	}
+
+	titles := func(query string) []string {
+		t.Helper()
+		params, _ := url.ParseQuery(query)
+		filtered, _, err := filterActions(db, params, today)
+		if err != nil {
+			t.Fatalf("%s: %v", query, err)
+		}
+		var actions []models.ProductAction
+		if err := filtered.Find(&actions).Error; err != nil {
+			t.Fatalf("%s: %v", query, err)
+		}
+		var got []string
+		for _, a := range actions {
+			got = append(got, a.Title)
+		}
+		return got
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"sort=-priority", []string{"done late", "upcoming", "overdue", "undated"}},
+		{"sort=priority", []string{"undated", "overdue", "upcoming", "done late"}},
+		{"sort=due_date&assigned_to=ben@example.com", []string{"upcoming", "undated"}},
+		{"sort=due_date&due_after=2025-03-01&due_before=2025-03-20", []string{"done late"}},
+		{"overdue=true", []string{"overdue"}},
+	}
+	for _, tt := range tests {
+		if got := titles(tt.query); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.query, got, tt.want)
+		}
+	}
+
+	for _, bad := range []url.Values{
+		{"sort": {"title; DROP TABLE product_actions"}},
+		{"due_before": {"soon"}},
+	} {
+		if _, _, err := filterActions(db, bad, today); err == nil {
+			t.Errorf("%v: expected an error", bad)
+		}
+	}
+}
+
+func TestGetAllActions_DueRange(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := openTestDB(t, productActionsDDL)
+	useTestDB(t, db)
+	productID := uuid.New()
+	for title, due := range map[string]string{"early": "2025-03-01", "middle": "2025-03-10", "late": "2025-03-20"} {
+		d := mustDate(t, due)
+		action := models.ProductAction{ProductID: productID, Title: title, ActionType: models.ActionTypeReview,
+			Status: models.ActionStatusPending, Priority: models.ActionPriorityMedium, DueDate: &d}
+		if err := db.Create(&action).Error; err != nil {
+			t.Fatalf("create action: %v", err)
+		}
+	}
+	db.Create(&models.ProductAction{ProductID: productID, Title: "undated", ActionType: models.ActionTypeReview,
+		Status: models.ActionStatusPending, Priority: models.ActionPriorityMedium})
+
+	tests := []struct {
+		query string
+		code  int
+		want  []string
+	}{
+		{"due_from=2025-03-01&due_to=2025-03-10", http.StatusOK, []string{"early", "middle"}},
+		{"due_from=2025-03-10", http.StatusOK, []string{"middle", "late"}},
+		{"due_to=2025-03-10", http.StatusOK, []string{"early", "middle"}},
+		{"due_from=2025-03-10&due_to=2025-03-10", http.StatusOK, []string{"middle"}},
+		{"due_from=March", http.StatusBadRequest, nil},
+		{"due_to=2025-13-01", http.StatusBadRequest, nil},
+		{"due_from=2025-03-10&due_to=2025-03-01", http.StatusBadRequest, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/actions?sort=due_date&"+tt.query, nil)
+
+			NewActionsHandler(nil).GetAllActions(c)
+			if w.Code != tt.code {
+				t.Fatalf("status = %d, want %d (body %s)", w.Code, tt.code, w.Body.String())
+			}
+			if tt.code != http.StatusOK {
+				return
+			}
+
+			var body struct {
+				Data []models.ProductAction `json:"data"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("decode %s: %v", w.Body.String(), err)
+			}
+			var got []string
+			for _, a := range body.Data {
+				got = append(got, a.Title)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestActionLinkError(t *testing.T) {
+	db := openTestDB(t,
+		`CREATE TABLE product_feedback (id TEXT PRIMARY KEY)`,
+		`CREATE TABLE product_escalations (id TEXT PRIMARY KEY)`,
+	)
+	feedbackID, escalationID, missing := uuid.New(), uuid.New(), uuid.New()
+	db.Exec(`INSERT INTO product_feedback (id) VALUES (?)`, feedbackID)
+	db.Exec(`INSERT INTO product_escalations (id) VALUES (?)`, escalationID)
+
+	tests := []struct {
+		name                 string
+		feedback, escalation *uuid.UUID
+		want                 string
+	}{
+		{"no links", nil, nil, ""},
+		{"existing links", &feedbackID, &escalationID, ""},
+		{"missing feedback", &missing, &escalationID, "Linked feedback not found"},
+		{"missing escalation", &feedbackID, &missing, "Linked escalation not found"},
+	}
+	for _, tt := range tests {
+		got, err := actionLinkError(db, tt.feedback, tt.escalation)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestApplyActionStatusChanges(t *testing.T) {
+	db := openTestDB(t, productActionsDDL)
+	productID := uuid.New()
+	seed := func(status models.ActionStatus) uuid.UUID {
+		action := models.ProductAction{ProductID: productID, ActionType: models.ActionTypeReview, Title: string(status),
+			Status: status, Priority: models.ActionPriorityMedium}
+		if err := db.Create(&action).Error; err != nil {
+			t.Fatalf("seed: %v", err)
+		}
+		return action.ID
+	}
+	pending, inProgress, cancelled := seed(models.ActionStatusPending), seed(models.ActionStatusInProgress), seed(models.ActionStatusCancelled)
+	missing := uuid.New()
+
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	results, err := applyActionStatusChanges(db, []models.ActionStatusChange{
+		{ID: pending, Status: models.ActionStatusCompleted},
+		{ID: inProgress, Status: models.ActionStatusCompleted},
+		{ID: cancelled, Status: models.ActionStatusCompleted},
+		{ID: missing, Status: models.ActionStatusCompleted},
+		{ID: pending, Status: "done"},
+	}, now)
+	if err != nil {
+		t.Fatalf("applyActionStatusChanges: %v", err)
+	}
+
+	wantSuccess := []bool{true, true, false, false, false}
+	if len(results) != len(wantSuccess) {
+		t.Fatalf("got %d results, want %d", len(results), len(wantSuccess))
+	}
+	for i, want := range wantSuccess {
+		if results[i].Success != want {
+			t.Errorf("result %d: success = %v (%s), want %v", i, results[i].Success, results[i].Error, want)
+		}
+		if !want && results[i].Error == "" {
+			t.Errorf("result %d: failure without an error", i)
+		}
+	}
+
+	for _, id := range []uuid.UUID{pending, inProgress} {
+		var action models.ProductAction
+		db.First(&action, "id = ?", id)
+		if action.Status != models.ActionStatusCompleted || action.CompletedAt == nil || !action.CompletedAt.Time.Equal(now) {
+			t.Errorf("%s: status %s, completed_at %v; want completed at %v", action.Title, action.Status, action.CompletedAt, now)
+		}
+	}
+	var stillCancelled models.ProductAction
+	db.First(&stillCancelled, "id = ?", cancelled)
+	if stillCancelled.Status != models.ActionStatusCancelled || stillCancelled.CompletedAt != nil {
+		t.Errorf("cancelled action changed to %s", stillCancelled.Status)
+	}
+}
+
+func TestSetActionStatus(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	completedAt := models.Timestamp{Time: now.Add(-time.Hour)}
+
+	tests := []struct {
+		name   string
+		action models.ProductAction
+		to     models.ActionStatus
+		want   interface{} // completed_at written; absent when missing
+	}{
+		{"first completion", models.ProductAction{Status: models.ActionStatusInProgress}, models.ActionStatusCompleted, now},
+		{"already completed", models.ProductAction{Status: models.ActionStatusCompleted, CompletedAt: &completedAt}, models.ActionStatusCompleted, "absent"},
+		{"reopened", models.ProductAction{Status: models.ActionStatusCompleted, CompletedAt: &completedAt}, models.ActionStatusInProgress, nil},
+		{"cancelled after completion", models.ProductAction{Status: models.ActionStatusCompleted, CompletedAt: &completedAt}, models.ActionStatusCancelled, nil},
+		{"started", models.ProductAction{Status: models.ActionStatusPending}, models.ActionStatusInProgress, "absent"},
+	}
+	for _, tt := range tests {
+		updates := map[string]interface{}{}
+		setActionStatus(updates, tt.action, tt.to, now)
+		got, ok := updates["completed_at"]
+		if !ok {
+			got = "absent"
+		}
+		if updates["status"] != tt.to || got != tt.want {
+			t.Errorf("%s: status %v, completed_at %v; want %s, %v", tt.name, updates["status"], got, tt.to, tt.want)
+		}
+	}
+}
+
+func TestActionComments(t *testing.T) {
+	db := openTestDB(t, productActionsDDL,
+		`CREATE TABLE action_comments (id TEXT PRIMARY KEY, action_id TEXT NOT NULL, author TEXT NOT NULL, body TEXT NOT NULL, created_at DATETIME)`,
+	)
+	action := models.ProductAction{ProductID: uuid.New(), ActionType: models.ActionTypeReview, Title: "Review"}
+	if err := db.Create(&action).Error; err != nil {
+		t.Fatalf("create action: %v", err)
+	}
+	comment := func(author, body string) models.ActionComment {
+		c := models.ActionComment{ActionID: action.ID, Author: author, Body: body}
+		if err := db.Create(&c).Error; err != nil {
+			t.Fatalf("create comment: %v", err)
+		}
+		return c
+	}
+	first, second := comment("ana", "first"), comment("ben", "second")
+
+	t.Run("list", func(t *testing.T) {
+		comments, total, err := actionComments(db, action.ID, 1, 10)
+		if err != nil || total != 2 || len(comments) != 2 || comments[0].Body != "first" {
+			t.Errorf("comments = %+v, total %d, %v", comments, total, err)
+		}
+		if _, _, err := actionComments(db, uuid.New(), 1, 10); !errors.Is(err, errActionNotFound) {
+			t.Errorf("unknown action: %v, want errActionNotFound", err)
+		}
+	})
+
+	t.Run("non-author", func(t *testing.T) {
+		if err := deleteActionComment(db, action.ID, first.ID, "ben", false); !errors.Is(err, errNotCommentAuthor) {
+			t.Errorf("got %v, want errNotCommentAuthor", err)
+		}
+	})
+
+	t.Run("wrong action", func(t *testing.T) {
+		if err := deleteActionComment(db, uuid.New(), first.ID, "ana", false); !errors.Is(err, errCommentNotFound) {
+			t.Errorf("got %v, want errCommentNotFound", err)
+		}
+	})
+
+	t.Run("author", func(t *testing.T) {
+		if err := deleteActionComment(db, action.ID, first.ID, "ana", false); err != nil {
+			t.Fatalf("author delete: %v", err)
+		}
+		if err := deleteActionComment(db, action.ID, first.ID, "ana", false); !errors.Is(err, errCommentNotFound) {
+			t.Errorf("deleting again: %v, want errCommentNotFound", err)
+		}
+	})
+
+	t.Run("admin", func(t *testing.T) {
+		if err := deleteActionComment(db, action.ID, second.ID, "carol", true); err != nil {
+			t.Fatalf("admin delete: %v", err)
+		}
+		if _, total, _ := actionComments(db, action.ID, 1, 10); total != 0 {
+			t.Errorf("%d comments left, want none", total)
+		}
+	})
+}
+
+func TestBuildBurndown(t *testing.T) {
+	at := func(s string) models.Timestamp {
+		ts, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatalf("parse %q: %v", s, err)
+		}
+		return models.NewTimestamp(ts)
+	}
+	action := func(created string, completed string, status models.ActionStatus, updated string) models.ProductAction {
+		a := models.ProductAction{CreatedAt: at(created), Status: status}
+		if completed != "" {
+			done := at(completed)
+			a.CompletedAt = &done
+		}
+		if updated != "" {
+			a.UpdatedAt = at(updated)
+		}
+		return a
+	}
+	from, to := mustDate(t, "2025-03-01"), mustDate(t, "2025-03-03")
+
+	tests := []struct {
+		name    string
+		actions []models.ProductAction
+		want    []int
+	}{
+		{"none", nil, []int{0, 0, 0}},
+		{"open before the range stays open", []models.ProductAction{
+			action("2025-02-20T10:00:00Z", "", models.ActionStatusPending, ""),
+		}, []int{1, 1, 1}},
+		{"opened mid-range and still open at to", []models.ProductAction{
+			action("2025-03-02T23:59:00Z", "", models.ActionStatusInProgress, ""),
+		}, []int{0, 1, 1}},
+		{"opened and closed the same day never counts", []models.ProductAction{
+			action("2025-03-02T09:00:00Z", "2025-03-02T17:00:00Z", models.ActionStatusCompleted, ""),
+		}, []int{0, 0, 0}},
+		{"closed on the first day", []models.ProductAction{
+			action("2025-02-10T09:00:00Z", "2025-03-01T12:00:00Z", models.ActionStatusCompleted, ""),
+		}, []int{0, 0, 0}},
+		{"closed just after the last day", []models.ProductAction{
+			action("2025-02-10T09:00:00Z", "2025-03-04T00:00:00Z", models.ActionStatusCompleted, ""),
+		}, []int{1, 1, 1}},
+		{"cancelled closes at its last update", []models.ProductAction{
+			action("2025-02-10T09:00:00Z", "", models.ActionStatusCancelled, "2025-03-02T08:00:00Z"),
+		}, []int{1, 0, 0}},
+		{"created after the range", []models.ProductAction{
+			action("2025-03-04T00:00:00Z", "", models.ActionStatusPending, ""),
+		}, []int{0, 0, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			series := buildBurndown(tt.actions, from, to)
+			if len(series) != 3 || series[0].Date.Format("2006-01-02") != "2025-03-01" || series[2].Date.Format("2006-01-02") != "2025-03-03" {
+				t.Fatalf("series = %+v, want one point per day from 2025-03-01 to 2025-03-03", series)
+			}
+			got := make([]int, len(series))
+			for i, point := range series {
+				got[i] = point.Open
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("open = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if series := buildBurndown(nil, to, to); len(series) != 1 {
+		t.Errorf("single day: %+v, want one point", series)
+	}
+}
+
+func TestGetProductActionBurndown_RejectsBadRanges(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	for _, query := range []string{"from=2025-03-05&to=2025-03-01", "from=2024-01-01&to=2025-03-01", "to=tomorrow"} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "id", Value: uuid.NewString()}}
+		c.Request = httptest.NewRequest(http.MethodGet, "/burndown?"+query, nil)
+
+		NewActionsHandler(nil).GetProductActionBurndown(c)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", query, w.Code)
+		}
+	}
+}
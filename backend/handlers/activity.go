@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pauly7610/studio-pilot-vision/backend/database"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+)
+
+// ActivityHandler serves operational write-volume stats for admins. It is a
+// health view of what is being written, not a business metric.
+type ActivityHandler struct{}
+
+func NewActivityHandler() *ActivityHandler {
+	return &ActivityHandler{}
+}
+
+const (
+	defaultActivityDays = 7
+	maxActivityDays     = 90
+)
+
+// activityResource is a table whose writes are counted. Tables without an
+// updated_at column only report creates.
+type activityResource struct {
+	Name         string
+	Table        string
+	TracksUpdate bool
+}
+
+var activityResources = []activityResource{
+	{Name: "products", Table: "products", TracksUpdate: true},
+	{Name: "feedback", Table: "product_feedback"},
+	{Name: "actions", Table: "product_actions", TracksUpdate: true},
+	{Name: "action_comments", Table: "action_comments"},
+	{Name: "metrics", Table: "product_metrics"},
+	{Name: "dependencies", Table: "product_dependencies", TracksUpdate: true},
+}
+
+// ActivityDay is the write volume for one resource on one day
+type ActivityDay struct {
+	Date    models.Date `json:"date"`
+	Created int         `json:"created"`
+	Updated int         `json:"updated"`
+}
+
+// ResourceActivity is the daily write volume for one resource. Updated counts
+// rows whose latest update fell on that day, since earlier updates are not
+// kept.
+type ResourceActivity struct {
+	Resource      string        `json:"resource"`
+	TracksUpdates bool          `json:"tracks_updates"`
+	TotalCreated  int           `json:"total_created"`
+	TotalUpdated  int           `json:"total_updated"`
+	Days          []ActivityDay `json:"days"`
+}
+
+// ActivityReport covers every counted resource over the same window
+type ActivityReport struct {
+	From      models.Date        `json:"from"`
+	To        models.Date        `json:"to"`
+	Resources []ResourceActivity `json:"resources"`
+}
+
+type dailyCount struct {
+	Day   time.Time
+	Count int
+}
+
+// buildResourceActivity lays daily counts onto a zero-filled series from
+// from to to inclusive
+func buildResourceActivity(resource activityResource, from, to models.Date, created, updated []dailyCount) ResourceActivity {
+	activity := ResourceActivity{Resource: resource.Name, TracksUpdates: resource.TracksUpdate, Days: []ActivityDay{}}
+	index := map[string]int{}
+	for day := from.Time; !day.After(to.Time); day = day.AddDate(0, 0, 1) {
+		date := models.NewDate(day)
+		index[date.String()] = len(activity.Days)
+		activity.Days = append(activity.Days, ActivityDay{Date: date})
+	}
+
+	for _, row := range created {
+		if i, ok := index[models.NewDate(row.Day).String()]; ok {
+			activity.Days[i].Created += row.Count
+			activity.TotalCreated += row.Count
+		}
+	}
+	for _, row := range updated {
+		if i, ok := index[models.NewDate(row.Day).String()]; ok {
+			activity.Days[i].Updated += row.Count
+			activity.TotalUpdated += row.Count
+		}
+	}
+	return activity
+}
+
+func countByDay(table, column string, since time.Time, extra string) ([]dailyCount, error) {
+	query := database.DB.Table(table).
+		Select("date_trunc('day', "+column+") AS day, COUNT(*) AS count").
+		Where(column+" >= ?", since)
+	if extra != "" {
+		query = query.Where(extra)
+	}
+
+	var rows []dailyCount
+	err := query.Group("day").Scan(&rows).Error
+	return rows, err
+}
+
+// GetActivity returns daily create and update counts per resource for the
+// last ?days= days (default 7, max 90), so admins can spot unusual spikes
+func (h *ActivityHandler) GetActivity(c *gin.Context) {
+	days := defaultActivityDays
+	if raw := c.Query("days"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > maxActivityDays {
+			respondWithError(c, http.StatusBadRequest, "days must be between 1 and 90")
+			return
+		}
+		days = parsed
+	}
+
+	to := models.Today()
+	from := models.NewDate(to.AddDate(0, 0, -(days - 1)))
+	report := ActivityReport{From: from, To: to, Resources: []ResourceActivity{}}
+
+	for _, resource := range activityResources {
+		created, err := countByDay(resource.Table, "created_at", from.Time, "")
+		if err != nil {
+			respondWithError(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		var updated []dailyCount
+		if resource.TracksUpdate {
+			updated, err = countByDay(resource.Table, "updated_at", from.Time, "updated_at > created_at")
+			if err != nil {
+				respondWithError(c, http.StatusInternalServerError, err.Error())
+				return
+			}
+		}
+
+		report.Resources = append(report.Resources, buildResourceActivity(resource, from, to, created, updated))
+	}
+
+	respondWithData(c, http.StatusOK, report)
+}
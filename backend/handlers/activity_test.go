@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+)
+
+func TestBuildResourceActivity(t *testing.T) {
+	from, _ := models.ParseDate("2025-03-01")
+	to, _ := models.ParseDate("2025-03-03")
+	day := func(d int) time.Time { return time.Date(2025, 3, d, 0, 0, 0, 0, time.UTC) }
+
+	created := []dailyCount{{day(1), 4}, {day(3), 2000}, {day(5), 9}}
+	updated := []dailyCount{{day(2), 3}}
+
+	activity := buildResourceActivity(activityResource{Name: "feedback", TracksUpdate: true}, from, to, created, updated)
+	if len(activity.Days) != 3 {
+		t.Fatalf("got %d days, want 3", len(activity.Days))
+	}
+	if activity.TotalCreated != 2004 || activity.TotalUpdated != 3 {
+		t.Errorf("totals = %d created, %d updated; want 2004, 3", activity.TotalCreated, activity.TotalUpdated)
+	}
+	want := []ActivityDay{
+		{Date: from, Created: 4},
+		{Date: models.NewDate(day(2)), Updated: 3},
+		{Date: to, Created: 2000},
+	}
+	for i, w := range want {
+		got := activity.Days[i]
+		if got.Date.String() != w.Date.String() || got.Created != w.Created || got.Updated != w.Updated {
+			t.Errorf("day %d = %+v, want %+v", i, got, w)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/pauly7610/studio-pilot-vision/backend/config"
+	"github.com/pauly7610/studio-pilot-vision/backend/database"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+	"gorm.io/gorm"
+)
+
+// Product risk = Σ points of the signals that fire, giving 0-100:
+//
+//   - high_risk_band (30):       the readiness risk band is high
+//   - escalation (25 or 15):     the derived escalation is critical (25) or exec steerco (15)
+//   - negative_sentiment (20):   the merchant signal is negative
+//   - blocked_dependencies (25): 5 per blocked dependency, capped at 25
+const (
+	atRiskPointsHighRiskBand = 30.0
+	atRiskPointsCritical     = 25.0
+	atRiskPointsExecSteerCo  = 15.0
+	atRiskPointsNegative     = 20.0
+	atRiskPointsPerBlocked   = 5.0
+	atRiskPointsBlockedCap   = 25.0
+
+	defaultAtRiskLimit = 10
+	maxAtRiskLimit     = 100
+)
+
+// AtRiskReason is one signal that raised a product's risk score
+type AtRiskReason struct {
+	Signal string  `json:"signal"`
+	Points float64 `json:"points"`
+	Detail string  `json:"detail"`
+}
+
+// ProductAtRisk is a product's risk score with the reasons behind it
+type ProductAtRisk struct {
+	ProductID      uuid.UUID             `json:"product_id"`
+	Name           string                `json:"name"`
+	LifecycleStage models.LifecycleStage `json:"lifecycle_stage"`
+	Region         string                `json:"region"`
+	OwnerEmail     string                `json:"owner_email"`
+	Score          float64               `json:"score"`
+	Reasons        []AtRiskReason        `json:"reasons"`
+}
+
+// productAtRisk scores one product. Readiness, Feedback (newest first) and
+// Dependencies must be preloaded.
+func productAtRisk(rules config.EscalationRules, product models.Product) ProductAtRisk {
+	risk := ProductAtRisk{
+		ProductID:      product.ID,
+		Name:           product.Name,
+		LifecycleStage: product.LifecycleStage,
+		Region:         product.Region,
+		OwnerEmail:     product.OwnerEmail,
+		Reasons:        []AtRiskReason{},
+	}
+	add := func(signal string, points float64, detail string) {
+		risk.Reasons = append(risk.Reasons, AtRiskReason{Signal: signal, Points: points, Detail: detail})
+		risk.Score += points
+	}
+
+	level, band, cycles := evaluateEscalation(rules, product)
+	if models.RiskBand(band) == models.RiskBandHigh {
+		add("high_risk_band", atRiskPointsHighRiskBand, fmt.Sprintf("readiness score %.0f", product.Readiness.ReadinessScore))
+	}
+	switch level {
+	case models.EscalationLevelCritical:
+		add("escalation", atRiskPointsCritical, fmt.Sprintf("critical escalation after %d cycles at high risk", cycles))
+	case models.EscalationLevelExecSteerCo:
+		add("escalation", atRiskPointsExecSteerCo, fmt.Sprintf("exec steerco escalation after %d cycles at high risk", cycles))
+	}
+
+	if signal := merchantSignal(product.ID, product.Feedback); signal.Status == "negative" {
+		add("negative_sentiment", atRiskPointsNegative,
+			fmt.Sprintf("average sentiment %.2f across %d feedback", signal.AverageSentiment, signal.TotalFeedback))
+	}
+
+	blocked := 0
+	for _, dependency := range product.Dependencies {
+		if dependency.Status == models.DependencyStatusBlocked {
+			blocked++
+		}
+	}
+	if blocked > 0 {
+		add("blocked_dependencies", math.Min(atRiskPointsPerBlocked*float64(blocked), atRiskPointsBlockedCap),
+			fmt.Sprintf("%d blocked dependencies", blocked))
+	}
+
+	risk.Score = roundTo2(risk.Score)
+	return risk
+}
+
+// rankProductsAtRisk scores the products and returns the limit riskiest
+// scoring at least minScore, riskiest first. Products with no risk signal
+// are never listed.
+func rankProductsAtRisk(rules config.EscalationRules, products []models.Product, limit int, minScore float64) []ProductAtRisk {
+	ranked := []ProductAtRisk{}
+	for _, product := range products {
+		risk := productAtRisk(rules, product)
+		if risk.Score > 0 && risk.Score >= minScore {
+			ranked = append(ranked, risk)
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return ranked[i].Name < ranked[j].Name
+	})
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+	return ranked
+}
+
+// GetProductsAtRisk ranks live products by combined risk signals, returning
+// the top ?limit= (default 10, max 100) scoring at least ?min_score= (0-100)
+func (h *PortfolioHandler) GetProductsAtRisk(c *gin.Context) {
+	limit := defaultAtRiskLimit
+	if raw := c.Query("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > maxAtRiskLimit {
+			respondWithError(c, http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxAtRiskLimit))
+			return
+		}
+		limit = parsed
+	}
+	minScore := 0.0
+	if raw := c.Query("min_score"); raw != "" {
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil || parsed < 0 || parsed > 100 {
+			respondWithError(c, http.StatusBadRequest, "min_score must be between 0 and 100")
+			return
+		}
+		minScore = parsed
+	}
+
+	var products []models.Product
+	if err := database.DB.Scopes(models.ExcludeDrafts, models.ExcludeArchived).
+		Preload("Readiness").
+		Preload("Feedback", func(db *gorm.DB) *gorm.DB { return db.Order("created_at DESC") }).
+		Preload("Dependencies").
+		Find(&products).Error; err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithData(c, http.StatusOK, rankProductsAtRisk(h.rules, products, limit, minScore))
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pauly7610/studio-pilot-vision/backend/config"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+)
+
+func TestRankProductsAtRisk(t *testing.T) {
+	rules := config.DefaultEscalationRules()
+	atRisk := func(name string, band models.RiskBand, cyclesAgo int, sentiment float64, blocked int) models.Product {
+		product := models.Product{ID: uuid.New(), Name: name, Readiness: &models.ProductReadiness{ReadinessScore: 30, RiskBand: band}}
+		if cyclesAgo > 0 {
+			since := models.NewTimestamp(time.Now().AddDate(0, 0, -cyclesAgo*rules.CycleLengthDays-1))
+			product.GatingStatusSince = &since
+		}
+		if sentiment != 0 {
+			product.Feedback = []models.ProductFeedback{{SentimentScore: &sentiment}}
+		}
+		for i := 0; i < blocked; i++ {
+			product.Dependencies = append(product.Dependencies, models.ProductDependency{Status: models.DependencyStatusBlocked})
+		}
+		product.Dependencies = append(product.Dependencies, models.ProductDependency{Status: models.DependencyStatusPending})
+		return product
+	}
+	products := []models.Product{
+		atRisk("Healthy", models.RiskBandLow, 0, 0.8, 0),
+		atRisk("Critical", models.RiskBandHigh, 3, -0.6, 7),
+		atRisk("SteerCo", models.RiskBandHigh, 2, 0, 0),
+		atRisk("Blocked", models.RiskBandMedium, 0, 0, 1),
+		atRisk("Grumpy", models.RiskBandLow, 0, -0.5, 0),
+	}
+
+	ranked := rankProductsAtRisk(rules, products, 10, 0)
+	want := []struct {
+		name    string
+		score   float64
+		reasons int
+	}{
+		{"Critical", 100, 4},
+		{"SteerCo", 45, 2},
+		{"Grumpy", 20, 1},
+		{"Blocked", 5, 1},
+	}
+	if len(ranked) != len(want) {
+		t.Fatalf("got %d products, want %d: %+v", len(ranked), len(want), ranked)
+	}
+	for i, w := range want {
+		if ranked[i].Name != w.name || ranked[i].Score != w.score || len(ranked[i].Reasons) != w.reasons {
+			t.Errorf("rank %d = %s (%v, %d reasons), want %s (%v, %d reasons)",
+				i, ranked[i].Name, ranked[i].Score, len(ranked[i].Reasons), w.name, w.score, w.reasons)
+		}
+	}
+	if reason := ranked[0].Reasons[3]; reason.Signal != "blocked_dependencies" || reason.Points != atRiskPointsBlockedCap {
+		t.Errorf("blocked dependencies should be capped: %+v", reason)
+	}
+
+	if top := rankProductsAtRisk(rules, products, 1, 0); len(top) != 1 || top[0].Name != "Critical" {
+		t.Errorf("limit 1: got %+v", top)
+	}
+	if cut := rankProductsAtRisk(rules, products, 10, 20); len(cut) != 3 {
+		t.Errorf("min_score 20: got %d products, want 3", len(cut))
+	}
+	if none := rankProductsAtRisk(rules, nil, 10, 0); none == nil || len(none) != 0 {
+		t.Errorf("no products: got %#v, want an empty list", none)
+	}
+}
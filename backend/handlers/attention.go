@@ -0,0 +1,294 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/pauly7610/studio-pilot-vision/backend/database"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+	"gorm.io/gorm"
+)
+
+// Attention item kinds
+const (
+	AttentionKindAction     = "action"
+	AttentionKindEscalation = "escalation"
+	AttentionKindCompliance = "compliance"
+)
+
+// Urgency tiers of an attention item, most urgent first:
+//
+//   - critical: overdue or critical-priority actions, critical escalations,
+//     lapsed certifications
+//   - high:     high-priority actions or ones due within attentionSoonDays,
+//     exec steerco escalations, certifications lapsing within attentionSoonDays
+//   - normal:   everything else
+const (
+	UrgencyCritical = "critical"
+	UrgencyHigh     = "high"
+	UrgencyNormal   = "normal"
+
+	attentionSoonDays = 7
+)
+
+var urgencyRank = map[string]int{UrgencyCritical: 3, UrgencyHigh: 2, UrgencyNormal: 1}
+
+var attentionKindRank = map[string]int{AttentionKindAction: 0, AttentionKindEscalation: 1, AttentionKindCompliance: 2}
+
+// AttentionItem is one thing waiting on the user. Exactly one of Action,
+// Escalation and Compliance is set, matching Kind.
+type AttentionItem struct {
+	Kind        string       `json:"kind"`
+	ID          uuid.UUID    `json:"id"`
+	ProductID   uuid.UUID    `json:"product_id"`
+	ProductName string       `json:"product_name"`
+	Title       string       `json:"title"`
+	Urgency     string       `json:"urgency"`
+	Reason      string       `json:"reason"`
+	DueDate     *models.Date `json:"due_date,omitempty"`
+
+	Action     *models.ProductAction     `json:"action,omitempty"`
+	Escalation *models.ProductEscalation `json:"escalation,omitempty"`
+	Compliance *models.ProductCompliance `json:"compliance,omitempty"`
+}
+
+// AttentionInbox is everything needing the user's attention, most urgent
+// first
+type AttentionInbox struct {
+	Email  string          `json:"email"`
+	Region *string         `json:"region,omitempty"`
+	Counts map[string]int  `json:"counts"` // by kind
+	Items  []AttentionItem `json:"items"`
+}
+
+// attentionProducts returns the live products the profile answers for: those
+// it owns by owner_email, plus, for a regional lead, every product in its
+// region
+func attentionProducts(db *gorm.DB, profile models.Profile) (map[uuid.UUID]string, error) {
+	query := db.Model(&models.Product{}).
+		Select("products.id, products.name").
+		Scopes(models.ExcludeArchived)
+	if profile.Role == models.UserRoleRegionalLead && profile.Region != nil && *profile.Region != "" {
+		query = query.Where("LOWER(products.owner_email) = LOWER(?) OR products.region = ?", profile.Email, *profile.Region)
+	} else {
+		query = query.Where("LOWER(products.owner_email) = LOWER(?)", profile.Email)
+	}
+
+	var rows []struct {
+		ID   uuid.UUID
+		Name string
+	}
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	products := make(map[uuid.UUID]string, len(rows))
+	for _, row := range rows {
+		products[row.ID] = row.Name
+	}
+	return products, nil
+}
+
+// actionAttention rates an open action's urgency
+func actionAttention(action models.ProductAction, today models.Date) (urgency, reason string) {
+	switch {
+	case action.DueDate != nil && action.DueDate.Before(today.Time):
+		return UrgencyCritical, "overdue"
+	case action.Priority == models.ActionPriorityCritical:
+		return UrgencyCritical, "critical_priority"
+	case action.DueDate != nil && action.DueDate.Before(today.AddDate(0, 0, attentionSoonDays+1)):
+		return UrgencyHigh, "due_soon"
+	case action.Priority == models.ActionPriorityHigh:
+		return UrgencyHigh, "high_priority"
+	}
+	return UrgencyNormal, "assigned"
+}
+
+// escalationAttention rates an open escalation by its level
+func escalationAttention(level models.EscalationLevel) string {
+	switch level {
+	case models.EscalationLevelCritical:
+		return UrgencyCritical
+	case models.EscalationLevelExecSteerCo:
+		return UrgencyHigh
+	}
+	return UrgencyNormal
+}
+
+// complianceAttention rates an expiring certification by how soon it lapses
+func complianceAttention(record ExpiringCompliance) (urgency, reason string) {
+	switch {
+	case record.Expired:
+		return UrgencyCritical, "expired"
+	case record.DaysUntilExpiry <= attentionSoonDays:
+		return UrgencyHigh, "expiring_soon"
+	}
+	return UrgencyNormal, "expiring"
+}
+
+// sortAttention orders items by urgency, then due date with undated items
+// last, then kind and title
+func sortAttention(items []AttentionItem) {
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if urgencyRank[a.Urgency] != urgencyRank[b.Urgency] {
+			return urgencyRank[a.Urgency] > urgencyRank[b.Urgency]
+		}
+		if (a.DueDate == nil) != (b.DueDate == nil) {
+			return a.DueDate != nil
+		}
+		if a.DueDate != nil && !a.DueDate.Equal(b.DueDate.Time) {
+			return a.DueDate.Before(b.DueDate.Time)
+		}
+		if a.Kind != b.Kind {
+			return attentionKindRank[a.Kind] < attentionKindRank[b.Kind]
+		}
+		return a.Title < b.Title
+	})
+}
+
+// attentionInbox gathers the profile's open assigned actions, open
+// escalations on its products and their certifications lapsing within
+// withinDays. Actions are matched to the profile by email or full name, as
+// action reminders are.
+func attentionInbox(db *gorm.DB, profile models.Profile, today models.Date, withinDays int) (AttentionInbox, error) {
+	inbox := AttentionInbox{
+		Email:  profile.Email,
+		Region: profile.Region,
+		Counts: map[string]int{AttentionKindAction: 0, AttentionKindEscalation: 0, AttentionKindCompliance: 0},
+		Items:  []AttentionItem{},
+	}
+	add := func(item AttentionItem) {
+		inbox.Items = append(inbox.Items, item)
+		inbox.Counts[item.Kind]++
+	}
+
+	assignees := []string{profile.Email}
+	if profile.FullName != nil && *profile.FullName != "" {
+		assignees = append(assignees, *profile.FullName)
+	}
+	var actions []struct {
+		models.ProductAction
+		ProductName string
+	}
+	if err := db.Model(&models.ProductAction{}).
+		Select("product_actions.*, products.name AS product_name").
+		Joins("JOIN products ON products.id = product_actions.product_id").
+		Scopes(models.ExcludeDeleted, models.ExcludeArchived).
+		Where("product_actions.status IN ?", openActionStatuses).
+		Where("product_actions.assigned_to IN ?", assignees).
+		Scan(&actions).Error; err != nil {
+		return inbox, err
+	}
+	for i := range actions {
+		action := actions[i].ProductAction
+		urgency, reason := actionAttention(action, today)
+		add(AttentionItem{
+			Kind:        AttentionKindAction,
+			ID:          action.ID,
+			ProductID:   action.ProductID,
+			ProductName: actions[i].ProductName,
+			Title:       action.Title,
+			Urgency:     urgency,
+			Reason:      reason,
+			DueDate:     action.DueDate,
+			Action:      &action,
+		})
+	}
+
+	products, err := attentionProducts(db, profile)
+	if err != nil {
+		return inbox, err
+	}
+	if len(products) > 0 {
+		productIDs := make([]uuid.UUID, 0, len(products))
+		for id := range products {
+			productIDs = append(productIDs, id)
+		}
+
+		var escalations []models.ProductEscalation
+		if err := db.
+			Where("resolved_at IS NULL AND product_id IN ?", productIDs).
+			Find(&escalations).Error; err != nil {
+			return inbox, err
+		}
+		for i := range escalations {
+			escalation := escalations[i]
+			add(AttentionItem{
+				Kind:        AttentionKindEscalation,
+				ID:          escalation.ID,
+				ProductID:   escalation.ProductID,
+				ProductName: products[escalation.ProductID],
+				Title:       escalation.Action,
+				Urgency:     escalationAttention(escalation.Level),
+				Reason:      string(escalation.Level),
+				Escalation:  &escalation,
+			})
+		}
+
+		expiring, err := expiringCompliance(db, today, withinDays)
+		if err != nil {
+			return inbox, err
+		}
+		for i := range expiring {
+			if _, ok := products[expiring[i].ProductID]; !ok {
+				continue
+			}
+			record := expiring[i].ProductCompliance
+			urgency, reason := complianceAttention(expiring[i])
+			add(AttentionItem{
+				Kind:        AttentionKindCompliance,
+				ID:          record.ID,
+				ProductID:   record.ProductID,
+				ProductName: expiring[i].ProductName,
+				Title:       record.CertificationType,
+				Urgency:     urgency,
+				Reason:      reason,
+				DueDate:     record.ExpiryDate,
+				Compliance:  &record,
+			})
+		}
+	}
+
+	sortAttention(inbox.Items)
+	return inbox, nil
+}
+
+// GetMyAttention returns the authenticated user's inbox: open actions
+// assigned to them, open escalations on products they own and certifications
+// on those products lapsing within ?within_days= days (default 30), most
+// urgent first
+func (h *DashboardHandler) GetMyAttention(c *gin.Context) {
+	id, err := uuid.Parse(currentUserID(c))
+	if err != nil {
+		respondWithError(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	withinDays := defaultExpiringWithinDays
+	if raw := c.Query("within_days"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 0 || parsed > maxExpiringWithinDays {
+			respondWithError(c, http.StatusBadRequest, fmt.Sprintf("within_days must be between 0 and %d", maxExpiringWithinDays))
+			return
+		}
+		withinDays = parsed
+	}
+
+	var profile models.Profile
+	if database.DB.First(&profile, "id = ?", id).Error != nil {
+		respondWithError(c, http.StatusNotFound, "Profile not found")
+		return
+	}
+
+	inbox, err := attentionInbox(database.DB, profile, models.Today(), withinDays)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithData(c, http.StatusOK, inbox)
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+)
+
+func TestAttentionInbox(t *testing.T) {
+	db := openTestDB(t, productActionsDDL,
+		`CREATE TABLE products (id TEXT PRIMARY KEY, name TEXT, owner_email TEXT, region TEXT, archived_at DATETIME, deleted_at DATETIME)`,
+		`CREATE TABLE product_escalations (
+			id TEXT PRIMARY KEY, product_id TEXT NOT NULL, level TEXT NOT NULL, action TEXT NOT NULL,
+			owner TEXT NOT NULL, next_milestone TEXT, cycles_in_status INTEGER, triggered_at DATETIME,
+			resolved_at DATETIME, notes TEXT, acknowledged_at DATETIME, acknowledged_by TEXT,
+			created_at DATETIME, updated_at DATETIME)`,
+		`CREATE TABLE product_compliances (
+			id TEXT PRIMARY KEY, product_id TEXT NOT NULL, certification_type TEXT NOT NULL,
+			status TEXT NOT NULL, completed_date DATE, expiry_date DATE, notes TEXT,
+			created_at DATETIME, updated_at DATETIME)`,
+		`INSERT INTO products (id, name, owner_email, region) VALUES
+			('8d1e5a42-1f6b-4c55-9b39-0a4c1b0e0001', 'Wallet', 'Ana@Example.com', 'LATAM'),
+			('8d1e5a42-1f6b-4c55-9b39-0a4c1b0e0002', 'Checkout', 'bob@example.com', 'EU'),
+			('8d1e5a42-1f6b-4c55-9b39-0a4c1b0e0003', 'Payouts', 'bob@example.com', 'LATAM')`,
+		`INSERT INTO products (id, name, owner_email, region, archived_at) VALUES
+			('8d1e5a42-1f6b-4c55-9b39-0a4c1b0e0004', 'Legacy', 'ana@example.com', 'LATAM', '2025-01-01')`,
+		`INSERT INTO product_actions (id, product_id, action_type, title, assigned_to, status, priority, due_date) VALUES
+			('a0000000-0000-0000-0000-000000000001', '8d1e5a42-1f6b-4c55-9b39-0a4c1b0e0002', 'review', 'Overdue review', 'ana@example.com', 'pending', 'low', '2025-03-01'),
+			('a0000000-0000-0000-0000-000000000002', '8d1e5a42-1f6b-4c55-9b39-0a4c1b0e0002', 'review', 'Someday', 'Ana Lima', 'in_progress', 'medium', NULL),
+			('a0000000-0000-0000-0000-000000000003', '8d1e5a42-1f6b-4c55-9b39-0a4c1b0e0002', 'review', 'Due soon', 'ana@example.com', 'pending', 'low', '2025-03-12'),
+			('a0000000-0000-0000-0000-000000000004', '8d1e5a42-1f6b-4c55-9b39-0a4c1b0e0002', 'review', 'Finished', 'ana@example.com', 'completed', 'critical', '2025-03-01'),
+			('a0000000-0000-0000-0000-000000000005', '8d1e5a42-1f6b-4c55-9b39-0a4c1b0e0002', 'review', 'Not mine', 'bob@example.com', 'pending', 'critical', '2025-03-01'),
+			('a0000000-0000-0000-0000-000000000006', '8d1e5a42-1f6b-4c55-9b39-0a4c1b0e0004', 'review', 'Archived product', 'ana@example.com', 'pending', 'low', '2025-03-01')`,
+		`INSERT INTO product_escalations (id, product_id, level, action, owner, resolved_at) VALUES
+			('e0000000-0000-0000-0000-000000000001', '8d1e5a42-1f6b-4c55-9b39-0a4c1b0e0001', 'critical', 'Executive intervention', 'VP', NULL),
+			('e0000000-0000-0000-0000-000000000002', '8d1e5a42-1f6b-4c55-9b39-0a4c1b0e0001', 'ambassador_review', 'Resolved review', 'VP', '2025-03-01'),
+			('e0000000-0000-0000-0000-000000000003', '8d1e5a42-1f6b-4c55-9b39-0a4c1b0e0003', 'exec_steerco', 'SteerCo review', 'VP', NULL),
+			('e0000000-0000-0000-0000-000000000004', '8d1e5a42-1f6b-4c55-9b39-0a4c1b0e0002', 'critical', 'Someone else', 'VP', NULL)`,
+		`INSERT INTO product_compliances (id, product_id, certification_type, status, expiry_date) VALUES
+			('c0000000-0000-0000-0000-000000000001', '8d1e5a42-1f6b-4c55-9b39-0a4c1b0e0001', 'LGPD', 'complete', '2025-03-05'),
+			('c0000000-0000-0000-0000-000000000002', '8d1e5a42-1f6b-4c55-9b39-0a4c1b0e0001', 'PCI DSS', 'complete', '2025-04-01'),
+			('c0000000-0000-0000-0000-000000000003', '8d1e5a42-1f6b-4c55-9b39-0a4c1b0e0001', 'SOC 2', 'complete', '2025-09-01'),
+			('c0000000-0000-0000-0000-000000000004', '8d1e5a42-1f6b-4c55-9b39-0a4c1b0e0002', 'BACEN', 'complete', '2025-03-05')`,
+	)
+	today := mustDate(t, "2025-03-10")
+	name, region := "Ana Lima", "LATAM"
+
+	type want struct{ title, urgency, reason string }
+	check := func(t *testing.T, inbox AttentionInbox, expected []want) {
+		t.Helper()
+		if len(inbox.Items) != len(expected) {
+			t.Fatalf("got %d items, want %d: %+v", len(inbox.Items), len(expected), inbox.Items)
+		}
+		for i, w := range expected {
+			item := inbox.Items[i]
+			if item.Title != w.title || item.Urgency != w.urgency || item.Reason != w.reason {
+				t.Errorf("item %d = %s (%s, %s), want %s (%s, %s)", i, item.Title, item.Urgency, item.Reason, w.title, w.urgency, w.reason)
+			}
+		}
+	}
+
+	t.Run("owner", func(t *testing.T) {
+		profile := models.Profile{Email: "ana@example.com", FullName: &name, Role: models.UserRoleViewer, Region: &region}
+		inbox, err := attentionInbox(db, profile, today, 30)
+		if err != nil {
+			t.Fatalf("attentionInbox: %v", err)
+		}
+		check(t, inbox, []want{
+			{"Overdue review", UrgencyCritical, "overdue"},
+			{"LGPD", UrgencyCritical, "expired"},
+			{"Executive intervention", UrgencyCritical, "critical"},
+			{"Due soon", UrgencyHigh, "due_soon"},
+			{"PCI DSS", UrgencyNormal, "expiring"},
+			{"Someday", UrgencyNormal, "assigned"},
+		})
+		if inbox.Counts[AttentionKindAction] != 3 || inbox.Counts[AttentionKindEscalation] != 1 || inbox.Counts[AttentionKindCompliance] != 2 {
+			t.Errorf("counts = %v", inbox.Counts)
+		}
+		if inbox.Items[0].Action == nil || inbox.Items[0].ProductName != "Checkout" {
+			t.Errorf("action item = %+v", inbox.Items[0])
+		}
+	})
+
+	t.Run("regional lead", func(t *testing.T) {
+		profile := models.Profile{Email: "ana@example.com", FullName: &name, Role: models.UserRoleRegionalLead, Region: &region}
+		inbox, err := attentionInbox(db, profile, today, 0)
+		if err != nil {
+			t.Fatalf("attentionInbox: %v", err)
+		}
+		if inbox.Counts[AttentionKindEscalation] != 2 || inbox.Counts[AttentionKindCompliance] != 1 {
+			t.Errorf("counts = %v, want both LATAM escalations and only the lapsed certification", inbox.Counts)
+		}
+	})
+
+	t.Run("nothing assigned", func(t *testing.T) {
+		inbox, err := attentionInbox(db, models.Profile{Email: "carol@example.com"}, today, 30)
+		if err != nil {
+			t.Fatalf("attentionInbox: %v", err)
+		}
+		if len(inbox.Items) != 0 || inbox.Items == nil {
+			t.Errorf("items = %#v, want an empty list", inbox.Items)
+		}
+	})
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pauly7610/studio-pilot-vision/backend/database"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+	"gorm.io/gorm"
+)
+
+// AuditHandler serves the persisted audit trail to admins
+type AuditHandler struct{}
+
+func NewAuditHandler() *AuditHandler {
+	return &AuditHandler{}
+}
+
+// auditLogFilter narrows the audit trail. From and To are inclusive dates.
+type auditLogFilter struct {
+	UserID string
+	Action string
+	From   *models.Date
+	To     *models.Date
+}
+
+// filterAuditLogs applies the filter to an audit log query, newest first
+func filterAuditLogs(db *gorm.DB, filter auditLogFilter) *gorm.DB {
+	query := db.Model(&models.AuditLog{}).Order("occurred_at DESC")
+	if filter.UserID != "" {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.From != nil {
+		query = query.Where("occurred_at >= ?", models.NewTimestamp(filter.From.Time))
+	}
+	if filter.To != nil {
+		query = query.Where("occurred_at < ?", models.NewTimestamp(filter.To.AddDate(0, 0, 1)))
+	}
+	return query
+}
+
+// GetAuditLogs lists audit records, newest first, filtered by ?user_id=,
+// ?action= and ?from= / ?to= (YYYY-MM-DD, inclusive)
+func (h *AuditHandler) GetAuditLogs(c *gin.Context) {
+	meta := newListMeta("-timestamp")
+	filter := auditLogFilter{UserID: c.Query("user_id"), Action: c.Query("action")}
+	if filter.UserID != "" {
+		meta.filter("user_id", filter.UserID)
+	}
+	if filter.Action != "" {
+		meta.filter("action", filter.Action)
+	}
+
+	for param, target := range map[string]**models.Date{"from": &filter.From, "to": &filter.To} {
+		raw := c.Query(param)
+		if raw == "" {
+			continue
+		}
+		date, err := models.ParseDate(raw)
+		if err != nil {
+			respondWithError(c, http.StatusBadRequest, param+" must be a date (YYYY-MM-DD)")
+			return
+		}
+		*target = &date
+		meta.filter(param, date.String())
+	}
+	if filter.From != nil && filter.To != nil && filter.To.Before(filter.From.Time) {
+		respondWithError(c, http.StatusBadRequest, "to must not be before from")
+		return
+	}
+
+	page, pageSize := parsePagination(c, defaultListPageSize, maxListPageSize)
+	pageQuery, total, err := paginate(filterAuditLogs(database.DB, filter), &models.AuditLog{}, page, pageSize)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	var logs []models.AuditLog
+	if err := pageQuery.Find(&logs).Error; err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithPagination(c, logs, total, page, pageSize, meta)
+}
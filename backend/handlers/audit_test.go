@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+)
+
+const auditLogsDDL = `CREATE TABLE audit_logs (
+	id TEXT PRIMARY KEY,
+	occurred_at DATETIME NOT NULL,
+	action TEXT NOT NULL,
+	resource TEXT NOT NULL,
+	method TEXT,
+	ip TEXT,
+	user_id TEXT,
+	user_email TEXT,
+	status_code INTEGER,
+	duration_ms INTEGER,
+	success BOOLEAN NOT NULL,
+	details TEXT,
+	error TEXT
+)`
+
+func TestFilterAuditLogs(t *testing.T) {
+	db := openTestDB(t, auditLogsDDL)
+
+	day := func(d int, hour int) models.Timestamp {
+		return models.NewTimestamp(time.Date(2026, 3, d, hour, 0, 0, 0, time.UTC))
+	}
+	seed := []models.AuditLog{
+		{Timestamp: day(1, 9), Action: "data.access", UserID: "alice"},
+		{Timestamp: day(2, 23), Action: "admin.action", UserID: "alice"},
+		{Timestamp: day(3, 0), Action: "admin.action", UserID: "bob"},
+		{Timestamp: day(4, 12), Action: "data.create", UserID: "alice"},
+	}
+	for i := range seed {
+		seed[i].ID = uuid.New()
+		seed[i].Resource = "/api/v1/products"
+	}
+	if err := db.Create(&seed).Error; err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	date := func(d int) *models.Date {
+		v := models.NewDate(time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC))
+		return &v
+	}
+	tests := []struct {
+		name   string
+		filter auditLogFilter
+		want   []string // actions, newest first
+	}{
+		{"no filter", auditLogFilter{}, []string{"data.create", "admin.action", "admin.action", "data.access"}},
+		{"by user", auditLogFilter{UserID: "alice"}, []string{"data.create", "admin.action", "data.access"}},
+		{"by action", auditLogFilter{Action: "admin.action", UserID: "bob"}, []string{"admin.action"}},
+		{"to is inclusive", auditLogFilter{From: date(2), To: date(2)}, []string{"admin.action"}},
+		{"date range", auditLogFilter{From: date(2), To: date(3), UserID: "alice"}, []string{"admin.action"}},
+	}
+	for _, tt := range tests {
+		var logs []models.AuditLog
+		if err := filterAuditLogs(db, tt.filter).Find(&logs).Error; err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		got := make([]string, len(logs))
+		for i, log := range logs {
+			got[i] = log.Action
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/pauly7610/studio-pilot-vision/backend/database"
+	"github.com/pauly7610/studio-pilot-vision/backend/middleware"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+	"gorm.io/gorm"
+)
+
+// AuthHandler issues, rotates and revokes refresh tokens. Users sign in
+// elsewhere; an authenticated user exchanges their sign-in access token for
+// a refresh token once, then uses /auth/refresh for new access tokens.
+type AuthHandler struct {
+	secret     string
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+}
+
+func NewAuthHandler(secret string, accessTTL, refreshTTL time.Duration) *AuthHandler {
+	return &AuthHandler{secret: secret, accessTTL: accessTTL, refreshTTL: refreshTTL}
+}
+
+var (
+	errRefreshTokenInvalid = errors.New("refresh token is invalid or expired")
+	errRefreshTokenReused  = errors.New("refresh token was already used; all sessions have been revoked")
+	errSessionAccessToken  = errors.New("access token was refreshed from a session; use /auth/refresh instead")
+)
+
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// storeRefreshToken creates a refresh token for the user and returns its
+// plaintext value, which is never stored
+func storeRefreshToken(db *gorm.DB, userID, email, role string, ttl time.Duration, now time.Time) (string, models.RefreshToken, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", models.RefreshToken{}, err
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	record := models.RefreshToken{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Email:     email,
+		Role:      role,
+		TokenHash: hashRefreshToken(token),
+		ExpiresAt: models.NewTimestamp(now.Add(ttl)),
+	}
+	if err := db.Create(&record).Error; err != nil {
+		return "", models.RefreshToken{}, err
+	}
+	return token, record, nil
+}
+
+// rotateRefreshToken revokes the presented token and issues its replacement.
+// Presenting a token that was already rotated means it leaked, so every
+// active token of that user is revoked.
+func rotateRefreshToken(db *gorm.DB, token string, ttl time.Duration, now time.Time) (string, models.RefreshToken, error) {
+	var current models.RefreshToken
+	if err := db.Where("token_hash = ?", hashRefreshToken(token)).First(&current).Error; err != nil {
+		return "", models.RefreshToken{}, errRefreshTokenInvalid
+	}
+
+	if current.ReplacedBy != nil {
+		if err := revokeUserRefreshTokens(db, current.UserID, now); err != nil {
+			return "", models.RefreshToken{}, err
+		}
+		return "", current, errRefreshTokenReused
+	}
+	if !current.Active(now) {
+		return "", models.RefreshToken{}, errRefreshTokenInvalid
+	}
+
+	var next string
+	var record models.RefreshToken
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		next, record, err = storeRefreshToken(tx, current.UserID, current.Email, current.Role, ttl, now)
+		if err != nil {
+			return err
+		}
+
+		// The revoked_at guard makes a concurrent refresh of the same token lose
+		result := tx.Model(&models.RefreshToken{}).
+			Where("id = ? AND revoked_at IS NULL", current.ID).
+			Updates(map[string]interface{}{"revoked_at": models.NewTimestamp(now), "replaced_by": record.ID})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errRefreshTokenInvalid
+		}
+		return nil
+	})
+	if err != nil {
+		return "", models.RefreshToken{}, err
+	}
+	return next, record, nil
+}
+
+func revokeUserRefreshTokens(db *gorm.DB, userID string, now time.Time) error {
+	return db.Model(&models.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", models.NewTimestamp(now)).Error
+}
+
+// revokeRefreshToken revokes a single token. Revoking an already-revoked
+// token succeeds.
+func revokeRefreshToken(db *gorm.DB, token string, now time.Time) error {
+	var current models.RefreshToken
+	if err := db.Where("token_hash = ?", hashRefreshToken(token)).First(&current).Error; err != nil {
+		return errRefreshTokenInvalid
+	}
+	if current.RevokedAt != nil {
+		return nil
+	}
+	return db.Model(&current).Update("revoked_at", models.NewTimestamp(now)).Error
+}
+
+// tokenResponse signs an access token for the refresh token's user. The
+// email and role come from the user's profile when there is one, so role
+// changes apply on the next refresh.
+func (h *AuthHandler) tokenResponse(refreshToken string, record models.RefreshToken) (models.TokenResponse, error) {
+	email, role := record.Email, record.Role
+	if id, err := uuid.Parse(record.UserID); err == nil {
+		var profile models.Profile
+		if database.DB.First(&profile, "id = ?", id).Error == nil {
+			email, role = profile.Email, string(profile.Role)
+		}
+	}
+
+	accessToken, err := middleware.NewAccessToken(h.secret, record.UserID, email, role, record.ID.String(), h.accessTTL)
+	if err != nil {
+		return models.TokenResponse{}, err
+	}
+	return models.TokenResponse{
+		AccessToken:      accessToken,
+		TokenType:        "Bearer",
+		ExpiresIn:        int(h.accessTTL.Seconds()),
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: record.ExpiresAt.UTC().Format(time.RFC3339),
+	}, nil
+}
+
+// IssueToken starts a refresh-token session for the authenticated user.
+// Only sign-in access tokens are accepted: letting refreshed ones mint
+// sessions would let a stolen access token outlive logout and reuse
+// revocation by minting fresh refresh tokens.
+func (h *AuthHandler) IssueToken(c *gin.Context) {
+	userID := currentUserID(c)
+	if userID == "" {
+		respondWithError(c, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+	if c.GetString("sessionID") != "" {
+		respondWithError(c, http.StatusForbidden, errSessionAccessToken.Error())
+		return
+	}
+
+	token, record, err := storeRefreshToken(database.DB, userID, c.GetString("email"), c.GetString("role"), h.refreshTTL, time.Now())
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response, err := h.tokenResponse(token, record)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	respondWithData(c, http.StatusCreated, response)
+}
+
+// Refresh exchanges a refresh token for a new access token and a new
+// refresh token; the presented one stops working
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req models.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	token, record, err := rotateRefreshToken(database.DB, req.RefreshToken, h.refreshTTL, time.Now())
+	if errors.Is(err, errRefreshTokenReused) {
+		middleware.LogSecurityEvent(middleware.AuditSecurityTokenReuse, c.ClientIP(), map[string]interface{}{
+			"user_id":  record.UserID,
+			"token_id": record.ID.String(),
+		})
+	}
+	if errors.Is(err, errRefreshTokenInvalid) || errors.Is(err, errRefreshTokenReused) {
+		respondWithError(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response, err := h.tokenResponse(token, record)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	respondWithData(c, http.StatusOK, response)
+}
+
+// Logout revokes the presented refresh token. Access tokens already issued
+// stay valid until they expire.
+func (h *AuthHandler) Logout(c *gin.Context) {
+	var req models.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err := revokeRefreshToken(database.DB, req.RefreshToken, time.Now())
+	if errors.Is(err, errRefreshTokenInvalid) {
+		respondWithError(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithSuccess(c, http.StatusOK, "Logged out", nil)
+}
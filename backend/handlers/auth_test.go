@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+)
+
+const refreshTokensDDL = `CREATE TABLE refresh_tokens (
+	id TEXT PRIMARY KEY,
+	user_id TEXT NOT NULL,
+	email TEXT,
+	role TEXT,
+	token_hash TEXT NOT NULL UNIQUE,
+	expires_at DATETIME NOT NULL,
+	revoked_at DATETIME,
+	replaced_by TEXT,
+	created_at DATETIME
+)`
+
+func TestRotateRefreshToken(t *testing.T) {
+	db := openTestDB(t, refreshTokensDDL)
+	now := time.Now()
+
+	first, record, err := storeRefreshToken(db, "user-1", "a@example.com", "viewer", time.Hour, now)
+	if err != nil {
+		t.Fatalf("store: %v", err)
+	}
+	if record.TokenHash == first {
+		t.Fatal("token stored in plaintext")
+	}
+
+	second, next, err := rotateRefreshToken(db, first, time.Hour, now)
+	if err != nil {
+		t.Fatalf("rotate: %v", err)
+	}
+	if second == first || next.UserID != "user-1" || next.Role != "viewer" {
+		t.Errorf("unexpected replacement %+v", next)
+	}
+
+	var old models.RefreshToken
+	db.First(&old, "id = ?", record.ID)
+	if old.RevokedAt == nil || old.ReplacedBy == nil || *old.ReplacedBy != next.ID {
+		t.Errorf("old token not revoked and linked: %+v", old)
+	}
+
+	if _, _, err := rotateRefreshToken(db, "not-a-token", time.Hour, now); !errors.Is(err, errRefreshTokenInvalid) {
+		t.Errorf("unknown token: got %v, want invalid", err)
+	}
+	if _, _, err := rotateRefreshToken(db, second, time.Hour, now.Add(2*time.Hour)); !errors.Is(err, errRefreshTokenInvalid) {
+		t.Errorf("expired token: got %v, want invalid", err)
+	}
+}
+
+func TestRotateRefreshToken_ReuseRevokesAllSessions(t *testing.T) {
+	db := openTestDB(t, refreshTokensDDL)
+	now := time.Now()
+
+	stolen, _, _ := storeRefreshToken(db, "user-1", "", "", time.Hour, now)
+	current, _, err := rotateRefreshToken(db, stolen, time.Hour, now)
+	if err != nil {
+		t.Fatalf("rotate: %v", err)
+	}
+	other, _, _ := storeRefreshToken(db, "user-2", "", "", time.Hour, now)
+
+	if _, _, err := rotateRefreshToken(db, stolen, time.Hour, now); !errors.Is(err, errRefreshTokenReused) {
+		t.Fatalf("reused token: got %v, want reuse detected", err)
+	}
+	if _, _, err := rotateRefreshToken(db, current, time.Hour, now); !errors.Is(err, errRefreshTokenInvalid) {
+		t.Errorf("sibling session should be revoked after reuse, got %v", err)
+	}
+	if _, _, err := rotateRefreshToken(db, other, time.Hour, now); err != nil {
+		t.Errorf("another user's session should be untouched, got %v", err)
+	}
+}
+
+func TestRevokeRefreshToken(t *testing.T) {
+	db := openTestDB(t, refreshTokensDDL)
+	now := time.Now()
+
+	token, _, _ := storeRefreshToken(db, "user-1", "", "", time.Hour, now)
+	if err := revokeRefreshToken(db, token, now); err != nil {
+		t.Fatalf("revoke: %v", err)
+	}
+	if err := revokeRefreshToken(db, token, now); err != nil {
+		t.Errorf("second revoke should succeed, got %v", err)
+	}
+	if _, _, err := rotateRefreshToken(db, token, time.Hour, now); !errors.Is(err, errRefreshTokenInvalid) {
+		t.Errorf("revoked token: got %v, want invalid", err)
+	}
+	if err := revokeRefreshToken(db, "unknown", now); !errors.Is(err, errRefreshTokenInvalid) {
+		t.Errorf("unknown token: got %v, want invalid", err)
+	}
+}
+
+func TestIssueToken_RejectsRefreshedAccessTokens(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/auth/token", nil)
+	c.Set("userID", "user-1")
+	c.Set("sessionID", uuid.NewString())
+
+	NewAuthHandler("secret", time.Minute, time.Hour).IssueToken(c)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want 403", w.Code)
+	}
+}
@@ -2,11 +2,13 @@ package handlers
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/pauly7610/studio-pilot-vision/backend/database"
 	"github.com/pauly7610/studio-pilot-vision/backend/models"
+	"gorm.io/gorm"
 )
 
 type ComplianceHandler struct{}
@@ -17,7 +19,7 @@ func NewComplianceHandler() *ComplianceHandler {
 
 // GetProductCompliance retrieves all compliance records for a product
 func (h *ComplianceHandler) GetProductCompliance(c *gin.Context) {
-	productID, err := uuid.Parse(c.Param("productId"))
+	productID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
 		return
@@ -37,6 +39,65 @@ func (h *ComplianceHandler) GetProductCompliance(c *gin.Context) {
 	respondWithData(c, http.StatusOK, compliance)
 }
 
+// ComplianceSummary rolls up a product's certifications. ComplianceComplete
+// is true only when there is at least one record, every record is complete
+// and none has expired.
+type ComplianceSummary struct {
+	ProductID          uuid.UUID `json:"product_id"`
+	Total              int       `json:"total"`
+	Complete           int       `json:"complete"`
+	InProgress         int       `json:"in_progress"`
+	Pending            int       `json:"pending"`
+	AnyExpired         bool      `json:"any_expired"`
+	ComplianceComplete bool      `json:"compliance_complete"`
+}
+
+// summarizeCompliance counts records by status as of today. A record past its
+// expiry date counts as expired whatever its status.
+func summarizeCompliance(records []models.ProductCompliance, today models.Date) ComplianceSummary {
+	summary := ComplianceSummary{Total: len(records)}
+	for _, record := range records {
+		switch record.Status {
+		case models.ComplianceStatusComplete:
+			summary.Complete++
+		case models.ComplianceStatusInProgress:
+			summary.InProgress++
+		case models.ComplianceStatusPending:
+			summary.Pending++
+		}
+		if record.ExpiryDate != nil && record.ExpiryDate.Before(today.Time) {
+			summary.AnyExpired = true
+		}
+	}
+	summary.ComplianceComplete = summary.Total > 0 && summary.Complete == summary.Total && !summary.AnyExpired
+	return summary
+}
+
+// GetProductComplianceSummary returns the product's compliance rollup
+func (h *ComplianceHandler) GetProductComplianceSummary(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	var product models.Product
+	if result := database.DB.Select("id").First(&product, "id = ?", productID); result.Error != nil {
+		respondWithError(c, http.StatusNotFound, "Product not found")
+		return
+	}
+
+	var records []models.ProductCompliance
+	if result := database.DB.Where("product_id = ?", productID).Find(&records); result.Error != nil {
+		respondWithError(c, http.StatusInternalServerError, result.Error.Error())
+		return
+	}
+
+	summary := summarizeCompliance(records, models.Today())
+	summary.ProductID = productID
+	respondWithData(c, http.StatusOK, summary)
+}
+
 // GetCompliance retrieves a single compliance record
 func (h *ComplianceHandler) GetCompliance(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
@@ -162,17 +223,91 @@ func (h *ComplianceHandler) GetAllCompliance(c *gin.Context) {
 	var compliance []models.ProductCompliance
 
 	query := database.DB.Order("created_at DESC")
+	meta := newListMeta("-created_at")
 
-	// Optional filtering by status
-	if status := c.Query("status"); status != "" {
-		query = query.Where("status = ?", status)
+	query, err := applyFilters(query, c.Request.URL.Query(), []queryFilter{
+		{Param: "status", Column: "status", Multi: true, Check: enumCheck(models.ComplianceStatus.Check)},
+	}, meta)
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
 	}
 
-	result := query.Find(&compliance)
+	page, pageSize := parsePagination(c, defaultListPageSize, maxListPageSize)
+	pageQuery, total, err := paginate(query, &models.ProductCompliance{}, page, pageSize)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result := pageQuery.Find(&compliance)
 	if result.Error != nil {
 		respondWithError(c, http.StatusInternalServerError, result.Error.Error())
 		return
 	}
 
-	respondWithData(c, http.StatusOK, compliance)
+	respondWithPagination(c, compliance, total, page, pageSize, meta)
+}
+
+const (
+	defaultExpiringWithinDays = 30
+	maxExpiringWithinDays     = 365
+)
+
+// ExpiringCompliance is a certification that lapses within the window, or
+// already has
+type ExpiringCompliance struct {
+	models.ProductCompliance
+	ProductName     string `json:"product_name"`
+	DaysUntilExpiry int    `json:"days_until_expiry"`
+	Expired         bool   `json:"expired"`
+}
+
+// expiringCompliance returns the records expiring on or before today plus
+// withinDays, soonest first. Lapsed ones are included with a negative
+// days_until_expiry. Archived and deleted products are left out.
+func expiringCompliance(db *gorm.DB, today models.Date, withinDays int) ([]ExpiringCompliance, error) {
+	cutoff := models.NewDate(today.AddDate(0, 0, withinDays))
+
+	var rows []ExpiringCompliance
+	err := db.Model(&models.ProductCompliance{}).
+		Select("product_compliances.*, products.name AS product_name").
+		Joins("JOIN products ON products.id = product_compliances.product_id").
+		Scopes(models.ExcludeDeleted, models.ExcludeArchived).
+		Where("product_compliances.expiry_date IS NOT NULL AND product_compliances.expiry_date <= ?", cutoff).
+		Order("product_compliances.expiry_date ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range rows {
+		rows[i].DaysUntilExpiry = int(rows[i].ExpiryDate.Sub(today.Time).Hours() / 24)
+		rows[i].Expired = rows[i].DaysUntilExpiry < 0
+	}
+	return rows, nil
+}
+
+// GetExpiringCompliance lists certifications expiring within ?within_days=
+// days (default 30), and those already expired, soonest first
+func (h *ComplianceHandler) GetExpiringCompliance(c *gin.Context) {
+	withinDays := defaultExpiringWithinDays
+	if raw := c.Query("within_days"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 0 || parsed > maxExpiringWithinDays {
+			respondWithError(c, http.StatusBadRequest, "within_days must be between 0 and 365")
+			return
+		}
+		withinDays = parsed
+	}
+
+	expiring, err := expiringCompliance(database.DB, models.Today(), withinDays)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	meta := newListMeta("expiry_date")
+	meta.filter("within_days", strconv.Itoa(withinDays))
+	respondWithList(c, expiring, meta)
 }
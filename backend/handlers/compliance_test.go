@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+)
+
+func TestExpiringCompliance(t *testing.T) {
+	db := openTestDB(t,
+		`CREATE TABLE products (id TEXT PRIMARY KEY, name TEXT, archived_at DATETIME, deleted_at DATETIME)`,
+		`CREATE TABLE product_compliances (
+			id TEXT PRIMARY KEY, product_id TEXT NOT NULL, certification_type TEXT NOT NULL,
+			status TEXT NOT NULL, completed_date DATE, expiry_date DATE, notes TEXT,
+			created_at DATETIME, updated_at DATETIME)`,
+		`INSERT INTO products VALUES ('cfe073eb-20ac-4631-9c67-fffc180c3979', 'Wallet', NULL, NULL), ('6293b1be-0024-45ae-ba0a-aaac9da06637', 'Archived', '2025-01-01', NULL), ('0f9009eb-7b49-43c6-92b1-fc85c0b04842', 'Deleted', NULL, '2025-01-01')`,
+		`INSERT INTO product_compliances (id, product_id, certification_type, status, expiry_date) VALUES
+			('75a05aef-ffb1-45a2-98bb-639b21a1b9ca', 'cfe073eb-20ac-4631-9c67-fffc180c3979', 'PCI DSS', 'complete', '2025-03-20'),
+			('8b8ca70d-2350-49a2-978d-313ddb4b9723', 'cfe073eb-20ac-4631-9c67-fffc180c3979', 'LGPD', 'complete', '2025-04-10'),
+			('2dcf2573-4fa0-47ba-bff3-34e250cc0c63', 'cfe073eb-20ac-4631-9c67-fffc180c3979', 'BACEN', 'complete', '2025-03-01'),
+			('6001fa37-c9b7-4d1c-9046-b49e43a6185c', 'cfe073eb-20ac-4631-9c67-fffc180c3979', 'SOC 2', 'complete', '2025-06-01'),
+			('710e8713-8e47-4ad5-a2cd-118f6a083bb1', 'cfe073eb-20ac-4631-9c67-fffc180c3979', 'Banxico', 'pending', NULL),
+			('e7a8b112-0b9d-4ee3-9c5b-2a9bbc9e0dae', '6293b1be-0024-45ae-ba0a-aaac9da06637', 'PCI DSS', 'complete', '2025-03-20'),
+			('cf4bf6c4-7b18-4cf2-87fc-260a53c636c2', '0f9009eb-7b49-43c6-92b1-fc85c0b04842', 'PCI DSS', 'complete', '2025-03-20')`,
+	)
+
+	today := mustDate(t, "2025-03-15")
+	expiring, err := expiringCompliance(db, today, 30)
+	if err != nil {
+		t.Fatalf("expiringCompliance: %v", err)
+	}
+
+	want := []struct {
+		certification string
+		days          int
+		expired       bool
+	}{
+		{"BACEN", -14, true},
+		{"PCI DSS", 5, false},
+		{"LGPD", 26, false},
+	}
+	if len(expiring) != len(want) {
+		t.Fatalf("got %d records, want %d: %+v", len(expiring), len(want), expiring)
+	}
+	for i, w := range want {
+		got := expiring[i]
+		if got.CertificationType != w.certification || got.DaysUntilExpiry != w.days || got.Expired != w.expired {
+			t.Errorf("row %d = %s, %d days, expired %v; want %s, %d, %v",
+				i, got.CertificationType, got.DaysUntilExpiry, got.Expired, w.certification, w.days, w.expired)
+		}
+		if got.ProductName != "Wallet" {
+			t.Errorf("row %d product name = %q, want Wallet", i, got.ProductName)
+		}
+	}
+
+	// Expiring today is inside a zero-day window and not yet expired
+	expiring, err = expiringCompliance(db, mustDate(t, "2025-03-20"), 0)
+	if err != nil {
+		t.Fatalf("expiringCompliance: %v", err)
+	}
+	last := expiring[len(expiring)-1]
+	if last.CertificationType != "PCI DSS" || last.DaysUntilExpiry != 0 || last.Expired {
+		t.Errorf("record expiring today = %+v, want 0 days and not expired", last)
+	}
+}
+
+func TestSummarizeCompliance(t *testing.T) {
+	today := mustDate(t, "2025-03-15")
+	record := func(status models.ComplianceStatus, expiry string) models.ProductCompliance {
+		r := models.ProductCompliance{Status: status}
+		if expiry != "" {
+			date := mustDate(t, expiry)
+			r.ExpiryDate = &date
+		}
+		return r
+	}
+
+	tests := []struct {
+		name    string
+		records []models.ProductCompliance
+		want    ComplianceSummary
+	}{
+		{"no records", nil, ComplianceSummary{}},
+		{"all complete", []models.ProductCompliance{
+			record(models.ComplianceStatusComplete, "2025-12-31"),
+			record(models.ComplianceStatusComplete, ""),
+		}, ComplianceSummary{Total: 2, Complete: 2, ComplianceComplete: true}},
+		{"expires today", []models.ProductCompliance{
+			record(models.ComplianceStatusComplete, "2025-03-15"),
+		}, ComplianceSummary{Total: 1, Complete: 1, ComplianceComplete: true}},
+		{"complete but expired", []models.ProductCompliance{
+			record(models.ComplianceStatusComplete, "2025-12-31"),
+			record(models.ComplianceStatusComplete, "2025-03-14"),
+		}, ComplianceSummary{Total: 2, Complete: 2, AnyExpired: true}},
+		{"mixed", []models.ProductCompliance{
+			record(models.ComplianceStatusComplete, ""),
+			record(models.ComplianceStatusInProgress, ""),
+			record(models.ComplianceStatusPending, "2025-01-01"),
+		}, ComplianceSummary{Total: 3, Complete: 1, InProgress: 1, Pending: 1, AnyExpired: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := summarizeCompliance(tt.records, today); got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"maps"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Destructive admin operations are two-step: a preview call returns the rows
+// that will be removed along with a short-lived signed token, and the
+// destructive call must present that token. The token is bound to the
+// operation, target, admin and the counts, so a stale preview is rejected.
+const (
+	ConfirmationHeader = "X-Confirmation-Token"
+	confirmationTTL    = 5 * time.Minute
+)
+
+var (
+	errConfirmationMissing  = errors.New("confirmation token required; request a preview first")
+	errConfirmationInvalid  = errors.New("confirmation token is invalid or expired")
+	errConfirmationMismatch = errors.New("confirmation token does not match this operation")
+	errConfirmationStale    = errors.New("data changed since the confirmation was issued; request a new preview")
+)
+
+// confirmationTokenType sets confirmation tokens apart from access tokens,
+// which the auth middleware checks along with the signing key
+const confirmationTokenType = "confirmation"
+
+// confirmationKey derives the key confirmation tokens are signed with from the
+// JWT secret, so a confirmation token never verifies as an access token
+func confirmationKey(secret string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("studio-pilot confirmation token"))
+	return mac.Sum(nil)
+}
+
+type confirmationClaims struct {
+	TokenType string           `json:"typ"`
+	Operation string           `json:"op"`
+	Target    string           `json:"target"`
+	Counts    map[string]int64 `json:"counts"`
+	jwt.RegisteredClaims
+}
+
+// ConfirmationPreview describes what a destructive operation will delete
+type ConfirmationPreview struct {
+	Operation string           `json:"operation"`
+	Target    string           `json:"target"`
+	Counts    map[string]int64 `json:"counts"`
+	Token     string           `json:"confirmation_token"`
+	ExpiresAt string           `json:"expires_at"`
+}
+
+func issueConfirmation(secret, operation, target, userID string, counts map[string]int64) (ConfirmationPreview, error) {
+	expiresAt := time.Now().Add(confirmationTTL).UTC()
+	claims := confirmationClaims{
+		TokenType: confirmationTokenType,
+		Operation: operation,
+		Target:    target,
+		Counts:    counts,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   userID,
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(confirmationKey(secret))
+	if err != nil {
+		return ConfirmationPreview{}, err
+	}
+
+	return ConfirmationPreview{
+		Operation: operation,
+		Target:    target,
+		Counts:    counts,
+		Token:     token,
+		ExpiresAt: expiresAt.Format(time.RFC3339),
+	}, nil
+}
+
+// verifyConfirmation checks a token against the operation about to run and
+// the counts as they are now
+func verifyConfirmation(secret, token, operation, target, userID string, counts map[string]int64) error {
+	if token == "" {
+		return errConfirmationMissing
+	}
+
+	var claims confirmationClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(t *jwt.Token) (interface{}, error) {
+		return confirmationKey(secret), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		return errConfirmationInvalid
+	}
+
+	if claims.TokenType != confirmationTokenType {
+		return errConfirmationInvalid
+	}
+	if claims.Operation != operation || claims.Target != target || claims.Subject != userID {
+		return errConfirmationMismatch
+	}
+	if !maps.Equal(claims.Counts, counts) {
+		return errConfirmationStale
+	}
+	return nil
+}
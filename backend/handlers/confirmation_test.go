@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pauly7610/studio-pilot-vision/backend/middleware"
+)
+
+func TestConfirmationToken(t *testing.T) {
+	const secret = "test-secret"
+	counts := map[string]int64{"products": 1, "feedback": 347}
+
+	preview, err := issueConfirmation(secret, "product.delete", "p1", "admin-1", counts)
+	if err != nil {
+		t.Fatalf("issue: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		secret    string
+		token     string
+		operation string
+		target    string
+		userID    string
+		counts    map[string]int64
+		want      error
+	}{
+		{"valid", secret, preview.Token, "product.delete", "p1", "admin-1", counts, nil},
+		{"missing", secret, "", "product.delete", "p1", "admin-1", counts, errConfirmationMissing},
+		{"wrong secret", "other", preview.Token, "product.delete", "p1", "admin-1", counts, errConfirmationInvalid},
+		{"other target", secret, preview.Token, "product.delete", "p2", "admin-1", counts, errConfirmationMismatch},
+		{"other admin", secret, preview.Token, "product.delete", "p1", "admin-2", counts, errConfirmationMismatch},
+		{"counts changed", secret, preview.Token, "product.delete", "p1", "admin-1",
+			map[string]int64{"products": 1, "feedback": 348}, errConfirmationStale},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyConfirmation(tt.secret, tt.token, tt.operation, tt.target, tt.userID, tt.counts)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfirmationToken_IsNotAnAccessToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	const secret = "test-secret"
+
+	preview, err := issueConfirmation(secret, "product.delete", "p1", "admin-1", map[string]int64{"products": 1})
+	if err != nil {
+		t.Fatalf("issue: %v", err)
+	}
+	// A token of another type is refused even when signed with the JWT secret
+	typed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, confirmationClaims{
+		TokenType:        confirmationTokenType,
+		RegisteredClaims: jwt.RegisteredClaims{Subject: "admin-1", ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute))},
+	}).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+
+	router := gin.New()
+	protected := router.Group("", middleware.AuthMiddleware(secret))
+	protected.GET("/me", func(c *gin.Context) { c.Status(http.StatusOK) })
+	protected.POST("/auth/token", NewAuthHandler(secret, time.Minute, time.Hour).IssueToken)
+
+	for _, token := range []string{preview.Token, typed} {
+		for _, route := range []struct{ method, path string }{{http.MethodGet, "/me"}, {http.MethodPost, "/auth/token"}} {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(route.method, route.path, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			router.ServeHTTP(w, req)
+			if w.Code != http.StatusUnauthorized {
+				t.Errorf("%s %s with a confirmation token: status = %d, want 401", route.method, route.path, w.Code)
+			}
+		}
+	}
+
+	access, err := middleware.NewAccessToken(secret, "admin-1", "a@example.com", "vp_product", "", time.Minute)
+	if err != nil {
+		t.Fatalf("access token: %v", err)
+	}
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/me", nil)
+	req.Header.Set("Authorization", "Bearer "+access)
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("access token: status = %d, want 200", w.Code)
+	}
+	if err := verifyConfirmation(secret, access, "product.delete", "p1", "admin-1", nil); !errors.Is(err, errConfirmationInvalid) {
+		t.Errorf("access token as confirmation: %v, want errConfirmationInvalid", err)
+	}
+}
@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/pauly7610/studio-pilot-vision/backend/middleware"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+)
+
+// currentUserID returns the authenticated user's ID, or "" for anonymous requests
+func currentUserID(c *gin.Context) string {
+	userID, _ := c.Get("userID")
+	id, _ := userID.(string)
+	return id
+}
+
+// isAdmin reports whether the authenticated user holds an admin role
+func isAdmin(c *gin.Context) bool {
+	role, _ := c.Get("role")
+	roleStr, _ := role.(string)
+	profile := models.Profile{Role: models.UserRole(roleStr)}
+	return profile.IsAdmin()
+}
+
+// scopedRegion returns the region a region-scoped route limits the caller to
+func scopedRegion(c *gin.Context) (string, bool) {
+	region, _ := c.Get(middleware.RegionScopeKey)
+	regionStr, ok := region.(string)
+	return regionStr, ok && regionStr != ""
+}
@@ -0,0 +1,381 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/pauly7610/studio-pilot-vision/backend/config"
+	"github.com/pauly7610/studio-pilot-vision/backend/database"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+	"gorm.io/gorm"
+)
+
+type DashboardHandler struct {
+	rules config.EscalationRules
+}
+
+func NewDashboardHandler(rules config.EscalationRules) *DashboardHandler {
+	return &DashboardHandler{rules: rules}
+}
+
+// Dashboard sections, each assembled from an existing aggregate
+const (
+	SectionRiskIndex           = "risk_index"
+	SectionEscalationSummary   = "escalation_summary"
+	SectionEscalations         = "escalations"
+	SectionRegionAttention     = "region_attention"
+	SectionTrainingCoverage    = "training_coverage"
+	SectionBlockedDependencies = "blocked_dependencies"
+	SectionDataFreshness       = "data_freshness"
+)
+
+// dashboardSections maps a role to the sections of its landing view:
+//
+//   - vp_product:        risk index, escalation summary, active escalations
+//   - studio_ambassador: escalation summary, active escalations, data freshness
+//   - regional_lead:     attention list and blocked dependencies for their region
+//   - sales:             training coverage
+//   - partner_ops:       blocked dependencies, data freshness
+//   - viewer and unknown roles: risk index, escalation summary
+func dashboardSections(role models.UserRole) []string {
+	switch role {
+	case models.UserRoleVPProduct:
+		return []string{SectionRiskIndex, SectionEscalationSummary, SectionEscalations}
+	case models.UserRoleStudioAmbassador:
+		return []string{SectionEscalationSummary, SectionEscalations, SectionDataFreshness}
+	case models.UserRoleRegionalLead:
+		return []string{SectionRegionAttention, SectionBlockedDependencies}
+	case models.UserRoleSales:
+		return []string{SectionTrainingCoverage}
+	case models.UserRolePartnerOps:
+		return []string{SectionBlockedDependencies, SectionDataFreshness}
+	}
+	return []string{SectionRiskIndex, SectionEscalationSummary}
+}
+
+// DashboardResponse is a role-tailored landing payload keyed by section
+type DashboardResponse struct {
+	Role     models.UserRole        `json:"role"`
+	Region   *string                `json:"region,omitempty"`
+	Sections []string               `json:"sections"`
+	Data     map[string]interface{} `json:"data"`
+}
+
+// RegionAttentionItem is a product in the lead's region that needs a look
+type RegionAttentionItem struct {
+	ProductID       string                 `json:"product_id"`
+	Name            string                 `json:"name"`
+	LifecycleStage  models.LifecycleStage  `json:"lifecycle_stage"`
+	RiskBand        string                 `json:"risk_band,omitempty"`
+	EscalationLevel models.EscalationLevel `json:"escalation_level"`
+	Freshness       FreshnessStatus        `json:"freshness"`
+	Reasons         []string               `json:"reasons"`
+}
+
+// ProductTrainingCoverage is one product's sales training coverage
+type ProductTrainingCoverage struct {
+	ProductID        string       `json:"product_id"`
+	Name             string       `json:"name"`
+	TotalReps        int          `json:"total_reps"`
+	TrainedReps      int          `json:"trained_reps"`
+	CoveragePct      float64      `json:"coverage_pct"`
+	LastTrainingDate *models.Date `json:"last_training_date,omitempty"`
+}
+
+// TrainingCoverage is sales training coverage across the portfolio, least
+// covered products first
+type TrainingCoverage struct {
+	TotalReps   int                       `json:"total_reps"`
+	TrainedReps int                       `json:"trained_reps"`
+	CoveragePct float64                   `json:"coverage_pct"`
+	Products    []ProductTrainingCoverage `json:"products"`
+}
+
+// regionAttention lists products that are escalated, high risk or have stale
+// data, most escalated first. Readiness must be preloaded.
+func regionAttention(rules config.EscalationRules, products []models.Product) []RegionAttentionItem {
+	items := []RegionAttentionItem{}
+	for _, product := range products {
+		level, riskBand, _ := evaluateEscalation(rules, product)
+		freshness, _, _ := evaluateFreshness(product)
+
+		var reasons []string
+		if level != models.EscalationLevelNone {
+			reasons = append(reasons, "escalated")
+		}
+		if models.RiskBand(riskBand) == models.RiskBandHigh {
+			reasons = append(reasons, "high_risk")
+		}
+		if freshness == FreshnessStatusStale || freshness == FreshnessStatusOutdated {
+			reasons = append(reasons, "stale_data")
+		}
+		if len(reasons) == 0 {
+			continue
+		}
+
+		items = append(items, RegionAttentionItem{
+			ProductID:       product.ID.String(),
+			Name:            product.Name,
+			LifecycleStage:  product.LifecycleStage,
+			RiskBand:        riskBand,
+			EscalationLevel: level,
+			Freshness:       freshness,
+			Reasons:         reasons,
+		})
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		wi, wj := escalationRiskWeights[items[i].EscalationLevel], escalationRiskWeights[items[j].EscalationLevel]
+		if wi != wj {
+			return wi > wj
+		}
+		return items[i].Name < items[j].Name
+	})
+	return items
+}
+
+func trainingCoverage() (TrainingCoverage, error) {
+	var rows []struct {
+		models.SalesTraining
+		ProductName string
+	}
+	err := database.DB.Model(&models.SalesTraining{}).
+		Select("sales_trainings.*, products.name AS product_name").
+		Joins("JOIN products ON products.id = sales_trainings.product_id").
+		Scopes(models.ExcludeDrafts, models.ExcludeArchived, models.ExcludeDeleted).
+		Scan(&rows).Error
+	if err != nil {
+		return TrainingCoverage{}, err
+	}
+
+	coverage := TrainingCoverage{Products: []ProductTrainingCoverage{}}
+	for _, row := range rows {
+		pct := 0.0
+		if row.TotalReps > 0 {
+			pct = roundTo2(float64(row.TrainedReps) * 100 / float64(row.TotalReps))
+		}
+		coverage.TotalReps += row.TotalReps
+		coverage.TrainedReps += row.TrainedReps
+		coverage.Products = append(coverage.Products, ProductTrainingCoverage{
+			ProductID:        row.ProductID.String(),
+			Name:             row.ProductName,
+			TotalReps:        row.TotalReps,
+			TrainedReps:      row.TrainedReps,
+			CoveragePct:      pct,
+			LastTrainingDate: row.LastTrainingDate,
+		})
+	}
+	if coverage.TotalReps > 0 {
+		coverage.CoveragePct = roundTo2(float64(coverage.TrainedReps) * 100 / float64(coverage.TotalReps))
+	}
+
+	sort.SliceStable(coverage.Products, func(i, j int) bool {
+		return coverage.Products[i].CoveragePct < coverage.Products[j].CoveragePct
+	})
+	return coverage, nil
+}
+
+// buildSection assembles one dashboard section. region scopes the
+// region-specific sections; an empty region means the whole portfolio.
+func (h *DashboardHandler) buildSection(section, region string) (interface{}, error) {
+	switch section {
+	case SectionRiskIndex:
+		return computePortfolioRiskIndex(h.rules)
+	case SectionTrainingCoverage:
+		return trainingCoverage()
+	case SectionBlockedDependencies:
+		return listBlockedDependencies(h.rules, region, defaultBlockedSLADays)
+	}
+
+	query := database.DB.Scopes(models.ExcludeDrafts, models.ExcludeArchived).Preload("Readiness")
+	if region != "" {
+		query = query.Where("region = ?", region)
+	}
+	var products []models.Product
+	if err := query.Find(&products).Error; err != nil {
+		return nil, err
+	}
+
+	switch section {
+	case SectionEscalationSummary:
+		return summarizeEscalations(h.rules, products), nil
+	case SectionEscalations:
+		open, err := openEscalations()
+		if err != nil {
+			return nil, err
+		}
+		return activeEscalations(h.rules, products, open), nil
+	case SectionDataFreshness:
+		return summarizeFreshness(products), nil
+	case SectionRegionAttention:
+		return regionAttention(h.rules, products), nil
+	}
+	return nil, nil
+}
+
+// GetMyDashboard returns the landing view for the authenticated user's role
+func (h *DashboardHandler) GetMyDashboard(c *gin.Context) {
+	response := DashboardResponse{
+		Role: models.UserRole(c.GetString("role")),
+		Data: map[string]interface{}{},
+	}
+
+	// The profile is authoritative for role and region; fall back to the token
+	if id, err := uuid.Parse(currentUserID(c)); err == nil {
+		var profile models.Profile
+		if database.DB.First(&profile, "id = ?", id).Error == nil {
+			response.Role = profile.Role
+			response.Region = profile.Region
+		}
+	}
+
+	// Regional leads see their own region; without one on the profile their
+	// sections cover the whole portfolio
+	region := ""
+	if response.Role == models.UserRoleRegionalLead && response.Region != nil {
+		region = *response.Region
+	}
+
+	response.Sections = dashboardSections(response.Role)
+	for _, section := range response.Sections {
+		data, err := h.buildSection(section, region)
+		if err != nil {
+			respondWithError(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		response.Data[section] = data
+	}
+
+	respondWithData(c, http.StatusOK, response)
+}
+
+// PortfolioDashboard is the portfolio-wide landing payload. Every count
+// covers live products: drafts, archived and deleted products are left out.
+type PortfolioDashboard struct {
+	TotalProducts    int64            `json:"total_products"`
+	ByLifecycleStage map[string]int64 `json:"by_lifecycle_stage"`
+
+	// ByRiskBand counts products by readiness risk band; products without a
+	// readiness record are counted as "unscored"
+	ByRiskBand map[string]int64 `json:"by_risk_band"`
+
+	// ActiveEscalations counts products by the escalation level they are at
+	// now, evaluated as /escalations/summary does rather than read from the
+	// snapshotted records. Products on track are left out.
+	ActiveEscalations      map[string]int64 `json:"active_escalations"`
+	TotalActiveEscalations int64            `json:"total_active_escalations"`
+
+	BlockedDependencies int64   `json:"blocked_dependencies"`
+	AvgContractPercent  float64 `json:"avg_contract_percent"`
+}
+
+// contractPercentSQL is the share of data-contract fields a product has
+// filled, 0-100. It must check the same fields as mandatoryFields.
+const contractPercentSQL = `(CASE WHEN products.owner_email <> '' THEN 1 ELSE 0 END +
+	CASE WHEN products.region <> '' THEN 1 ELSE 0 END +
+	CASE WHEN products.budget_code <> '' THEN 1 ELSE 0 END +
+	CASE WHEN products.pii_flag IS NOT NULL THEN 1 ELSE 0 END +
+	CASE WHEN products.gating_status <> '' THEN 1 ELSE 0 END +
+	CASE WHEN products.success_metric <> '' THEN 1 ELSE 0 END) * 100.0 / 6`
+
+type groupCount struct {
+	Key   string
+	Count int64
+}
+
+func countsByKey(rows []groupCount) map[string]int64 {
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Key] += row.Count
+	}
+	return counts
+}
+
+// escalationInputs are the product columns evaluateEscalation reads
+type escalationInputs struct {
+	GatingStatus      *string
+	GatingStatusSince *models.Timestamp
+	RiskBand          *models.RiskBand
+}
+
+// portfolioDashboard aggregates the portfolio with grouped counts in the
+// database rather than loading every product. Escalation levels cannot be
+// grouped in SQL, so only the columns they depend on are loaded for them.
+func portfolioDashboard(db *gorm.DB, rules config.EscalationRules) (PortfolioDashboard, error) {
+	live := func(model interface{}) *gorm.DB {
+		return db.Model(model).Scopes(models.ExcludeDrafts, models.ExcludeArchived, models.ExcludeDeleted)
+	}
+	dashboard := PortfolioDashboard{}
+
+	var stages []groupCount
+	if err := live(&models.Product{}).
+		Select("products.lifecycle_stage AS key, COUNT(*) AS count").
+		Group("products.lifecycle_stage").
+		Scan(&stages).Error; err != nil {
+		return PortfolioDashboard{}, err
+	}
+	dashboard.ByLifecycleStage = countsByKey(stages)
+	for _, row := range stages {
+		dashboard.TotalProducts += row.Count
+	}
+
+	var bands []groupCount
+	if err := live(&models.Product{}).
+		Select("COALESCE(product_readiness.risk_band, 'unscored') AS key, COUNT(*) AS count").
+		Joins("LEFT JOIN product_readiness ON product_readiness.product_id = products.id").
+		Group("COALESCE(product_readiness.risk_band, 'unscored')").
+		Scan(&bands).Error; err != nil {
+		return PortfolioDashboard{}, err
+	}
+	dashboard.ByRiskBand = countsByKey(bands)
+
+	var inputs []escalationInputs
+	if err := live(&models.Product{}).
+		Select("products.gating_status, products.gating_status_since, product_readiness.risk_band").
+		Joins("LEFT JOIN product_readiness ON product_readiness.product_id = products.id").
+		Scan(&inputs).Error; err != nil {
+		return PortfolioDashboard{}, err
+	}
+	dashboard.ActiveEscalations = make(map[string]int64)
+	for _, input := range inputs {
+		product := models.Product{GatingStatus: input.GatingStatus, GatingStatusSince: input.GatingStatusSince}
+		if input.RiskBand != nil {
+			product.Readiness = &models.ProductReadiness{RiskBand: *input.RiskBand}
+		}
+		if level, _, _ := evaluateEscalation(rules, product); level != models.EscalationLevelNone {
+			dashboard.ActiveEscalations[string(level)]++
+			dashboard.TotalActiveEscalations++
+		}
+	}
+
+	if err := live(&models.ProductDependency{}).
+		Joins("JOIN products ON products.id = product_dependencies.product_id").
+		Where("product_dependencies.status = ?", models.DependencyStatusBlocked).
+		Count(&dashboard.BlockedDependencies).Error; err != nil {
+		return PortfolioDashboard{}, err
+	}
+
+	var avg *float64
+	if err := live(&models.Product{}).
+		Select("AVG(" + contractPercentSQL + ")").
+		Scan(&avg).Error; err != nil {
+		return PortfolioDashboard{}, err
+	}
+	if avg != nil {
+		dashboard.AvgContractPercent = roundTo2(*avg)
+	}
+
+	return dashboard, nil
+}
+
+// GetPortfolioDashboard returns the portfolio-wide landing view in one call
+func (h *DashboardHandler) GetPortfolioDashboard(c *gin.Context) {
+	dashboard, err := portfolioDashboard(database.DB, h.rules)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	respondWithData(c, http.StatusOK, dashboard)
+}
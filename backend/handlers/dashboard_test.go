@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pauly7610/studio-pilot-vision/backend/config"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+)
+
+func TestDashboardSections(t *testing.T) {
+	tests := []struct {
+		role models.UserRole
+		want []string
+	}{
+		{models.UserRoleVPProduct, []string{SectionRiskIndex, SectionEscalationSummary, SectionEscalations}},
+		{models.UserRoleStudioAmbassador, []string{SectionEscalationSummary, SectionEscalations, SectionDataFreshness}},
+		{models.UserRoleRegionalLead, []string{SectionRegionAttention, SectionBlockedDependencies}},
+		{models.UserRoleSales, []string{SectionTrainingCoverage}},
+		{models.UserRolePartnerOps, []string{SectionBlockedDependencies, SectionDataFreshness}},
+		{models.UserRoleViewer, []string{SectionRiskIndex, SectionEscalationSummary}},
+		{"unknown", []string{SectionRiskIndex, SectionEscalationSummary}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.role), func(t *testing.T) {
+			if got := dashboardSections(tt.role); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("dashboardSections(%q) = %v, want %v", tt.role, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDashboardSectionsAreBuildable(t *testing.T) {
+	known := map[string]bool{
+		SectionRiskIndex: true, SectionEscalationSummary: true, SectionEscalations: true,
+		SectionRegionAttention: true, SectionTrainingCoverage: true,
+		SectionBlockedDependencies: true, SectionDataFreshness: true,
+	}
+	for _, role := range []models.UserRole{
+		models.UserRoleVPProduct, models.UserRoleStudioAmbassador, models.UserRoleRegionalLead,
+		models.UserRoleSales, models.UserRolePartnerOps, models.UserRoleViewer,
+	} {
+		for _, section := range dashboardSections(role) {
+			if !known[section] {
+				t.Errorf("role %s maps to unknown section %q", role, section)
+			}
+		}
+	}
+}
+
+// portfolioDashboardDDL holds just the columns the portfolio aggregation reads
+var portfolioDashboardDDL = []string{
+	`CREATE TABLE products (
+		id TEXT PRIMARY KEY,
+		lifecycle_stage TEXT NOT NULL,
+		owner_email TEXT NOT NULL DEFAULT '',
+		region TEXT NOT NULL DEFAULT '',
+		budget_code TEXT,
+		pii_flag BOOLEAN,
+		gating_status TEXT,
+		success_metric TEXT,
+		is_draft BOOLEAN NOT NULL DEFAULT false,
+		archived_at DATETIME,
+		deleted_at DATETIME,
+		gating_status_since DATETIME
+	)`,
+	`CREATE TABLE product_readiness (product_id TEXT, risk_band TEXT)`,
+	`CREATE TABLE product_dependencies (product_id TEXT, status TEXT)`,
+}
+
+func TestPortfolioDashboard(t *testing.T) {
+	db := openTestDB(t, portfolioDashboardDDL...)
+	rules := config.EscalationRules{
+		CycleLengthDays:            14,
+		CriticalHighRiskCycles:     3,
+		ExecSteerCoHighRiskCycles:  2,
+		AmbassadorMediumRiskCycles: 2,
+		AutoEscalateGatingStatuses: []string{"Regional Legal"},
+	}
+	sixWeeksAgo := time.Now().AddDate(0, 0, -43).UTC().Format("2006-01-02 15:04:05")
+
+	stmts := []string{
+		// Fully filled data contract; low risk, so on track
+		`INSERT INTO products VALUES ('p1', 'pilot', 'a@x.com', 'EU', 'B1', true, 'open', 'nps', false, NULL, NULL, NULL)`,
+		// Half filled: owner, region, pii flag. High risk for 3 cycles: critical
+		`INSERT INTO products VALUES ('p2', 'pilot', 'b@x.com', 'NA', NULL, false, NULL, '', false, NULL, NULL, '` + sixWeeksAgo + `')`,
+		// Half filled: owner, region, gating status. Unscored, so medium
+		// risk, held at an auto-escalating gate: ambassador review
+		`INSERT INTO products VALUES ('p3', 'scaling', 'c@x.com', 'EU', NULL, NULL, 'Regional Legal', NULL, false, NULL, NULL, NULL)`,
+		// Left out: draft, archived, deleted
+		`INSERT INTO products VALUES ('d1', 'concept', '', '', NULL, NULL, NULL, NULL, true, NULL, NULL, NULL)`,
+		`INSERT INTO products VALUES ('a1', 'sunset', 'e@x.com', 'EU', NULL, NULL, NULL, NULL, false, '2026-01-01', NULL, NULL)`,
+		`INSERT INTO products VALUES ('x1', 'pilot', 'f@x.com', 'EU', NULL, NULL, NULL, NULL, false, NULL, '2026-01-01', '` + sixWeeksAgo + `')`,
+
+		`INSERT INTO product_readiness VALUES ('p1', 'low'), ('p2', 'high'), ('x1', 'high')`,
+		`INSERT INTO product_dependencies VALUES ('p1', 'blocked'), ('p2', 'blocked'), ('p3', 'pending'), ('a1', 'blocked')`,
+	}
+	for _, stmt := range stmts {
+		if err := db.Exec(stmt).Error; err != nil {
+			t.Fatalf("seed: %v", err)
+		}
+	}
+
+	dashboard, err := portfolioDashboard(db, rules)
+	if err != nil {
+		t.Fatalf("portfolioDashboard: %v", err)
+	}
+
+	if dashboard.TotalProducts != 3 {
+		t.Errorf("TotalProducts = %d, want 3", dashboard.TotalProducts)
+	}
+	if want := map[string]int64{"pilot": 2, "scaling": 1}; !reflect.DeepEqual(dashboard.ByLifecycleStage, want) {
+		t.Errorf("ByLifecycleStage = %v, want %v", dashboard.ByLifecycleStage, want)
+	}
+	if want := map[string]int64{"low": 1, "high": 1, "unscored": 1}; !reflect.DeepEqual(dashboard.ByRiskBand, want) {
+		t.Errorf("ByRiskBand = %v, want %v", dashboard.ByRiskBand, want)
+	}
+	if want := map[string]int64{"critical": 1, "ambassador_review": 1}; !reflect.DeepEqual(dashboard.ActiveEscalations, want) {
+		t.Errorf("ActiveEscalations = %v, want %v", dashboard.ActiveEscalations, want)
+	}
+	if dashboard.TotalActiveEscalations != 2 {
+		t.Errorf("TotalActiveEscalations = %d, want 2", dashboard.TotalActiveEscalations)
+	}
+	if dashboard.BlockedDependencies != 2 {
+		t.Errorf("BlockedDependencies = %d, want 2", dashboard.BlockedDependencies)
+	}
+	// (100 + 50 + 50) / 3
+	if dashboard.AvgContractPercent != 66.67 {
+		t.Errorf("AvgContractPercent = %v, want 66.67", dashboard.AvgContractPercent)
+	}
+}
+
+func TestPortfolioDashboard_Empty(t *testing.T) {
+	db := openTestDB(t, portfolioDashboardDDL...)
+
+	dashboard, err := portfolioDashboard(db, config.EscalationRules{CycleLengthDays: 14})
+	if err != nil {
+		t.Fatalf("portfolioDashboard: %v", err)
+	}
+	if dashboard.TotalProducts != 0 || dashboard.AvgContractPercent != 0 {
+		t.Errorf("dashboard = %+v, want zero counts", dashboard)
+	}
+	if dashboard.ByLifecycleStage == nil || dashboard.ActiveEscalations == nil {
+		t.Error("empty dashboard should have non-nil maps")
+	}
+}
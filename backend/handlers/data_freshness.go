@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -34,6 +35,7 @@ type DataFreshnessResponse struct {
 	DataContractComplete  bool            `json:"data_contract_complete"`
 	MandatoryFieldsFilled int             `json:"mandatory_fields_filled"`
 	TotalMandatoryFields  int             `json:"total_mandatory_fields"`
+	MissingFields         []string        `json:"missing_fields"` // JSON keys of the unfilled fields, as UpdateProduct takes them
 	ContractPercent       int             `json:"contract_percent"`
 	Message               string          `json:"message"`
 }
@@ -53,6 +55,44 @@ func getFreshnessStatus(lastUpdated time.Time, contractComplete bool) FreshnessS
 	return FreshnessStatusOutdated
 }
 
+// mandatoryField is a data-contract field, named by its JSON key in
+// UpdateProductRequest
+type mandatoryField struct {
+	Name   string
+	Filled func(models.Product) bool
+}
+
+// mandatoryFields make up the data contract; contractPercentSQL must check
+// the same fields
+var mandatoryFields = []mandatoryField{
+	{"owner_email", func(p models.Product) bool { return p.OwnerEmail != "" }},
+	{"region", func(p models.Product) bool { return p.Region != "" }},
+	{"budget_code", func(p models.Product) bool { return p.BudgetCode != nil && *p.BudgetCode != "" }},
+	{"pii_flag", func(p models.Product) bool { return p.PIIFlag != nil }},
+	{"gating_status", func(p models.Product) bool { return p.GatingStatus != nil && *p.GatingStatus != "" }},
+	{"success_metric", func(p models.Product) bool { return p.SuccessMetric != nil && *p.SuccessMetric != "" }},
+}
+
+// missingMandatoryFields names the data-contract fields a product has not
+// filled, in contract order
+func missingMandatoryFields(product models.Product) []string {
+	missing := []string{}
+	for _, field := range mandatoryFields {
+		if !field.Filled(product) {
+			missing = append(missing, field.Name)
+		}
+	}
+	return missing
+}
+
+// evaluateFreshness checks a product's data contract and returns its freshness
+// status along with how many mandatory fields are filled out of the total
+func evaluateFreshness(product models.Product) (FreshnessStatus, int, int) {
+	filled := len(mandatoryFields) - len(missingMandatoryFields(product))
+	contractComplete := filled == len(mandatoryFields)
+	return getFreshnessStatus(product.UpdatedAt.Time, contractComplete), filled, len(mandatoryFields)
+}
+
 func getStatusLabel(status FreshnessStatus) string {
 	switch status {
 	case FreshnessStatusSynced:
@@ -79,28 +119,38 @@ func getStatusMessage(status FreshnessStatus) string {
 	}
 }
 
+// formatTimeAgo renders how long ago t was for the dashboard, switching to
+// weeks and then months once a span passes 30 and 90 days
 func formatTimeAgo(t time.Time) string {
 	d := time.Since(t)
 	if d < time.Hour {
 		return "just now"
 	}
 	if d < 24*time.Hour {
-		hours := int(d.Hours())
-		if hours == 1 {
-			return "1 hour ago"
-		}
-		return string(rune(hours)) + " hours ago"
+		return pluralAgo(int(d.Hours()), "hour")
 	}
+
 	days := int(d.Hours() / 24)
-	if days == 1 {
-		return "1 day ago"
+	switch {
+	case days < 30:
+		return pluralAgo(days, "day")
+	case days < 90:
+		return pluralAgo(days/7, "week")
+	default:
+		return pluralAgo(days/30, "month")
 	}
-	return string(rune(days)) + " days ago"
+}
+
+func pluralAgo(n int, unit string) string {
+	if n == 1 {
+		return "1 " + unit + " ago"
+	}
+	return strconv.Itoa(n) + " " + unit + "s ago"
 }
 
 // GetProductDataFreshness returns data freshness status for a product
 func (h *DataFreshnessHandler) GetProductDataFreshness(c *gin.Context) {
-	productID, err := uuid.Parse(c.Param("productId"))
+	productID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
 		return
@@ -113,38 +163,20 @@ func (h *DataFreshnessHandler) GetProductDataFreshness(c *gin.Context) {
 		return
 	}
 
-	// Count mandatory fields filled
-	mandatoryFields := []bool{
-		product.OwnerEmail != "",
-		product.Region != "",
-		product.BudgetCode != nil && *product.BudgetCode != "",
-		product.PIIFlag != nil,
-		product.GatingStatus != nil && *product.GatingStatus != "",
-		product.SuccessMetric != nil && *product.SuccessMetric != "",
-	}
-
-	filled := 0
-	for _, f := range mandatoryFields {
-		if f {
-			filled++
-		}
-	}
-
-	totalFields := len(mandatoryFields)
+	status, filled, totalFields := evaluateFreshness(product)
 	contractComplete := filled == totalFields
 	contractPercent := (filled * 100) / totalFields
 
-	status := getFreshnessStatus(product.UpdatedAt, contractComplete)
-
 	response := DataFreshnessResponse{
 		ProductID:             productID.String(),
 		Status:                status,
 		StatusLabel:           getStatusLabel(status),
-		LastUpdated:           product.UpdatedAt.Format(time.RFC3339),
-		LastUpdatedAgo:        formatTimeAgo(product.UpdatedAt),
+		LastUpdated:           product.UpdatedAt.String(),
+		LastUpdatedAgo:        formatTimeAgo(product.UpdatedAt.Time),
 		DataContractComplete:  contractComplete,
 		MandatoryFieldsFilled: filled,
 		TotalMandatoryFields:  totalFields,
+		MissingFields:         missingMandatoryFields(product),
 		ContractPercent:       contractPercent,
 		Message:               getStatusMessage(status),
 	}
@@ -155,7 +187,7 @@ func (h *DataFreshnessHandler) GetProductDataFreshness(c *gin.Context) {
 // GetAllDataFreshness returns data freshness for all products
 func (h *DataFreshnessHandler) GetAllDataFreshness(c *gin.Context) {
 	var products []models.Product
-	result := database.DB.Find(&products)
+	result := database.DB.Scopes(models.ExcludeDrafts, models.ExcludeArchived).Find(&products)
 	if result.Error != nil {
 		respondWithError(c, http.StatusInternalServerError, result.Error.Error())
 		return
@@ -164,85 +196,45 @@ func (h *DataFreshnessHandler) GetAllDataFreshness(c *gin.Context) {
 	var responses []DataFreshnessResponse
 
 	for _, product := range products {
-		mandatoryFields := []bool{
-			product.OwnerEmail != "",
-			product.Region != "",
-			product.BudgetCode != nil && *product.BudgetCode != "",
-			product.PIIFlag != nil,
-			product.GatingStatus != nil && *product.GatingStatus != "",
-			product.SuccessMetric != nil && *product.SuccessMetric != "",
-		}
-
-		filled := 0
-		for _, f := range mandatoryFields {
-			if f {
-				filled++
-			}
-		}
-
-		totalFields := len(mandatoryFields)
+		status, filled, totalFields := evaluateFreshness(product)
 		contractComplete := filled == totalFields
 		contractPercent := (filled * 100) / totalFields
 
-		status := getFreshnessStatus(product.UpdatedAt, contractComplete)
-
 		responses = append(responses, DataFreshnessResponse{
 			ProductID:             product.ID.String(),
 			Status:                status,
 			StatusLabel:           getStatusLabel(status),
-			LastUpdated:           product.UpdatedAt.Format(time.RFC3339),
-			LastUpdatedAgo:        formatTimeAgo(product.UpdatedAt),
+			LastUpdated:           product.UpdatedAt.String(),
+			LastUpdatedAgo:        formatTimeAgo(product.UpdatedAt.Time),
 			DataContractComplete:  contractComplete,
 			MandatoryFieldsFilled: filled,
 			TotalMandatoryFields:  totalFields,
+			MissingFields:         missingMandatoryFields(product),
 			ContractPercent:       contractPercent,
 			Message:               getStatusMessage(status),
 		})
 	}
 
-	respondWithData(c, http.StatusOK, responses)
+	respondWithList(c, responses, newListMeta(""))
 }
 
-// GetDataFreshnessSummary returns summary of data freshness across all products
-func (h *DataFreshnessHandler) GetDataFreshnessSummary(c *gin.Context) {
-	var products []models.Product
-	result := database.DB.Find(&products)
-	if result.Error != nil {
-		respondWithError(c, http.StatusInternalServerError, result.Error.Error())
-		return
-	}
-
-	type Summary struct {
-		TotalProducts       int `json:"total_products"`
-		SyncedCount         int `json:"synced_count"`
-		FreshCount          int `json:"fresh_count"`
-		StaleCount          int `json:"stale_count"`
-		OutdatedCount       int `json:"outdated_count"`
-		AvgContractPercent  int `json:"avg_contract_percent"`
-		FullyCompliantCount int `json:"fully_compliant_count"`
-	}
+// DataFreshnessSummary counts products by freshness and data-contract status
+type DataFreshnessSummary struct {
+	TotalProducts       int `json:"total_products"`
+	SyncedCount         int `json:"synced_count"`
+	FreshCount          int `json:"fresh_count"`
+	StaleCount          int `json:"stale_count"`
+	OutdatedCount       int `json:"outdated_count"`
+	AvgContractPercent  int `json:"avg_contract_percent"`
+	FullyCompliantCount int `json:"fully_compliant_count"`
+}
 
-	summary := Summary{TotalProducts: len(products)}
+func summarizeFreshness(products []models.Product) DataFreshnessSummary {
+	summary := DataFreshnessSummary{TotalProducts: len(products)}
 	totalPercent := 0
 
 	for _, product := range products {
-		mandatoryFields := []bool{
-			product.OwnerEmail != "",
-			product.Region != "",
-			product.BudgetCode != nil && *product.BudgetCode != "",
-			product.PIIFlag != nil,
-			product.GatingStatus != nil && *product.GatingStatus != "",
-			product.SuccessMetric != nil && *product.SuccessMetric != "",
-		}
-
-		filled := 0
-		for _, f := range mandatoryFields {
-			if f {
-				filled++
-			}
-		}
-
-		totalFields := len(mandatoryFields)
+		status, filled, totalFields := evaluateFreshness(product)
 		contractComplete := filled == totalFields
 		contractPercent := (filled * 100) / totalFields
 		totalPercent += contractPercent
@@ -251,7 +243,6 @@ func (h *DataFreshnessHandler) GetDataFreshnessSummary(c *gin.Context) {
 			summary.FullyCompliantCount++
 		}
 
-		status := getFreshnessStatus(product.UpdatedAt, contractComplete)
 		switch status {
 		case FreshnessStatusSynced:
 			summary.SyncedCount++
@@ -268,5 +259,17 @@ func (h *DataFreshnessHandler) GetDataFreshnessSummary(c *gin.Context) {
 		summary.AvgContractPercent = totalPercent / len(products)
 	}
 
-	respondWithData(c, http.StatusOK, summary)
+	return summary
+}
+
+// GetDataFreshnessSummary returns summary of data freshness across all products
+func (h *DataFreshnessHandler) GetDataFreshnessSummary(c *gin.Context) {
+	var products []models.Product
+	result := database.DB.Scopes(models.ExcludeDrafts, models.ExcludeArchived).Find(&products)
+	if result.Error != nil {
+		respondWithError(c, http.StatusInternalServerError, result.Error.Error())
+		return
+	}
+
+	respondWithData(c, http.StatusOK, summarizeFreshness(products))
 }
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+)
+
+func TestFormatTimeAgo(t *testing.T) {
+	day := 24 * time.Hour
+	tests := []struct {
+		ago  time.Duration
+		want string
+	}{
+		{10 * time.Minute, "just now"},
+		{time.Hour + time.Minute, "1 hour ago"},
+		{7*time.Hour + time.Minute, "7 hours ago"},
+		{day + time.Hour, "1 day ago"},
+		{12*day + time.Hour, "12 days ago"},
+		{29*day + time.Hour, "29 days ago"},
+		{45*day + time.Hour, "6 weeks ago"},
+		{100*day + time.Hour, "3 months ago"},
+		{400*day + time.Hour, "13 months ago"},
+	}
+	for _, tt := range tests {
+		if got := formatTimeAgo(time.Now().Add(-tt.ago)); got != tt.want {
+			t.Errorf("formatTimeAgo(-%v) = %q, want %q", tt.ago, got, tt.want)
+		}
+	}
+}
+
+func TestSummarizeFreshness(t *testing.T) {
+	if got := summarizeFreshness(nil); got != (DataFreshnessSummary{}) {
+		t.Errorf("no products: got %+v, want a zeroed summary", got)
+	}
+
+	product := models.Product{OwnerEmail: "owner@example.com", Region: "EMEA", UpdatedAt: models.Now()}
+	got := summarizeFreshness([]models.Product{product})
+	want := DataFreshnessSummary{TotalProducts: 1, FreshCount: 1, AvgContractPercent: 33}
+	if got != want {
+		t.Errorf("one product: got %+v, want %+v", got, want)
+	}
+}
+
+func TestMissingMandatoryFields(t *testing.T) {
+	budget, empty := "BC-1", ""
+	product := models.Product{OwnerEmail: "owner@example.com", BudgetCode: &budget, GatingStatus: &empty}
+	got := missingMandatoryFields(product)
+	want := []string{"region", "pii_flag", "gating_status", "success_metric"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("missing = %v, want %v", got, want)
+	}
+	if _, filled, total := evaluateFreshness(product); filled != total-len(want) {
+		t.Errorf("filled = %d of %d, want %d", filled, total, total-len(want))
+	}
+
+	// The UI links each missing field to its UpdateProduct input
+	keys := map[string]bool{}
+	request := reflect.TypeOf(models.UpdateProductRequest{})
+	for i := 0; i < request.NumField(); i++ {
+		keys[strings.Split(request.Field(i).Tag.Get("json"), ",")[0]] = true
+	}
+	for _, field := range mandatoryFields {
+		if !keys[field.Name] {
+			t.Errorf("%s is not an UpdateProductRequest JSON key", field.Name)
+		}
+	}
+}
@@ -1,126 +1,312 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/pauly7610/studio-pilot-vision/backend/config"
 	"github.com/pauly7610/studio-pilot-vision/backend/database"
+	"github.com/pauly7610/studio-pilot-vision/backend/middleware"
 	"github.com/pauly7610/studio-pilot-vision/backend/models"
+	"gorm.io/gorm"
 )
 
-type DependenciesHandler struct{}
+type DependenciesHandler struct {
+	rules config.EscalationRules
+}
+
+func NewDependenciesHandler(rules config.EscalationRules) *DependenciesHandler {
+	return &DependenciesHandler{rules: rules}
+}
 
-func NewDependenciesHandler() *DependenciesHandler {
-	return &DependenciesHandler{}
+// dependencyFilters are the equality filters shared by the dependency list
+// endpoints
+var dependencyFilters = []queryFilter{
+	{Param: "status", Column: "status", Multi: true, Check: enumCheck(models.DependencyStatus.Check)},
+	{Param: "type", Column: "type", Multi: true, Check: enumCheck(models.DependencyType.Check)},
+	{Param: "category", Column: "category", Multi: true, Check: enumCheck(models.DependencyCategory.Check)},
+}
+
+// applyDependencyFilters applies the ?status=, ?type=, ?category=,
+// ?active_only= and ?include_archived= filters shared by the dependency list
+// endpoints, recording each one in meta. Archived dependencies are excluded
+// unless ?include_archived=true.
+func applyDependencyFilters(c *gin.Context, query *gorm.DB, meta *ListMeta) (*gorm.DB, error) {
+	query, err := applyFilters(query, c.Request.URL.Query(), dependencyFilters, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Query("include_archived") == "true" {
+		meta.filter("include_archived", "true")
+	} else {
+		query = query.Where("archived_at IS NULL")
+	}
+
+	// Exclude resolved dependencies
+	if c.Query("active_only") == "true" {
+		query = query.Where("status <> ?", models.DependencyStatusResolved)
+		meta.filter("active_only", "true")
+	}
+
+	return query, nil
 }
 
 // GetProductDependencies retrieves all dependencies for a product
 func (h *DependenciesHandler) GetProductDependencies(c *gin.Context) {
-	productID, err := uuid.Parse(c.Param("productId"))
+	productID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
 		return
 	}
 
+	meta := newListMeta("-created_at")
+	query, err := applyDependencyFilters(c, database.DB.Where("product_id = ?", productID), meta)
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	var dependencies []models.ProductDependency
-	result := database.DB.
-		Where("product_id = ?", productID).
-		Order("created_at DESC").
-		Find(&dependencies)
+	result := query.Order("created_at DESC").Find(&dependencies)
 
 	if result.Error != nil {
 		respondWithError(c, http.StatusInternalServerError, result.Error.Error())
 		return
 	}
 
-	respondWithData(c, http.StatusOK, dependencies)
+	respondWithList(c, dependencies, meta)
 }
 
 // GetAllDependencies retrieves all dependencies with optional filtering
 func (h *DependenciesHandler) GetAllDependencies(c *gin.Context) {
 	var dependencies []models.ProductDependency
 
-	query := database.DB.Order("created_at DESC")
+	meta := newListMeta("-created_at")
+	query, err := applyDependencyFilters(c, database.DB.Order("created_at DESC"), meta)
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
 
-	// Filter by status (blocked, pending, resolved)
-	if status := c.Query("status"); status != "" {
-		query = query.Where("status = ?", status)
+	result := query.Find(&dependencies)
+	if result.Error != nil {
+		respondWithError(c, http.StatusInternalServerError, result.Error.Error())
+		return
 	}
 
-	// Filter by type (internal, external)
-	if depType := c.Query("type"); depType != "" {
-		query = query.Where("type = ?", depType)
+	respondWithList(c, dependencies, meta)
+}
+
+// BlockedDependency is a blocked dependency with the product context and
+// escalation implication the blocked-items board needs
+type BlockedDependency struct {
+	models.ProductDependency
+	ProductName           string                 `json:"product_name"`
+	Region                string                 `json:"region"`
+	DaysBlocked           int                    `json:"days_blocked"`
+	SLABreached           bool                   `json:"sla_breached"`
+	EscalationImplication models.EscalationLevel `json:"escalation_implication"`
+}
+
+const (
+	defaultBlockedSLADays = 14
+	maxBlockedSLADays     = 365
+)
+
+// parseBlockedThreshold reads ?blocked_days_threshold=, the number of days
+// blocked at which a dependency breaches its SLA
+func parseBlockedThreshold(c *gin.Context) (int, error) {
+	raw := c.Query("blocked_days_threshold")
+	if raw == "" {
+		return defaultBlockedSLADays, nil
 	}
+	threshold, err := strconv.Atoi(raw)
+	if err != nil || threshold < 1 || threshold > maxBlockedSLADays {
+		return 0, errors.New("blocked_days_threshold must be between 1 and 365")
+	}
+	return threshold, nil
+}
 
-	// Filter by category
-	if category := c.Query("category"); category != "" {
-		query = query.Where("category = ?", category)
+// breachedDependencies keeps the dependencies past their SLA, preserving the
+// longest-blocked-first order
+func breachedDependencies(dependencies []BlockedDependency) []BlockedDependency {
+	breached := []BlockedDependency{}
+	for _, dependency := range dependencies {
+		if dependency.SLABreached {
+			breached = append(breached, dependency)
+		}
 	}
+	return breached
+}
 
-	result := query.Find(&dependencies)
-	if result.Error != nil {
-		respondWithError(c, http.StatusInternalServerError, result.Error.Error())
-		return
+// blockedEscalationImplication maps time blocked onto the escalation ladder,
+// treating a blocker like high risk: one review cycle warrants ambassador
+// review, then the SteerCo and critical thresholds apply as for products
+func blockedEscalationImplication(rules config.EscalationRules, daysBlocked int) models.EscalationLevel {
+	cycles := daysBlocked / rules.CycleLengthDays
+
+	switch {
+	case cycles >= rules.CriticalHighRiskCycles:
+		return models.EscalationLevelCritical
+	case cycles >= rules.ExecSteerCoHighRiskCycles:
+		return models.EscalationLevelExecSteerCo
+	case cycles >= 1:
+		return models.EscalationLevelAmbassadorReview
+	}
+	return models.EscalationLevelNone
+}
+
+// listBlockedDependencies returns blocked dependencies, longest-blocked
+// first, optionally limited to one product region. A dependency blocked for
+// slaDays or more is flagged as breaching its SLA.
+func listBlockedDependencies(rules config.EscalationRules, region string, slaDays int) ([]BlockedDependency, error) {
+	query := database.DB.Table("product_dependencies").
+		Select("product_dependencies.*, products.name AS product_name, products.region AS region").
+		Joins("JOIN products ON products.id = product_dependencies.product_id").
+		Scopes(models.ExcludeArchived, models.ExcludeDeleted).
+		Where("product_dependencies.status = ?", models.DependencyStatusBlocked)
+
+	if region != "" {
+		query = query.Where("products.region = ?", region)
+	}
+
+	dependencies := []BlockedDependency{}
+	if err := query.Order("product_dependencies.blocked_since ASC").Scan(&dependencies).Error; err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for i := range dependencies {
+		if since := dependencies[i].BlockedSince; since != nil {
+			dependencies[i].DaysBlocked = int(now.Sub(since.Time).Hours() / 24)
+			dependencies[i].SLABreached = dependencies[i].DaysBlocked >= slaDays
+		}
+		dependencies[i].EscalationImplication = blockedEscalationImplication(rules, dependencies[i].DaysBlocked)
 	}
 
-	respondWithData(c, http.StatusOK, dependencies)
+	return dependencies, nil
 }
 
-// GetBlockedDependencies retrieves only blocked dependencies
+// GetBlockedDependencies retrieves blocked dependencies, longest-blocked
+// first, with product name, region, days blocked, SLA breach and escalation
+// implication
 func (h *DependenciesHandler) GetBlockedDependencies(c *gin.Context) {
-	var dependencies []models.ProductDependency
+	h.respondWithBlocked(c, false)
+}
 
-	result := database.DB.
-		Where("status = ?", models.DependencyStatusBlocked).
-		Order("blocked_since ASC").
-		Find(&dependencies)
+// GetBreachedDependencies retrieves only the blocked dependencies past the
+// ?blocked_days_threshold= SLA, longest-blocked first
+func (h *DependenciesHandler) GetBreachedDependencies(c *gin.Context) {
+	h.respondWithBlocked(c, true)
+}
 
-	if result.Error != nil {
-		respondWithError(c, http.StatusInternalServerError, result.Error.Error())
+func (h *DependenciesHandler) respondWithBlocked(c *gin.Context, breachedOnly bool) {
+	threshold, err := parseBlockedThreshold(c)
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	respondWithData(c, http.StatusOK, dependencies)
+	region := c.Query("region")
+	dependencies, err := listBlockedDependencies(h.rules, region, threshold)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	meta := newListMeta("-days_blocked")
+	meta.filter("status", string(models.DependencyStatusBlocked))
+	if region != "" {
+		meta.filter("region", region)
+	}
+	meta.filter("blocked_days_threshold", strconv.Itoa(threshold))
+	if breachedOnly {
+		dependencies = breachedDependencies(dependencies)
+		meta.filter("sla_breached", "true")
+	}
+	respondWithList(c, dependencies, meta)
+}
+
+// blockedProductIDs dedupes the other products a dependency blocks, drops the
+// owning product and checks that each one exists
+func blockedProductIDs(owner uuid.UUID, ids []uuid.UUID) (models.UUIDArray, error) {
+	blocks := models.UUIDArray{}
+	for _, id := range ids {
+		if id != owner && !blocks.Contains(id) {
+			blocks = append(blocks, id)
+		}
+	}
+	if len(blocks) == 0 {
+		return blocks, nil
+	}
+
+	var found int64
+	if err := database.DB.Model(&models.Product{}).Where("id IN ?", []uuid.UUID(blocks)).Count(&found).Error; err != nil {
+		return nil, err
+	}
+	if int(found) != len(blocks) {
+		return nil, errUnknownBlockedProduct
+	}
+	return blocks, nil
+}
+
+var errUnknownBlockedProduct = errors.New("blocks_product_ids contains an unknown product")
+
+// respondWithBlocksError reports a blockedProductIDs failure
+func respondWithBlocksError(c *gin.Context, err error) {
+	if errors.Is(err, errUnknownBlockedProduct) {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	respondWithError(c, http.StatusInternalServerError, err.Error())
 }
 
 // CreateDependency creates a new dependency
 func (h *DependenciesHandler) CreateDependency(c *gin.Context) {
 	var req models.CreateProductDependencyRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		respondWithError(c, http.StatusBadRequest, err.Error())
+	if !bindRequest(c, &req) {
 		return
 	}
 
-	// Verify product exists
-	var product models.Product
-	if result := database.DB.First(&product, "id = ?", req.ProductID); result.Error != nil {
-		respondWithError(c, http.StatusNotFound, "Product not found")
+	blocks, err := blockedProductIDs(req.ProductID, req.BlocksProductIDs)
+	if err != nil {
+		respondWithBlocksError(c, err)
 		return
 	}
 
 	dependency := models.ProductDependency{
-		ProductID: req.ProductID,
-		Name:      req.Name,
-		Type:      req.Type,
-		Category:  req.Category,
-		Notes:     req.Notes,
+		ProductID:        req.ProductID,
+		Name:             req.Name,
+		Type:             req.Type,
+		Category:         req.Category,
+		Notes:            req.Notes,
+		BlocksProductIDs: blocks,
 	}
 
 	if req.Status != nil {
 		dependency.Status = *req.Status
 		if *req.Status == models.DependencyStatusBlocked {
-			now := time.Now()
+			now := models.Now()
 			dependency.BlockedSince = &now
 		}
 	} else {
 		dependency.Status = models.DependencyStatusPending
 	}
 
-	result := database.DB.Create(&dependency)
-	if result.Error != nil {
-		respondWithError(c, http.StatusInternalServerError, result.Error.Error())
+	err = createForProduct(database.DB, req.ProductID, func(tx *gorm.DB) error {
+		return tx.Create(&dependency).Error
+	})
+	if errors.Is(err, errProductNotFound) {
+		respondWithError(c, http.StatusNotFound, "Product not found")
+		return
+	}
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -142,8 +328,7 @@ func (h *DependenciesHandler) UpdateDependency(c *gin.Context) {
 	}
 
 	var req models.UpdateProductDependencyRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		respondWithError(c, http.StatusBadRequest, err.Error())
+	if !bindRequest(c, &req) {
 		return
 	}
 
@@ -172,6 +357,14 @@ func (h *DependenciesHandler) UpdateDependency(c *gin.Context) {
 	if req.Notes != nil {
 		updates["notes"] = *req.Notes
 	}
+	if req.BlocksProductIDs != nil {
+		blocks, err := blockedProductIDs(dependency.ProductID, *req.BlocksProductIDs)
+		if err != nil {
+			respondWithBlocksError(c, err)
+			return
+		}
+		updates["blocks_product_ids"] = blocks
+	}
 
 	result := database.DB.Model(&dependency).Updates(updates)
 	if result.Error != nil {
@@ -206,42 +399,160 @@ func (h *DependenciesHandler) DeleteDependency(c *gin.Context) {
 	respondWithSuccess(c, http.StatusOK, "Dependency deleted successfully", nil)
 }
 
-// GetDependencySummary returns summary stats for dependencies
-func (h *DependenciesHandler) GetDependencySummary(c *gin.Context) {
-	type Summary struct {
-		TotalCount     int64   `json:"total_count"`
-		BlockedCount   int64   `json:"blocked_count"`
-		PendingCount   int64   `json:"pending_count"`
-		ResolvedCount  int64   `json:"resolved_count"`
-		InternalCount  int64   `json:"internal_count"`
-		ExternalCount  int64   `json:"external_count"`
-		AvgBlockedDays float64 `json:"avg_blocked_days"`
-	}
-
-	var summary Summary
-
-	database.DB.Model(&models.ProductDependency{}).Count(&summary.TotalCount)
-	database.DB.Model(&models.ProductDependency{}).Where("status = ?", "blocked").Count(&summary.BlockedCount)
-	database.DB.Model(&models.ProductDependency{}).Where("status = ?", "pending").Count(&summary.PendingCount)
-	database.DB.Model(&models.ProductDependency{}).Where("status = ?", "resolved").Count(&summary.ResolvedCount)
-	database.DB.Model(&models.ProductDependency{}).Where("type = ?", "internal").Count(&summary.InternalCount)
-	database.DB.Model(&models.ProductDependency{}).Where("type = ?", "external").Count(&summary.ExternalCount)
-
-	// Calculate average blocked days
-	var blockedDeps []models.ProductDependency
-	database.DB.Where("status = ? AND blocked_since IS NOT NULL", "blocked").Find(&blockedDeps)
+// ArchiveDependency hides a dependency from the default dependency lists
+func (h *DependenciesHandler) ArchiveDependency(c *gin.Context) {
+	h.setArchived(c, true)
+}
+
+// ReactivateDependency returns an archived dependency to the active lists
+func (h *DependenciesHandler) ReactivateDependency(c *gin.Context) {
+	h.setArchived(c, false)
+}
 
+func (h *DependenciesHandler) setArchived(c *gin.Context, archive bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "Invalid dependency ID")
+		return
+	}
+
+	var dependency models.ProductDependency
+	if result := database.DB.First(&dependency, "id = ?", id); result.Error != nil {
+		respondWithError(c, http.StatusNotFound, "Dependency not found")
+		return
+	}
+
+	if archive == (dependency.ArchivedAt != nil) {
+		if archive {
+			respondWithError(c, http.StatusConflict, "Dependency is already archived")
+		} else {
+			respondWithError(c, http.StatusConflict, "Dependency is not archived")
+		}
+		return
+	}
+
+	var archivedAt *models.Timestamp
+	if archive {
+		now := models.Now()
+		archivedAt = &now
+	}
+	if err := database.DB.Model(&dependency).Update("archived_at", archivedAt).Error; err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	dependency.ArchivedAt = archivedAt
+
+	description := "Dependency reactivated"
+	if archive {
+		description = "Dependency archived"
+	}
+	middleware.LogAdminAction(c, description, map[string]interface{}{
+		"dependency_id": dependency.ID.String(),
+		"product_id":    dependency.ProductID.String(),
+		"name":          dependency.Name,
+	})
+
+	respondWithData(c, http.StatusOK, dependency)
+}
+
+const (
+	defaultArchiveResolvedDays = 90
+	maxArchiveResolvedDays     = 365
+)
+
+// archiveResolvedDependencies archives the unarchived dependencies resolved
+// before cutoff, returning how many it archived. Dependencies resolved
+// without passing through blocked have no resolved_at, so their last update
+// stands in for it.
+func archiveResolvedDependencies(db *gorm.DB, cutoff, now time.Time) (int64, error) {
+	result := db.Model(&models.ProductDependency{}).
+		Where("status = ? AND archived_at IS NULL", models.DependencyStatusResolved).
+		Where("COALESCE(resolved_at, updated_at) < ?", cutoff).
+		Update("archived_at", models.NewTimestamp(now))
+	return result.RowsAffected, result.Error
+}
+
+// ArchiveResolvedDependencies archives dependencies resolved more than
+// ?resolved_days= days ago (default 90). Meant for an external scheduler;
+// safe to re-run.
+func (h *DependenciesHandler) ArchiveResolvedDependencies(c *gin.Context) {
+	days := defaultArchiveResolvedDays
+	if raw := c.Query("resolved_days"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > maxArchiveResolvedDays {
+			respondWithError(c, http.StatusBadRequest, "resolved_days must be between 1 and 365")
+			return
+		}
+		days = parsed
+	}
+
+	now := time.Now()
+	archived, err := archiveResolvedDependencies(database.DB, now.AddDate(0, 0, -days), now)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithData(c, http.StatusOK, gin.H{"archived": archived, "resolved_days": days})
+}
+
+// DependencySummary counts dependencies by status and type
+type DependencySummary struct {
+	TotalCount     int64   `json:"total_count"`
+	BlockedCount   int64   `json:"blocked_count"`
+	PendingCount   int64   `json:"pending_count"`
+	ResolvedCount  int64   `json:"resolved_count"`
+	InternalCount  int64   `json:"internal_count"`
+	ExternalCount  int64   `json:"external_count"`
+	AvgBlockedDays float64 `json:"avg_blocked_days"` // 0 with nothing blocked
+}
+
+// dependencySummary counts dependencies and averages how long the blocked
+// ones have been blocked as of now
+func dependencySummary(db *gorm.DB, now time.Time) (DependencySummary, error) {
+	var summary DependencySummary
+	if err := db.Model(&models.ProductDependency{}).Count(&summary.TotalCount).Error; err != nil {
+		return summary, err
+	}
+
+	counts := []struct {
+		column, value string
+		count         *int64
+	}{
+		{"status", string(models.DependencyStatusBlocked), &summary.BlockedCount},
+		{"status", string(models.DependencyStatusPending), &summary.PendingCount},
+		{"status", string(models.DependencyStatusResolved), &summary.ResolvedCount},
+		{"type", string(models.DependencyTypeInternal), &summary.InternalCount},
+		{"type", string(models.DependencyTypeExternal), &summary.ExternalCount},
+	}
+	for _, count := range counts {
+		if err := db.Model(&models.ProductDependency{}).Where(count.column+" = ?", count.value).Count(count.count).Error; err != nil {
+			return summary, err
+		}
+	}
+
+	var blockedDeps []models.ProductDependency
+	if err := db.Where("status = ? AND blocked_since IS NOT NULL", models.DependencyStatusBlocked).Find(&blockedDeps).Error; err != nil {
+		return summary, err
+	}
 	if len(blockedDeps) > 0 {
 		var totalDays float64
-		now := time.Now()
 		for _, dep := range blockedDeps {
-			if dep.BlockedSince != nil {
-				days := now.Sub(*dep.BlockedSince).Hours() / 24
-				totalDays += days
-			}
+			totalDays += now.Sub(dep.BlockedSince.Time).Hours() / 24
 		}
 		summary.AvgBlockedDays = totalDays / float64(len(blockedDeps))
 	}
 
+	return summary, nil
+}
+
+// GetDependencySummary returns summary stats for dependencies
+func (h *DependenciesHandler) GetDependencySummary(c *gin.Context) {
+	summary, err := dependencySummary(database.DB, time.Now())
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
 	respondWithData(c, http.StatusOK, summary)
 }
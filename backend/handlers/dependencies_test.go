@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/pauly7610/studio-pilot-vision/backend/config"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+)
+
+func TestParseBlockedThreshold(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		query   string
+		want    int
+		wantErr bool
+	}{
+		{"", defaultBlockedSLADays, false},
+		{"?blocked_days_threshold=7", 7, false},
+		{"?blocked_days_threshold=0", 0, true},
+		{"?blocked_days_threshold=366", 0, true},
+		{"?blocked_days_threshold=soon", 0, true},
+	}
+
+	for _, tt := range tests {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/dependencies/blocked"+tt.query, nil)
+
+		got, err := parseBlockedThreshold(c)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("%q: got %d, %v; want %d, error=%v", tt.query, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestBreachedDependencies_KeepsOrder(t *testing.T) {
+	dependencies := []BlockedDependency{
+		{ProductName: "oldest", DaysBlocked: 40, SLABreached: true},
+		{ProductName: "middle", DaysBlocked: 20, SLABreached: true},
+		{ProductName: "recent", DaysBlocked: 3},
+	}
+
+	breached := breachedDependencies(dependencies)
+	if len(breached) != 2 || breached[0].ProductName != "oldest" || breached[1].ProductName != "middle" {
+		t.Errorf("breached = %+v, want oldest then middle", breached)
+	}
+	if got := breachedDependencies(nil); got == nil || len(got) != 0 {
+		t.Errorf("expected an empty, non-nil slice, got %v", got)
+	}
+}
+
+func TestDependencySummary(t *testing.T) {
+	db := openTestDB(t, `CREATE TABLE product_dependencies (
+		id TEXT PRIMARY KEY, product_id TEXT, name TEXT, type TEXT, category TEXT, status TEXT,
+		blocked_since DATETIME, resolved_at DATETIME, notes TEXT, created_at DATETIME, updated_at DATETIME)`)
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	summary, err := dependencySummary(db, now)
+	if err != nil {
+		t.Fatalf("dependencySummary: %v", err)
+	}
+	if summary != (DependencySummary{}) {
+		t.Errorf("no dependencies: got %+v, want a zeroed summary", summary)
+	}
+
+	// Blocked without a blocked_since date: counted, but left out of the average
+	db.Exec(`INSERT INTO product_dependencies (id, product_id, name, type, category, status) VALUES ('3f6c2a10-9a51-4c1e-8d0b-5d2f7e1a0001', '3f6c2a10-9a51-4c1e-8d0b-5d2f7e1a0002', 'Rail', 'external', 'rail', 'blocked')`)
+	summary, err = dependencySummary(db, now)
+	if err != nil {
+		t.Fatalf("dependencySummary: %v", err)
+	}
+	want := DependencySummary{TotalCount: 1, BlockedCount: 1, ExternalCount: 1}
+	if summary != want {
+		t.Errorf("one undated blocker: got %+v, want %+v", summary, want)
+	}
+
+	db.Exec(`UPDATE product_dependencies SET blocked_since = ? WHERE name = 'Rail'`, now.AddDate(0, 0, -6))
+	summary, err = dependencySummary(db, now)
+	if err != nil {
+		t.Fatalf("dependencySummary: %v", err)
+	}
+	if summary.AvgBlockedDays != 6 {
+		t.Errorf("AvgBlockedDays = %v, want 6", summary.AvgBlockedDays)
+	}
+}
+
+func TestArchiveResolvedDependencies(t *testing.T) {
+	db := openTestDB(t, `CREATE TABLE product_dependencies (
+		id TEXT PRIMARY KEY, product_id TEXT, name TEXT, type TEXT, category TEXT, status TEXT,
+		blocked_since DATETIME, resolved_at DATETIME, notes TEXT, archived_at DATETIME,
+		created_at DATETIME, updated_at DATETIME)`)
+	now := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(n int) time.Time { return now.AddDate(0, 0, -n) }
+
+	for _, dep := range []struct {
+		name     string
+		status   models.DependencyStatus
+		resolved interface{}
+		updated  time.Time
+		archived interface{}
+	}{
+		{"Long resolved", models.DependencyStatusResolved, daysAgo(120), daysAgo(120), nil},
+		{"Recently resolved", models.DependencyStatusResolved, daysAgo(30), daysAgo(30), nil},
+		{"Resolved from pending", models.DependencyStatusResolved, nil, daysAgo(100), nil},
+		{"Already archived", models.DependencyStatusResolved, daysAgo(200), daysAgo(200), daysAgo(10)},
+		{"Old blocker", models.DependencyStatusBlocked, nil, daysAgo(150), nil},
+	} {
+		if err := db.Exec(`INSERT INTO product_dependencies (id, product_id, name, type, category, status, resolved_at, updated_at, archived_at)
+			VALUES (?, ?, ?, 'internal', 'legal', ?, ?, ?, ?)`,
+			uuid.NewString(), uuid.NewString(), dep.name, dep.status, dep.resolved, dep.updated, dep.archived).Error; err != nil {
+			t.Fatalf("insert %s: %v", dep.name, err)
+		}
+	}
+
+	archived, err := archiveResolvedDependencies(db, daysAgo(90), now)
+	if err != nil {
+		t.Fatalf("archiveResolvedDependencies: %v", err)
+	}
+	if archived != 2 {
+		t.Errorf("archived %d, want 2", archived)
+	}
+
+	var names []string
+	db.Model(&models.ProductDependency{}).Where("archived_at IS NULL").Order("name").Pluck("name", &names)
+	if want := []string{"Old blocker", "Recently resolved"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("still active = %v, want %v", names, want)
+	}
+
+	if archived, _ := archiveResolvedDependencies(db, daysAgo(90), now); archived != 0 {
+		t.Errorf("second run archived %d, want 0", archived)
+	}
+}
+
+func TestGetProductDependencies_Filters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := openTestDB(t, `CREATE TABLE product_dependencies (
+		id TEXT PRIMARY KEY, product_id TEXT, name TEXT, type TEXT, category TEXT, status TEXT,
+		blocked_since DATETIME, resolved_at DATETIME, notes TEXT, blocks_product_ids TEXT, archived_at DATETIME,
+		created_at DATETIME, updated_at DATETIME)`)
+	useTestDB(t, db)
+	productID := uuid.NewString()
+	for _, dep := range []struct{ name, depType, status string }{
+		{"Rail", "external", "blocked"},
+		{"Legal review", "internal", "pending"},
+		{"Old rail", "external", "resolved"},
+	} {
+		db.Exec(`INSERT INTO product_dependencies (id, product_id, name, type, category, status) VALUES (?, ?, ?, ?, 'legal', ?)`,
+			uuid.NewString(), productID, dep.name, dep.depType, dep.status)
+	}
+
+	tests := []struct {
+		query string
+		code  int
+		names []string
+	}{
+		{"", http.StatusOK, []string{"Legal review", "Old rail", "Rail"}},
+		{"?active_only=true", http.StatusOK, []string{"Legal review", "Rail"}},
+		{"?type=external&active_only=true", http.StatusOK, []string{"Rail"}},
+		{"?status=stuck", http.StatusBadRequest, nil},
+		{"?type=partner", http.StatusBadRequest, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/products/"+productID+"/dependencies"+tt.query, nil)
+			c.Params = gin.Params{{Key: "id", Value: productID}}
+
+			NewDependenciesHandler(config.EscalationRules{}).GetProductDependencies(c)
+			if w.Code != tt.code {
+				t.Fatalf("status = %d, want %d (body %s)", w.Code, tt.code, w.Body.String())
+			}
+			if tt.code != http.StatusOK {
+				return
+			}
+
+			var deps []models.ProductDependency
+			if err := json.Unmarshal(w.Body.Bytes(), &deps); err != nil {
+				t.Fatalf("decode %s: %v", w.Body.String(), err)
+			}
+			var names []string
+			for _, dep := range deps {
+				names = append(names, dep.Name)
+			}
+			sort.Strings(names)
+			if !reflect.DeepEqual(names, tt.names) {
+				t.Errorf("names = %v, want %v", names, tt.names)
+			}
+		})
+	}
+}
+
+func TestBlockedEscalationImplication(t *testing.T) {
+	rules := config.EscalationRules{CycleLengthDays: 14, ExecSteerCoHighRiskCycles: 2, CriticalHighRiskCycles: 3}
+	weekly := rules
+	weekly.CycleLengthDays = 7
+
+	tests := []struct {
+		rules       config.EscalationRules
+		daysBlocked int
+		want        models.EscalationLevel
+	}{
+		{rules, 13, models.EscalationLevelNone},
+		{rules, 14, models.EscalationLevelAmbassadorReview},
+		{rules, 28, models.EscalationLevelExecSteerCo},
+		{rules, 42, models.EscalationLevelCritical},
+		{weekly, 13, models.EscalationLevelAmbassadorReview},
+		{weekly, 21, models.EscalationLevelCritical},
+	}
+	for _, tt := range tests {
+		if got := blockedEscalationImplication(tt.rules, tt.daysBlocked); got != tt.want {
+			t.Errorf("%d-day cycles, %d days blocked: got %s, want %s", tt.rules.CycleLengthDays, tt.daysBlocked, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/pauly7610/studio-pilot-vision/backend/database"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+)
+
+// DependencyGraphNode is a product in the blocking graph
+type DependencyGraphNode struct {
+	ProductID      string                `json:"product_id"`
+	Name           string                `json:"name"`
+	Region         string                `json:"region"`
+	LifecycleStage models.LifecycleStage `json:"lifecycle_stage"`
+	BlockerCount   int                   `json:"blocker_count"`
+}
+
+// DependencyGraphEdge is an open dependency linking every product it blocks,
+// the owning product first
+type DependencyGraphEdge struct {
+	DependencyID   string                    `json:"dependency_id"`
+	Name           string                    `json:"name"`
+	Type           models.DependencyType     `json:"type"`
+	Category       models.DependencyCategory `json:"category"`
+	Status         models.DependencyStatus   `json:"status"`
+	BlockedSince   *models.Timestamp         `json:"blocked_since,omitempty"`
+	ProductIDs     []string                  `json:"product_ids"`
+	BlocksMultiple bool                      `json:"blocks_multiple"`
+}
+
+// DependencyGraph is a blocking graph of products and open dependencies
+type DependencyGraph struct {
+	Nodes []DependencyGraphNode `json:"nodes"`
+	Edges []DependencyGraphEdge `json:"edges"`
+
+	// SharedBlockers are the IDs of dependencies blocking more than one
+	// product, widest first: unblocking them frees the most products
+	SharedBlockers []string `json:"shared_blockers"`
+}
+
+// buildDependencyGraph links dependencies to the given products. Products not
+// in the list, such as archived ones, are left out of the edges; a dependency
+// left blocking none of them is dropped.
+func buildDependencyGraph(dependencies []models.ProductDependency, products []models.Product) DependencyGraph {
+	byID := make(map[uuid.UUID]models.Product, len(products))
+	for _, product := range products {
+		byID[product.ID] = product
+	}
+
+	graph := DependencyGraph{Nodes: []DependencyGraphNode{}, Edges: []DependencyGraphEdge{}, SharedBlockers: []string{}}
+	blockers := map[uuid.UUID]int{}
+
+	for _, dependency := range dependencies {
+		edge := DependencyGraphEdge{
+			DependencyID: dependency.ID.String(),
+			Name:         dependency.Name,
+			Type:         dependency.Type,
+			Category:     dependency.Category,
+			Status:       dependency.Status,
+			BlockedSince: dependency.BlockedSince,
+			ProductIDs:   []string{},
+		}
+		blocked := append(models.UUIDArray{dependency.ProductID}, dependency.BlocksProductIDs...)
+		for i, id := range blocked {
+			if _, ok := byID[id]; !ok || blocked[:i].Contains(id) {
+				continue
+			}
+			edge.ProductIDs = append(edge.ProductIDs, id.String())
+			blockers[id]++
+		}
+		if len(edge.ProductIDs) == 0 {
+			continue
+		}
+		edge.BlocksMultiple = len(edge.ProductIDs) > 1
+		graph.Edges = append(graph.Edges, edge)
+	}
+
+	sort.SliceStable(graph.Edges, func(i, j int) bool {
+		if len(graph.Edges[i].ProductIDs) != len(graph.Edges[j].ProductIDs) {
+			return len(graph.Edges[i].ProductIDs) > len(graph.Edges[j].ProductIDs)
+		}
+		return graph.Edges[i].Name < graph.Edges[j].Name
+	})
+	for _, edge := range graph.Edges {
+		if edge.BlocksMultiple {
+			graph.SharedBlockers = append(graph.SharedBlockers, edge.DependencyID)
+		}
+	}
+
+	for _, product := range products {
+		if blockers[product.ID] == 0 {
+			continue
+		}
+		graph.Nodes = append(graph.Nodes, DependencyGraphNode{
+			ProductID:      product.ID.String(),
+			Name:           product.Name,
+			Region:         product.Region,
+			LifecycleStage: product.LifecycleStage,
+			BlockerCount:   blockers[product.ID],
+		})
+	}
+	sort.SliceStable(graph.Nodes, func(i, j int) bool {
+		if graph.Nodes[i].BlockerCount != graph.Nodes[j].BlockerCount {
+			return graph.Nodes[i].BlockerCount > graph.Nodes[j].BlockerCount
+		}
+		return graph.Nodes[i].Name < graph.Nodes[j].Name
+	})
+
+	return graph
+}
+
+// GetDependencyGraph returns unresolved dependencies as a graph of the
+// products they block, flagging dependencies that block more than one
+func (h *DependenciesHandler) GetDependencyGraph(c *gin.Context) {
+	var dependencies []models.ProductDependency
+	if err := database.DB.
+		Where("status <> ?", models.DependencyStatusResolved).
+		Find(&dependencies).Error; err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	var products []models.Product
+	if err := database.DB.Scopes(models.ExcludeArchived).Find(&products).Error; err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithData(c, http.StatusOK, buildDependencyGraph(dependencies, products))
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+)
+
+func TestBuildDependencyGraph(t *testing.T) {
+	wallet := models.Product{ID: uuid.New(), Name: "Wallet"}
+	loyalty := models.Product{ID: uuid.New(), Name: "Loyalty"}
+	fraud := models.Product{ID: uuid.New(), Name: "Fraud"}
+	archived := uuid.New()
+
+	rail := models.ProductDependency{
+		ID:               uuid.New(),
+		ProductID:        wallet.ID,
+		Name:             "Partner rail",
+		BlocksProductIDs: models.UUIDArray{loyalty.ID, fraud.ID, archived},
+	}
+	legal := models.ProductDependency{ID: uuid.New(), ProductID: loyalty.ID, Name: "Legal review"}
+	orphan := models.ProductDependency{ID: uuid.New(), ProductID: archived, Name: "Orphan"}
+
+	graph := buildDependencyGraph(
+		[]models.ProductDependency{legal, orphan, rail},
+		[]models.Product{wallet, loyalty, fraud},
+	)
+
+	if len(graph.Edges) != 2 {
+		t.Fatalf("expected 2 edges (orphan dropped), got %+v", graph.Edges)
+	}
+	first := graph.Edges[0]
+	wantIDs := []string{wallet.ID.String(), loyalty.ID.String(), fraud.ID.String()}
+	if first.DependencyID != rail.ID.String() || !first.BlocksMultiple || !reflect.DeepEqual(first.ProductIDs, wantIDs) {
+		t.Errorf("first edge = %+v, want the rail blocking %v", first, wantIDs)
+	}
+	if graph.Edges[1].BlocksMultiple {
+		t.Errorf("single-product dependency flagged as shared: %+v", graph.Edges[1])
+	}
+	if !reflect.DeepEqual(graph.SharedBlockers, []string{rail.ID.String()}) {
+		t.Errorf("shared blockers = %v", graph.SharedBlockers)
+	}
+
+	if len(graph.Nodes) != 3 || graph.Nodes[0].Name != "Loyalty" || graph.Nodes[0].BlockerCount != 2 {
+		t.Errorf("nodes = %+v, want Loyalty first with 2 blockers", graph.Nodes)
+	}
+}
@@ -1,49 +1,90 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/pauly7610/studio-pilot-vision/backend/config"
 	"github.com/pauly7610/studio-pilot-vision/backend/database"
+	"github.com/pauly7610/studio-pilot-vision/backend/middleware"
 	"github.com/pauly7610/studio-pilot-vision/backend/models"
+	"github.com/pauly7610/studio-pilot-vision/backend/notify"
+	"gorm.io/gorm"
 )
 
-type EscalationsHandler struct{}
+type EscalationsHandler struct {
+	rules             config.EscalationRules
+	autoCreateActions bool
+	notifier          *notify.Dispatcher
+}
 
-func NewEscalationsHandler() *EscalationsHandler {
-	return &EscalationsHandler{}
+// NewEscalationsHandler creates the handler. When autoCreateActions is set,
+// snapshots open an intervention action for every newly-critical product.
+// Snapshots that raise a product's level publish an event to notifier, which
+// may be nil.
+func NewEscalationsHandler(rules config.EscalationRules, autoCreateActions bool, notifier *notify.Dispatcher) *EscalationsHandler {
+	return &EscalationsHandler{rules: rules, autoCreateActions: autoCreateActions, notifier: notifier}
 }
 
 // CalculateEscalationLevel determines escalation based on product status
-func calculateEscalationLevel(riskBand string, cyclesInStatus int, gatingStatus string) models.EscalationLevel {
+func calculateEscalationLevel(rules config.EscalationRules, riskBand string, cyclesInStatus int, gatingStatus string) models.EscalationLevel {
 	isHighRisk := riskBand == "high"
 	isMediumRisk := riskBand == "medium"
 
-	// Critical: High risk for 3+ cycles
-	if isHighRisk && cyclesInStatus >= 3 {
+	// Critical: High risk for CriticalHighRiskCycles+ cycles (default 3)
+	if isHighRisk && cyclesInStatus >= rules.CriticalHighRiskCycles {
 		return models.EscalationLevelCritical
 	}
 
-	// Exec SteerCo: High risk for 2 cycles
-	if isHighRisk && cyclesInStatus >= 2 {
+	// Exec SteerCo: High risk for ExecSteerCoHighRiskCycles cycles (default 2)
+	if isHighRisk && cyclesInStatus >= rules.ExecSteerCoHighRiskCycles {
 		return models.EscalationLevelExecSteerCo
 	}
 
-	// Ambassador Review: Medium risk for 2+ cycles
-	if isMediumRisk && cyclesInStatus >= 2 {
+	// Ambassador Review: Medium risk for AmbassadorMediumRiskCycles+ cycles (default 2)
+	if isMediumRisk && cyclesInStatus >= rules.AmbassadorMediumRiskCycles {
 		return models.EscalationLevelAmbassadorReview
 	}
 
-	// Ambassador Review: Legal/Privacy bottleneck
-	if gatingStatus == "Regional Legal" || gatingStatus == "PII/Privacy Review" {
-		return models.EscalationLevelAmbassadorReview
+	// Ambassador Review: Legal/Privacy bottleneck (auto-escalating gating statuses)
+	for _, status := range rules.AutoEscalateGatingStatuses {
+		if gatingStatus == status {
+			return models.EscalationLevelAmbassadorReview
+		}
 	}
 
 	return models.EscalationLevelNone
 }
 
+// evaluateEscalation derives the escalation level for a product along with the
+// risk band and cycle count it was based on. Readiness must be preloaded.
+func evaluateEscalation(rules config.EscalationRules, product models.Product) (models.EscalationLevel, string, int) {
+	// Calculate cycles in status based on gating_status_since
+	cyclesInStatus := 0
+	if product.GatingStatusSince != nil && rules.CycleLengthDays > 0 {
+		days := int(time.Since(product.GatingStatusSince.Time).Hours() / 24)
+		cyclesInStatus = days / rules.CycleLengthDays
+	}
+
+	riskBand := "medium"
+	if product.Readiness != nil {
+		riskBand = string(product.Readiness.RiskBand)
+	}
+
+	gatingStatus := ""
+	if product.GatingStatus != nil {
+		gatingStatus = *product.GatingStatus
+	}
+
+	return calculateEscalationLevel(rules, riskBand, cyclesInStatus, gatingStatus), riskBand, cyclesInStatus
+}
+
 func getEscalationConfig(level models.EscalationLevel) (string, string, string) {
 	switch level {
 	case models.EscalationLevelAmbassadorReview:
@@ -80,7 +121,7 @@ func getNextMilestone(lifecycleStage string, riskBand string) string {
 
 // GetProductEscalation calculates and returns escalation status for a product
 func (h *EscalationsHandler) GetProductEscalation(c *gin.Context) {
-	productID, err := uuid.Parse(c.Param("productId"))
+	productID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
 		return
@@ -96,24 +137,7 @@ func (h *EscalationsHandler) GetProductEscalation(c *gin.Context) {
 		return
 	}
 
-	// Calculate cycles in status based on gating_status_since
-	cyclesInStatus := 0
-	if product.GatingStatusSince != nil {
-		weeks := int(time.Since(*product.GatingStatusSince).Hours() / (24 * 7))
-		cyclesInStatus = weeks / 2 // 2 weeks per cycle
-	}
-
-	riskBand := "medium"
-	if product.Readiness != nil {
-		riskBand = string(product.Readiness.RiskBand)
-	}
-
-	gatingStatus := ""
-	if product.GatingStatus != nil {
-		gatingStatus = *product.GatingStatus
-	}
-
-	level := calculateEscalationLevel(riskBand, cyclesInStatus, gatingStatus)
+	level, riskBand, cyclesInStatus := evaluateEscalation(h.rules, product)
 	label, action, owner := getEscalationConfig(level)
 	nextMilestone := getNextMilestone(string(product.LifecycleStage), riskBand)
 
@@ -131,38 +155,32 @@ func (h *EscalationsHandler) GetProductEscalation(c *gin.Context) {
 	respondWithData(c, http.StatusOK, response)
 }
 
-// GetAllEscalations returns all products with active escalations
-func (h *EscalationsHandler) GetAllEscalations(c *gin.Context) {
-	var products []models.Product
-	result := database.DB.
-		Preload("Readiness").
-		Find(&products)
+// openEscalations returns each product's unresolved escalation record, the
+// most recently triggered one if several are open
+func openEscalations() (map[uuid.UUID]models.ProductEscalation, error) {
+	var rows []models.ProductEscalation
+	if err := database.DB.
+		Where("resolved_at IS NULL").
+		Order("triggered_at ASC").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
 
-	if result.Error != nil {
-		respondWithError(c, http.StatusInternalServerError, result.Error.Error())
-		return
+	open := make(map[uuid.UUID]models.ProductEscalation, len(rows))
+	for _, row := range rows {
+		open[row.ProductID] = row
 	}
+	return open, nil
+}
 
-	var escalations []models.EscalationResponse
+// activeEscalations evaluates products and returns those needing escalation,
+// linked to their open record when it is at the same level. Readiness must be
+// preloaded.
+func activeEscalations(rules config.EscalationRules, products []models.Product, open map[uuid.UUID]models.ProductEscalation) []models.EscalationResponse {
+	escalations := []models.EscalationResponse{}
 
 	for _, product := range products {
-		cyclesInStatus := 0
-		if product.GatingStatusSince != nil {
-			weeks := int(time.Since(*product.GatingStatusSince).Hours() / (24 * 7))
-			cyclesInStatus = weeks / 2
-		}
-
-		riskBand := "medium"
-		if product.Readiness != nil {
-			riskBand = string(product.Readiness.RiskBand)
-		}
-
-		gatingStatus := ""
-		if product.GatingStatus != nil {
-			gatingStatus = *product.GatingStatus
-		}
-
-		level := calculateEscalationLevel(riskBand, cyclesInStatus, gatingStatus)
+		level, riskBand, cyclesInStatus := evaluateEscalation(rules, product)
 
 		// Only include products with escalations
 		if level == models.EscalationLevelNone {
@@ -172,7 +190,7 @@ func (h *EscalationsHandler) GetAllEscalations(c *gin.Context) {
 		label, action, owner := getEscalationConfig(level)
 		nextMilestone := getNextMilestone(string(product.LifecycleStage), riskBand)
 
-		escalations = append(escalations, models.EscalationResponse{
+		response := models.EscalationResponse{
 			ProductID:      product.ID.String(),
 			Level:          string(level),
 			Label:          label,
@@ -181,16 +199,84 @@ func (h *EscalationsHandler) GetAllEscalations(c *gin.Context) {
 			NextMilestone:  nextMilestone,
 			CyclesInStatus: cyclesInStatus,
 			RequiresAction: true,
-		})
+		}
+		// A record at an older level is stale: the escalation has moved on and
+		// needs acknowledging again
+		if record, ok := open[product.ID]; ok && record.Level == level {
+			response.EscalationID = record.ID.String()
+			response.TriggeredAt = record.TriggeredAt.Format(time.RFC3339)
+			response.Acknowledged = record.AcknowledgedAt != nil
+			response.AcknowledgedAt = record.AcknowledgedAt
+		}
+		escalations = append(escalations, response)
 	}
 
-	respondWithData(c, http.StatusOK, escalations)
+	return escalations
+}
+
+// EscalationSummary counts products at each escalation level
+type EscalationSummary struct {
+	TotalProducts    int `json:"total_products"`
+	OnTrack          int `json:"on_track"`
+	AmbassadorReview int `json:"ambassador_review"`
+	ExecSteerCo      int `json:"exec_steerco"`
+	Critical         int `json:"critical"`
+	RequiresAction   int `json:"requires_action"`
+}
+
+// summarizeEscalations counts products by escalation level. Readiness must be
+// preloaded.
+func summarizeEscalations(rules config.EscalationRules, products []models.Product) EscalationSummary {
+	summary := EscalationSummary{TotalProducts: len(products)}
+
+	for _, product := range products {
+		level, _, _ := evaluateEscalation(rules, product)
+
+		switch level {
+		case models.EscalationLevelNone:
+			summary.OnTrack++
+		case models.EscalationLevelAmbassadorReview:
+			summary.AmbassadorReview++
+			summary.RequiresAction++
+		case models.EscalationLevelExecSteerCo:
+			summary.ExecSteerCo++
+			summary.RequiresAction++
+		case models.EscalationLevelCritical:
+			summary.Critical++
+			summary.RequiresAction++
+		}
+	}
+
+	return summary
+}
+
+// GetAllEscalations returns all products with active escalations
+func (h *EscalationsHandler) GetAllEscalations(c *gin.Context) {
+	var products []models.Product
+	result := database.DB.
+		Scopes(models.ExcludeDrafts, models.ExcludeArchived).
+		Preload("Readiness").
+		Find(&products)
+
+	if result.Error != nil {
+		respondWithError(c, http.StatusInternalServerError, result.Error.Error())
+		return
+	}
+
+	open, err := openEscalations()
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithList(c, activeEscalations(h.rules, products, open), newListMeta(""))
 }
 
 // GetEscalationSummary returns summary stats for escalations
 func (h *EscalationsHandler) GetEscalationSummary(c *gin.Context) {
 	var products []models.Product
 	result := database.DB.
+		Scopes(models.ExcludeDrafts, models.ExcludeArchived).
 		Preload("Readiness").
 		Find(&products)
 
@@ -199,50 +285,429 @@ func (h *EscalationsHandler) GetEscalationSummary(c *gin.Context) {
 		return
 	}
 
-	type Summary struct {
-		TotalProducts    int `json:"total_products"`
-		OnTrack          int `json:"on_track"`
-		AmbassadorReview int `json:"ambassador_review"`
-		ExecSteerCo      int `json:"exec_steerco"`
-		Critical         int `json:"critical"`
-		RequiresAction   int `json:"requires_action"`
+	respondWithData(c, http.StatusOK, summarizeEscalations(h.rules, products))
+}
+
+// EscalationSnapshotResult counts what an escalation snapshot did
+type EscalationSnapshotResult struct {
+	Evaluated      int `json:"evaluated"`
+	Changed        int `json:"changed"`
+	NewlyCritical  int `json:"newly_critical"`
+	ActionsCreated int `json:"actions_created"`
+	Notified       int `json:"notified"`
+}
+
+// SnapshotEscalations evaluates every product and persists a ProductEscalation
+// record whenever a product's level changes, resolving the previous one. A
+// change to a higher level than the open record's notifies webhook
+// subscribers; a level someone has already acknowledged is on the open record,
+// so it is not announced again.
+func (h *EscalationsHandler) SnapshotEscalations(c *gin.Context) {
+	summary, err := h.recordSnapshot(database.DB, time.Now())
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithData(c, http.StatusOK, summary)
+}
+
+// RecordEscalationSnapshot snapshots every active product's escalation level,
+// as POST /escalations/snapshot does. The scheduler runs it so escalation
+// webhooks, auto-created actions and persisted escalation counts keep up
+// without an admin triggering the snapshot.
+func RecordEscalationSnapshot(rules config.EscalationRules, autoCreateActions bool, notifier *notify.Dispatcher) (EscalationSnapshotResult, error) {
+	return NewEscalationsHandler(rules, autoCreateActions, notifier).recordSnapshot(database.DB, time.Now())
+}
+
+// recordSnapshot loads the active products and snapshots their levels
+func (h *EscalationsHandler) recordSnapshot(db *gorm.DB, now time.Time) (EscalationSnapshotResult, error) {
+	var products []models.Product
+	err := db.
+		Scopes(models.ExcludeDrafts, models.ExcludeArchived).
+		Preload("Readiness").
+		Find(&products).Error
+	if err != nil {
+		return EscalationSnapshotResult{}, err
 	}
+	return h.snapshotEscalations(db, products, now)
+}
 
-	summary := Summary{TotalProducts: len(products)}
+// snapshotEscalations records the level changes of products, which must have
+// Readiness preloaded. It stops at the first database error rather than
+// guessing, so a failed lookup never opens a duplicate escalation.
+func (h *EscalationsHandler) snapshotEscalations(db *gorm.DB, products []models.Product, now time.Time) (EscalationSnapshotResult, error) {
+	summary := EscalationSnapshotResult{Evaluated: len(products)}
 
 	for _, product := range products {
-		cyclesInStatus := 0
-		if product.GatingStatusSince != nil {
-			weeks := int(time.Since(*product.GatingStatusSince).Hours() / (24 * 7))
-			cyclesInStatus = weeks / 2
+		level, riskBand, cyclesInStatus := evaluateEscalation(h.rules, product)
+
+		var current models.ProductEscalation
+		err := db.
+			Where("product_id = ? AND resolved_at IS NULL", product.ID).
+			Order("triggered_at DESC").
+			First(&current).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return summary, err
+		}
+		hasCurrent := err == nil
+
+		if hasCurrent && current.Level == level {
+			continue
+		}
+		if !hasCurrent && level == models.EscalationLevelNone {
+			continue
+		}
+
+		previous := models.EscalationLevelNone
+		if hasCurrent {
+			previous = current.Level
+			if err := db.Model(&current).Update("resolved_at", now).Error; err != nil {
+				return summary, err
+			}
 		}
+		summary.Changed++
 
-		riskBand := "medium"
-		if product.Readiness != nil {
-			riskBand = string(product.Readiness.RiskBand)
+		if level == models.EscalationLevelNone {
+			continue
 		}
 
-		gatingStatus := ""
-		if product.GatingStatus != nil {
-			gatingStatus = *product.GatingStatus
+		escalation := newEscalationRecord(product, level, riskBand, cyclesInStatus)
+		if err := db.Create(&escalation).Error; err != nil {
+			return summary, err
 		}
 
-		level := calculateEscalationLevel(riskBand, cyclesInStatus, gatingStatus)
+		if level.Rank() > previous.Rank() && h.notifier != nil {
+			event := newEscalationRaisedEvent(product, escalation, previous)
+			if err := h.notifier.Publish(models.EscalationWebhookEvent(level), event); err != nil {
+				log.Printf("Failed to publish escalation %s: %v", escalation.ID, err)
+			} else {
+				summary.Notified++
+			}
+		}
 
-		switch level {
-		case models.EscalationLevelNone:
-			summary.OnTrack++
-		case models.EscalationLevelAmbassadorReview:
-			summary.AmbassadorReview++
-			summary.RequiresAction++
-		case models.EscalationLevelExecSteerCo:
-			summary.ExecSteerCo++
-			summary.RequiresAction++
-		case models.EscalationLevelCritical:
-			summary.Critical++
-			summary.RequiresAction++
+		if level != models.EscalationLevelCritical {
+			continue
+		}
+		summary.NewlyCritical++
+
+		if h.autoCreateActions {
+			created, err := createEscalationAction(db, product, escalation)
+			if err != nil {
+				return summary, err
+			}
+			if created {
+				summary.ActionsCreated++
+			}
 		}
 	}
 
-	respondWithData(c, http.StatusOK, summary)
+	return summary, nil
+}
+
+// newEscalationRecord builds the persisted form of a computed escalation
+func newEscalationRecord(product models.Product, level models.EscalationLevel, riskBand string, cyclesInStatus int) models.ProductEscalation {
+	_, action, owner := getEscalationConfig(level)
+	return models.ProductEscalation{
+		ProductID:      product.ID,
+		Level:          level,
+		Action:         action,
+		Owner:          owner,
+		NextMilestone:  getNextMilestone(string(product.LifecycleStage), riskBand),
+		CyclesInStatus: cyclesInStatus,
+	}
+}
+
+// EscalationRaisedEvent is the webhook payload for a product raised to a
+// higher escalation level
+type EscalationRaisedEvent struct {
+	EscalationID   string                 `json:"escalation_id"`
+	ProductID      string                 `json:"product_id"`
+	ProductName    string                 `json:"product_name"`
+	Level          models.EscalationLevel `json:"level"`
+	PreviousLevel  models.EscalationLevel `json:"previous_level"`
+	Label          string                 `json:"label"`
+	Action         string                 `json:"action"`
+	Owner          string                 `json:"owner"`
+	NextMilestone  string                 `json:"next_milestone"`
+	CyclesInStatus int                    `json:"cycles_in_status"`
+	TriggeredAt    models.Timestamp       `json:"triggered_at"`
+}
+
+func newEscalationRaisedEvent(product models.Product, escalation models.ProductEscalation, previous models.EscalationLevel) EscalationRaisedEvent {
+	label, _, _ := getEscalationConfig(escalation.Level)
+	return EscalationRaisedEvent{
+		EscalationID:   escalation.ID.String(),
+		ProductID:      product.ID.String(),
+		ProductName:    product.Name,
+		Level:          escalation.Level,
+		PreviousLevel:  previous,
+		Label:          label,
+		Action:         escalation.Action,
+		Owner:          escalation.Owner,
+		NextMilestone:  escalation.NextMilestone,
+		CyclesInStatus: escalation.CyclesInStatus,
+		TriggeredAt:    escalation.TriggeredAt,
+	}
+}
+
+var errEscalationAcknowledged = errors.New("escalation already acknowledged")
+
+// AcknowledgeEscalation records that the product's current escalation has been
+// seen. The open record is acknowledged if it is at the current level;
+// otherwise it is resolved and a new acknowledged record is stored. A
+// critical-priority remediation action linked to the escalation is opened
+// unless one was already generated from it.
+func (h *EscalationsHandler) AcknowledgeEscalation(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	var product models.Product
+	if result := database.DB.Preload("Readiness").First(&product, "id = ?", productID); result.Error != nil {
+		respondWithError(c, http.StatusNotFound, "Product not found")
+		return
+	}
+
+	level, riskBand, cyclesInStatus := evaluateEscalation(h.rules, product)
+	if level == models.EscalationLevelNone {
+		respondWithError(c, http.StatusConflict, "Product has no active escalation")
+		return
+	}
+
+	now := models.Now()
+	var acknowledgedBy *string
+	if userID := currentUserID(c); userID != "" {
+		acknowledgedBy = &userID
+	}
+
+	var escalation models.ProductEscalation
+	var action models.ProductAction
+	var actionCreated bool
+	status := http.StatusOK
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		hasCurrent := tx.
+			Where("product_id = ? AND resolved_at IS NULL", productID).
+			Order("triggered_at DESC").
+			First(&escalation).Error == nil
+
+		if hasCurrent && escalation.Level == level {
+			if escalation.AcknowledgedAt != nil {
+				return errEscalationAcknowledged
+			}
+			escalation.AcknowledgedAt = &now
+			escalation.AcknowledgedBy = acknowledgedBy
+			if err := tx.Model(&escalation).Updates(map[string]interface{}{
+				"acknowledged_at": now,
+				"acknowledged_by": acknowledgedBy,
+			}).Error; err != nil {
+				return err
+			}
+		} else {
+			if hasCurrent {
+				if err := tx.Model(&escalation).Update("resolved_at", now).Error; err != nil {
+					return err
+				}
+			}
+			escalation = newEscalationRecord(product, level, riskBand, cyclesInStatus)
+			escalation.AcknowledgedAt = &now
+			escalation.AcknowledgedBy = acknowledgedBy
+			status = http.StatusCreated
+			if err := tx.Create(&escalation).Error; err != nil {
+				return err
+			}
+		}
+
+		action = newRemediationAction(product, escalation)
+		action.CreatedBy = acknowledgedBy
+		var err error
+		actionCreated, err = ensureEscalationAction(tx, escalation, &action)
+		return err
+	})
+	if errors.Is(err, errEscalationAcknowledged) {
+		respondWithError(c, http.StatusConflict, "Escalation already acknowledged")
+		return
+	}
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	details := map[string]interface{}{
+		"escalation_id": escalation.ID.String(),
+		"product_id":    productID.String(),
+		"level":         string(level),
+	}
+	if actionCreated {
+		details["action_id"] = action.ID.String()
+	}
+	middleware.LogAdminAction(c, "Escalation acknowledged", details)
+
+	respondWithData(c, status, escalation)
+}
+
+// ResolveEscalation closes an escalation record with optional notes
+func (h *EscalationsHandler) ResolveEscalation(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "Invalid escalation ID")
+		return
+	}
+
+	var req models.ResolveEscalationRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var escalation models.ProductEscalation
+	if result := database.DB.First(&escalation, "id = ?", id); result.Error != nil {
+		respondWithError(c, http.StatusNotFound, "Escalation not found")
+		return
+	}
+	if escalation.ResolvedAt != nil {
+		respondWithError(c, http.StatusConflict, "Escalation already resolved")
+		return
+	}
+
+	now := models.Now()
+	updates := map[string]interface{}{"resolved_at": now}
+	if req.Notes != nil {
+		updates["notes"] = *req.Notes
+	}
+	if result := database.DB.Model(&escalation).Updates(updates); result.Error != nil {
+		respondWithError(c, http.StatusInternalServerError, result.Error.Error())
+		return
+	}
+
+	middleware.LogAdminAction(c, "Escalation resolved", map[string]interface{}{
+		"escalation_id": escalation.ID.String(),
+		"product_id":    escalation.ProductID.String(),
+	})
+
+	respondWithData(c, http.StatusOK, escalation)
+}
+
+// createEscalationAction opens a high-priority intervention for a critical
+// escalation unless one is already linked to it
+func createEscalationAction(db *gorm.DB, product models.Product, escalation models.ProductEscalation) (bool, error) {
+	description := escalation.Action
+	owner := escalation.Owner
+	action := models.ProductAction{
+		ProductID:   product.ID,
+		ActionType:  models.ActionTypeIntervention,
+		Title:       "Critical escalation: " + product.Name,
+		Description: &description,
+		AssignedTo:  &owner,
+		Status:      models.ActionStatusPending,
+		Priority:    models.ActionPriorityHigh,
+	}
+	return ensureEscalationAction(db, escalation, &action)
+}
+
+// newRemediationAction builds the critical-priority intervention opened when
+// an escalation is acknowledged, titled from the escalation's label and
+// assigned to its owner
+func newRemediationAction(product models.Product, escalation models.ProductEscalation) models.ProductAction {
+	label, _, _ := getEscalationConfig(escalation.Level)
+	description := escalation.Action
+	owner := escalation.Owner
+	return models.ProductAction{
+		ProductID:   product.ID,
+		ActionType:  models.ActionTypeIntervention,
+		Title:       label + ": " + product.Name,
+		Description: &description,
+		AssignedTo:  &owner,
+		Status:      models.ActionStatusPending,
+		Priority:    models.ActionPriorityCritical,
+	}
+}
+
+// escalationActionsQuery matches the actions generated from an escalation.
+// Actions created before linked_escalation_id existed carry only the
+// "escalation:<id>" source marker.
+func escalationActionsQuery(db *gorm.DB, escalationID uuid.UUID) *gorm.DB {
+	return db.Model(&models.ProductAction{}).
+		Where("linked_escalation_id = ? OR source_ref = ?", escalationID, "escalation:"+escalationID.String())
+}
+
+// ensureEscalationAction links action to the escalation and creates it,
+// unless an action has already been generated from that escalation
+func ensureEscalationAction(tx *gorm.DB, escalation models.ProductEscalation, action *models.ProductAction) (bool, error) {
+	var existing int64
+	if err := escalationActionsQuery(tx, escalation.ID).Count(&existing).Error; err != nil {
+		return false, err
+	}
+	if existing > 0 {
+		return false, nil
+	}
+
+	marker := "escalation:" + escalation.ID.String()
+	action.LinkedEscalationID = &escalation.ID
+	action.SourceRef = &marker
+	if err := tx.Create(action).Error; err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// GetEscalationActions lists the actions generated from an escalation,
+// oldest first
+func (h *EscalationsHandler) GetEscalationActions(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "Invalid escalation ID")
+		return
+	}
+
+	var escalation models.ProductEscalation
+	if result := database.DB.First(&escalation, "id = ?", id); result.Error != nil {
+		respondWithError(c, http.StatusNotFound, "Escalation not found")
+		return
+	}
+
+	var actions []models.ProductAction
+	if err := escalationActionsQuery(database.DB, id).Order("created_at ASC").Find(&actions).Error; err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithList(c, actions, newListMeta("created_at"))
+}
+
+// GetEscalationConfig returns the escalation rules currently in effect so
+// reviewers can see why a product sits at a given level
+func (h *EscalationsHandler) GetEscalationConfig(c *gin.Context) {
+	type LevelRule struct {
+		Level     models.EscalationLevel `json:"level"`
+		Label     string                 `json:"label"`
+		Owner     string                 `json:"owner"`
+		Condition string                 `json:"condition"`
+	}
+
+	levelRule := func(level models.EscalationLevel, condition string) LevelRule {
+		label, _, owner := getEscalationConfig(level)
+		return LevelRule{Level: level, Label: label, Owner: owner, Condition: condition}
+	}
+
+	response := struct {
+		config.EscalationRules
+		AutoCreateActions bool        `json:"auto_create_actions"`
+		Levels            []LevelRule `json:"levels"`
+	}{
+		EscalationRules:   h.rules,
+		AutoCreateActions: h.autoCreateActions,
+		Levels: []LevelRule{
+			levelRule(models.EscalationLevelCritical,
+				fmt.Sprintf("high risk for %d+ cycles", h.rules.CriticalHighRiskCycles)),
+			levelRule(models.EscalationLevelExecSteerCo,
+				fmt.Sprintf("high risk for %d+ cycles", h.rules.ExecSteerCoHighRiskCycles)),
+			levelRule(models.EscalationLevelAmbassadorReview,
+				fmt.Sprintf("medium risk for %d+ cycles, or gating status in auto_escalate_gating_statuses", h.rules.AmbassadorMediumRiskCycles)),
+		},
+	}
+
+	respondWithData(c, http.StatusOK, response)
 }
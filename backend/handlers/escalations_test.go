@@ -0,0 +1,264 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/pauly7610/studio-pilot-vision/backend/config"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+	"gorm.io/gorm"
+)
+
+func TestActiveEscalations_MarksAcknowledged(t *testing.T) {
+	rules := config.DefaultEscalationRules()
+	since := models.NewTimestamp(time.Now().AddDate(0, 0, -rules.CycleLengthDays*rules.CriticalHighRiskCycles-1))
+	highRisk := func() models.Product {
+		return models.Product{
+			ID:                uuid.New(),
+			GatingStatusSince: &since,
+			Readiness:         &models.ProductReadiness{RiskBand: models.RiskBandHigh},
+		}
+	}
+	acknowledged, stale, unrecorded := highRisk(), highRisk(), highRisk()
+
+	ackedAt := models.Now()
+	open := map[uuid.UUID]models.ProductEscalation{
+		acknowledged.ID: {ID: uuid.New(), ProductID: acknowledged.ID, Level: models.EscalationLevelCritical, AcknowledgedAt: &ackedAt},
+		stale.ID:        {ID: uuid.New(), ProductID: stale.ID, Level: models.EscalationLevelExecSteerCo, AcknowledgedAt: &ackedAt},
+	}
+
+	escalations := activeEscalations(rules, []models.Product{acknowledged, stale, unrecorded}, open)
+	if len(escalations) != 3 {
+		t.Fatalf("expected 3 escalations, got %d", len(escalations))
+	}
+
+	got := map[string]models.EscalationResponse{}
+	for _, e := range escalations {
+		if e.Level != string(models.EscalationLevelCritical) {
+			t.Errorf("product %s level = %s, want critical", e.ProductID, e.Level)
+		}
+		got[e.ProductID] = e
+	}
+
+	if e := got[acknowledged.ID.String()]; !e.Acknowledged || e.EscalationID != open[acknowledged.ID].ID.String() {
+		t.Errorf("acknowledged escalation = %+v, want acknowledged and linked", e)
+	}
+	if e := got[stale.ID.String()]; e.Acknowledged || e.EscalationID != "" {
+		t.Errorf("escalation acknowledged at an older level should need acknowledging again, got %+v", e)
+	}
+	if e := got[unrecorded.ID.String()]; e.Acknowledged {
+		t.Errorf("unrecorded escalation should not be acknowledged")
+	}
+}
+
+func TestEvaluateEscalation_ShorterCycleEscalatesSooner(t *testing.T) {
+	since := models.NewTimestamp(time.Now().AddDate(0, 0, -22))
+	product := models.Product{
+		GatingStatusSince: &since,
+		Readiness:         &models.ProductReadiness{RiskBand: models.RiskBandHigh},
+	}
+
+	biweekly := config.DefaultEscalationRules()
+	if level, _, cycles := evaluateEscalation(biweekly, product); level != models.EscalationLevelNone || cycles != 1 {
+		t.Errorf("14-day cycles: got %s after %d cycles, want none after 1", level, cycles)
+	}
+
+	weekly := config.DefaultEscalationRules()
+	weekly.CycleLengthDays = 7
+	if level, _, cycles := evaluateEscalation(weekly, product); level != models.EscalationLevelCritical || cycles != 3 {
+		t.Errorf("7-day cycles: got %s after %d cycles, want critical after 3", level, cycles)
+	}
+}
+
+func TestEnsureEscalationAction_LinksOnceAndFindsLegacyMarkers(t *testing.T) {
+	db := openTestDB(t, productActionsDDL)
+	product := models.Product{ID: uuid.New(), Name: "Wallet"}
+	escalation := models.ProductEscalation{
+		ID:        uuid.New(),
+		ProductID: product.ID,
+		Level:     models.EscalationLevelExecSteerCo,
+		Action:    "Escalate to Executive Steering Committee",
+		Owner:     "VP Product",
+	}
+
+	action := newRemediationAction(product, escalation)
+	created, err := ensureEscalationAction(db, escalation, &action)
+	if err != nil || !created {
+		t.Fatalf("first ensure: created=%v err=%v", created, err)
+	}
+	if action.Title != "🚨 Exec SteerCo: Wallet" || action.Priority != models.ActionPriorityCritical ||
+		action.ActionType != models.ActionTypeIntervention || *action.AssignedTo != "VP Product" {
+		t.Errorf("unexpected remediation action %+v", action)
+	}
+
+	again := newRemediationAction(product, escalation)
+	if created, err := ensureEscalationAction(db, escalation, &again); err != nil || created {
+		t.Errorf("second ensure: created=%v err=%v, want no duplicate", created, err)
+	}
+
+	// An action from before linked_escalation_id only carries the marker
+	legacy := uuid.New()
+	marker := "escalation:" + legacy.String()
+	if err := db.Create(&models.ProductAction{ProductID: product.ID, ActionType: models.ActionTypeIntervention, Title: "old", SourceRef: &marker}).Error; err != nil {
+		t.Fatalf("create legacy action: %v", err)
+	}
+
+	for id, want := range map[uuid.UUID]int64{escalation.ID: 1, legacy: 1, uuid.New(): 0} {
+		var count int64
+		if err := escalationActionsQuery(db, id).Count(&count).Error; err != nil {
+			t.Fatalf("count: %v", err)
+		}
+		if count != want {
+			t.Errorf("escalation %s: %d actions, want %d", id, count, want)
+		}
+	}
+}
+
+func TestSummarizeEscalations_EmptyAndSingle(t *testing.T) {
+	rules := config.DefaultEscalationRules()
+	if got := summarizeEscalations(rules, nil); got != (EscalationSummary{}) {
+		t.Errorf("no products: got %+v, want a zeroed summary", got)
+	}
+
+	gating := rules.AutoEscalateGatingStatuses[0]
+	product := models.Product{GatingStatus: &gating}
+	want := EscalationSummary{TotalProducts: 1, AmbassadorReview: 1, RequiresAction: 1}
+	if got := summarizeEscalations(rules, []models.Product{product}); got != want {
+		t.Errorf("one product: got %+v, want %+v", got, want)
+	}
+}
+
+const productEscalationsDDL = `CREATE TABLE product_escalations (
+	id TEXT PRIMARY KEY, product_id TEXT NOT NULL, level TEXT NOT NULL, action TEXT NOT NULL,
+	owner TEXT NOT NULL, next_milestone TEXT, cycles_in_status INTEGER, triggered_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	resolved_at DATETIME, notes TEXT, acknowledged_at DATETIME, acknowledged_by TEXT,
+	created_at DATETIME, updated_at DATETIME)`
+
+func TestSnapshotEscalations_CreatesOneActionForNewlyCritical(t *testing.T) {
+	rules := config.DefaultEscalationRules()
+	since := models.NewTimestamp(time.Now().AddDate(0, 0, -rules.CycleLengthDays*rules.CriticalHighRiskCycles-1))
+	critical := models.Product{
+		ID:                uuid.New(),
+		Name:              "Wallet",
+		GatingStatusSince: &since,
+		Readiness:         &models.ProductReadiness{RiskBand: models.RiskBandHigh},
+	}
+	onTrack := models.Product{ID: uuid.New(), Name: "Checkout", Readiness: &models.ProductReadiness{RiskBand: models.RiskBandLow}}
+	products := []models.Product{critical, onTrack}
+
+	countActions := func(t *testing.T, db *gorm.DB) int64 {
+		t.Helper()
+		var count int64
+		if err := db.Model(&models.ProductAction{}).Count(&count).Error; err != nil {
+			t.Fatalf("count actions: %v", err)
+		}
+		return count
+	}
+
+	t.Run("enabled", func(t *testing.T) {
+		db := openTestDB(t, productActionsDDL, productEscalationsDDL)
+		h := NewEscalationsHandler(rules, true, nil)
+
+		summary, err := h.snapshotEscalations(db, products, time.Now())
+		if err != nil {
+			t.Fatalf("snapshot: %v", err)
+		}
+		if summary.NewlyCritical != 1 || summary.ActionsCreated != 1 {
+			t.Errorf("summary = %+v, want one newly critical product and one action", summary)
+		}
+
+		var action models.ProductAction
+		if err := db.First(&action).Error; err != nil {
+			t.Fatalf("load action: %v", err)
+		}
+		if action.ProductID != critical.ID || action.Title != "Critical escalation: Wallet" || action.LinkedEscalationID == nil {
+			t.Errorf("action = %+v", action)
+		}
+
+		// The open critical escalation is unchanged, so nothing new is created
+		summary, err = h.snapshotEscalations(db, products, time.Now())
+		if err != nil {
+			t.Fatalf("second snapshot: %v", err)
+		}
+		if summary.Changed != 0 || countActions(t, db) != 1 {
+			t.Errorf("second snapshot = %+v with %d actions, want no change", summary, countActions(t, db))
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		db := openTestDB(t, productActionsDDL, productEscalationsDDL)
+		summary, err := NewEscalationsHandler(rules, false, nil).snapshotEscalations(db, products, time.Now())
+		if err != nil {
+			t.Fatalf("snapshot: %v", err)
+		}
+		if summary.NewlyCritical != 1 || summary.ActionsCreated != 0 || countActions(t, db) != 0 {
+			t.Errorf("summary = %+v, want no actions with the flag off", summary)
+		}
+	})
+
+	t.Run("lookup errors are not treated as no escalation", func(t *testing.T) {
+		db := openTestDB(t, productActionsDDL)
+		if _, err := NewEscalationsHandler(rules, true, nil).snapshotEscalations(db, products, time.Now()); err == nil {
+			t.Fatal("expected an error without an escalations table")
+		}
+		if countActions(t, db) != 0 {
+			t.Error("a failed lookup created an action")
+		}
+	})
+}
+
+func TestGetEscalationConfig(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rules := config.EscalationRules{
+		CycleLengthDays:            7,
+		CriticalHighRiskCycles:     4,
+		ExecSteerCoHighRiskCycles:  3,
+		AmbassadorMediumRiskCycles: 1,
+		AutoEscalateGatingStatuses: []string{"Regional Legal"},
+		BAUReadyPercent:            75,
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/escalations/config", nil)
+	NewEscalationsHandler(rules, true, nil).GetEscalationConfig(c)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d", w.Code)
+	}
+
+	var body struct {
+		config.EscalationRules
+		AutoCreateActions bool `json:"auto_create_actions"`
+		Levels            []struct {
+			Level     models.EscalationLevel `json:"level"`
+			Owner     string                 `json:"owner"`
+			Condition string                 `json:"condition"`
+		} `json:"levels"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode %s: %v", w.Body.String(), err)
+	}
+	if !reflect.DeepEqual(body.EscalationRules, rules) || !body.AutoCreateActions {
+		t.Errorf("rules = %+v (auto %v), want %+v", body.EscalationRules, body.AutoCreateActions, rules)
+	}
+
+	wantConditions := map[models.EscalationLevel]string{
+		models.EscalationLevelCritical:         "high risk for 4+ cycles",
+		models.EscalationLevelExecSteerCo:      "high risk for 3+ cycles",
+		models.EscalationLevelAmbassadorReview: "medium risk for 1+ cycles, or gating status in auto_escalate_gating_statuses",
+	}
+	if len(body.Levels) != len(wantConditions) {
+		t.Fatalf("levels = %+v", body.Levels)
+	}
+	for _, level := range body.Levels {
+		if level.Condition != wantConditions[level.Level] || level.Owner == "" {
+			t.Errorf("level %s = %+v, want condition %q", level.Level, level, wantConditions[level.Level])
+		}
+	}
+}
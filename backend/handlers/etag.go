@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// weakETag builds a weak validator from the parts that version a response,
+// such as row counts and last-updated times. Any change to a part changes
+// the tag.
+func weakETag(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "|")))
+	return `W/"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// etagMatches reports whether an If-None-Match header lists etag, using weak
+// comparison as conditional GETs require
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	want := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == want {
+			return true
+		}
+	}
+	return false
+}
+
+// notModified sets the ETag header and, when the client already holds that
+// version, responds 304 Not Modified. Handlers return early when it reports
+// true, before loading the full response. The enveloped and legacy shapes
+// of the same data get different tags.
+func notModified(c *gin.Context, etag string) bool {
+	if wantsEnvelope(c) {
+		etag = weakETag(etag, envelopeMediaType)
+	}
+	c.Header("ETag", etag)
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return true
+	}
+	return false
+}
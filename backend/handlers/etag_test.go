@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestEtagMatches(t *testing.T) {
+	etag := `W/"abc"`
+	tests := []struct {
+		header string
+		want   bool
+	}{
+		{"", false},
+		{`W/"abc"`, true},
+		{`"abc"`, true},
+		{`W/"xyz", W/"abc"`, true},
+		{`W/"xyz"`, false},
+		{"*", true},
+	}
+	for _, tt := range tests {
+		if got := etagMatches(tt.header, etag); got != tt.want {
+			t.Errorf("etagMatches(%q) = %v, want %v", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestNotModified(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	etag := weakETag("products:1:2026-01-01")
+
+	for _, tt := range []struct {
+		ifNoneMatch string
+		want        bool
+	}{
+		{etag, true},
+		{weakETag("products:1:2026-01-02"), false},
+	} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/products/1", nil)
+		c.Request.Header.Set("If-None-Match", tt.ifNoneMatch)
+
+		if got := notModified(c, etag); got != tt.want {
+			t.Errorf("If-None-Match %s: notModified = %v, want %v", tt.ifNoneMatch, got, tt.want)
+		}
+		if w.Header().Get("ETag") != etag {
+			t.Errorf("ETag header = %q, want %q", w.Header().Get("ETag"), etag)
+		}
+		if tt.want && c.Writer.Status() != http.StatusNotModified {
+			t.Errorf("status = %d, want 304", c.Writer.Status())
+		}
+	}
+}
+
+// productDetailDDL has just the columns productDetailETag reads
+var productDetailDDL = []string{
+	`CREATE TABLE products (id TEXT PRIMARY KEY, updated_at DATETIME, deleted_at DATETIME)`,
+	`CREATE TABLE product_readiness (product_id TEXT, evaluated_at DATETIME, updated_at DATETIME)`,
+	`CREATE TABLE product_predictions (product_id TEXT, scored_at DATETIME, updated_at DATETIME)`,
+	`CREATE TABLE product_compliances (product_id TEXT, updated_at DATETIME)`,
+	`CREATE TABLE product_market_evidences (product_id TEXT, updated_at DATETIME)`,
+	`CREATE TABLE product_partners (product_id TEXT, updated_at DATETIME)`,
+	`CREATE TABLE sales_trainings (product_id TEXT, updated_at DATETIME)`,
+	`CREATE TABLE product_feedback (id TEXT, product_id TEXT, created_at DATETIME, updated_at DATETIME)`,
+	`CREATE TABLE product_actions (product_id TEXT, updated_at DATETIME)`,
+	`CREATE TABLE product_metrics (product_id TEXT, created_at DATETIME, updated_at DATETIME)`,
+	`CREATE TABLE product_dependencies (product_id TEXT, updated_at DATETIME)`,
+	`CREATE TABLE product_readiness_history (product_id TEXT, recorded_at DATETIME)`,
+}
+
+func TestProductDetailETag(t *testing.T) {
+	db := openTestDB(t, productDetailDDL...)
+	id := uuid.New()
+
+	exec := func(sql string, args ...interface{}) {
+		t.Helper()
+		if err := db.Exec(sql, args...).Error; err != nil {
+			t.Fatalf("%s: %v", sql, err)
+		}
+	}
+	etag := func() string {
+		t.Helper()
+		tag, err := productDetailETag(db, id)
+		if err != nil {
+			t.Fatalf("productDetailETag: %v", err)
+		}
+		return tag
+	}
+
+	if _, err := productDetailETag(db, id); !errors.Is(err, errProductNotFound) {
+		t.Fatalf("missing product: err = %v, want errProductNotFound", err)
+	}
+
+	exec(`INSERT INTO products VALUES (?, '2026-01-01 00:00:00', NULL)`, id)
+	exec(`INSERT INTO product_feedback VALUES ('f1', ?, '2026-01-02 00:00:00', NULL)`, id)
+	initial := etag()
+	if etag() != initial {
+		t.Fatal("ETag should be stable while nothing changes")
+	}
+
+	exec(`UPDATE product_feedback SET updated_at = '2026-01-03 00:00:00' WHERE id = 'f1'`)
+	updated := etag()
+	if updated == initial {
+		t.Error("ETag should change when an association is updated")
+	}
+
+	exec(`INSERT INTO product_metrics VALUES (?, '2025-12-01 00:00:00', NULL)`, id)
+	added := etag()
+	if added == updated {
+		t.Error("ETag should change when an association row is added, even with an older timestamp")
+	}
+
+	exec(`DELETE FROM product_metrics`)
+	if etag() != updated {
+		t.Error("deleting the added row should restore the earlier ETag")
+	}
+
+	exec(`INSERT INTO product_metrics VALUES (?, '2026-01-04 00:00:00', NULL)`, uuid.New())
+	if etag() != updated {
+		t.Error("another product's rows should not change the ETag")
+	}
+
+	exec(`UPDATE products SET deleted_at = '2026-01-05 00:00:00'`)
+	if _, err := productDetailETag(db, id); !errors.Is(err, errProductNotFound) {
+		t.Errorf("deleted product: err = %v, want errProductNotFound", err)
+	}
+}
@@ -1,23 +1,39 @@
 package handlers
 
 import (
+	"context"
+	"errors"
+	"log"
+	"math"
 	"net/http"
+	"sort"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/pauly7610/studio-pilot-vision/backend/database"
+	"github.com/pauly7610/studio-pilot-vision/backend/middleware"
 	"github.com/pauly7610/studio-pilot-vision/backend/models"
+	"github.com/pauly7610/studio-pilot-vision/backend/services/sentiment"
+	"gorm.io/gorm"
 )
 
-type FeedbackHandler struct{}
+type FeedbackHandler struct {
+	analyzer sentiment.Analyzer
+	aliases  models.ThemeAliases
+}
 
-func NewFeedbackHandler() *FeedbackHandler {
-	return &FeedbackHandler{}
+// NewFeedbackHandler creates the handler. The analyzer scores feedback that
+// arrives without a sentiment score; themes are stored in the canonical form
+// given by aliases.
+func NewFeedbackHandler(analyzer sentiment.Analyzer, aliases models.ThemeAliases) *FeedbackHandler {
+	return &FeedbackHandler{analyzer: analyzer, aliases: aliases}
 }
 
 // GetProductFeedback retrieves all feedback for a product
 func (h *FeedbackHandler) GetProductFeedback(c *gin.Context) {
-	productID, err := uuid.Parse(c.Param("productId"))
+	productID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
 		return
@@ -64,30 +80,77 @@ func (h *FeedbackHandler) CreateFeedback(c *gin.Context) {
 		return
 	}
 
-	// Verify product exists
-	var product models.Product
-	if result := database.DB.First(&product, "id = ?", req.ProductID); result.Error != nil {
+	feedback, err := createFeedback(c.Request.Context(), h.analyzer, h.aliases, req)
+	if errors.Is(err, errProductNotFound) {
 		respondWithError(c, http.StatusNotFound, "Product not found")
 		return
 	}
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithData(c, http.StatusCreated, feedback)
+}
+
+// createFeedback stores a feedback entry for an existing product, resolving
+// its theme through aliases and defaulting its volume. Entries without a
+// sentiment score are scored by the analyzer, which also fills a missing
+// theme.
+func createFeedback(ctx context.Context, analyzer sentiment.Analyzer, aliases models.ThemeAliases, req models.CreateProductFeedbackRequest) (models.ProductFeedback, error) {
+	// Fail fast before scoring; createForProduct checks again as it writes
+	var product models.Product
+	if result := database.DB.Select("id").First(&product, "id = ?", req.ProductID); result.Error != nil {
+		return models.ProductFeedback{}, errProductNotFound
+	}
+
+	if req.SentimentScore == nil && analyzer != nil {
+		applySentiment(ctx, analyzer, &req)
+	}
+
+	var rawTheme *string
+	if req.Theme != nil {
+		rawTheme = req.Theme
+		theme := aliases.Canonical(*req.Theme)
+		req.Theme = &theme
+	}
+
+	if req.Volume == nil {
+		volume := models.DefaultFeedbackVolume
+		req.Volume = &volume
+	}
 
 	feedback := models.ProductFeedback{
 		ProductID:      req.ProductID,
 		Source:         req.Source,
 		RawText:        req.RawText,
 		Theme:          req.Theme,
+		RawTheme:       rawTheme,
 		SentimentScore: req.SentimentScore,
 		ImpactLevel:    req.ImpactLevel,
 		Volume:         req.Volume,
 	}
 
-	result := database.DB.Create(&feedback)
-	if result.Error != nil {
-		respondWithError(c, http.StatusInternalServerError, result.Error.Error())
+	err := createForProduct(database.DB, req.ProductID, func(tx *gorm.DB) error {
+		return tx.Create(&feedback).Error
+	})
+	return feedback, err
+}
+
+// applySentiment fills the request's score, and its theme if missing, from
+// the analyzer. A failed analysis leaves the entry unscored rather than
+// rejecting the feedback.
+func applySentiment(ctx context.Context, analyzer sentiment.Analyzer, req *models.CreateProductFeedbackRequest) {
+	result, err := analyzer.Analyze(ctx, req.RawText)
+	if err != nil {
+		log.Printf("sentiment analysis failed for product %s: %v", req.ProductID, err)
 		return
 	}
 
-	respondWithData(c, http.StatusCreated, feedback)
+	req.SentimentScore = &result.Score
+	if (req.Theme == nil || *req.Theme == "") && result.Theme != "" {
+		req.Theme = &result.Theme
+	}
 }
 
 // UpdateFeedback updates feedback
@@ -118,7 +181,8 @@ func (h *FeedbackHandler) UpdateFeedback(c *gin.Context) {
 		updates["raw_text"] = *req.RawText
 	}
 	if req.Theme != nil {
-		updates["theme"] = *req.Theme
+		updates["theme"] = h.aliases.Canonical(*req.Theme)
+		updates["raw_theme"] = *req.Theme
 	}
 	if req.SentimentScore != nil {
 		updates["sentiment_score"] = *req.SentimentScore
@@ -161,76 +225,397 @@ func (h *FeedbackHandler) DeleteFeedback(c *gin.Context) {
 	respondWithSuccess(c, http.StatusOK, "Feedback deleted successfully", nil)
 }
 
-// GetAllFeedback retrieves all feedback with optional filtering
+// ThemeNormalizationResult reports a NormalizeThemes run
+type ThemeNormalizationResult struct {
+	Scanned  int `json:"scanned"`
+	Remapped int `json:"remapped"`
+	// Themes counts the remapped rows by the canonical theme they now carry
+	Themes map[string]int `json:"themes"`
+}
+
+// normalizeFeedbackThemes recomputes every stored theme from its raw theme
+// through aliases, in one transaction. Rows written before raw themes were
+// kept have their current theme recorded as the raw one first.
+func normalizeFeedbackThemes(db *gorm.DB, aliases models.ThemeAliases) (ThemeNormalizationResult, error) {
+	result := ThemeNormalizationResult{Themes: map[string]int{}}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var rows []models.ProductFeedback
+		if err := tx.Select("id", "theme", "raw_theme").
+			Where("theme IS NOT NULL OR raw_theme IS NOT NULL").
+			Find(&rows).Error; err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			result.Scanned++
+			updates := make(map[string]interface{})
+			raw := row.RawTheme
+			if raw == nil {
+				raw = row.Theme
+				updates["raw_theme"] = *raw
+			}
+			canonical := aliases.Canonical(*raw)
+			if row.Theme == nil || *row.Theme != canonical {
+				updates["theme"] = canonical
+				result.Remapped++
+				result.Themes[canonical]++
+			}
+			if len(updates) == 0 {
+				continue
+			}
+			if err := tx.Model(&models.ProductFeedback{}).Where("id = ?", row.ID).Updates(updates).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return ThemeNormalizationResult{}, err
+	}
+	return result, nil
+}
+
+// NormalizeThemes brings the themes of existing feedback in line with the
+// current aliases so the summary aggregates them, reporting how many rows
+// were remapped
+func (h *FeedbackHandler) NormalizeThemes(c *gin.Context) {
+	result, err := normalizeFeedbackThemes(database.DB, h.aliases)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	middleware.LogAdminAction(c, "Feedback themes normalized", map[string]interface{}{
+		"scanned":  result.Scanned,
+		"remapped": result.Remapped,
+	})
+
+	respondWithData(c, http.StatusOK, result)
+}
+
+// feedbackFilter holds a feedback listing's range filters. The sentiment bounds are
+// inclusive and exclude unscored feedback; CreatedAfter and CreatedBefore are
+// inclusive dates.
+type feedbackFilter struct {
+	SentimentMin  *float64
+	SentimentMax  *float64
+	CreatedAfter  *models.Date
+	CreatedBefore *models.Date
+}
+
+// filterFeedback applies the filter to a feedback query, newest first
+func filterFeedback(db *gorm.DB, filter feedbackFilter) *gorm.DB {
+	query := db.Model(&models.ProductFeedback{}).Order("created_at DESC")
+	if filter.SentimentMin != nil {
+		query = query.Where("sentiment_score >= ?", *filter.SentimentMin)
+	}
+	if filter.SentimentMax != nil {
+		query = query.Where("sentiment_score <= ?", *filter.SentimentMax)
+	}
+	if filter.CreatedAfter != nil {
+		query = query.Where("created_at >= ?", models.NewTimestamp(filter.CreatedAfter.Time))
+	}
+	if filter.CreatedBefore != nil {
+		query = query.Where("created_at < ?", models.NewTimestamp(filter.CreatedBefore.AddDate(0, 0, 1)))
+	}
+	return query
+}
+
+// GetAllFeedback retrieves all feedback, newest first and paginated, filtered
+// by ?source=, ?theme=, ?impact_level=, ?sentiment_min= / ?sentiment_max=
+// and ?created_after= / ?created_before= (YYYY-MM-DD, inclusive)
 func (h *FeedbackHandler) GetAllFeedback(c *gin.Context) {
 	var feedback []models.ProductFeedback
 
-	query := database.DB.Order("created_at DESC")
+	meta := newListMeta("-created_at")
+	var filter feedbackFilter
+
+	for param, target := range map[string]**float64{"sentiment_min": &filter.SentimentMin, "sentiment_max": &filter.SentimentMax} {
+		raw := c.Query(param)
+		if raw == "" {
+			continue
+		}
+		score, err := strconv.ParseFloat(raw, 64)
+		if err != nil || math.IsNaN(score) {
+			respondWithError(c, http.StatusBadRequest, param+" must be a number")
+			return
+		}
+		*target = &score
+		meta.filter(param, raw)
+	}
+	if filter.SentimentMin != nil && filter.SentimentMax != nil && *filter.SentimentMin > *filter.SentimentMax {
+		respondWithError(c, http.StatusBadRequest, "sentiment_min must not be greater than sentiment_max")
+		return
+	}
 
-	// Optional filtering
-	if source := c.Query("source"); source != "" {
-		query = query.Where("source = ?", source)
+	for param, target := range map[string]**models.Date{"created_after": &filter.CreatedAfter, "created_before": &filter.CreatedBefore} {
+		raw := c.Query(param)
+		if raw == "" {
+			continue
+		}
+		date, err := models.ParseDate(raw)
+		if err != nil {
+			respondWithError(c, http.StatusBadRequest, param+" must be a date (YYYY-MM-DD)")
+			return
+		}
+		*target = &date
+		meta.filter(param, date.String())
+	}
+	if filter.CreatedAfter != nil && filter.CreatedBefore != nil && filter.CreatedBefore.Before(filter.CreatedAfter.Time) {
+		respondWithError(c, http.StatusBadRequest, "created_before must not be before created_after")
+		return
 	}
-	if theme := c.Query("theme"); theme != "" {
-		query = query.Where("theme = ?", theme)
+
+	query, err := applyFilters(filterFeedback(database.DB, filter), c.Request.URL.Query(), []queryFilter{
+		{Param: "source", Column: "source"},
+		{Param: "theme", Column: "theme", Normalize: h.aliases.Canonical},
+		{Param: "impact_level", Column: "impact_level"},
+	}, meta)
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
 	}
-	if impactLevel := c.Query("impact_level"); impactLevel != "" {
-		query = query.Where("impact_level = ?", impactLevel)
+
+	page, pageSize := parsePagination(c, defaultListPageSize, maxListPageSize)
+	pageQuery, total, err := paginate(query, &models.ProductFeedback{}, page, pageSize)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
 	}
 
-	result := query.Find(&feedback)
+	result := pageQuery.Find(&feedback)
 	if result.Error != nil {
 		respondWithError(c, http.StatusInternalServerError, result.Error.Error())
 		return
 	}
 
-	respondWithData(c, http.StatusOK, feedback)
+	respondWithPagination(c, feedback, total, page, pageSize, meta)
 }
 
-// GetFeedbackSummary returns aggregated feedback statistics
-func (h *FeedbackHandler) GetFeedbackSummary(c *gin.Context) {
-	type ThemeSummary struct {
-		Theme        string  `json:"theme"`
-		Count        int     `json:"count"`
-		AvgSentiment float64 `json:"avg_sentiment"`
-		TotalVolume  int     `json:"total_volume"`
+// FeedbackThemeSummary aggregates the feedback on one theme; untagged
+// feedback is grouped under ""
+type FeedbackThemeSummary struct {
+	Theme        string  `json:"theme"`
+	Count        int     `json:"count"`
+	AvgSentiment float64 `json:"avg_sentiment"` // 0 when no entry has a score
+	TotalVolume  int     `json:"total_volume"`
+
+	// Trend is set when a comparison period is requested
+	Trend *FeedbackThemeTrend `json:"trend,omitempty" gorm:"-"`
+}
+
+// FeedbackThemeTrend compares a theme's feedback over the latest period with
+// the period before it. Percentages are nil when the previous period had no
+// volume, and sentiment fields nil when a period has no scored entries.
+type FeedbackThemeTrend struct {
+	Period               string   `json:"period"`
+	CurrentCount         int      `json:"current_count"`
+	PreviousCount        int      `json:"previous_count"`
+	CurrentVolume        int      `json:"current_volume"`
+	PreviousVolume       int      `json:"previous_volume"`
+	VolumeChange         int      `json:"volume_change"`
+	VolumeChangePct      *float64 `json:"volume_change_pct"`
+	CurrentAvgSentiment  *float64 `json:"current_avg_sentiment"`
+	PreviousAvgSentiment *float64 `json:"previous_avg_sentiment"`
+	SentimentChange      *float64 `json:"sentiment_change"`
+}
+
+// feedbackComparePeriods maps ?compare= onto the period length in days
+var feedbackComparePeriods = map[string]int{"week": 7, "month": 30}
+
+// feedbackSummary groups feedback by theme. SQL averages over no scores are
+// NULL, so they are coalesced to keep every field numeric.
+func feedbackSummary(db *gorm.DB) ([]FeedbackThemeSummary, error) {
+	summaries := []FeedbackThemeSummary{}
+	err := db.Model(&models.ProductFeedback{}).
+		Select("COALESCE(theme, '') AS theme, COUNT(*) AS count, " +
+			"COALESCE(AVG(sentiment_score), 0) AS avg_sentiment, " +
+			"COALESCE(SUM(" + models.EffectiveVolumeSQL + "), 0) AS total_volume").
+		Group("COALESCE(theme, '')").
+		Order("count DESC, theme ASC").
+		Scan(&summaries).Error
+	return summaries, err
+}
+
+// feedbackTrends compares each theme's feedback created in the period ending
+// at now with the period before it, in one query grouped by theme and period.
+// It groups by position since Postgres does not match the two bound cutoffs
+// as the same expression.
+func feedbackTrends(db *gorm.DB, period string, days int, now time.Time) (map[string]*FeedbackThemeTrend, error) {
+	currentStart := now.AddDate(0, 0, -days)
+	previousStart := currentStart.AddDate(0, 0, -days)
+
+	var rows []struct {
+		Theme        string
+		InCurrent    bool
+		Count        int
+		AvgSentiment *float64
+		Volume       int
+	}
+	err := db.Model(&models.ProductFeedback{}).
+		Select("COALESCE(theme, '') AS theme, created_at >= ? AS in_current, COUNT(*) AS count, "+
+			"AVG(sentiment_score) AS avg_sentiment, "+
+			"COALESCE(SUM("+models.EffectiveVolumeSQL+"), 0) AS volume", currentStart).
+		Where("created_at >= ? AND created_at < ?", previousStart, now).
+		Group("1, 2").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
 	}
 
-	var summaries []ThemeSummary
-	result := database.DB.Model(&models.ProductFeedback{}).
-		Select("theme, COUNT(*) as count, AVG(sentiment_score) as avg_sentiment, SUM(COALESCE(volume, 1)) as total_volume").
-		Group("theme").
-		Find(&summaries)
+	trends := map[string]*FeedbackThemeTrend{}
+	for _, row := range rows {
+		trend := trends[row.Theme]
+		if trend == nil {
+			trend = &FeedbackThemeTrend{Period: period}
+			trends[row.Theme] = trend
+		}
+		if row.InCurrent {
+			trend.CurrentCount, trend.CurrentVolume, trend.CurrentAvgSentiment = row.Count, row.Volume, row.AvgSentiment
+		} else {
+			trend.PreviousCount, trend.PreviousVolume, trend.PreviousAvgSentiment = row.Count, row.Volume, row.AvgSentiment
+		}
+	}
 
-	if result.Error != nil {
-		respondWithError(c, http.StatusInternalServerError, result.Error.Error())
+	for _, trend := range trends {
+		trend.VolumeChange = trend.CurrentVolume - trend.PreviousVolume
+		if trend.PreviousVolume > 0 {
+			pct := roundTo2(float64(trend.VolumeChange) / float64(trend.PreviousVolume) * 100)
+			trend.VolumeChangePct = &pct
+		}
+		for _, avg := range []*float64{trend.CurrentAvgSentiment, trend.PreviousAvgSentiment} {
+			if avg != nil {
+				*avg = roundTo2(*avg)
+			}
+		}
+		if trend.CurrentAvgSentiment != nil && trend.PreviousAvgSentiment != nil {
+			change := roundTo2(*trend.CurrentAvgSentiment - *trend.PreviousAvgSentiment)
+			trend.SentimentChange = &change
+		}
+	}
+	return trends, nil
+}
+
+// GetFeedbackSummary returns aggregated feedback statistics. With
+// ?compare=week or ?compare=month each theme also carries its trend over the
+// last 7 or 30 days against the 7 or 30 days before.
+func (h *FeedbackHandler) GetFeedbackSummary(c *gin.Context) {
+	period := c.Query("compare")
+	days, compare := feedbackComparePeriods[period]
+	if period != "" && !compare {
+		respondWithError(c, http.StatusBadRequest, "compare must be week or month")
 		return
 	}
 
+	summaries, err := feedbackSummary(database.DB)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if compare {
+		trends, err := feedbackTrends(database.DB, period, days, time.Now())
+		if err != nil {
+			respondWithError(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		for i := range summaries {
+			if trend := trends[summaries[i].Theme]; trend != nil {
+				summaries[i].Trend = trend
+			} else {
+				summaries[i].Trend = &FeedbackThemeTrend{Period: period}
+			}
+		}
+	}
+
 	respondWithData(c, http.StatusOK, summaries)
 }
 
+// FacetCount is a distinct value of a filterable column and how often it occurs
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// GetFeedbackFacets returns the distinct themes, sources and impact levels in
+// use so the UI can build its filter menus from real data
+func (h *FeedbackHandler) GetFeedbackFacets(c *gin.Context) {
+	var productID *uuid.UUID
+	if raw := c.Query("product_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			respondWithError(c, http.StatusBadRequest, "Invalid product ID")
+			return
+		}
+		productID = &id
+	}
+
+	facets, err := feedbackFacets(database.DB, productID)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithData(c, http.StatusOK, facets)
+}
+
+// FeedbackFacets are the values feedback can be filtered by, most common
+// first
+type FeedbackFacets struct {
+	Themes       []FacetCount `json:"themes"`
+	Sources      []FacetCount `json:"sources"`
+	ImpactLevels []FacetCount `json:"impact_levels"`
+}
+
+// feedbackFacets counts the non-empty values of each filterable column,
+// across all feedback or one product's when productID is set
+func feedbackFacets(db *gorm.DB, productID *uuid.UUID) (FeedbackFacets, error) {
+	facet := func(column string) ([]FacetCount, error) {
+		counts := []FacetCount{}
+		query := db.Model(&models.ProductFeedback{}).
+			Select(column + " AS value, COUNT(*) AS count").
+			Where(column + " IS NOT NULL AND " + column + " <> ''")
+		if productID != nil {
+			query = query.Where("product_id = ?", *productID)
+		}
+		err := query.Group(column).Order("count DESC, value ASC").Scan(&counts).Error
+		return counts, err
+	}
+
+	var facets FeedbackFacets
+	var err error
+	if facets.Themes, err = facet("theme"); err != nil {
+		return facets, err
+	}
+	if facets.Sources, err = facet("source"); err != nil {
+		return facets, err
+	}
+	facets.ImpactLevels, err = facet("impact_level")
+	return facets, err
+}
+
+// MerchantSignalResponse aggregates a product's feedback sentiment
+type MerchantSignalResponse struct {
+	ProductID        string   `json:"product_id"`
+	Status           string   `json:"status"` // positive, negative, neutral, no_data
+	AverageSentiment float64  `json:"average_sentiment"`
+	TotalFeedback    int64    `json:"total_feedback"`
+	PositiveCount    int64    `json:"positive_count"`
+	NegativeCount    int64    `json:"negative_count"`
+	NeutralCount     int64    `json:"neutral_count"`
+	HighImpactCount  int64    `json:"high_impact_count"`
+	TopThemes        []string `json:"top_themes"`
+	RecentTrend      string   `json:"recent_trend"` // improving, declining, stable
+}
+
 // GetMerchantSignal returns aggregated sentiment metrics for a product (Merchant Signal)
 func (h *FeedbackHandler) GetMerchantSignal(c *gin.Context) {
-	productID, err := uuid.Parse(c.Param("productId"))
+	productID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
 		return
 	}
 
-	type MerchantSignalResponse struct {
-		ProductID        string   `json:"product_id"`
-		Status           string   `json:"status"` // positive, negative, neutral, no_data
-		AverageSentiment float64  `json:"average_sentiment"`
-		TotalFeedback    int64    `json:"total_feedback"`
-		PositiveCount    int64    `json:"positive_count"`
-		NegativeCount    int64    `json:"negative_count"`
-		NeutralCount     int64    `json:"neutral_count"`
-		HighImpactCount  int64    `json:"high_impact_count"`
-		TopThemes        []string `json:"top_themes"`
-		RecentTrend      string   `json:"recent_trend"` // improving, declining, stable
-	}
-
 	var feedback []models.ProductFeedback
 	result := database.DB.
 		Where("product_id = ?", productID).
@@ -242,6 +627,19 @@ func (h *FeedbackHandler) GetMerchantSignal(c *gin.Context) {
 		return
 	}
 
+	respondWithData(c, http.StatusOK, merchantSignal(productID, feedback))
+}
+
+func sentimentScore(f models.ProductFeedback) float64 {
+	if f.SentimentScore == nil {
+		return 0
+	}
+	return *f.SentimentScore
+}
+
+// merchantSignal aggregates feedback, newest first, into a Merchant Signal.
+// Top themes are ranked by volume with ties broken alphabetically.
+func merchantSignal(productID uuid.UUID, feedback []models.ProductFeedback) MerchantSignalResponse {
 	response := MerchantSignalResponse{
 		ProductID: productID.String(),
 		TopThemes: []string{},
@@ -250,8 +648,7 @@ func (h *FeedbackHandler) GetMerchantSignal(c *gin.Context) {
 	if len(feedback) == 0 {
 		response.Status = "no_data"
 		response.RecentTrend = "stable"
-		respondWithData(c, http.StatusOK, response)
-		return
+		return response
 	}
 
 	// Calculate metrics
@@ -259,10 +656,7 @@ func (h *FeedbackHandler) GetMerchantSignal(c *gin.Context) {
 	themeCounts := make(map[string]int)
 
 	for _, f := range feedback {
-		score := 0.0
-		if f.SentimentScore != nil {
-			score = *f.SentimentScore
-		}
+		score := sentimentScore(f)
 		totalSentiment += score
 
 		if score > 0.3 {
@@ -278,11 +672,7 @@ func (h *FeedbackHandler) GetMerchantSignal(c *gin.Context) {
 		}
 
 		if f.Theme != nil && *f.Theme != "" {
-			volume := 1
-			if f.Volume != nil {
-				volume = *f.Volume
-			}
-			themeCounts[*f.Theme] += volume
+			themeCounts[*f.Theme] += f.EffectiveVolume()
 		}
 	}
 
@@ -299,18 +689,14 @@ func (h *FeedbackHandler) GetMerchantSignal(c *gin.Context) {
 	}
 
 	// Calculate trend (compare recent half vs older half)
-	midpoint := len(feedback) / 2
-	if midpoint > 0 {
+	response.RecentTrend = "stable"
+	if midpoint := len(feedback) / 2; midpoint > 0 {
 		var recentSum, olderSum float64
 		for i, f := range feedback {
-			score := 0.0
-			if f.SentimentScore != nil {
-				score = *f.SentimentScore
-			}
 			if i < midpoint {
-				recentSum += score
+				recentSum += sentimentScore(f)
 			} else {
-				olderSum += score
+				olderSum += sentimentScore(f)
 			}
 		}
 		recentAvg := recentSum / float64(midpoint)
@@ -320,33 +706,24 @@ func (h *FeedbackHandler) GetMerchantSignal(c *gin.Context) {
 			response.RecentTrend = "improving"
 		} else if recentAvg-olderAvg < -0.1 {
 			response.RecentTrend = "declining"
-		} else {
-			response.RecentTrend = "stable"
 		}
-	} else {
-		response.RecentTrend = "stable"
 	}
 
 	// Get top 3 themes
-	type themeCount struct {
-		theme string
-		count int
-	}
-	var themes []themeCount
-	for t, c := range themeCounts {
-		themes = append(themes, themeCount{t, c})
-	}
-	// Sort by count descending
-	for i := 0; i < len(themes); i++ {
-		for j := i + 1; j < len(themes); j++ {
-			if themes[j].count > themes[i].count {
-				themes[i], themes[j] = themes[j], themes[i]
-			}
-		}
+	themes := make([]string, 0, len(themeCounts))
+	for theme := range themeCounts {
+		themes = append(themes, theme)
 	}
-	for i := 0; i < len(themes) && i < 3; i++ {
-		response.TopThemes = append(response.TopThemes, themes[i].theme)
+	sort.Slice(themes, func(i, j int) bool {
+		if themeCounts[themes[i]] != themeCounts[themes[j]] {
+			return themeCounts[themes[i]] > themeCounts[themes[j]]
+		}
+		return themes[i] < themes[j]
+	})
+	if len(themes) > 3 {
+		themes = themes[:3]
 	}
+	response.TopThemes = append(response.TopThemes, themes...)
 
-	respondWithData(c, http.StatusOK, response)
+	return response
 }
@@ -0,0 +1,363 @@
+package handlers
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+)
+
+func feedbackEntry(theme string, score float64) models.ProductFeedback {
+	f := models.ProductFeedback{SentimentScore: &score}
+	if theme != "" {
+		f.Theme = &theme
+	}
+	return f
+}
+
+func TestMerchantSignal_NoData(t *testing.T) {
+	productID := uuid.New()
+	got := merchantSignal(productID, nil)
+	if got.Status != "no_data" || got.RecentTrend != "stable" || got.ProductID != productID.String() {
+		t.Errorf("unexpected empty signal %+v", got)
+	}
+	if got.TopThemes == nil || len(got.TopThemes) != 0 {
+		t.Errorf("expected empty top themes, got %v", got.TopThemes)
+	}
+}
+
+func TestMerchantSignal_TopThemesBreakTiesAlphabetically(t *testing.T) {
+	feedback := []models.ProductFeedback{
+		feedbackEntry("pricing", 0),
+		feedbackEntry("onboarding", 0),
+		feedbackEntry("latency", 0),
+		feedbackEntry("api docs", 0),
+		feedbackEntry("latency", 0),
+		feedbackEntry("", 0),
+	}
+
+	want := []string{"latency", "api docs", "onboarding"}
+	for i := 0; i < 20; i++ {
+		if got := merchantSignal(uuid.New(), feedback).TopThemes; !reflect.DeepEqual(got, want) {
+			t.Fatalf("TopThemes = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestMerchantSignal_Trend(t *testing.T) {
+	tests := []struct {
+		name   string
+		scores []float64 // newest first
+		want   string
+	}{
+		{"single entry", []float64{0.9}, "stable"},
+		{"improving", []float64{0.8, 0.6, -0.2, -0.4}, "improving"},
+		{"declining", []float64{-0.5, -0.1, 0.4, 0.6}, "declining"},
+		{"within threshold", []float64{0.35, 0.3, 0.3}, "stable"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var feedback []models.ProductFeedback
+			for _, score := range tt.scores {
+				feedback = append(feedback, feedbackEntry("", score))
+			}
+			if got := merchantSignal(uuid.New(), feedback).RecentTrend; got != tt.want {
+				t.Errorf("RecentTrend = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMerchantSignal_Counts(t *testing.T) {
+	high := "HIGH"
+	volume := 3
+	weighted := feedbackEntry("pricing", 0.5)
+	weighted.Volume = &volume
+	weighted.ImpactLevel = &high
+
+	got := merchantSignal(uuid.New(), []models.ProductFeedback{
+		weighted,
+		feedbackEntry("latency", -0.5),
+		feedbackEntry("latency", 0),
+	})
+	if got.PositiveCount != 1 || got.NegativeCount != 1 || got.NeutralCount != 1 || got.HighImpactCount != 1 {
+		t.Errorf("unexpected counts %+v", got)
+	}
+	if got.Status != "neutral" {
+		t.Errorf("Status = %q, want neutral", got.Status)
+	}
+	if want := []string{"pricing", "latency"}; !reflect.DeepEqual(got.TopThemes, want) {
+		t.Errorf("TopThemes = %v, want %v (volume-weighted)", got.TopThemes, want)
+	}
+}
+
+func TestNormalizeFeedbackThemes(t *testing.T) {
+	db := openTestDB(t, `CREATE TABLE product_feedback (
+		id TEXT PRIMARY KEY, product_id TEXT NOT NULL, source TEXT NOT NULL, raw_text TEXT NOT NULL,
+		theme TEXT, raw_theme TEXT, sentiment_score REAL, impact_level TEXT, volume INTEGER DEFAULT 1,
+		created_at DATETIME, updated_at DATETIME)`)
+	aliases := models.NewThemeAliases(map[string]string{"on-boarding": "onboarding"})
+
+	str := func(s string) *string { return &s }
+	productID := uuid.New()
+	seed := []models.ProductFeedback{
+		{Theme: str("Onboarding")},                                 // legacy row, not yet normalized
+		{Theme: str("on-boarding")},                                // legacy row, normalized but not aliased
+		{Theme: str("onboarding"), RawTheme: str("On-Boarding")},   // already canonical
+		{Theme: str("pricing")},                                    // legacy row, already canonical
+		{Theme: str("on-boarding"), RawTheme: str(" On-boarding")}, // aliased after it was written
+		{},
+	}
+	for i := range seed {
+		seed[i].ProductID = productID
+		seed[i].Source = "survey"
+		seed[i].RawText = "text"
+		if err := db.Create(&seed[i]).Error; err != nil {
+			t.Fatalf("seed: %v", err)
+		}
+	}
+
+	result, err := normalizeFeedbackThemes(db, aliases)
+	if err != nil {
+		t.Fatalf("normalize: %v", err)
+	}
+	if result.Scanned != 5 || result.Remapped != 3 || result.Themes["onboarding"] != 3 {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	var stored []models.ProductFeedback
+	db.Find(&stored)
+	for i, f := range stored {
+		if seed[i].Theme == nil {
+			if f.Theme != nil || f.RawTheme != nil {
+				t.Errorf("row %d: themeless row gained %v/%v", i, f.Theme, f.RawTheme)
+			}
+			continue
+		}
+		if f.RawTheme == nil {
+			t.Errorf("row %d: raw theme not kept", i)
+			continue
+		}
+		if want := aliases.Canonical(*f.RawTheme); *f.Theme != want {
+			t.Errorf("row %d: theme %q, want %q", i, *f.Theme, want)
+		}
+	}
+	if *stored[0].RawTheme != "Onboarding" {
+		t.Errorf("legacy raw theme = %q, want the original Onboarding", *stored[0].RawTheme)
+	}
+
+	again, err := normalizeFeedbackThemes(db, aliases)
+	if err != nil || again.Remapped != 0 {
+		t.Errorf("second run remapped %d rows (err %v), want 0", again.Remapped, err)
+	}
+}
+
+func TestMerchantSignal_SingleEntry(t *testing.T) {
+	got := merchantSignal(uuid.New(), []models.ProductFeedback{feedbackEntry("pricing", -0.6)})
+	if got.TotalFeedback != 1 || got.AverageSentiment != -0.6 || got.NegativeCount != 1 {
+		t.Errorf("unexpected counts %+v", got)
+	}
+	if got.Status != "negative" || got.RecentTrend != "stable" {
+		t.Errorf("status %q, trend %q; want negative and stable", got.Status, got.RecentTrend)
+	}
+
+	// An unscored entry counts as neutral rather than poisoning the average
+	unscored := merchantSignal(uuid.New(), []models.ProductFeedback{{}})
+	if unscored.AverageSentiment != 0 || unscored.NeutralCount != 1 || unscored.Status != "neutral" {
+		t.Errorf("unexpected unscored signal %+v", unscored)
+	}
+}
+
+func TestFeedbackSummary(t *testing.T) {
+	db := openTestDB(t, `CREATE TABLE product_feedback (
+		id TEXT PRIMARY KEY, product_id TEXT NOT NULL, theme TEXT, sentiment_score REAL, volume INTEGER)`)
+
+	summaries, err := feedbackSummary(db)
+	if err != nil {
+		t.Fatalf("feedbackSummary: %v", err)
+	}
+	if summaries == nil || len(summaries) != 0 {
+		t.Fatalf("empty table: got %#v, want an empty list", summaries)
+	}
+
+	db.Exec(`INSERT INTO product_feedback (id, product_id, theme, sentiment_score, volume) VALUES ('f1', 'p1', 'pricing', NULL, NULL)`)
+	summaries, err = feedbackSummary(db)
+	if err != nil {
+		t.Fatalf("feedbackSummary: %v", err)
+	}
+	want := []FeedbackThemeSummary{{Theme: "pricing", Count: 1, AvgSentiment: 0, TotalVolume: 1}}
+	if !reflect.DeepEqual(summaries, want) {
+		t.Errorf("single unscored entry: got %+v, want %+v", summaries, want)
+	}
+
+	db.Exec(`INSERT INTO product_feedback (id, product_id, theme, sentiment_score, volume) VALUES
+		('f2', 'p1', 'pricing', 0.5, 4), ('f3', 'p1', NULL, -0.5, 0), ('f4', 'p1', '', 0.1, 2)`)
+	summaries, err = feedbackSummary(db)
+	if err != nil {
+		t.Fatalf("feedbackSummary: %v", err)
+	}
+	want = []FeedbackThemeSummary{
+		{Theme: "", Count: 2, AvgSentiment: -0.2, TotalVolume: 3},
+		{Theme: "pricing", Count: 2, AvgSentiment: 0.5, TotalVolume: 5},
+	}
+	if len(summaries) != len(want) {
+		t.Fatalf("got %+v, want %+v", summaries, want)
+	}
+	for i := range want {
+		got := summaries[i]
+		if got.Theme != want[i].Theme || got.Count != want[i].Count || got.TotalVolume != want[i].TotalVolume ||
+			math.Abs(got.AvgSentiment-want[i].AvgSentiment) > 1e-9 {
+			t.Errorf("summary %d = %+v, want %+v", i, got, want[i])
+		}
+	}
+}
+
+func TestFeedbackTrends(t *testing.T) {
+	db := openTestDB(t, `CREATE TABLE product_feedback (
+		id TEXT PRIMARY KEY, product_id TEXT NOT NULL, theme TEXT, sentiment_score REAL, volume INTEGER, created_at DATETIME)`)
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	insert := func(id, theme string, sentiment interface{}, volume int, created time.Time) {
+		t.Helper()
+		if err := db.Exec(`INSERT INTO product_feedback (id, product_id, theme, sentiment_score, volume, created_at) VALUES (?, 'p1', ?, ?, ?, ?)`,
+			id, theme, sentiment, volume, models.NewTimestamp(created)).Error; err != nil {
+			t.Fatalf("insert: %v", err)
+		}
+	}
+	day := func(n int) time.Time { return now.AddDate(0, 0, -n) }
+
+	insert("f1", "onboarding", -0.5, 10, day(9))
+	insert("f2", "onboarding", -0.2, 3, day(2))
+	insert("f3", "onboarding", -0.8, 10, day(1))
+	insert("f4", "pricing", 0.4, 2, day(3))
+	insert("f5", "pricing", nil, 1, day(20)) // before both periods
+	insert("f6", "reliability", 0.1, 4, day(10))
+
+	trends, err := feedbackTrends(db, "week", 7, now)
+	if err != nil {
+		t.Fatalf("feedbackTrends: %v", err)
+	}
+	if len(trends) != 3 {
+		t.Fatalf("got %d themes, want 3: %+v", len(trends), trends)
+	}
+
+	onboarding := trends["onboarding"]
+	if onboarding.CurrentCount != 2 || onboarding.PreviousCount != 1 ||
+		onboarding.CurrentVolume != 13 || onboarding.PreviousVolume != 10 || onboarding.VolumeChange != 3 {
+		t.Errorf("onboarding = %+v", onboarding)
+	}
+	if onboarding.VolumeChangePct == nil || *onboarding.VolumeChangePct != 30 {
+		t.Errorf("onboarding volume change = %v%%, want 30%%", onboarding.VolumeChangePct)
+	}
+	if onboarding.SentimentChange == nil || math.Abs(*onboarding.SentimentChange-0) > 1e-9 {
+		t.Errorf("onboarding sentiment change = %v, want 0", onboarding.SentimentChange)
+	}
+
+	pricing := trends["pricing"]
+	if pricing.PreviousVolume != 0 || pricing.VolumeChangePct != nil || pricing.SentimentChange != nil ||
+		pricing.CurrentAvgSentiment == nil || *pricing.CurrentAvgSentiment != 0.4 {
+		t.Errorf("new theme should have no percentage or sentiment change: %+v", pricing)
+	}
+
+	reliability := trends["reliability"]
+	if reliability.CurrentVolume != 0 || reliability.VolumeChange != -4 || *reliability.VolumeChangePct != -100 {
+		t.Errorf("reliability = %+v", reliability)
+	}
+}
+
+func TestFilterFeedback(t *testing.T) {
+	db := openTestDB(t, `CREATE TABLE product_feedback (
+		id TEXT PRIMARY KEY, product_id TEXT NOT NULL, source TEXT, raw_text TEXT, theme TEXT, raw_theme TEXT,
+		sentiment_score REAL, impact_level TEXT, volume INTEGER, created_at DATETIME, updated_at DATETIME)`)
+	insert := func(text, source string, sentiment interface{}, day int) {
+		t.Helper()
+		if err := db.Exec(`INSERT INTO product_feedback (id, product_id, source, raw_text, sentiment_score, created_at) VALUES (?, ?, ?, ?, ?, ?)`,
+			uuid.New(), uuid.New(), source, text, sentiment, models.NewTimestamp(time.Date(2026, 3, day, 15, 0, 0, 0, time.UTC))).Error; err != nil {
+			t.Fatalf("insert: %v", err)
+		}
+	}
+	insert("furious", "support", -0.9, 1)
+	insert("unhappy", "survey", -0.6, 2)
+	insert("meh", "support", 0.0, 3)
+	insert("unscored", "support", nil, 3)
+	insert("delighted", "support", 0.8, 4)
+
+	score := func(v float64) *float64 { return &v }
+	date := func(d int) *models.Date {
+		v := models.NewDate(time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC))
+		return &v
+	}
+	tests := []struct {
+		name   string
+		filter feedbackFilter
+		want   []string // raw text, newest first
+	}{
+		{"no filter", feedbackFilter{}, []string{"delighted", "meh", "unscored", "unhappy", "furious"}},
+		{"strongly negative", feedbackFilter{SentimentMax: score(-0.5)}, []string{"unhappy", "furious"}},
+		{"bounds are inclusive", feedbackFilter{SentimentMin: score(-0.6), SentimentMax: score(0)}, []string{"meh", "unhappy"}},
+		{"created range is inclusive", feedbackFilter{CreatedAfter: date(2), CreatedBefore: date(3)}, []string{"meh", "unscored", "unhappy"}},
+		{"combined", feedbackFilter{SentimentMax: score(-0.5), CreatedAfter: date(2)}, []string{"unhappy"}},
+	}
+	for _, tt := range tests {
+		var feedback []models.ProductFeedback
+		if err := filterFeedback(db, tt.filter).Order("raw_text").Find(&feedback).Error; err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		got := make([]string, len(feedback))
+		for i, f := range feedback {
+			got[i] = f.RawText
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFeedbackFacets(t *testing.T) {
+	db := openTestDB(t, `CREATE TABLE product_feedback (
+		id TEXT PRIMARY KEY, product_id TEXT NOT NULL, source TEXT NOT NULL, raw_text TEXT NOT NULL,
+		theme TEXT, raw_theme TEXT, sentiment_score REAL, impact_level TEXT, volume INTEGER DEFAULT 1,
+		created_at DATETIME, updated_at DATETIME)`,
+		`INSERT INTO product_feedback (id, product_id, source, raw_text, theme, impact_level) VALUES
+			('f0000000-0000-0000-0000-000000000001', '8d1e5a42-1f6b-4c55-9b39-0a4c1b0e0001', 'survey', 'a', 'pricing', 'high'),
+			('f0000000-0000-0000-0000-000000000002', '8d1e5a42-1f6b-4c55-9b39-0a4c1b0e0001', 'survey', 'b', 'onboarding', 'high'),
+			('f0000000-0000-0000-0000-000000000003', '8d1e5a42-1f6b-4c55-9b39-0a4c1b0e0001', 'support', 'c', 'pricing', ''),
+			('f0000000-0000-0000-0000-000000000004', '8d1e5a42-1f6b-4c55-9b39-0a4c1b0e0002', 'support', 'd', 'pricing', 'low'),
+			('f0000000-0000-0000-0000-000000000005', '8d1e5a42-1f6b-4c55-9b39-0a4c1b0e0002', 'nps', 'e', NULL, NULL)`,
+	)
+
+	facets, err := feedbackFacets(db, nil)
+	if err != nil {
+		t.Fatalf("feedbackFacets: %v", err)
+	}
+	want := FeedbackFacets{
+		Themes:       []FacetCount{{"pricing", 3}, {"onboarding", 1}},
+		Sources:      []FacetCount{{"support", 2}, {"survey", 2}, {"nps", 1}},
+		ImpactLevels: []FacetCount{{"high", 2}, {"low", 1}},
+	}
+	if !reflect.DeepEqual(facets, want) {
+		t.Errorf("facets = %+v, want %+v", facets, want)
+	}
+
+	productID := uuid.MustParse("8d1e5a42-1f6b-4c55-9b39-0a4c1b0e0002")
+	facets, err = feedbackFacets(db, &productID)
+	if err != nil {
+		t.Fatalf("feedbackFacets for a product: %v", err)
+	}
+	want = FeedbackFacets{
+		Themes:       []FacetCount{{"pricing", 1}},
+		Sources:      []FacetCount{{"nps", 1}, {"support", 1}},
+		ImpactLevels: []FacetCount{{"low", 1}},
+	}
+	if !reflect.DeepEqual(facets, want) {
+		t.Errorf("product facets = %+v, want %+v", facets, want)
+	}
+
+	none := uuid.New()
+	if facets, err = feedbackFacets(db, &none); err != nil || facets.Themes == nil || len(facets.Themes) != 0 {
+		t.Errorf("no feedback: facets = %#v, %v; want empty lists", facets, err)
+	}
+}
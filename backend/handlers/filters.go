@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// filterKind is how a filter's raw value is coerced before it is compared
+type filterKind int
+
+const (
+	filterString filterKind = iota
+	filterBool
+)
+
+// queryFilter maps a query parameter onto the column it filters by equality.
+// Column is SQL and must come from code, never from the request.
+type queryFilter struct {
+	Param  string
+	Column string
+	Kind   filterKind
+	// Multi accepts a comma-separated list of string values, matching any
+	// of them, e.g. ?lifecycle_stage=pilot,commercial
+	Multi bool
+
+	// Normalize, if set, rewrites a string value before it is checked and
+	// applied, e.g. to a canonical theme
+	Normalize func(string) string
+	// Check, if set, rejects string values outside the column's enum
+	Check func(string) error
+}
+
+// enumCheck adapts an enum's Check method to queryFilter.Check
+func enumCheck[T ~string](check func(T) error) func(string) error {
+	return func(value string) error { return check(T(value)) }
+}
+
+// applyFilters adds an equality condition for each filter whose parameter is
+// set, or an IN condition for a Multi filter given several values, recording
+// it in meta. Only the listed parameters are read, so the filters double as
+// the allowlist: any other parameter is left to the caller. A value that
+// fails coercion or its Check is an error.
+func applyFilters(query *gorm.DB, params url.Values, filters []queryFilter, meta *ListMeta) (*gorm.DB, error) {
+	for _, filter := range filters {
+		raw := params.Get(filter.Param)
+		if raw == "" {
+			continue
+		}
+
+		if filter.Kind == filterBool {
+			b, err := strconv.ParseBool(raw)
+			if err != nil {
+				return nil, fmt.Errorf("%s must be true or false", filter.Param)
+			}
+			query = query.Where(filter.Column+" = ?", b)
+			meta.filter(filter.Param, strconv.FormatBool(b))
+			continue
+		}
+
+		raws := []string{raw}
+		if filter.Multi {
+			raws = strings.Split(raw, ",")
+		}
+		values := make([]string, 0, len(raws))
+		for _, value := range raws {
+			if filter.Multi {
+				value = strings.TrimSpace(value)
+				if value == "" {
+					continue
+				}
+			}
+			if filter.Normalize != nil {
+				value = filter.Normalize(value)
+			}
+			if filter.Check != nil {
+				if err := filter.Check(value); err != nil {
+					return nil, err
+				}
+			}
+			if !slices.Contains(values, value) {
+				values = append(values, value)
+			}
+		}
+
+		switch len(values) {
+		case 0:
+			continue
+		case 1:
+			query = query.Where(filter.Column+" = ?", values[0])
+		default:
+			query = query.Where(filter.Column+" IN ?", values)
+		}
+		meta.filter(filter.Param, strings.Join(values, ","))
+	}
+	return query, nil
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+)
+
+func TestApplyFilters(t *testing.T) {
+	db := openTestDB(t,
+		`CREATE TABLE items (name TEXT, status TEXT, theme TEXT, enabled BOOLEAN)`,
+		`INSERT INTO items VALUES
+			('a', 'blocked', 'pricing', true),
+			('b', 'pending', 'pricing', false),
+			('c', 'blocked', 'onboarding', false)`,
+	)
+	filters := []queryFilter{
+		{Param: "status", Column: "status", Multi: true, Check: enumCheck(models.DependencyStatus.Check)},
+		{Param: "theme", Column: "theme", Normalize: models.NormalizeTheme},
+		{Param: "enabled", Column: "enabled", Kind: filterBool},
+	}
+	run := func(t *testing.T, query string) ([]string, *ListMeta, error) {
+		t.Helper()
+		params, err := url.ParseQuery(query)
+		if err != nil {
+			t.Fatalf("parse %q: %v", query, err)
+		}
+		meta := newListMeta("")
+		filtered, err := applyFilters(db.Table("items").Order("name"), params, filters, meta)
+		if err != nil {
+			return nil, meta, err
+		}
+		names := []string{}
+		if err := filtered.Pluck("name", &names).Error; err != nil {
+			t.Fatalf("query: %v", err)
+		}
+		return names, meta, nil
+	}
+
+	tests := []struct {
+		name    string
+		query   string
+		want    []string
+		filters map[string]string
+	}{
+		{"none", "", []string{"a", "b", "c"}, map[string]string{}},
+		{"equality", "status=blocked", []string{"a", "c"}, map[string]string{"status": "blocked"}},
+		{"normalized", "theme=+Pricing+&status=blocked", []string{"a"}, map[string]string{"status": "blocked", "theme": "pricing"}},
+		{"bool true", "enabled=TRUE", []string{"a"}, map[string]string{"enabled": "true"}},
+		{"bool 0", "enabled=0", []string{"b", "c"}, map[string]string{"enabled": "false"}},
+		{"unlisted params are ignored", "name=a&name+%3D+name+OR+1=1", []string{"a", "b", "c"}, map[string]string{}},
+		{"any of several values", "status=pending,+blocked", []string{"a", "b", "c"}, map[string]string{"status": "pending,blocked"}},
+		{"repeated and empty values", "status=blocked,,blocked,", []string{"a", "c"}, map[string]string{"status": "blocked"}},
+		{"commas are literal without Multi", "theme=pricing,onboarding", []string{}, map[string]string{"theme": "pricing,onboarding"}},
+		{"values are bound", "theme=pricing'+OR+'1'='1", []string{}, map[string]string{"theme": "pricing' or '1'='1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, meta, err := run(t, tt.query)
+			if err != nil {
+				t.Fatalf("applyFilters: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(meta.Filters, tt.filters) {
+				t.Errorf("meta filters = %v, want %v", meta.Filters, tt.filters)
+			}
+		})
+	}
+
+	t.Run("rejects values outside the enum", func(t *testing.T) {
+		_, _, err := run(t, "status=stuck")
+		var enumErr *models.EnumError
+		if !errors.As(err, &enumErr) || enumErr.Value != "stuck" {
+			t.Errorf("err = %v, want an EnumError for stuck", err)
+		}
+	})
+
+	t.Run("checks every listed value", func(t *testing.T) {
+		_, _, err := run(t, "status=blocked,stuck")
+		var enumErr *models.EnumError
+		if !errors.As(err, &enumErr) || enumErr.Value != "stuck" {
+			t.Errorf("err = %v, want an EnumError for stuck", err)
+		}
+	})
+
+	t.Run("rejects bad bools", func(t *testing.T) {
+		if _, _, err := run(t, "enabled=yes"); err == nil || !strings.Contains(err.Error(), "enabled must be true or false") {
+			t.Errorf("err = %v", err)
+		}
+	})
+}
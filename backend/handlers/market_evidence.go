@@ -17,7 +17,7 @@ func NewMarketEvidenceHandler() *MarketEvidenceHandler {
 
 // GetProductMarketEvidence retrieves all market evidence for a product
 func (h *MarketEvidenceHandler) GetProductMarketEvidence(c *gin.Context) {
-	productID, err := uuid.Parse(c.Param("productId"))
+	productID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
 		return
@@ -151,5 +151,5 @@ func (h *MarketEvidenceHandler) GetAllMarketEvidence(c *gin.Context) {
 		return
 	}
 
-	respondWithData(c, http.StatusOK, evidence)
+	respondWithList(c, evidence, newListMeta("-measurement_date"))
 }
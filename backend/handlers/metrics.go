@@ -1,12 +1,17 @@
 package handlers
 
 import (
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/pauly7610/studio-pilot-vision/backend/database"
 	"github.com/pauly7610/studio-pilot-vision/backend/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type MetricsHandler struct{}
@@ -15,16 +20,39 @@ func NewMetricsHandler() *MetricsHandler {
 	return &MetricsHandler{}
 }
 
-// GetProductMetrics retrieves all metrics for a specific product
+// GetProductMetrics retrieves a product's daily metrics, optionally limited
+// to ?start_date= and ?end_date=. With ?granularity=day|week|month the days
+// are rolled up into one aggregate per period instead.
 func (h *MetricsHandler) GetProductMetrics(c *gin.Context) {
-	productID, err := uuid.Parse(c.Param("productId"))
+	productID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
 		return
 	}
 
+	dates, err := parseMetricRange(c)
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if raw := c.Query("granularity"); raw != "" {
+		granularity, err := parseMetricGranularity(raw)
+		if err != nil {
+			respondWithError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		rollups, err := metricRollups(database.DB, productID, granularity, dates)
+		if err != nil {
+			respondWithError(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		respondWithData(c, http.StatusOK, rollups)
+		return
+	}
+
 	var metrics []models.ProductMetric
-	result := database.DB.
+	result := dates.apply(database.DB).
 		Where("product_id = ?", productID).
 		Order("date ASC").
 		Find(&metrics)
@@ -56,20 +84,62 @@ func (h *MetricsHandler) GetMetric(c *gin.Context) {
 	respondWithData(c, http.StatusOK, metric)
 }
 
-// CreateMetric creates a new metric
+// upsertMetric writes a product's metrics for a day, replacing the values of
+// any row already recorded for that product and date. It reloads the stored
+// row into metric and reports whether it was newly created.
+func upsertMetric(db *gorm.DB, metric *models.ProductMetric) (bool, error) {
+	metric.ID = uuid.New()
+	generatedID := metric.ID
+
+	err := db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "product_id"}, {Name: "date"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"actual_revenue", "adoption_rate", "active_users", "transaction_volume", "churn_rate", "updated_at",
+		}),
+	}).Create(metric).Error
+	if err != nil {
+		return false, err
+	}
+
+	err = db.Where("product_id = ? AND date = ?", metric.ProductID, metric.Date).First(metric).Error
+	if err != nil {
+		return false, err
+	}
+	return metric.ID == generatedID, nil
+}
+
+// CreateMetric records a product's metrics for a day. Metrics are daily
+// snapshots, so posting a date that already exists updates that day's row.
 func (h *MetricsHandler) CreateMetric(c *gin.Context) {
 	var req models.CreateProductMetricRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		respondWithError(c, http.StatusBadRequest, err.Error())
+	if !bindRequest(c, &req) {
 		return
 	}
 
-	// Verify product exists
-	var product models.Product
-	if result := database.DB.First(&product, "id = ?", req.ProductID); result.Error != nil {
+	metric, created, err := recordMetric(req)
+	if errors.Is(err, errProductNotFound) {
 		respondWithError(c, http.StatusNotFound, "Product not found")
 		return
 	}
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	respondWithData(c, status, metric)
+}
+
+// recordMetric upserts a product's metrics for a day after checking the
+// product exists; created reports whether the day was new
+func recordMetric(req models.CreateProductMetricRequest) (models.ProductMetric, bool, error) {
+	var product models.Product
+	if result := database.DB.First(&product, "id = ?", req.ProductID); result.Error != nil {
+		return models.ProductMetric{}, false, errProductNotFound
+	}
 
 	metric := models.ProductMetric{
 		ProductID:         req.ProductID,
@@ -81,13 +151,8 @@ func (h *MetricsHandler) CreateMetric(c *gin.Context) {
 		ChurnRate:         req.ChurnRate,
 	}
 
-	result := database.DB.Create(&metric)
-	if result.Error != nil {
-		respondWithError(c, http.StatusInternalServerError, result.Error.Error())
-		return
-	}
-
-	respondWithData(c, http.StatusCreated, metric)
+	created, err := upsertMetric(database.DB, &metric)
+	return metric, created, err
 }
 
 // UpdateMetric updates an existing metric
@@ -111,7 +176,18 @@ func (h *MetricsHandler) UpdateMetric(c *gin.Context) {
 	}
 
 	updates := make(map[string]interface{})
-	if req.Date != nil {
+	if req.Date != nil && !req.Date.Equal(metric.Date.Time) {
+		var count int64
+		if err := database.DB.Model(&models.ProductMetric{}).
+			Where("product_id = ? AND date = ?", metric.ProductID, *req.Date).
+			Count(&count).Error; err != nil {
+			respondWithError(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if count > 0 {
+			respondWithError(c, http.StatusConflict, "Metrics already exist for this product and date")
+			return
+		}
 		updates["date"] = *req.Date
 	}
 	if req.ActualRevenue != nil {
@@ -166,20 +242,197 @@ func (h *MetricsHandler) GetAllMetrics(c *gin.Context) {
 	var metrics []models.ProductMetric
 
 	query := database.DB.Order("date DESC")
+	meta := newListMeta("-date")
 
 	// Optional date range filtering
 	if startDate := c.Query("start_date"); startDate != "" {
 		query = query.Where("date >= ?", startDate)
+		meta.filter("start_date", startDate)
 	}
 	if endDate := c.Query("end_date"); endDate != "" {
 		query = query.Where("date <= ?", endDate)
+		meta.filter("end_date", endDate)
+	}
+
+	page, pageSize := parsePagination(c, defaultListPageSize, maxListPageSize)
+	pageQuery, total, err := paginate(query, &models.ProductMetric{}, page, pageSize)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
 	}
 
-	result := query.Find(&metrics)
+	result := pageQuery.Find(&metrics)
 	if result.Error != nil {
 		respondWithError(c, http.StatusInternalServerError, result.Error.Error())
 		return
 	}
 
-	respondWithData(c, http.StatusOK, metrics)
+	respondWithPagination(c, metrics, total, page, pageSize, meta)
+}
+
+// MetricAnomaly is a metric point that falls outside its rolling expected range
+type MetricAnomaly struct {
+	Metric      string      `json:"metric"`
+	Date        models.Date `json:"date"`
+	Value       float64     `json:"value"`
+	Expected    float64     `json:"expected"`
+	ExpectedMin float64     `json:"expected_min"`
+	ExpectedMax float64     `json:"expected_max"`
+	PctChange   float64     `json:"pct_change"`
+}
+
+// AnomalyOptions controls how far a point may stray from its rolling trend
+type AnomalyOptions struct {
+	Window       int     // number of preceding points forming the trend
+	StdDevs      float64 // allowed deviation in standard deviations
+	MaxPctChange float64 // allowed % change from the trend mean; 0 disables
+}
+
+const minAnomalyBaseline = 3
+
+// metricSeries extracts the numeric fields of a metric row keyed by JSON name
+func metricSeries(m models.ProductMetric) map[string]*float64 {
+	series := map[string]*float64{
+		"actual_revenue": m.ActualRevenue,
+		"adoption_rate":  m.AdoptionRate,
+		"churn_rate":     m.ChurnRate,
+	}
+	if m.ActiveUsers != nil {
+		v := float64(*m.ActiveUsers)
+		series["active_users"] = &v
+	} else {
+		series["active_users"] = nil
+	}
+	if m.TransactionVolume != nil {
+		v := float64(*m.TransactionVolume)
+		series["transaction_volume"] = &v
+	} else {
+		series["transaction_volume"] = nil
+	}
+	return series
+}
+
+var anomalyMetricNames = []string{"actual_revenue", "adoption_rate", "active_users", "transaction_volume", "churn_rate"}
+
+// detectMetricAnomalies flags points that deviate from the rolling mean of the
+// preceding window by more than opts.StdDevs standard deviations, or by more
+// than opts.MaxPctChange percent. After a flat window, with no deviation to
+// scale by, any change from it is flagged. Metrics must be sorted by date
+// ascending.
+func detectMetricAnomalies(metrics []models.ProductMetric, opts AnomalyOptions) []MetricAnomaly {
+	anomalies := []MetricAnomaly{}
+	if opts.Window < minAnomalyBaseline {
+		opts.Window = minAnomalyBaseline
+	}
+
+	type point struct {
+		date  models.Date
+		value float64
+	}
+	points := make(map[string][]point)
+	for _, m := range metrics {
+		for name, v := range metricSeries(m) {
+			if v != nil {
+				points[name] = append(points[name], point{m.Date, *v})
+			}
+		}
+	}
+
+	for _, name := range anomalyMetricNames {
+		series := points[name]
+		for i := minAnomalyBaseline; i < len(series); i++ {
+			start := i - opts.Window
+			if start < 0 {
+				start = 0
+			}
+			baseline := series[start:i]
+
+			var sum float64
+			for _, p := range baseline {
+				sum += p.value
+			}
+			mean := sum / float64(len(baseline))
+
+			var variance float64
+			for _, p := range baseline {
+				variance += (p.value - mean) * (p.value - mean)
+			}
+			stdDev := math.Sqrt(variance / float64(len(baseline)))
+
+			value := series[i].value
+			pctChange := 0.0
+			if mean != 0 {
+				pctChange = (value - mean) / math.Abs(mean) * 100
+			}
+
+			outsideRange := math.Abs(value-mean) > opts.StdDevs*stdDev
+			if stdDev == 0 {
+				outsideRange = value != mean
+			}
+			outsidePct := opts.MaxPctChange > 0 && mean != 0 && math.Abs(pctChange) > opts.MaxPctChange
+			if !outsideRange && !outsidePct {
+				continue
+			}
+
+			anomalies = append(anomalies, MetricAnomaly{
+				Metric:      name,
+				Date:        series[i].date,
+				Value:       value,
+				Expected:    mean,
+				ExpectedMin: mean - opts.StdDevs*stdDev,
+				ExpectedMax: mean + opts.StdDevs*stdDev,
+				PctChange:   pctChange,
+			})
+		}
+	}
+
+	return anomalies
+}
+
+// GetProductMetricAnomalies scans a product's metric history for outliers
+func (h *MetricsHandler) GetProductMetricAnomalies(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	opts := AnomalyOptions{Window: 6, StdDevs: 2}
+	if window := c.Query("window"); window != "" {
+		n, err := strconv.Atoi(window)
+		if err != nil || n < minAnomalyBaseline {
+			respondWithError(c, http.StatusBadRequest, "window must be an integer >= 3")
+			return
+		}
+		opts.Window = n
+	}
+	if stdDevs := c.Query("std_devs"); stdDevs != "" {
+		f, err := strconv.ParseFloat(stdDevs, 64)
+		if err != nil || f <= 0 {
+			respondWithError(c, http.StatusBadRequest, "std_devs must be a positive number")
+			return
+		}
+		opts.StdDevs = f
+	}
+	if pct := c.Query("pct_change"); pct != "" {
+		f, err := strconv.ParseFloat(pct, 64)
+		if err != nil || f < 0 {
+			respondWithError(c, http.StatusBadRequest, "pct_change must be a non-negative number")
+			return
+		}
+		opts.MaxPctChange = f
+	}
+
+	var metrics []models.ProductMetric
+	result := database.DB.
+		Where("product_id = ?", productID).
+		Order("date ASC").
+		Find(&metrics)
+
+	if result.Error != nil {
+		respondWithError(c, http.StatusInternalServerError, result.Error.Error())
+		return
+	}
+
+	respondWithData(c, http.StatusOK, detectMetricAnomalies(metrics, opts))
 }
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+	"gorm.io/gorm"
+)
+
+// MetricGranularity is the period metrics are rolled up into
+type MetricGranularity string
+
+const (
+	MetricGranularityDay   MetricGranularity = "day"
+	MetricGranularityWeek  MetricGranularity = "week"
+	MetricGranularityMonth MetricGranularity = "month"
+)
+
+// MetricRollup aggregates one product's daily metrics over a period. Revenue
+// and transactions are summed, rates averaged, and active users are the last
+// recorded value in the period.
+type MetricRollup struct {
+	PeriodStart       models.Date `json:"period_start"`
+	PeriodEnd         models.Date `json:"period_end"`
+	Days              int         `json:"days"`
+	ActualRevenue     *float64    `json:"actual_revenue"`
+	AdoptionRate      *float64    `json:"adoption_rate"`
+	ActiveUsers       *int        `json:"active_users"`
+	TransactionVolume *int64      `json:"transaction_volume"`
+	ChurnRate         *float64    `json:"churn_rate"`
+}
+
+// metricRange is an optional inclusive date range for metric queries
+type metricRange struct {
+	Start *models.Date
+	End   *models.Date
+}
+
+func (r metricRange) apply(query *gorm.DB) *gorm.DB {
+	if r.Start != nil {
+		query = query.Where("date >= ?", *r.Start)
+	}
+	if r.End != nil {
+		query = query.Where("date <= ?", *r.End)
+	}
+	return query
+}
+
+// parseMetricRange reads ?start_date= and ?end_date= (YYYY-MM-DD, inclusive)
+func parseMetricRange(c *gin.Context) (metricRange, error) {
+	var r metricRange
+	for param, target := range map[string]**models.Date{"start_date": &r.Start, "end_date": &r.End} {
+		raw := c.Query(param)
+		if raw == "" {
+			continue
+		}
+		date, err := models.ParseDate(raw)
+		if err != nil {
+			return metricRange{}, fmt.Errorf("%s must be a date (YYYY-MM-DD)", param)
+		}
+		*target = &date
+	}
+	if r.Start != nil && r.End != nil && r.End.Before(r.Start.Time) {
+		return metricRange{}, fmt.Errorf("end_date must not be before start_date")
+	}
+	return r, nil
+}
+
+func parseMetricGranularity(raw string) (MetricGranularity, error) {
+	switch granularity := MetricGranularity(raw); granularity {
+	case MetricGranularityDay, MetricGranularityWeek, MetricGranularityMonth:
+		return granularity, nil
+	}
+	return "", fmt.Errorf("granularity must be one of day, week, month")
+}
+
+// metricPeriodEnd is the last day of the period starting at start. Weeks are
+// ISO weeks, Monday to Sunday, matching date_trunc.
+func metricPeriodEnd(granularity MetricGranularity, start models.Date) models.Date {
+	switch granularity {
+	case MetricGranularityWeek:
+		return models.NewDate(start.AddDate(0, 0, 6))
+	case MetricGranularityMonth:
+		return models.NewDate(start.AddDate(0, 1, -1))
+	}
+	return start
+}
+
+// metricRollups aggregates a product's metrics by period in the database.
+// Buckets start at date_trunc of each row's date; periods cut by the range
+// only cover the days inside it.
+func metricRollups(db *gorm.DB, productID uuid.UUID, granularity MetricGranularity, dates metricRange) ([]MetricRollup, error) {
+	var rows []MetricRollup
+	err := dates.apply(db.Model(&models.ProductMetric{})).
+		Select(`date_trunc(?, date::timestamp)::date AS period_start,
+			COUNT(*) AS days,
+			SUM(actual_revenue) AS actual_revenue,
+			AVG(adoption_rate) AS adoption_rate,
+			(array_agg(active_users ORDER BY date DESC) FILTER (WHERE active_users IS NOT NULL))[1] AS active_users,
+			SUM(transaction_volume) AS transaction_volume,
+			AVG(churn_rate) AS churn_rate`, string(granularity)).
+		Where("product_id = ?", productID).
+		Group("period_start").
+		Order("period_start ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range rows {
+		rows[i].PeriodEnd = metricPeriodEnd(granularity, rows[i].PeriodStart)
+		for _, value := range []*float64{rows[i].ActualRevenue, rows[i].AdoptionRate, rows[i].ChurnRate} {
+			if value != nil {
+				*value = roundTo2(*value)
+			}
+		}
+	}
+	return rows, nil
+}
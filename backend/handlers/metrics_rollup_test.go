@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+)
+
+func mustDate(t *testing.T, s string) models.Date {
+	t.Helper()
+	d, err := models.ParseDate(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestMetricPeriodEnd(t *testing.T) {
+	tests := []struct {
+		granularity MetricGranularity
+		start, want string
+	}{
+		{MetricGranularityDay, "2026-01-31", "2026-01-31"},
+		{MetricGranularityMonth, "2026-01-01", "2026-01-31"},
+		{MetricGranularityMonth, "2026-02-01", "2026-02-28"},
+		{MetricGranularityMonth, "2024-02-01", "2024-02-29"},
+		{MetricGranularityMonth, "2026-04-01", "2026-04-30"},
+		{MetricGranularityMonth, "2025-12-01", "2025-12-31"},
+		// ISO week spanning a month and a year end
+		{MetricGranularityWeek, "2025-12-29", "2026-01-04"},
+		{MetricGranularityWeek, "2026-01-26", "2026-02-01"},
+	}
+	for _, tt := range tests {
+		got := metricPeriodEnd(tt.granularity, mustDate(t, tt.start))
+		if got.String() != tt.want {
+			t.Errorf("%s from %s: end = %s, want %s", tt.granularity, tt.start, got, tt.want)
+		}
+	}
+}
+
+func TestParseMetricRange(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		query      string
+		wantStart  string
+		wantEnd    string
+		wantErrSub string
+	}{
+		{query: ""},
+		{query: "start_date=2026-01-31&end_date=2026-02-01", wantStart: "2026-01-31", wantEnd: "2026-02-01"},
+		{query: "end_date=2024-02-29", wantEnd: "2024-02-29"},
+		{query: "start_date=2026-02-30", wantErrSub: "start_date"},
+		{query: "start_date=2026-03-01&end_date=2026-02-28", wantErrSub: "end_date must not be before"},
+	}
+	for _, tt := range tests {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/metrics?"+tt.query, nil)
+
+		r, err := parseMetricRange(c)
+		if tt.wantErrSub != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErrSub) {
+				t.Errorf("%q: err = %v, want %q", tt.query, err, tt.wantErrSub)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%q: %v", tt.query, err)
+		}
+		if got := dateOrEmpty(r.Start); got != tt.wantStart {
+			t.Errorf("%q: start = %q, want %q", tt.query, got, tt.wantStart)
+		}
+		if got := dateOrEmpty(r.End); got != tt.wantEnd {
+			t.Errorf("%q: end = %q, want %q", tt.query, got, tt.wantEnd)
+		}
+	}
+}
+
+func dateOrEmpty(d *models.Date) string {
+	if d == nil {
+		return ""
+	}
+	return d.String()
+}
+
+func TestParseMetricGranularity(t *testing.T) {
+	for _, raw := range []string{"day", "week", "month"} {
+		if _, err := parseMetricGranularity(raw); err != nil {
+			t.Errorf("%q: unexpected error %v", raw, err)
+		}
+	}
+	for _, raw := range []string{"year", "Month", "quarter"} {
+		if _, err := parseMetricGranularity(raw); err == nil {
+			t.Errorf("%q: expected an error", raw)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+)
+
+func metricOn(day int, revenue float64, churn float64) models.ProductMetric {
+	return models.ProductMetric{
+		Date:          models.NewDate(time.Date(2025, 1, day, 0, 0, 0, 0, time.UTC)),
+		ActualRevenue: &revenue,
+		ChurnRate:     &churn,
+	}
+}
+
+func TestDetectMetricAnomalies_FlagsSpike(t *testing.T) {
+	metrics := []models.ProductMetric{
+		metricOn(1, 100, 2.0),
+		metricOn(2, 102, 2.1),
+		metricOn(3, 98, 1.9),
+		metricOn(4, 101, 2.0),
+		metricOn(5, 99, 9.5),
+	}
+
+	anomalies := detectMetricAnomalies(metrics, AnomalyOptions{Window: 6, StdDevs: 2})
+
+	if len(anomalies) != 1 {
+		t.Fatalf("expected 1 anomaly, got %d: %+v", len(anomalies), anomalies)
+	}
+	if anomalies[0].Metric != "churn_rate" || anomalies[0].Value != 9.5 {
+		t.Errorf("unexpected anomaly: %+v", anomalies[0])
+	}
+}
+
+func TestDetectMetricAnomalies_PctChange(t *testing.T) {
+	metrics := []models.ProductMetric{
+		metricOn(1, 100, 2),
+		metricOn(2, 104, 2),
+		metricOn(3, 96, 2),
+		metricOn(4, 85, 2),
+	}
+
+	// Within 5 standard deviations (about 3.3 each), so only the % rule can fire
+	if got := detectMetricAnomalies(metrics, AnomalyOptions{Window: 6, StdDevs: 5}); len(got) != 0 {
+		t.Errorf("expected no anomalies without pct rule, got %+v", got)
+	}
+
+	got := detectMetricAnomalies(metrics, AnomalyOptions{Window: 6, StdDevs: 5, MaxPctChange: 10})
+	if len(got) != 1 || got[0].Metric != "actual_revenue" || got[0].PctChange != -15 {
+		t.Errorf("expected revenue drop of -15%%, got %+v", got)
+	}
+}
+
+func TestDetectMetricAnomalies_FlatBaseline(t *testing.T) {
+	metrics := []models.ProductMetric{
+		metricOn(1, 100, 2),
+		metricOn(2, 100, 2),
+		metricOn(3, 100, 2),
+		metricOn(4, 100, 2),
+		metricOn(5, 0, 2),
+	}
+
+	// With no deviation to scale by, any change from a flat trend is flagged
+	got := detectMetricAnomalies(metrics, AnomalyOptions{Window: 6, StdDevs: 2})
+	if len(got) != 1 || got[0].Metric != "actual_revenue" || got[0].Value != 0 || got[0].PctChange != -100 {
+		t.Errorf("expected the drop to 0 to be flagged, got %+v", got)
+	}
+	if got[0].ExpectedMin != 100 || got[0].ExpectedMax != 100 {
+		t.Errorf("expected range = %v-%v, want exactly 100", got[0].ExpectedMin, got[0].ExpectedMax)
+	}
+}
+
+func TestDetectMetricAnomalies_ShortHistory(t *testing.T) {
+	metrics := []models.ProductMetric{metricOn(1, 100, 2), metricOn(2, 500, 20)}
+
+	if got := detectMetricAnomalies(metrics, AnomalyOptions{Window: 6, StdDevs: 2, MaxPctChange: 10}); len(got) != 0 {
+		t.Errorf("expected no anomalies below baseline length, got %+v", got)
+	}
+}
+
+func TestDetectMetricAnomalies_NoData(t *testing.T) {
+	if got := detectMetricAnomalies(nil, AnomalyOptions{Window: 6, StdDevs: 2}); got == nil || len(got) != 0 {
+		t.Errorf("no metrics: got %#v, want an empty list", got)
+	}
+	if got := detectMetricAnomalies([]models.ProductMetric{metricOn(1, 100, 2)}, AnomalyOptions{Window: 6, StdDevs: 2}); len(got) != 0 {
+		t.Errorf("single metric: got %+v, want no anomalies", got)
+	}
+}
+
+func TestDetectMetricAnomalies_ZeroWindow(t *testing.T) {
+	metrics := []models.ProductMetric{
+		metricOn(1, 100, 2),
+		metricOn(2, 100, 2),
+		metricOn(3, 100, 2),
+		metricOn(4, 60, 2),
+	}
+	// A window below the minimum baseline is widened rather than averaging
+	// no points into a NaN mean
+	got := detectMetricAnomalies(metrics, AnomalyOptions{Window: 0, StdDevs: 2, MaxPctChange: 25})
+	if len(got) != 1 || got[0].Expected != 100 || got[0].PctChange != -40 {
+		t.Errorf("expected revenue drop of -40%% from 100, got %+v", got)
+	}
+}
+
+func TestCreateMetric_RequiresDate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	body := `{"product_id": "8d1e5a42-1f6b-4c55-9b39-0a4c1b0e0001", "actual_revenue": 100}`
+	c.Request = httptest.NewRequest(http.MethodPost, "/metrics", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	NewMetricsHandler().CreateMetric(c)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "date is required") {
+		t.Errorf("status = %d, body %s; want 400 requiring the date", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+)
+
+const productMetricsDDL = `CREATE TABLE product_metrics (
+	id TEXT PRIMARY KEY,
+	product_id TEXT NOT NULL,
+	date TEXT NOT NULL,
+	actual_revenue REAL,
+	adoption_rate REAL,
+	active_users INTEGER,
+	transaction_volume INTEGER,
+	churn_rate REAL,
+	created_at DATETIME,
+	updated_at DATETIME,
+	UNIQUE (product_id, date)
+)`
+
+func TestUpsertMetric_SameDateTwiceKeepsOneRow(t *testing.T) {
+	db := openTestDB(t, productMetricsDDL)
+	productID := uuid.New()
+	date, _ := models.ParseDate("2025-03-01")
+
+	first := 100.0
+	metric := models.ProductMetric{ProductID: productID, Date: date, ActualRevenue: &first}
+	created, err := upsertMetric(db, &metric)
+	if err != nil || !created {
+		t.Fatalf("first upsert: created=%v err=%v", created, err)
+	}
+	firstID := metric.ID
+
+	second := 250.0
+	metric = models.ProductMetric{ProductID: productID, Date: date, ActualRevenue: &second}
+	created, err = upsertMetric(db, &metric)
+	if err != nil {
+		t.Fatalf("second upsert: %v", err)
+	}
+	if created {
+		t.Error("expected second post for the same date to update, not create")
+	}
+	if metric.ID != firstID {
+		t.Errorf("expected the existing row to be returned, got id %s want %s", metric.ID, firstID)
+	}
+
+	var rows []models.ProductMetric
+	db.Where("product_id = ?", productID).Find(&rows)
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row for the product and date, got %d", len(rows))
+	}
+	if rows[0].ActualRevenue == nil || *rows[0].ActualRevenue != second {
+		t.Errorf("expected revenue updated to %v, got %v", second, rows[0].ActualRevenue)
+	}
+
+	nextDay, _ := models.ParseDate("2025-03-02")
+	metric = models.ProductMetric{ProductID: productID, Date: nextDay, ActualRevenue: &first}
+	if created, err := upsertMetric(db, &metric); err != nil || !created {
+		t.Errorf("expected a new row for a new date: created=%v err=%v", created, err)
+	}
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/pauly7610/studio-pilot-vision/backend/database"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+)
+
+// A product's revenue target is an annual figure; it is prorated by day over
+// the requested period
+const revenueTargetDays = 365
+
+// Revenue variance statuses
+const (
+	RevenueStatusOnTrack  = "on_track"
+	RevenueStatusBehind   = "behind"
+	RevenueStatusNoTarget = "no_target"
+)
+
+// RevenueVariance compares a product's actual revenue over a period with its
+// prorated target. Target figures are null when the product has no target.
+type RevenueVariance struct {
+	ProductID      string      `json:"product_id"`
+	StartDate      models.Date `json:"start_date"`
+	EndDate        models.Date `json:"end_date"`
+	Days           int         `json:"days"`
+	Status         string      `json:"status"`
+	ActualRevenue  float64     `json:"actual_revenue"`
+	RevenueTarget  *float64    `json:"revenue_target"`
+	ProratedTarget *float64    `json:"prorated_target"`
+	Variance       *float64    `json:"variance"`
+	VariancePct    *float64    `json:"variance_pct"`
+	OnTrack        *bool       `json:"on_track"`
+}
+
+// computeRevenueVariance prorates the annual target over [start, end] and
+// compares it with the actual revenue. A product is on track when actual
+// revenue meets or beats the prorated target.
+func computeRevenueVariance(target *float64, actual float64, start, end models.Date) RevenueVariance {
+	days := int(end.Sub(start.Time).Hours()/24) + 1
+	variance := RevenueVariance{
+		StartDate:     start,
+		EndDate:       end,
+		Days:          days,
+		Status:        RevenueStatusNoTarget,
+		ActualRevenue: roundTo2(actual),
+		RevenueTarget: target,
+	}
+	if target == nil {
+		return variance
+	}
+
+	prorated := roundTo2(*target * float64(days) / revenueTargetDays)
+	diff := roundTo2(actual - prorated)
+	onTrack := diff >= 0
+	variance.ProratedTarget = &prorated
+	variance.Variance = &diff
+	variance.OnTrack = &onTrack
+	if prorated != 0 {
+		pct := roundTo2(diff * 100 / prorated)
+		variance.VariancePct = &pct
+	}
+
+	variance.Status = RevenueStatusBehind
+	if onTrack {
+		variance.Status = RevenueStatusOnTrack
+	}
+	return variance
+}
+
+// GetProductRevenueVariance compares actual revenue with the prorated revenue
+// target over ?start_date= to ?end_date= (inclusive). The period defaults to
+// the year to date.
+func (h *MetricsHandler) GetProductRevenueVariance(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	dates, err := parseMetricRange(c)
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if dates.End == nil {
+		today := models.Today()
+		dates.End = &today
+	}
+	if dates.Start == nil {
+		start := models.NewDate(time.Date(dates.End.Year(), time.January, 1, 0, 0, 0, 0, time.UTC))
+		dates.Start = &start
+	}
+	if dates.End.Before(dates.Start.Time) {
+		respondWithError(c, http.StatusBadRequest, "end_date must not be before start_date")
+		return
+	}
+
+	var product models.Product
+	if err := database.DB.First(&product, "id = ?", productID).Error; err != nil {
+		respondWithError(c, http.StatusNotFound, "Product not found")
+		return
+	}
+
+	var actual float64
+	if err := dates.apply(database.DB.Model(&models.ProductMetric{})).
+		Where("product_id = ?", productID).
+		Select("COALESCE(SUM(actual_revenue), 0)").
+		Scan(&actual).Error; err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	variance := computeRevenueVariance(product.RevenueTarget, actual, *dates.Start, *dates.End)
+	variance.ProductID = productID.String()
+	respondWithData(c, http.StatusOK, variance)
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import "testing"
+
+func TestComputeRevenueVariance(t *testing.T) {
+	target := 365000.0
+
+	tests := []struct {
+		name         string
+		actual       float64
+		start, end   string
+		wantDays     int
+		wantStatus   string
+		wantProrated float64
+		wantVariance float64
+		wantPct      float64
+	}{
+		{"ahead over January", 35000, "2026-01-01", "2026-01-31", 31, RevenueStatusOnTrack, 31000, 4000, 12.9},
+		{"exactly on target", 28000, "2026-02-01", "2026-02-28", 28, RevenueStatusOnTrack, 28000, 0, 0},
+		{"behind over a single day", 400, "2026-03-15", "2026-03-15", 1, RevenueStatusBehind, 1000, -600, -60},
+	}
+	for _, tt := range tests {
+		got := computeRevenueVariance(&target, tt.actual, mustDate(t, tt.start), mustDate(t, tt.end))
+
+		if got.Days != tt.wantDays || got.Status != tt.wantStatus {
+			t.Errorf("%s: days=%d status=%s, want %d %s", tt.name, got.Days, got.Status, tt.wantDays, tt.wantStatus)
+		}
+		if got.ProratedTarget == nil || *got.ProratedTarget != tt.wantProrated {
+			t.Errorf("%s: prorated = %v, want %v", tt.name, got.ProratedTarget, tt.wantProrated)
+		}
+		if got.Variance == nil || *got.Variance != tt.wantVariance {
+			t.Errorf("%s: variance = %v, want %v", tt.name, got.Variance, tt.wantVariance)
+		}
+		if got.VariancePct == nil || *got.VariancePct != tt.wantPct {
+			t.Errorf("%s: variance pct = %v, want %v", tt.name, got.VariancePct, tt.wantPct)
+		}
+		if got.OnTrack == nil || *got.OnTrack != (tt.wantStatus == RevenueStatusOnTrack) {
+			t.Errorf("%s: on_track = %v", tt.name, got.OnTrack)
+		}
+	}
+}
+
+func TestComputeRevenueVariance_NoTarget(t *testing.T) {
+	got := computeRevenueVariance(nil, 1200, mustDate(t, "2026-01-01"), mustDate(t, "2026-01-10"))
+
+	if got.Status != RevenueStatusNoTarget {
+		t.Errorf("status = %s, want %s", got.Status, RevenueStatusNoTarget)
+	}
+	if got.ActualRevenue != 1200 || got.Days != 10 {
+		t.Errorf("actual=%v days=%d, want 1200 and 10", got.ActualRevenue, got.Days)
+	}
+	if got.ProratedTarget != nil || got.Variance != nil || got.VariancePct != nil || got.OnTrack != nil {
+		t.Errorf("expected no target figures, got %+v", got)
+	}
+}
+
+func TestComputeRevenueVariance_ZeroTarget(t *testing.T) {
+	zero := 0.0
+	got := computeRevenueVariance(&zero, 50, mustDate(t, "2026-01-01"), mustDate(t, "2026-01-31"))
+
+	if got.Status != RevenueStatusOnTrack || got.VariancePct != nil {
+		t.Errorf("zero target: status=%s pct=%v, want on_track and no percentage", got.Status, got.VariancePct)
+	}
+}
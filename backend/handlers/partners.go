@@ -7,6 +7,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/pauly7610/studio-pilot-vision/backend/database"
 	"github.com/pauly7610/studio-pilot-vision/backend/models"
+	"gorm.io/gorm"
 )
 
 type PartnersHandler struct{}
@@ -17,7 +18,7 @@ func NewPartnersHandler() *PartnersHandler {
 
 // GetProductPartners retrieves all partners for a product
 func (h *PartnersHandler) GetProductPartners(c *gin.Context) {
-	productID, err := uuid.Parse(c.Param("productId"))
+	productID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
 		return
@@ -37,6 +38,128 @@ func (h *PartnersHandler) GetProductPartners(c *gin.Context) {
 	respondWithData(c, http.StatusOK, partners)
 }
 
+// PartnerEnablement is the share of a product's partners that are enabled,
+// derived from the partner rows
+type PartnerEnablement struct {
+	ProductID  string  `json:"product_id"`
+	Enabled    int64   `json:"enabled"`
+	Total      int64   `json:"total"`
+	EnabledPct float64 `json:"enabled_pct"`
+}
+
+// enabledPct is enabled / total as a 0-100 percentage; no partners is 0%
+func enabledPct(enabled, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return roundTo2(float64(enabled) * 100 / float64(total))
+}
+
+// partnerEnablement counts a product's enabled and total partners
+func partnerEnablement(db *gorm.DB, productID uuid.UUID) (PartnerEnablement, error) {
+	var counts struct {
+		Enabled int64
+		Total   int64
+	}
+	err := db.Model(&models.ProductPartner{}).
+		Select("COALESCE(SUM(CASE WHEN enabled THEN 1 ELSE 0 END), 0) AS enabled, COUNT(*) AS total").
+		Where("product_id = ?", productID).
+		Scan(&counts).Error
+	if err != nil {
+		return PartnerEnablement{}, err
+	}
+
+	return PartnerEnablement{
+		ProductID:  productID.String(),
+		Enabled:    counts.Enabled,
+		Total:      counts.Total,
+		EnabledPct: enabledPct(counts.Enabled, counts.Total),
+	}, nil
+}
+
+// GetPartnerEnablement returns the percentage of a product's partners that
+// are enabled along with the raw counts
+func (h *PartnersHandler) GetPartnerEnablement(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	var product models.Product
+	if result := database.DB.First(&product, "id = ?", productID); result.Error != nil {
+		respondWithError(c, http.StatusNotFound, "Product not found")
+		return
+	}
+
+	enablement, err := partnerEnablement(database.DB, productID)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithData(c, http.StatusOK, enablement)
+}
+
+// PipelineStage counts the partners at one integration status
+type PipelineStage struct {
+	Status models.IntegrationStatus `json:"status"`
+	Count  int64                    `json:"count"`
+	Pct    float64                  `json:"pct"` // share of all partners, 0-100
+}
+
+// PartnerPipeline is the portfolio's partner integration funnel
+type PartnerPipeline struct {
+	Total  int64           `json:"total"`
+	Stages []PipelineStage `json:"stages"` // every status, in funnel order
+}
+
+// partnerPipeline counts the partners of live products by integration
+// status. Partners without a status count as not started.
+func partnerPipeline(db *gorm.DB) (PartnerPipeline, error) {
+	var rows []struct {
+		Status models.IntegrationStatus
+		Count  int64
+	}
+	status := "COALESCE(product_partners.integration_status, '" + string(models.IntegrationStatusNotStarted) + "')"
+	err := db.Model(&models.ProductPartner{}).
+		Select(status+" AS status, COUNT(*) AS count").
+		Joins("JOIN products ON products.id = product_partners.product_id").
+		Scopes(models.ExcludeDrafts, models.ExcludeArchived, models.ExcludeDeleted).
+		Group(status).
+		Scan(&rows).Error
+	if err != nil {
+		return PartnerPipeline{}, err
+	}
+
+	counts := make(map[models.IntegrationStatus]int64, len(rows))
+	pipeline := PartnerPipeline{Stages: []PipelineStage{}}
+	for _, row := range rows {
+		counts[row.Status] += row.Count
+		pipeline.Total += row.Count
+	}
+	for _, status := range models.IntegrationStatuses {
+		pipeline.Stages = append(pipeline.Stages, PipelineStage{
+			Status: status,
+			Count:  counts[status],
+			Pct:    enabledPct(counts[status], pipeline.Total),
+		})
+	}
+	return pipeline, nil
+}
+
+// GetPartnerPipeline returns the funnel of partners by integration status
+// across the portfolio
+func (h *PartnersHandler) GetPartnerPipeline(c *gin.Context) {
+	pipeline, err := partnerPipeline(database.DB)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithData(c, http.StatusOK, pipeline)
+}
+
 // GetPartner retrieves a single partner
 func (h *PartnersHandler) GetPartner(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
@@ -59,8 +182,7 @@ func (h *PartnersHandler) GetPartner(c *gin.Context) {
 // CreatePartner creates a new partner
 func (h *PartnersHandler) CreatePartner(c *gin.Context) {
 	var req models.CreateProductPartnerRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		respondWithError(c, http.StatusBadRequest, err.Error())
+	if !bindRequest(c, &req) {
 		return
 	}
 
@@ -79,6 +201,14 @@ func (h *PartnersHandler) CreatePartner(c *gin.Context) {
 		IntegrationStatus: req.IntegrationStatus,
 		RailType:          req.RailType,
 	}
+	if partner.IntegrationStatus == nil {
+		status := models.IntegrationStatusNotStarted
+		partner.IntegrationStatus = &status
+	}
+	if *partner.IntegrationStatus == models.IntegrationStatusLive && partner.OnboardedDate == nil {
+		today := models.Today()
+		partner.OnboardedDate = &today
+	}
 
 	result := database.DB.Create(&partner)
 	if result.Error != nil {
@@ -104,8 +234,7 @@ func (h *PartnersHandler) UpdatePartner(c *gin.Context) {
 	}
 
 	var req models.UpdateProductPartnerRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		respondWithError(c, http.StatusBadRequest, err.Error())
+	if !bindRequest(c, &req) {
 		return
 	}
 
@@ -121,6 +250,10 @@ func (h *PartnersHandler) UpdatePartner(c *gin.Context) {
 	}
 	if req.IntegrationStatus != nil {
 		updates["integration_status"] = *req.IntegrationStatus
+		// Stamp the onboarded date the first time the partner goes live
+		if *req.IntegrationStatus == models.IntegrationStatusLive && partner.OnboardedDate == nil && req.OnboardedDate == nil {
+			updates["onboarded_date"] = models.Today()
+		}
 	}
 	if req.RailType != nil {
 		updates["rail_type"] = *req.RailType
@@ -162,10 +295,12 @@ func (h *PartnersHandler) GetAllPartners(c *gin.Context) {
 	var partners []models.ProductPartner
 
 	query := database.DB.Order("created_at DESC")
+	meta := newListMeta("-created_at")
 
-	// Optional filtering by enabled status
-	if enabled := c.Query("enabled"); enabled != "" {
-		query = query.Where("enabled = ?", enabled == "true")
+	query, err := applyFilters(query, c.Request.URL.Query(), []queryFilter{{Param: "enabled", Column: "enabled", Kind: filterBool}}, meta)
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
 	}
 
 	result := query.Find(&partners)
@@ -174,5 +309,5 @@ func (h *PartnersHandler) GetAllPartners(c *gin.Context) {
 		return
 	}
 
-	respondWithData(c, http.StatusOK, partners)
+	respondWithList(c, partners, meta)
 }
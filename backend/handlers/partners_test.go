@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+)
+
+func TestPartnerEnablement(t *testing.T) {
+	db := openTestDB(t, `CREATE TABLE product_partners (
+		id TEXT PRIMARY KEY, product_id TEXT NOT NULL, partner_name TEXT NOT NULL,
+		enabled BOOLEAN, onboarded_date DATE, integration_status TEXT, rail_type TEXT,
+		created_at DATETIME, updated_at DATETIME)`)
+
+	product, other, empty := uuid.New(), uuid.New(), uuid.New()
+	for _, row := range []struct {
+		product uuid.UUID
+		enabled interface{}
+	}{
+		{product, true},
+		{product, false},
+		{product, nil},
+		{other, true},
+	} {
+		if err := db.Exec(`INSERT INTO product_partners (id, product_id, partner_name, enabled) VALUES (?, ?, 'Partner', ?)`,
+			uuid.NewString(), row.product, row.enabled).Error; err != nil {
+			t.Fatalf("insert: %v", err)
+		}
+	}
+
+	got, err := partnerEnablement(db, product)
+	if err != nil {
+		t.Fatalf("partnerEnablement: %v", err)
+	}
+	if got.Enabled != 1 || got.Total != 3 || got.EnabledPct != 33.33 {
+		t.Errorf("got %+v, want 1 of 3 enabled (33.33%%); unset counts as not enabled", got)
+	}
+
+	got, err = partnerEnablement(db, empty)
+	if err != nil {
+		t.Fatalf("partnerEnablement: %v", err)
+	}
+	if got.Enabled != 0 || got.Total != 0 || got.EnabledPct != 0 {
+		t.Errorf("no partners: got %+v, want 0%%", got)
+	}
+}
+
+func TestPartnerPipeline(t *testing.T) {
+	db := openTestDB(t,
+		`CREATE TABLE products (id TEXT PRIMARY KEY, is_draft BOOLEAN DEFAULT false, archived_at DATETIME, deleted_at DATETIME)`,
+		`CREATE TABLE product_partners (id TEXT PRIMARY KEY, product_id TEXT NOT NULL, partner_name TEXT, integration_status TEXT)`)
+
+	pipeline, err := partnerPipeline(db)
+	if err != nil {
+		t.Fatalf("partnerPipeline: %v", err)
+	}
+	if pipeline.Total != 0 || len(pipeline.Stages) != len(models.IntegrationStatuses) {
+		t.Fatalf("empty portfolio: got %+v, want every stage at 0", pipeline)
+	}
+
+	live, archived := uuid.New(), uuid.New()
+	db.Exec(`INSERT INTO products (id) VALUES (?)`, live)
+	db.Exec(`INSERT INTO products (id, archived_at) VALUES (?, '2025-01-01')`, archived)
+	for _, row := range []struct {
+		product uuid.UUID
+		status  interface{}
+	}{
+		{live, "live"},
+		{live, "live"},
+		{live, "testing"},
+		{live, nil},
+		{archived, "failed"},
+	} {
+		db.Exec(`INSERT INTO product_partners (id, product_id, partner_name, integration_status) VALUES (?, ?, 'Partner', ?)`,
+			uuid.NewString(), row.product, row.status)
+	}
+
+	pipeline, err = partnerPipeline(db)
+	if err != nil {
+		t.Fatalf("partnerPipeline: %v", err)
+	}
+	want := map[models.IntegrationStatus]int64{
+		models.IntegrationStatusNotStarted: 1,
+		models.IntegrationStatusTesting:    1,
+		models.IntegrationStatusLive:       2,
+	}
+	if pipeline.Total != 4 {
+		t.Errorf("total = %d, want 4 (archived products left out)", pipeline.Total)
+	}
+	for i, stage := range pipeline.Stages {
+		if stage.Status != models.IntegrationStatuses[i] || stage.Count != want[stage.Status] {
+			t.Errorf("stage %d = %+v, want %s with %d", i, stage, models.IntegrationStatuses[i], want[stage.Status])
+		}
+	}
+	if pipeline.Stages[3].Pct != 50 {
+		t.Errorf("live pct = %v, want 50", pipeline.Stages[3].Pct)
+	}
+}
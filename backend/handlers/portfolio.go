@@ -0,0 +1,242 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pauly7610/studio-pilot-vision/backend/config"
+	"github.com/pauly7610/studio-pilot-vision/backend/database"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PortfolioHandler struct {
+	rules config.EscalationRules
+}
+
+func NewPortfolioHandler(rules config.EscalationRules) *PortfolioHandler {
+	return &PortfolioHandler{rules: rules}
+}
+
+// Portfolio risk index = Σ weight × ratio, giving 0-100. Each ratio is 0-1:
+//
+//   - risk_band (35):            (high + 0.5 × medium) / products
+//   - escalations (30):          Σ level weight / (3 × products); ambassador=1, steerco=2, critical=3
+//   - blocked_dependencies (20): total blocked days / (30 × products), capped at 1
+//   - stale_data (15):           (outdated + 0.5 × stale) / products
+const (
+	riskIndexWeightRiskBand   = 35.0
+	riskIndexWeightEscalation = 30.0
+	riskIndexWeightBlocked    = 20.0
+	riskIndexWeightStale      = 15.0
+
+	blockedDaysCeiling = 30.0
+	riskTrendTolerance = 2.0
+)
+
+var escalationRiskWeights = map[models.EscalationLevel]float64{
+	models.EscalationLevelAmbassadorReview: 1,
+	models.EscalationLevelExecSteerCo:      2,
+	models.EscalationLevelCritical:         3,
+}
+
+// RiskIndexComponent is one weighted input to the portfolio risk index
+type RiskIndexComponent struct {
+	Name         string  `json:"name"`
+	Weight       float64 `json:"weight"`
+	Ratio        float64 `json:"ratio"`
+	Contribution float64 `json:"contribution"`
+}
+
+// PortfolioRiskIndex is the headline 0-100 portfolio risk number
+type PortfolioRiskIndex struct {
+	Index        float64              `json:"index"`
+	ProductCount int                  `json:"product_count"`
+	Components   []RiskIndexComponent `json:"components"`
+	Previous     *float64             `json:"previous,omitempty"`
+	PreviousWeek *string              `json:"previous_week,omitempty"`
+	Delta        *float64             `json:"delta,omitempty"`
+	Trend        string               `json:"trend"` // rising, falling, flat, no_history
+}
+
+func roundTo2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// computePortfolioRiskIndex derives the current risk index from live data
+func computePortfolioRiskIndex(rules config.EscalationRules) (PortfolioRiskIndex, error) {
+	var products []models.Product
+	if err := database.DB.Scopes(models.ExcludeDrafts, models.ExcludeArchived).Preload("Readiness").Find(&products).Error; err != nil {
+		return PortfolioRiskIndex{}, err
+	}
+
+	var blockedDeps []models.ProductDependency
+	if err := database.DB.
+		Where("status = ? AND blocked_since IS NOT NULL", models.DependencyStatusBlocked).
+		Find(&blockedDeps).Error; err != nil {
+		return PortfolioRiskIndex{}, err
+	}
+
+	return portfolioRiskIndex(rules, products, blockedDeps, time.Now()), nil
+}
+
+// portfolioRiskIndex weighs the products and blocked dependencies into the
+// risk index. Every ratio is per product, so an empty portfolio scores 0 on
+// each component rather than dividing by zero. Readiness must be preloaded.
+func portfolioRiskIndex(rules config.EscalationRules, products []models.Product, blockedDeps []models.ProductDependency, now time.Time) PortfolioRiskIndex {
+	var riskBand, escalation, stale float64
+	for _, product := range products {
+		level, band, _ := evaluateEscalation(rules, product)
+		switch models.RiskBand(band) {
+		case models.RiskBandHigh:
+			riskBand++
+		case models.RiskBandMedium:
+			riskBand += 0.5
+		}
+		escalation += escalationRiskWeights[level]
+
+		switch status, _, _ := evaluateFreshness(product); status {
+		case FreshnessStatusOutdated:
+			stale++
+		case FreshnessStatusStale:
+			stale += 0.5
+		}
+	}
+
+	var blockedDays float64
+	for _, dep := range blockedDeps {
+		if dep.BlockedSince != nil {
+			blockedDays += now.Sub(dep.BlockedSince.Time).Hours() / 24
+		}
+	}
+
+	ratio := func(v, per float64) float64 {
+		if len(products) == 0 {
+			return 0
+		}
+		return v / (per * float64(len(products)))
+	}
+	ratios := []struct {
+		name   string
+		weight float64
+		ratio  float64
+	}{
+		{"risk_band", riskIndexWeightRiskBand, ratio(riskBand, 1)},
+		{"escalations", riskIndexWeightEscalation, ratio(escalation, 3)},
+		{"blocked_dependencies", riskIndexWeightBlocked, math.Min(ratio(blockedDays, blockedDaysCeiling), 1)},
+		{"stale_data", riskIndexWeightStale, ratio(stale, 1)},
+	}
+
+	index := PortfolioRiskIndex{ProductCount: len(products), Components: []RiskIndexComponent{}}
+	if len(products) == 0 {
+		index.Trend = "no_history"
+	}
+	for _, r := range ratios {
+		contribution := r.weight * r.ratio
+		index.Index += contribution
+		index.Components = append(index.Components, RiskIndexComponent{
+			Name:         r.name,
+			Weight:       r.weight,
+			Ratio:        roundTo2(r.ratio),
+			Contribution: roundTo2(contribution),
+		})
+	}
+	index.Index = roundTo2(index.Index)
+
+	return index
+}
+
+// RecordPortfolioRiskSnapshot computes the index and upserts this ISO week's
+// snapshot. It is run by the scheduler and the admin snapshot endpoint.
+func RecordPortfolioRiskSnapshot(rules config.EscalationRules) (models.PortfolioRiskSnapshot, error) {
+	index, err := computePortfolioRiskIndex(rules)
+	if err != nil {
+		return models.PortfolioRiskSnapshot{}, err
+	}
+
+	year, week := time.Now().UTC().ISOWeek()
+	snapshot := models.PortfolioRiskSnapshot{
+		Index:        index.Index,
+		ProductCount: index.ProductCount,
+		WeekNumber:   week,
+		Year:         year,
+	}
+	for _, component := range index.Components {
+		switch component.Name {
+		case "risk_band":
+			snapshot.RiskBandScore = component.Contribution
+		case "escalations":
+			snapshot.EscalationScore = component.Contribution
+		case "blocked_dependencies":
+			snapshot.BlockedDependencyScore = component.Contribution
+		case "stale_data":
+			snapshot.StaleDataScore = component.Contribution
+		}
+	}
+
+	err = database.DB.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "year"}, {Name: "week_number"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"index", "risk_band_score", "escalation_score",
+			"blocked_dependency_score", "stale_data_score", "product_count", "recorded_at",
+		}),
+	}).Create(&snapshot).Error
+
+	return snapshot, err
+}
+
+// GetRiskIndex returns the current portfolio risk index with its component
+// contributions and the trend versus the most recent prior-week snapshot
+func (h *PortfolioHandler) GetRiskIndex(c *gin.Context) {
+	index, err := computePortfolioRiskIndex(h.rules)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	year, week := time.Now().UTC().ISOWeek()
+	var previous models.PortfolioRiskSnapshot
+	result := database.DB.
+		Where("year < ? OR (year = ? AND week_number < ?)", year, year, week).
+		Order("year DESC, week_number DESC").
+		First(&previous)
+
+	switch {
+	case result.Error == gorm.ErrRecordNotFound:
+		index.Trend = "no_history"
+	case result.Error != nil:
+		respondWithError(c, http.StatusInternalServerError, result.Error.Error())
+		return
+	default:
+		delta := roundTo2(index.Index - previous.Index)
+		label := fmt.Sprintf("%d-W%02d", previous.Year, previous.WeekNumber)
+		index.Previous = &previous.Index
+		index.PreviousWeek = &label
+		index.Delta = &delta
+		switch {
+		case delta > riskTrendTolerance:
+			index.Trend = "rising"
+		case delta < -riskTrendTolerance:
+			index.Trend = "falling"
+		default:
+			index.Trend = "flat"
+		}
+	}
+
+	respondWithData(c, http.StatusOK, index)
+}
+
+// SnapshotRiskIndex records this week's risk index snapshot on demand
+func (h *PortfolioHandler) SnapshotRiskIndex(c *gin.Context) {
+	snapshot, err := RecordPortfolioRiskSnapshot(h.rules)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithData(c, http.StatusOK, snapshot)
+}
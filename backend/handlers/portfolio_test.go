@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pauly7610/studio-pilot-vision/backend/config"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+)
+
+func TestPortfolioRiskIndex_NoProducts(t *testing.T) {
+	now := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	since := models.NewTimestamp(now.AddDate(0, 0, -10))
+	blocked := []models.ProductDependency{{BlockedSince: &since}}
+
+	index := portfolioRiskIndex(config.DefaultEscalationRules(), nil, blocked, now)
+	if index.Index != 0 || index.ProductCount != 0 || index.Trend != "no_history" {
+		t.Errorf("unexpected empty index %+v", index)
+	}
+	if len(index.Components) != 4 {
+		t.Fatalf("expected all 4 components, got %+v", index.Components)
+	}
+	for _, component := range index.Components {
+		if component.Ratio != 0 || component.Contribution != 0 {
+			t.Errorf("component %s = %+v, want zeroed", component.Name, component)
+		}
+	}
+}
+
+func TestPortfolioRiskIndex_SingleProduct(t *testing.T) {
+	now := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	since := models.NewTimestamp(now.AddDate(0, 0, -15))
+	product := models.Product{
+		OwnerEmail: "owner@example.com",
+		UpdatedAt:  models.NewTimestamp(time.Now()),
+		Readiness:  &models.ProductReadiness{RiskBand: models.RiskBandHigh},
+	}
+	blocked := []models.ProductDependency{{BlockedSince: &since}, {}}
+
+	index := portfolioRiskIndex(config.DefaultEscalationRules(), []models.Product{product}, blocked, now)
+	want := map[string]float64{
+		"risk_band":            1,
+		"escalations":          0,
+		"blocked_dependencies": 0.5,
+		"stale_data":           0,
+	}
+	for _, component := range index.Components {
+		if component.Ratio != want[component.Name] {
+			t.Errorf("%s ratio = %v, want %v", component.Name, component.Ratio, want[component.Name])
+		}
+	}
+	if index.Index != 45 {
+		t.Errorf("Index = %v, want 45", index.Index)
+	}
+}
@@ -1,12 +1,16 @@
 package handlers
 
 import (
+	"encoding/json"
+	"errors"
+	"math"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/pauly7610/studio-pilot-vision/backend/database"
 	"github.com/pauly7610/studio-pilot-vision/backend/models"
+	"gorm.io/gorm"
 )
 
 type PredictionsHandler struct{}
@@ -17,7 +21,7 @@ func NewPredictionsHandler() *PredictionsHandler {
 
 // GetProductPrediction retrieves the latest prediction for a product
 func (h *PredictionsHandler) GetProductPrediction(c *gin.Context) {
-	productID, err := uuid.Parse(c.Param("productId"))
+	productID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
 		return
@@ -39,7 +43,7 @@ func (h *PredictionsHandler) GetProductPrediction(c *gin.Context) {
 
 // GetProductPredictionHistory retrieves all predictions for a product
 func (h *PredictionsHandler) GetProductPredictionHistory(c *gin.Context) {
-	productID, err := uuid.Parse(c.Param("productId"))
+	productID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
 		return
@@ -66,6 +70,10 @@ func (h *PredictionsHandler) CreatePrediction(c *gin.Context) {
 		respondWithError(c, http.StatusBadRequest, err.Error())
 		return
 	}
+	if err := req.Normalize(); err != nil {
+		respondWithError(c, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
 
 	// Verify product exists
 	var product models.Product
@@ -111,6 +119,10 @@ func (h *PredictionsHandler) UpdatePrediction(c *gin.Context) {
 		respondWithError(c, http.StatusBadRequest, err.Error())
 		return
 	}
+	if err := req.Normalize(); err != nil {
+		respondWithError(c, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
 
 	updates := make(map[string]interface{})
 	if req.SuccessProbability != nil {
@@ -173,5 +185,120 @@ func (h *PredictionsHandler) GetAllPredictions(c *gin.Context) {
 		return
 	}
 
-	respondWithData(c, http.StatusOK, predictions)
+	respondWithList(c, predictions, newListMeta("-scored_at"))
+}
+
+// PredictionFeatures is the model input assembled from a product's current
+// state. Inputs the product has no data for are null rather than zero so the
+// scoring job can tell "missing" from "bad".
+type PredictionFeatures struct {
+	// ReadinessScore is the readiness score scaled to 0-1
+	ReadinessScore *float64 `json:"readiness_score"`
+	// TrainingCoverage is trained reps / total reps, 0-1
+	TrainingCoverage *float64 `json:"training_coverage"`
+	// BlockedDependencies counts dependencies currently blocked
+	BlockedDependencies int `json:"blocked_dependencies"`
+	// FeedbackSentiment is the mean scored feedback sentiment, -1 to 1
+	FeedbackSentiment *float64 `json:"feedback_sentiment"`
+	// DaysInGatingStatus is whole days since the gating status last changed
+	DaysInGatingStatus *int `json:"days_in_gating_status"`
+}
+
+// PredictionFeaturesResponse has the product_id and features fields of
+// CreateProductPredictionRequest, so a scoring job can add its probabilities
+// and model_version and POST it back
+type PredictionFeaturesResponse struct {
+	ProductID uuid.UUID       `json:"product_id"`
+	Features  json.RawMessage `json:"features"`
+}
+
+func roundTo4(v float64) float64 {
+	return math.Round(v*10000) / 10000
+}
+
+// predictionFeatures gathers the feature vector for product as of today
+func predictionFeatures(db *gorm.DB, product models.Product, today models.Date) (PredictionFeatures, error) {
+	var features PredictionFeatures
+
+	var readiness models.ProductReadiness
+	err := db.Where("product_id = ?", product.ID).First(&readiness).Error
+	switch {
+	case err == nil:
+		score := roundTo4(readiness.ReadinessScore / 100)
+		features.ReadinessScore = &score
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return features, err
+	}
+
+	var training models.SalesTraining
+	err = db.Where("product_id = ?", product.ID).First(&training).Error
+	switch {
+	case err == nil:
+		if training.TotalReps > 0 {
+			coverage := roundTo4(float64(training.TrainedReps) / float64(training.TotalReps))
+			features.TrainingCoverage = &coverage
+		}
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return features, err
+	}
+
+	var blocked int64
+	if err := db.Model(&models.ProductDependency{}).
+		Where("product_id = ? AND status = ?", product.ID, models.DependencyStatusBlocked).
+		Count(&blocked).Error; err != nil {
+		return features, err
+	}
+	features.BlockedDependencies = int(blocked)
+
+	var sentiment struct {
+		Mean  *float64
+		Count int64
+	}
+	if err := db.Model(&models.ProductFeedback{}).
+		Select("AVG(sentiment_score) AS mean, COUNT(sentiment_score) AS count").
+		Where("product_id = ?", product.ID).
+		Scan(&sentiment).Error; err != nil {
+		return features, err
+	}
+	if sentiment.Count > 0 && sentiment.Mean != nil {
+		mean := roundTo4(*sentiment.Mean)
+		features.FeedbackSentiment = &mean
+	}
+
+	if product.GatingStatusSince != nil {
+		days := int(today.Sub(models.NewDate(product.GatingStatusSince.Time).Time).Hours() / 24)
+		features.DaysInGatingStatus = &days
+	}
+
+	return features, nil
+}
+
+// GetPredictionFeatures assembles the product's current prediction features in
+// the shape CreatePrediction accepts
+func (h *PredictionsHandler) GetPredictionFeatures(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	var product models.Product
+	if result := database.DB.First(&product, "id = ?", productID); result.Error != nil {
+		respondWithError(c, http.StatusNotFound, "Product not found")
+		return
+	}
+
+	features, err := predictionFeatures(database.DB, product, models.Today())
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	raw, err := json.Marshal(features)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithData(c, http.StatusOK, PredictionFeaturesResponse{ProductID: product.ID, Features: raw})
 }
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+)
+
+func TestPredictionFeatures(t *testing.T) {
+	db := openTestDB(t,
+		`CREATE TABLE product_readiness (id TEXT, product_id TEXT, readiness_score REAL, risk_band TEXT)`,
+		`CREATE TABLE sales_trainings (id TEXT, product_id TEXT, total_reps INTEGER, trained_reps INTEGER)`,
+		`CREATE TABLE product_dependencies (id TEXT, product_id TEXT, status TEXT)`,
+		`CREATE TABLE product_feedback (id TEXT, product_id TEXT, sentiment_score REAL)`,
+	)
+	id, other := uuid.New(), uuid.New()
+	stmts := []struct {
+		sql  string
+		args []interface{}
+	}{
+		{`INSERT INTO product_readiness VALUES (?, ?, 72.5, 'medium')`, []interface{}{uuid.NewString(), id}},
+		{`INSERT INTO sales_trainings VALUES (?, ?, 30, 20)`, []interface{}{uuid.NewString(), id}},
+		{`INSERT INTO product_dependencies VALUES (?, ?, 'blocked'), (?, ?, 'blocked'), (?, ?, 'resolved'), (?, ?, 'blocked')`,
+			[]interface{}{uuid.NewString(), id, uuid.NewString(), id, uuid.NewString(), id, uuid.NewString(), other}},
+		{`INSERT INTO product_feedback VALUES (?, ?, 0.5), (?, ?, -0.2), (?, ?, NULL)`,
+			[]interface{}{uuid.NewString(), id, uuid.NewString(), id, uuid.NewString(), id}},
+	}
+	for _, stmt := range stmts {
+		if err := db.Exec(stmt.sql, stmt.args...).Error; err != nil {
+			t.Fatalf("insert: %v", err)
+		}
+	}
+
+	since := models.NewTimestamp(time.Date(2025, 2, 13, 16, 30, 0, 0, time.UTC))
+	features, err := predictionFeatures(db, models.Product{ID: id, GatingStatusSince: &since}, mustDate(t, "2025-03-15"))
+	if err != nil {
+		t.Fatalf("predictionFeatures: %v", err)
+	}
+
+	raw, _ := json.Marshal(features)
+	want := `{"readiness_score":0.725,"training_coverage":0.6667,"blocked_dependencies":2,"feedback_sentiment":0.15,"days_in_gating_status":30}`
+	if string(raw) != want {
+		t.Errorf("features = %s, want %s", raw, want)
+	}
+
+	// A product with no data reports nulls rather than zeros
+	features, err = predictionFeatures(db, models.Product{ID: uuid.New()}, mustDate(t, "2025-03-15"))
+	if err != nil {
+		t.Fatalf("predictionFeatures: %v", err)
+	}
+	raw, _ = json.Marshal(features)
+	want = `{"readiness_score":null,"training_coverage":null,"blocked_dependencies":0,"feedback_sentiment":null,"days_in_gating_status":null}`
+	if string(raw) != want {
+		t.Errorf("empty features = %s, want %s", raw, want)
+	}
+}
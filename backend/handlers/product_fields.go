@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"encoding/json"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+	"gorm.io/gorm"
+)
+
+// productColumns and productAssociations map the product's top-level JSON
+// fields to its struct fields; associations are the ones loaded by Preload
+var productColumns, productAssociations = func() (map[string]bool, map[string]string) {
+	columns, associations := map[string]bool{}, map[string]string{}
+	t := reflect.TypeOf(models.Product{})
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		if strings.Contains(field.Tag.Get("gorm"), "foreignKey") {
+			associations[name] = field.Name
+		} else {
+			columns[name] = true
+		}
+	}
+	return columns, associations
+}()
+
+// productDetailPreloads and productListPreloads are the associations the
+// product endpoints load, by JSON field name
+var (
+	productDetailPreloads = []string{
+		"readiness", "prediction", "compliance", "market_evidence", "partners", "training",
+		"feedback", "actions", "metrics", "dependencies", "readiness_history",
+	}
+	productListPreloads = []string{
+		"readiness", "prediction", "compliance", "market_evidence", "partners", "feedback", "dependencies",
+	}
+)
+
+// parseProductFields reads ?fields=, a comma-separated list of top-level
+// product fields. Allowed are the product's own fields and the associations
+// the endpoint loads. It returns nil when the parameter is absent, meaning
+// the full response; id is always included.
+func parseProductFields(c *gin.Context, preloads []string) ([]string, error) {
+	raw, ok := c.GetQuery("fields")
+	if !ok {
+		return nil, nil
+	}
+
+	selected := map[string]bool{"id": true}
+	var unknown []string
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !productColumns[name] && !slices.Contains(preloads, name) {
+			unknown = append(unknown, name)
+			continue
+		}
+		selected[name] = true
+	}
+	if len(unknown) > 0 {
+		allowed := slices.Sorted(maps.Keys(productColumns))
+		allowed = append(allowed, preloads...)
+		return nil, &fieldsError{unknown: unknown, allowed: allowed}
+	}
+
+	return slices.Sorted(maps.Keys(selected)), nil
+}
+
+type fieldsError struct {
+	unknown, allowed []string
+}
+
+func (e *fieldsError) Error() string {
+	return "Unknown fields: " + strings.Join(e.unknown, ", ") + ". Allowed: " + strings.Join(e.allowed, ", ")
+}
+
+// preloadProducts adds the endpoint's preloads to query, skipping the
+// associations a field selection leaves out
+func preloadProducts(query *gorm.DB, preloads, fields []string) *gorm.DB {
+	for _, name := range preloads {
+		if fields == nil || slices.Contains(fields, name) {
+			query = query.Preload(productAssociations[name])
+		}
+	}
+	return query
+}
+
+// selectProductFields projects a product, or a slice of products, onto the
+// selected top-level fields. nil fields leaves v whole.
+func selectProductFields(v interface{}, fields []string) (interface{}, error) {
+	if fields == nil {
+		return v, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice {
+		var items []map[string]json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil, err
+		}
+		for i := range items {
+			items[i] = projectFields(items[i], fields)
+		}
+		return items, nil
+	}
+
+	var item map[string]json.RawMessage
+	if err := json.Unmarshal(data, &item); err != nil {
+		return nil, err
+	}
+	return projectFields(item, fields), nil
+}
+
+func projectFields(item map[string]json.RawMessage, fields []string) map[string]json.RawMessage {
+	projected := make(map[string]json.RawMessage, len(fields))
+	for _, name := range fields {
+		if value, ok := item[name]; ok {
+			projected[name] = value
+		}
+	}
+	return projected
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+)
+
+func TestParseProductFields(t *testing.T) {
+	tests := []struct {
+		query   string
+		want    []string
+		wantErr bool
+	}{
+		{"", nil, false},
+		{"?fields=name,region,readiness", []string{"id", "name", "readiness", "region"}, false},
+		{"?fields=%20name%20,,region", []string{"id", "name", "region"}, false},
+		{"?fields=name,actions", nil, true}, // actions are not loaded by the list
+		{"?fields=name,secret", nil, true},
+	}
+	for _, tt := range tests {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest("GET", "/products"+tt.query, nil)
+		got, err := parseProductFields(c, productListPreloads)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%q: err = %v, wantErr %v", tt.query, err, tt.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%q: fields = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestSelectProductFields(t *testing.T) {
+	products := []models.Product{
+		{ID: uuid.New(), Name: "Tap to Pay", Region: "EMEA", OwnerEmail: "owner@example.com",
+			Readiness: &models.ProductReadiness{RiskBand: models.RiskBandHigh}},
+	}
+
+	data, err := selectProductFields(products, []string{"id", "name", "readiness"})
+	if err != nil {
+		t.Fatalf("selectProductFields: %v", err)
+	}
+	out, _ := json.Marshal(data)
+	var got []map[string]interface{}
+	json.Unmarshal(out, &got)
+
+	if len(got) != 1 || len(got[0]) != 3 {
+		t.Fatalf("got %v, want id, name and readiness only", got)
+	}
+	if got[0]["name"] != "Tap to Pay" {
+		t.Errorf("name = %v", got[0]["name"])
+	}
+	if readiness, _ := got[0]["readiness"].(map[string]interface{}); readiness["risk_band"] != "high" {
+		t.Errorf("readiness = %v", got[0]["readiness"])
+	}
+
+	whole, _ := selectProductFields(products[0], nil)
+	if _, ok := whole.(models.Product); !ok {
+		t.Errorf("without fields got %T, want the product unchanged", whole)
+	}
+}
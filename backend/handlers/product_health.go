@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/pauly7610/studio-pilot-vision/backend/config"
+	"github.com/pauly7610/studio-pilot-vision/backend/database"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+	"gorm.io/gorm"
+)
+
+type ProductHealthHandler struct {
+	rules config.EscalationRules
+}
+
+func NewProductHealthHandler(rules config.EscalationRules) *ProductHealthHandler {
+	return &ProductHealthHandler{rules: rules}
+}
+
+// Product health = Σ weight × component score, giving 0-100. Each component
+// scores 0-100, higher being healthier:
+//
+//   - readiness (40):    the readiness score
+//   - sentiment (20):    (mean feedback sentiment + 1) × 50
+//   - escalation (20):   100 less a third per escalation level; ambassador=1, steerco=2, critical=3
+//   - dependencies (20): 100 less 25 per blocked and 5 per pending dependency, floored at 0
+//
+// A product with no readiness record or no scored feedback leaves that
+// component out; the remaining weights are scaled up to still total 100.
+const (
+	healthWeightReadiness    = 40.0
+	healthWeightSentiment    = 20.0
+	healthWeightEscalation   = 20.0
+	healthWeightDependencies = 20.0
+
+	healthBlockedPenalty = 25.0
+	healthPendingPenalty = 5.0
+)
+
+// Health bands reuse the readiness risk thresholds: below HighRiskBelow is
+// red, below MediumRiskBelow amber, anything else green
+const (
+	HealthBandGreen = "green"
+	HealthBandAmber = "amber"
+	HealthBandRed   = "red"
+)
+
+// HealthInputs are the raw signals a health score is built from
+type HealthInputs struct {
+	ReadinessScore      *float64 // nil without a readiness record
+	Sentiment           *float64 // mean of -1 to 1, nil without scored feedback
+	EscalationLevel     models.EscalationLevel
+	BlockedDependencies int
+	PendingDependencies int
+}
+
+// HealthComponent is one weighted input to a product's health score. Score
+// is null and Contribution 0 when the product has no data for it.
+type HealthComponent struct {
+	Name          string   `json:"name"`
+	Weight        float64  `json:"weight"`         // documented weight
+	AppliedWeight float64  `json:"applied_weight"` // weight after missing components are left out
+	Score         *float64 `json:"score"`
+	Contribution  float64  `json:"contribution"`
+}
+
+// ProductHealth is a product's 0-100 composite health score
+type ProductHealth struct {
+	ProductID  uuid.UUID         `json:"product_id"`
+	Score      float64           `json:"score"`
+	Band       string            `json:"band"` // green, amber, red
+	Components []HealthComponent `json:"components"`
+}
+
+// HealthBandForScore maps a health score onto its band
+func HealthBandForScore(score float64) string {
+	switch {
+	case score < models.HighRiskBelow:
+		return HealthBandRed
+	case score < models.MediumRiskBelow:
+		return HealthBandAmber
+	}
+	return HealthBandGreen
+}
+
+// computeProductHealth blends the inputs into a composite score
+func computeProductHealth(inputs HealthInputs) ProductHealth {
+	var readiness, sentiment *float64
+	if inputs.ReadinessScore != nil {
+		score := math.Min(math.Max(*inputs.ReadinessScore, 0), 100)
+		readiness = &score
+	}
+	if inputs.Sentiment != nil {
+		score := (math.Min(math.Max(*inputs.Sentiment, -1), 1) + 1) * 50
+		sentiment = &score
+	}
+	escalation := 100 - 100*escalationRiskWeights[inputs.EscalationLevel]/3
+	dependencies := math.Max(0, 100-
+		healthBlockedPenalty*float64(inputs.BlockedDependencies)-
+		healthPendingPenalty*float64(inputs.PendingDependencies))
+
+	components := []struct {
+		name   string
+		weight float64
+		score  *float64
+	}{
+		{"readiness", healthWeightReadiness, readiness},
+		{"sentiment", healthWeightSentiment, sentiment},
+		{"escalation", healthWeightEscalation, &escalation},
+		{"dependencies", healthWeightDependencies, &dependencies},
+	}
+
+	var available float64
+	for _, component := range components {
+		if component.score != nil {
+			available += component.weight
+		}
+	}
+
+	health := ProductHealth{Components: []HealthComponent{}}
+	for _, component := range components {
+		result := HealthComponent{Name: component.name, Weight: component.weight}
+		if component.score != nil {
+			score := roundTo2(*component.score)
+			applied := component.weight * 100 / available
+			contribution := applied * *component.score / 100
+			health.Score += contribution
+			result.Score = &score
+			result.AppliedWeight = roundTo2(applied)
+			result.Contribution = roundTo2(contribution)
+		}
+		health.Components = append(health.Components, result)
+	}
+	health.Score = roundTo2(health.Score)
+	health.Band = HealthBandForScore(health.Score)
+	return health
+}
+
+// productHealthInputs gathers the product's current health signals.
+// Readiness must be preloaded.
+func productHealthInputs(db *gorm.DB, rules config.EscalationRules, product models.Product) (HealthInputs, error) {
+	var inputs HealthInputs
+	if product.Readiness != nil {
+		score := product.Readiness.ReadinessScore
+		inputs.ReadinessScore = &score
+	}
+	inputs.EscalationLevel, _, _ = evaluateEscalation(rules, product)
+
+	var sentiment struct {
+		Mean  *float64
+		Count int64
+	}
+	if err := db.Model(&models.ProductFeedback{}).
+		Select("AVG(sentiment_score) AS mean, COUNT(sentiment_score) AS count").
+		Where("product_id = ?", product.ID).
+		Scan(&sentiment).Error; err != nil {
+		return inputs, err
+	}
+	if sentiment.Count > 0 {
+		inputs.Sentiment = sentiment.Mean
+	}
+
+	var statuses []struct {
+		Status models.DependencyStatus
+		Count  int
+	}
+	if err := db.Model(&models.ProductDependency{}).
+		Select("status, COUNT(*) AS count").
+		Where("product_id = ?", product.ID).
+		Group("status").
+		Scan(&statuses).Error; err != nil {
+		return inputs, err
+	}
+	for _, s := range statuses {
+		switch s.Status {
+		case models.DependencyStatusBlocked:
+			inputs.BlockedDependencies = s.Count
+		case models.DependencyStatusPending:
+			inputs.PendingDependencies = s.Count
+		}
+	}
+
+	return inputs, nil
+}
+
+// GetProductHealth returns the product's composite health score with its
+// band and the component breakdown behind it
+func (h *ProductHealthHandler) GetProductHealth(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	var product models.Product
+	if result := database.DB.Preload("Readiness").First(&product, "id = ?", productID); result.Error != nil {
+		respondWithError(c, http.StatusNotFound, "Product not found")
+		return
+	}
+
+	inputs, err := productHealthInputs(database.DB, h.rules, product)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	health := computeProductHealth(inputs)
+	health.ProductID = product.ID
+	respondWithData(c, http.StatusOK, health)
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+)
+
+func TestComputeProductHealth(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name   string
+		inputs HealthInputs
+		score  float64
+		band   string
+	}{
+		{"all healthy", HealthInputs{ReadinessScore: f(100), Sentiment: f(1)}, 100, HealthBandGreen},
+		{
+			"weighted blend",
+			// 40×0.8 + 20×0.75 + 20×(2/3) + 20×0.7
+			HealthInputs{ReadinessScore: f(80), Sentiment: f(0.5), EscalationLevel: models.EscalationLevelAmbassadorReview, BlockedDependencies: 1, PendingDependencies: 1},
+			74.33, HealthBandGreen,
+		},
+		{
+			"critical with heavy blocking",
+			HealthInputs{ReadinessScore: f(30), Sentiment: f(-0.5), EscalationLevel: models.EscalationLevelCritical, BlockedDependencies: 5},
+			17, HealthBandRed,
+		},
+		// Without sentiment the other 80 points are scaled to 100:
+		// 50×0.6 + 25×1 + 25×1
+		{"no feedback", HealthInputs{ReadinessScore: f(60)}, 80, HealthBandGreen},
+		// Only escalation and dependencies remain, at 50 each: 50×(1/3) + 50×0.5
+		{"no readiness or feedback", HealthInputs{EscalationLevel: models.EscalationLevelExecSteerCo, BlockedDependencies: 2}, 41.67, HealthBandAmber},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := computeProductHealth(tt.inputs)
+			if got.Score != tt.score || got.Band != tt.band {
+				t.Errorf("got %v (%s), want %v (%s)", got.Score, got.Band, tt.score, tt.band)
+			}
+			if len(got.Components) != 4 {
+				t.Fatalf("got %d components, want 4", len(got.Components))
+			}
+			var applied, contributions float64
+			for _, component := range got.Components {
+				applied += component.AppliedWeight
+				contributions += component.Contribution
+				if component.Score == nil && (component.AppliedWeight != 0 || component.Contribution != 0) {
+					t.Errorf("missing component %s still weighted: %+v", component.Name, component)
+				}
+			}
+			if roundTo2(applied) != 100 {
+				t.Errorf("applied weights total %v, want 100", applied)
+			}
+			if diff := contributions - got.Score; diff > 0.05 || diff < -0.05 {
+				t.Errorf("contributions total %v, score %v", contributions, got.Score)
+			}
+		})
+	}
+}
+
+func TestHealthBandForScore(t *testing.T) {
+	for score, want := range map[float64]string{0: HealthBandRed, 39.99: HealthBandRed, 40: HealthBandAmber, 69.99: HealthBandAmber, 70: HealthBandGreen} {
+		if got := HealthBandForScore(score); got != want {
+			t.Errorf("HealthBandForScore(%v) = %s, want %s", score, got, want)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/pauly7610/studio-pilot-vision/backend/database"
+	"github.com/pauly7610/studio-pilot-vision/backend/middleware"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+	"gorm.io/gorm"
+)
+
+var (
+	errTooManyTags = fmt.Errorf("a product may have at most %d tags", models.MaxProductTags)
+	errTagNotFound = errors.New("product does not have that tag")
+)
+
+// tagProduct adds the normalized tags the product does not have yet,
+// returning the ones added. Nothing is written when there are none. The
+// write only lands while the product is still at the version it was loaded
+// at, so concurrent tag changes get errVersionConflict rather than one
+// silently dropping the other's tags.
+func tagProduct(db *gorm.DB, product *models.Product, tags models.TagArray) (models.TagArray, error) {
+	merged := append(models.TagArray{}, product.Tags...)
+	added := models.TagArray{}
+	for _, tag := range tags {
+		if !merged.Contains(tag) {
+			merged = append(merged, tag)
+			added = append(added, tag)
+		}
+	}
+	if len(added) == 0 {
+		return added, nil
+	}
+	if len(merged) > models.MaxProductTags {
+		return nil, errTooManyTags
+	}
+
+	expected := product.Version
+	if err := versionedUpdates(db, product, &expected, map[string]interface{}{"tags": merged}); err != nil {
+		return nil, err
+	}
+	product.Tags = merged
+	return added, nil
+}
+
+// untagProduct removes a normalized tag from the product, with the same
+// version check as tagProduct
+func untagProduct(db *gorm.DB, product *models.Product, tag string) error {
+	if !product.Tags.Contains(tag) {
+		return errTagNotFound
+	}
+	remaining := models.TagArray{}
+	for _, existing := range product.Tags {
+		if existing != tag {
+			remaining = append(remaining, existing)
+		}
+	}
+
+	expected := product.Version
+	if err := versionedUpdates(db, product, &expected, map[string]interface{}{"tags": remaining}); err != nil {
+		return err
+	}
+	product.Tags = remaining
+	return nil
+}
+
+// tagFilter reads ?tag=, given as a comma-separated list, repeated, or both.
+// A product must carry every listed tag to match.
+func tagFilter(params url.Values) (models.TagArray, error) {
+	var raw []string
+	for _, value := range params["tag"] {
+		for _, tag := range strings.Split(value, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				raw = append(raw, tag)
+			}
+		}
+	}
+	return models.NormalizeTags(raw)
+}
+
+// AddProductTags adds tags to a product; tags it already has are left alone
+func (h *ProductHandler) AddProductTags(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	var req models.ProductTagsRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+	tags, err := models.NormalizeTags(req.Tags)
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var product models.Product
+	if result := database.DB.First(&product, "id = ?", id); result.Error != nil {
+		respondWithError(c, http.StatusNotFound, "Product not found")
+		return
+	}
+
+	loaded := product.Version
+	added, err := tagProduct(database.DB, &product, tags)
+	if errors.Is(err, errTooManyTags) {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, errVersionConflict) {
+		respondVersionConflict(c, "Product", loaded)
+		return
+	}
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if len(added) > 0 {
+		middleware.LogAdminAction(c, "Product tagged", productAuditDetails(product, map[string]interface{}{"tags": added}))
+	}
+
+	respondWithData(c, http.StatusOK, product)
+}
+
+// RemoveProductTag removes one tag from a product
+func (h *ProductHandler) RemoveProductTag(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+	tag, err := models.NormalizeTag(c.Param("tag"))
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var product models.Product
+	if result := database.DB.First(&product, "id = ?", id); result.Error != nil {
+		respondWithError(c, http.StatusNotFound, "Product not found")
+		return
+	}
+
+	loaded := product.Version
+	err = untagProduct(database.DB, &product, tag)
+	if errors.Is(err, errTagNotFound) {
+		respondWithError(c, http.StatusNotFound, "Product is not tagged "+tag)
+		return
+	}
+	if errors.Is(err, errVersionConflict) {
+		respondVersionConflict(c, "Product", loaded)
+		return
+	}
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	middleware.LogAdminAction(c, "Product untagged", productAuditDetails(product, map[string]interface{}{"tag": tag}))
+
+	respondWithData(c, http.StatusOK, product)
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+)
+
+const taggedProductID = "8d1e5a42-1f6b-4c55-9b39-0a4c1b0e0001"
+
+// taggedProductDDL holds the product columns tagging touches and one product
+// already tagged board-watch
+var taggedProductDDL = []string{
+	`CREATE TABLE products (
+		id TEXT PRIMARY KEY, name TEXT, tags TEXT NOT NULL DEFAULT '{}',
+		version INTEGER NOT NULL DEFAULT 1, updated_at DATETIME, deleted_at DATETIME)`,
+	`INSERT INTO products (id, name, tags) VALUES ('` + taggedProductID + `', 'Wallet', '{board-watch}')`,
+}
+
+func TestTagProduct(t *testing.T) {
+	db := openTestDB(t, taggedProductDDL...)
+	load := func(t *testing.T) models.Product {
+		t.Helper()
+		var product models.Product
+		if err := db.First(&product, "id = ?", taggedProductID).Error; err != nil {
+			t.Fatalf("load: %v", err)
+		}
+		return product
+	}
+
+	product := load(t)
+	added, err := tagProduct(db, &product, models.TagArray{"board-watch", "2024-initiative"})
+	if err != nil {
+		t.Fatalf("tagProduct: %v", err)
+	}
+	if !reflect.DeepEqual(added, models.TagArray{"2024-initiative"}) {
+		t.Errorf("added = %v, want only the new tag", added)
+	}
+	stored := load(t)
+	if !reflect.DeepEqual(stored.Tags, models.TagArray{"board-watch", "2024-initiative"}) || stored.Version != 2 {
+		t.Errorf("stored tags = %v at version %d", stored.Tags, stored.Version)
+	}
+
+	if added, err := tagProduct(db, &stored, models.TagArray{"board-watch"}); err != nil || len(added) != 0 {
+		t.Errorf("retag = %v, %v; want nothing added", added, err)
+	}
+	if load(t).Version != 2 {
+		t.Error("retagging wrote the product")
+	}
+
+	many := models.TagArray{}
+	for i := 0; i < models.MaxProductTags; i++ {
+		many = append(many, "tag-"+string(rune('a'+i)))
+	}
+	if _, err := tagProduct(db, &stored, many); !errors.Is(err, errTooManyTags) {
+		t.Errorf("over the limit: %v, want errTooManyTags", err)
+	}
+
+	if err := untagProduct(db, &stored, "board-watch"); err != nil {
+		t.Fatalf("untagProduct: %v", err)
+	}
+	if tags := load(t).Tags; !reflect.DeepEqual(tags, models.TagArray{"2024-initiative"}) {
+		t.Errorf("after untag = %v", tags)
+	}
+	if err := untagProduct(db, &stored, "board-watch"); !errors.Is(err, errTagNotFound) {
+		t.Errorf("untag missing: %v, want errTagNotFound", err)
+	}
+}
+
+func TestTagFilter(t *testing.T) {
+	tests := []struct {
+		query   string
+		want    models.TagArray
+		wantErr bool
+	}{
+		{"", models.TagArray{}, false},
+		{"tag=", models.TagArray{}, false},
+		{"tag=Board-Watch", models.TagArray{"board-watch"}, false},
+		{"tag=board-watch,+2024-initiative&tag=board-watch&tag=q3", models.TagArray{"board-watch", "2024-initiative", "q3"}, false},
+		{"tag=board_watch", nil, true},
+	}
+	for _, tt := range tests {
+		params, _ := url.ParseQuery(tt.query)
+		got, err := tagFilter(params)
+		if (err != nil) != tt.wantErr || !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("tagFilter(%q) = %v, %v; want %v", tt.query, got, err, tt.want)
+		}
+	}
+}
+
+func TestTagProduct_ConcurrentChangeConflicts(t *testing.T) {
+	db := openTestDB(t, taggedProductDDL...)
+	var first, second models.Product
+	db.First(&first, "id = ?", taggedProductID)
+	db.First(&second, "id = ?", taggedProductID)
+
+	if _, err := tagProduct(db, &first, models.TagArray{"emea"}); err != nil {
+		t.Fatalf("first tag: %v", err)
+	}
+	if _, err := tagProduct(db, &second, models.TagArray{"apac"}); !errors.Is(err, errVersionConflict) {
+		t.Errorf("stale tag: %v, want errVersionConflict", err)
+	}
+	if err := untagProduct(db, &second, "board-watch"); !errors.Is(err, errVersionConflict) {
+		t.Errorf("stale untag: %v, want errVersionConflict", err)
+	}
+
+	var stored models.Product
+	db.First(&stored, "id = ?", taggedProductID)
+	if !reflect.DeepEqual(stored.Tags, models.TagArray{"board-watch", "emea"}) {
+		t.Errorf("stored tags = %v, want the first change kept", stored.Tags)
+	}
+}
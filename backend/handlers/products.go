@@ -1,32 +1,216 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/pauly7610/studio-pilot-vision/backend/database"
+	"github.com/pauly7610/studio-pilot-vision/backend/middleware"
 	"github.com/pauly7610/studio-pilot-vision/backend/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-type ProductHandler struct{}
+type ProductHandler struct {
+	// confirmationSecret signs delete confirmation tokens
+	confirmationSecret string
 
-func NewProductHandler() *ProductHandler {
-	return &ProductHandler{}
+	// staleDays is the default inactivity window for GetStaleProducts
+	staleDays int
 }
 
-// GetProducts retrieves all products with related data
+func NewProductHandler(confirmationSecret string, staleDays int) *ProductHandler {
+	return &ProductHandler{confirmationSecret: confirmationSecret, staleDays: staleDays}
+}
+
+// errProductNotFound is returned by shared create helpers when the referenced
+// product does not exist
+var errProductNotFound = errors.New("product not found")
+
+// createForProduct runs create in a transaction that holds a share lock on
+// the product, so a concurrent delete waits for the child row to commit
+// rather than leaving it orphaned. The product is checked again before
+// commit for databases without row locks. errProductNotFound is returned,
+// and nothing written, if the product is missing or deleted at either point.
+func createForProduct(db *gorm.DB, productID uuid.UUID, create func(tx *gorm.DB) error) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var product models.Product
+		err := tx.Clauses(clause.Locking{Strength: "SHARE"}).Select("id").First(&product, "id = ?", productID).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errProductNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := create(tx); err != nil {
+			return err
+		}
+
+		var live int64
+		if err := tx.Model(&models.Product{}).Where("id = ?", productID).Count(&live).Error; err != nil {
+			return err
+		}
+		if live == 0 {
+			return errProductNotFound
+		}
+		return nil
+	})
+}
+
+// errOutsideRegion is returned when a region-scoped caller asks for a product
+// in another region
+var errOutsideRegion = errors.New("product is outside your region")
+
+// regionScoped limits a product query to the caller's region on
+// region-scoped routes and leaves it untouched elsewhere
+func regionScoped(c *gin.Context) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if region, ok := scopedRegion(c); ok {
+			return db.Where("products.region = ?", region)
+		}
+		return db
+	}
+}
+
+// checkProductRegion returns errOutsideRegion when the caller is
+// region-scoped and the product belongs to another region. A missing product
+// is left for the caller's own lookup to report.
+func checkProductRegion(c *gin.Context, db *gorm.DB, id uuid.UUID) error {
+	region, ok := scopedRegion(c)
+	if !ok {
+		return nil
+	}
+	var regions []string
+	if err := db.Model(&models.Product{}).Where("id = ?", id).Pluck("region", &regions).Error; err != nil {
+		return err
+	}
+	if len(regions) == 1 && regions[0] != region {
+		return errOutsideRegion
+	}
+	return nil
+}
+
+// respondRegionError answers a checkProductRegion failure and reports whether
+// there was one
+func respondRegionError(c *gin.Context, err error) bool {
+	switch {
+	case err == nil:
+		return false
+	case errors.Is(err, errOutsideRegion):
+		respondWithError(c, http.StatusForbidden, "Product is outside your region")
+	default:
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+	}
+	return true
+}
+
+// RequireProductRegion stops a region-scoped caller from reading another
+// region's product through its child routes. Mount it after
+// middleware.RegionScope on routes whose :id is a product ID; an invalid or
+// unknown ID is left for the handler to report.
+func RequireProductRegion(c *gin.Context) {
+	if id, err := uuid.Parse(c.Param("id")); err == nil {
+		if respondRegionError(c, checkProductRegion(c, database.DB, id)) {
+			c.Abort()
+			return
+		}
+	}
+	c.Next()
+}
+
+// productFilters are GetProducts' equality filters
+var productFilters = []queryFilter{
+	{Param: "region", Column: "products.region", Multi: true},
+	{Param: "lifecycle_stage", Column: "products.lifecycle_stage", Multi: true, Check: enumCheck(models.LifecycleStage.Check)},
+	{Param: "product_type", Column: "products.product_type", Multi: true, Check: enumCheck(models.ProductType.Check)},
+}
+
+// GetProducts retrieves all products with related data. Drafts are excluded
+// unless ?status=draft (drafts only) or ?status=all is given. Archived
+// products are excluded unless ?include_archived=true, or ?archived=true
+// for archived products only. ?region=, ?lifecycle_stage= and ?product_type=
+// take one value or a comma-separated list; ?tag= lists tags a product must
+// all carry. Region-scoped callers only see their region.
 func (h *ProductHandler) GetProducts(c *gin.Context) {
 	var products []models.Product
 
-	result := database.DB.
-		Preload("Readiness").
-		Preload("Prediction").
-		Preload("Compliance").
-		Preload("MarketEvidence").
-		Preload("Partners").
-		Preload("Feedback").
-		Preload("Dependencies").
+	includeDeleted := c.Query("include_deleted") == "true"
+	if includeDeleted && !isAdmin(c) {
+		respondWithError(c, http.StatusForbidden, "include_deleted requires admin access")
+		return
+	}
+
+	query := database.DB
+	status := c.DefaultQuery("status", "active")
+	meta := newListMeta("-created_at")
+	meta.filter("status", status)
+	if includeDeleted {
+		query = query.Unscoped()
+		meta.filter("include_deleted", "true")
+	}
+	switch status {
+	case "active":
+		query = query.Scopes(models.ExcludeDrafts)
+	case "draft":
+		query = query.Where("is_draft = ?", true)
+	case "all":
+	default:
+		respondWithError(c, http.StatusBadRequest, "status must be one of active, draft, all")
+		return
+	}
+
+	query, err := applyFilters(query, c.Request.URL.Query(), productFilters, meta)
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	tags, err := tagFilter(c.Request.URL.Query())
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if len(tags) > 0 {
+		query = query.Where("products.tags @> ?", tags)
+		meta.filter("tag", strings.Join(tags, ","))
+	}
+	if region, ok := scopedRegion(c); ok {
+		query = query.Where("products.region = ?", region)
+		meta.filter("region", region)
+	}
+
+	switch {
+	case c.Query("archived") == "true":
+		query = query.Where("archived_at IS NOT NULL")
+		meta.filter("archived", "true")
+	case c.Query("include_archived") == "true":
+		meta.filter("include_archived", "true")
+	default:
+		query = query.Scopes(models.ExcludeArchived)
+	}
+
+	fields, err := parseProductFields(c, productListPreloads)
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if fields != nil {
+		meta.accept("fields")
+	}
+
+	page, pageSize := parsePagination(c, defaultListPageSize, maxListPageSize)
+	pageQuery, total, err := paginate(query, &models.Product{}, page, pageSize)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result := preloadProducts(pageQuery, productListPreloads, fields).
 		Order("created_at DESC").
 		Find(&products)
 
@@ -35,30 +219,110 @@ func (h *ProductHandler) GetProducts(c *gin.Context) {
 		return
 	}
 
-	respondWithData(c, http.StatusOK, products)
+	data, err := selectProductFields(products, fields)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	respondWithPagination(c, data, total, page, pageSize, meta)
 }
 
-// GetProduct retrieves a single product by ID with all related data
+// productDetailSources are the associations GetProduct preloads, with the
+// column recording when each row last changed
+var productDetailSources = []struct {
+	model   interface{}
+	changed string
+}{
+	{&models.ProductReadiness{}, "COALESCE(updated_at, evaluated_at)"},
+	{&models.ProductPrediction{}, "COALESCE(updated_at, scored_at)"},
+	{&models.ProductCompliance{}, "updated_at"},
+	{&models.ProductMarketEvidence{}, "updated_at"},
+	{&models.ProductPartner{}, "updated_at"},
+	{&models.SalesTraining{}, "updated_at"},
+	{&models.ProductFeedback{}, "COALESCE(updated_at, created_at)"},
+	{&models.ProductAction{}, "updated_at"},
+	{&models.ProductMetric{}, "COALESCE(updated_at, created_at)"},
+	{&models.ProductDependency{}, "updated_at"},
+	{&models.ProductReadinessHistory{}, "recorded_at"},
+}
+
+// productDetailETag versions a product's detail payload from its own
+// updated_at and each association's row count and latest change, in one
+// query. Counts catch deleted rows, which leave no timestamp behind.
+func productDetailETag(db *gorm.DB, id uuid.UUID) (string, error) {
+	selects := []string{`SELECT 'products' AS source, COUNT(*) AS row_count, MAX(updated_at) AS latest
+		FROM products WHERE id = ? AND deleted_at IS NULL`}
+	args := []interface{}{id}
+	for _, source := range productDetailSources {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(source.model); err != nil {
+			return "", err
+		}
+		selects = append(selects, "SELECT '"+stmt.Table+"', COUNT(*), MAX("+source.changed+") FROM "+stmt.Table+" WHERE product_id = ?")
+		args = append(args, id)
+	}
+
+	var rows []struct {
+		Source   string
+		RowCount int64
+		Latest   *string
+	}
+	if err := db.Raw(strings.Join(selects, " UNION ALL ")+" ORDER BY source", args...).Scan(&rows).Error; err != nil {
+		return "", err
+	}
+
+	parts := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if row.Source == "products" && row.RowCount == 0 {
+			return "", errProductNotFound
+		}
+		latest := ""
+		if row.Latest != nil {
+			latest = *row.Latest
+		}
+		parts = append(parts, row.Source+":"+strconv.FormatInt(row.RowCount, 10)+":"+latest)
+	}
+	return weakETag(parts...), nil
+}
+
+// GetProduct retrieves a single product by ID with all related data. A
+// region-scoped caller gets 403 for a product outside their region.
 func (h *ProductHandler) GetProduct(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
 		return
 	}
+	if respondRegionError(c, checkProductRegion(c, database.DB, id)) {
+		return
+	}
+	fields, err := parseProductFields(c, productDetailPreloads)
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Polling clients usually hold the current version; answer them without
+	// loading the associations
+	etag, err := productDetailETag(database.DB, id)
+	if errors.Is(err, errProductNotFound) {
+		respondWithError(c, http.StatusNotFound, "Product not found")
+		return
+	}
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if fields != nil {
+		// A field selection is a different representation of the product
+		etag = weakETag(etag, "fields:"+strings.Join(fields, ","))
+	}
+	if notModified(c, etag) {
+		return
+	}
 
 	var product models.Product
-	result := database.DB.
-		Preload("Readiness").
-		Preload("Prediction").
-		Preload("Compliance").
-		Preload("MarketEvidence").
-		Preload("Partners").
-		Preload("Training").
-		Preload("Feedback").
-		Preload("Actions").
-		Preload("Metrics").
-		Preload("Dependencies").
-		Preload("ReadinessHistory").
+	result := preloadProducts(database.DB, productDetailPreloads, fields).
 		First(&product, "id = ?", id)
 
 	if result.Error != nil {
@@ -66,14 +330,18 @@ func (h *ProductHandler) GetProduct(c *gin.Context) {
 		return
 	}
 
-	respondWithData(c, http.StatusOK, product)
+	data, err := selectProductFields(product, fields)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	respondWithData(c, http.StatusOK, data)
 }
 
 // CreateProduct creates a new product
 func (h *ProductHandler) CreateProduct(c *gin.Context) {
 	var req models.CreateProductRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		respondWithError(c, http.StatusBadRequest, err.Error())
+	if !bindRequest(c, &req) {
 		return
 	}
 
@@ -89,6 +357,15 @@ func (h *ProductHandler) CreateProduct(c *gin.Context) {
 		GovernanceTier: req.GovernanceTier,
 		BudgetCode:     req.BudgetCode,
 		PIIFlag:        req.PIIFlag,
+		IsDraft:        req.Draft,
+	}
+
+	if !product.IsDraft {
+		if missing := product.MissingRequiredFields(); len(missing) > 0 {
+			respondWithError(c, http.StatusBadRequest,
+				"Missing required fields: "+strings.Join(missing, ", ")+" (set draft to save an incomplete product)")
+			return
+		}
 	}
 
 	if product.Region == "" {
@@ -119,8 +396,10 @@ func (h *ProductHandler) UpdateProduct(c *gin.Context) {
 	}
 
 	var req models.UpdateProductRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		respondWithError(c, http.StatusBadRequest, err.Error())
+	if !bindRequest(c, &req) {
+		return
+	}
+	if !checkVersion(c, "Product", req.Version, product.Version) {
 		return
 	}
 
@@ -134,6 +413,13 @@ func (h *ProductHandler) UpdateProduct(c *gin.Context) {
 	if req.Region != nil {
 		updates["region"] = *req.Region
 	}
+	// A draft getting its first stage is not a lifecycle move
+	stageChanged := req.LifecycleStage != nil && product.LifecycleStage != "" && *req.LifecycleStage != product.LifecycleStage
+	if stageChanged && !models.CanTransitionLifecycle(product.LifecycleStage, *req.LifecycleStage) {
+		respondWithError(c, http.StatusUnprocessableEntity,
+			"Cannot move product from "+string(product.LifecycleStage)+" to "+string(*req.LifecycleStage))
+		return
+	}
 	if req.LifecycleStage != nil {
 		updates["lifecycle_stage"] = *req.LifecycleStage
 	}
@@ -167,10 +453,53 @@ func (h *ProductHandler) UpdateProduct(c *gin.Context) {
 	if req.EngineeringLead != nil {
 		updates["engineering_lead"] = *req.EngineeringLead
 	}
+	if req.Draft != nil {
+		updates["is_draft"] = *req.Draft
+	}
 
-	result := database.DB.Model(&product).Updates(updates)
-	if result.Error != nil {
-		respondWithError(c, http.StatusInternalServerError, result.Error.Error())
+	// Non-draft products, including drafts being promoted, must keep every
+	// required field
+	isDraft := product.IsDraft
+	if req.Draft != nil {
+		isDraft = *req.Draft
+	}
+	if !isDraft {
+		merged := product
+		if req.ProductType != nil {
+			merged.ProductType = *req.ProductType
+		}
+		if req.LifecycleStage != nil {
+			merged.LifecycleStage = *req.LifecycleStage
+		}
+		if req.OwnerEmail != nil {
+			merged.OwnerEmail = *req.OwnerEmail
+		}
+		if missing := merged.MissingRequiredFields(); len(missing) > 0 {
+			respondWithError(c, http.StatusBadRequest, "Missing required fields: "+strings.Join(missing, ", "))
+			return
+		}
+	}
+
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		// A draft getting its first owner is not an ownership change
+		if req.OwnerEmail != nil && product.OwnerEmail != "" && *req.OwnerEmail != product.OwnerEmail {
+			if err := recordOwnershipChange(tx, c, product, *req.OwnerEmail, nil); err != nil {
+				return err
+			}
+		}
+		if stageChanged {
+			if err := recordLifecycleChange(tx, c, product, *req.LifecycleStage); err != nil {
+				return err
+			}
+		}
+		return versionedUpdates(tx, &product, req.Version, updates)
+	})
+	if errors.Is(err, errVersionConflict) {
+		respondVersionConflict(c, "Product", *req.Version)
+		return
+	}
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -185,37 +514,174 @@ func (h *ProductHandler) UpdateProduct(c *gin.Context) {
 	respondWithData(c, http.StatusOK, product)
 }
 
-// DeleteProduct deletes a product
-func (h *ProductHandler) DeleteProduct(c *gin.Context) {
+const operationDeleteProduct = "product.delete"
+
+// productDependents are the tables holding a product's data. They are left
+// intact when the product is soft-deleted, so a restore brings everything
+// back.
+var productDependents = []struct {
+	name  string
+	model interface{}
+}{
+	{"readiness", &models.ProductReadiness{}},
+	{"readiness_history", &models.ProductReadinessHistory{}},
+	{"metrics", &models.ProductMetric{}},
+	{"compliance", &models.ProductCompliance{}},
+	{"partners", &models.ProductPartner{}},
+	{"feedback", &models.ProductFeedback{}},
+	{"predictions", &models.ProductPrediction{}},
+	{"market_evidence", &models.ProductMarketEvidence{}},
+	{"training", &models.SalesTraining{}},
+	{"actions", &models.ProductAction{}},
+	{"dependencies", &models.ProductDependency{}},
+	{"escalations", &models.ProductEscalation{}},
+	{"transition_items", &models.TransitionItem{}},
+	{"ownership_changes", &models.ProductOwnershipChange{}},
+	{"lifecycle_events", &models.ProductLifecycleEvent{}},
+}
+
+// productDeletionCounts counts the product's rows that deleting it hides
+func productDeletionCounts(db *gorm.DB, id uuid.UUID) (map[string]int64, error) {
+	counts := map[string]int64{"products": 1}
+	for _, dep := range productDependents {
+		var n int64
+		if err := db.Model(dep.model).Where("product_id = ?", id).Count(&n).Error; err != nil {
+			return nil, err
+		}
+		counts[dep.name] = n
+	}
+
+	var comments int64
+	err := db.Model(&models.ActionComment{}).
+		Where("action_id IN (?)", db.Model(&models.ProductAction{}).Select("id").Where("product_id = ?", id)).
+		Count(&comments).Error
+	if err != nil {
+		return nil, err
+	}
+	counts["action_comments"] = comments
+
+	return counts, nil
+}
+
+// PreviewDeleteProduct returns the product's related rows that deleting it
+// would hide and a short-lived token that DeleteProduct requires
+func (h *ProductHandler) PreviewDeleteProduct(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
 		return
 	}
 
-	result := database.DB.Delete(&models.Product{}, "id = ?", id)
-	if result.Error != nil {
-		respondWithError(c, http.StatusInternalServerError, result.Error.Error())
+	var product models.Product
+	if result := database.DB.First(&product, "id = ?", id); result.Error != nil {
+		respondWithError(c, http.StatusNotFound, "Product not found")
+		return
+	}
+
+	counts, err := productDeletionCounts(database.DB, id)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	if result.RowsAffected == 0 {
+	preview, err := issueConfirmation(h.confirmationSecret, operationDeleteProduct, id.String(), currentUserID(c), counts)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithData(c, http.StatusOK, preview)
+}
+
+// DeleteProduct soft-deletes a product, leaving its related records in place
+// for RestoreProduct. It requires the token from PreviewDeleteProduct in the
+// X-Confirmation-Token header.
+func (h *ProductHandler) DeleteProduct(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	var product models.Product
+	if result := database.DB.First(&product, "id = ?", id); result.Error != nil {
 		respondWithError(c, http.StatusNotFound, "Product not found")
 		return
 	}
 
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		counts, err := productDeletionCounts(tx, id)
+		if err != nil {
+			return err
+		}
+		token := c.GetHeader(ConfirmationHeader)
+		if err := verifyConfirmation(h.confirmationSecret, token, operationDeleteProduct, id.String(), currentUserID(c), counts); err != nil {
+			return err
+		}
+		return tx.Delete(&product).Error
+	})
+
+	switch {
+	case errors.Is(err, errConfirmationStale):
+		respondWithError(c, http.StatusConflict, err.Error())
+		return
+	case errors.Is(err, errConfirmationMissing), errors.Is(err, errConfirmationInvalid), errors.Is(err, errConfirmationMismatch):
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	case err != nil:
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	middleware.LogAdminAction(c, "product deleted", productAuditDetails(product, nil))
+
 	respondWithSuccess(c, http.StatusOK, "Product deleted successfully", nil)
 }
 
-// GetProductsByRegion retrieves products filtered by region
+// RestoreProduct brings back a soft-deleted product with its related records
+func (h *ProductHandler) RestoreProduct(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	var product models.Product
+	if result := database.DB.Unscoped().First(&product, "id = ?", id); result.Error != nil {
+		respondWithError(c, http.StatusNotFound, "Product not found")
+		return
+	}
+	if !product.DeletedAt.Valid {
+		respondWithError(c, http.StatusConflict, "Product is not deleted")
+		return
+	}
+
+	if err := versionedUpdates(database.DB.Unscoped(), &product, nil, map[string]interface{}{"deleted_at": nil}); err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	product.DeletedAt = gorm.DeletedAt{}
+
+	middleware.LogAdminAction(c, "Product restored", productAuditDetails(product, nil))
+
+	respondWithData(c, http.StatusOK, product)
+}
+
+// GetProductsByRegion retrieves products filtered by region. A region-scoped
+// caller may only list their own.
 func (h *ProductHandler) GetProductsByRegion(c *gin.Context) {
 	region := c.Param("region")
+	if scoped, ok := scopedRegion(c); ok && scoped != region {
+		respondWithError(c, http.StatusForbidden, "Region is outside your scope")
+		return
+	}
 
 	var products []models.Product
 	result := database.DB.
 		Preload("Readiness").
 		Preload("Prediction").
 		Where("region = ?", region).
+		Scopes(models.ExcludeDrafts, models.ExcludeArchived).
 		Order("created_at DESC").
 		Find(&products)
 
@@ -236,6 +702,7 @@ func (h *ProductHandler) GetProductsByLifecycle(c *gin.Context) {
 		Preload("Readiness").
 		Preload("Prediction").
 		Where("lifecycle_stage = ?", stage).
+		Scopes(models.ExcludeDrafts, models.ExcludeArchived, regionScoped(c)).
 		Order("created_at DESC").
 		Find(&products)
 
@@ -255,6 +722,7 @@ func (h *ProductHandler) GetProductsByRiskBand(c *gin.Context) {
 	result := database.DB.
 		Joins("JOIN product_readiness ON product_readiness.product_id = products.id").
 		Where("product_readiness.risk_band = ?", riskBand).
+		Scopes(models.ExcludeDrafts, models.ExcludeArchived, regionScoped(c)).
 		Preload("Readiness").
 		Preload("Prediction").
 		Order("products.created_at DESC").
@@ -267,3 +735,464 @@ func (h *ProductHandler) GetProductsByRiskBand(c *gin.Context) {
 
 	respondWithData(c, http.StatusOK, products)
 }
+
+// recordOwnershipChange writes an ownership history row for a product moving to newOwner
+func recordOwnershipChange(tx *gorm.DB, c *gin.Context, product models.Product, newOwner string, reason *string) error {
+	change := models.ProductOwnershipChange{
+		ProductID: product.ID,
+		FromOwner: product.OwnerEmail,
+		ToOwner:   newOwner,
+		Reason:    reason,
+	}
+	if userID := currentUserID(c); userID != "" {
+		change.ChangedBy = &userID
+	}
+	return tx.Create(&change).Error
+}
+
+// productAuditDetails describes a product in an admin audit record, with the
+// given extra details. A product flagged as holding PII is marked pii_flag so
+// the audit logger redacts its name under REDACT_PII.
+func productAuditDetails(product models.Product, extra map[string]interface{}) map[string]interface{} {
+	details := map[string]interface{}{
+		"product_id": product.ID.String(),
+		"name":       product.Name,
+	}
+	if product.PIIFlag != nil && *product.PIIFlag {
+		details["pii_flag"] = true
+	}
+	for key, value := range extra {
+		details[key] = value
+	}
+	return details
+}
+
+// recordLifecycleChange writes a lifecycle history row for a product moving to stage
+func recordLifecycleChange(tx *gorm.DB, c *gin.Context, product models.Product, stage models.LifecycleStage) error {
+	event := models.ProductLifecycleEvent{
+		ProductID: product.ID,
+		FromStage: product.LifecycleStage,
+		ToStage:   stage,
+	}
+	if userID := currentUserID(c); userID != "" {
+		event.ChangedBy = &userID
+	}
+	return tx.Create(&event).Error
+}
+
+var errNoOwnerProfile = errors.New("no profile exists for the new owner")
+
+// transferOwnership moves the product to the profile with the requested
+// email, matched case-insensitively, and records the change. It returns
+// errNoOwnerProfile when no profile has that email.
+func transferOwnership(db *gorm.DB, c *gin.Context, product *models.Product, req models.TransferOwnershipRequest) error {
+	var profile models.Profile
+	err := db.Where("LOWER(email) = LOWER(?)", req.OwnerEmail).First(&profile).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errNoOwnerProfile
+	}
+	if err != nil {
+		return err
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := recordOwnershipChange(tx, c, *product, profile.Email, req.Reason); err != nil {
+			return err
+		}
+		return versionedUpdates(tx, product, nil, map[string]interface{}{"owner_email": profile.Email})
+	})
+	if err != nil {
+		return err
+	}
+	product.OwnerEmail = profile.Email
+	return nil
+}
+
+// TransferOwnership hands a product to a new owner and records the change
+func (h *ProductHandler) TransferOwnership(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	var product models.Product
+	if result := database.DB.First(&product, "id = ?", id); result.Error != nil {
+		respondWithError(c, http.StatusNotFound, "Product not found")
+		return
+	}
+
+	var req models.TransferOwnershipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if strings.EqualFold(req.OwnerEmail, product.OwnerEmail) {
+		respondWithError(c, http.StatusBadRequest, "Product is already owned by "+product.OwnerEmail)
+		return
+	}
+
+	err = transferOwnership(database.DB, c, &product, req)
+	if errors.Is(err, errNoOwnerProfile) {
+		respondWithError(c, http.StatusBadRequest, "No profile exists for "+req.OwnerEmail)
+		return
+	}
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithData(c, http.StatusOK, product)
+}
+
+// ArchiveProduct marks a product as done but kept for reference. Archived
+// products drop out of the default lists and portfolio stats.
+func (h *ProductHandler) ArchiveProduct(c *gin.Context) {
+	h.setArchived(c, true)
+}
+
+// UnarchiveProduct returns an archived product to the active portfolio
+func (h *ProductHandler) UnarchiveProduct(c *gin.Context) {
+	h.setArchived(c, false)
+}
+
+func (h *ProductHandler) setArchived(c *gin.Context, archive bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	var product models.Product
+	if result := database.DB.First(&product, "id = ?", id); result.Error != nil {
+		respondWithError(c, http.StatusNotFound, "Product not found")
+		return
+	}
+
+	if archive == (product.ArchivedAt != nil) {
+		if archive {
+			respondWithError(c, http.StatusConflict, "Product is already archived")
+		} else {
+			respondWithError(c, http.StatusConflict, "Product is not archived")
+		}
+		return
+	}
+
+	var archivedAt *models.Timestamp
+	if archive {
+		now := models.Now()
+		archivedAt = &now
+	}
+	if err := versionedUpdates(database.DB, &product, nil, map[string]interface{}{"archived_at": archivedAt}); err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	product.ArchivedAt = archivedAt
+
+	description := "Product unarchived"
+	if archive {
+		description = "Product archived"
+	}
+	middleware.LogAdminAction(c, description, productAuditDetails(product, nil))
+
+	respondWithData(c, http.StatusOK, product)
+}
+
+// GetOwnershipHistory lists a product's ownership changes, most recent first
+func (h *ProductHandler) GetOwnershipHistory(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	var history []models.ProductOwnershipChange
+	result := database.DB.
+		Where("product_id = ?", id).
+		Order("changed_at DESC").
+		Find(&history)
+
+	if result.Error != nil {
+		respondWithError(c, http.StatusInternalServerError, result.Error.Error())
+		return
+	}
+
+	respondWithData(c, http.StatusOK, history)
+}
+
+// GetLifecycleHistory lists a product's lifecycle stage changes, most recent first
+func (h *ProductHandler) GetLifecycleHistory(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	var history []models.ProductLifecycleEvent
+	result := database.DB.
+		Where("product_id = ?", id).
+		Order("changed_at DESC").
+		Find(&history)
+
+	if result.Error != nil {
+		respondWithError(c, http.StatusInternalServerError, result.Error.Error())
+		return
+	}
+
+	respondWithData(c, http.StatusOK, history)
+}
+
+const (
+	defaultNeighborLimit = 5
+	maxNeighborLimit     = 20
+)
+
+// ProductNeighbor is a comparable product used as a peer benchmark
+type ProductNeighbor struct {
+	ID                 uuid.UUID             `json:"id"`
+	Name               string                `json:"name"`
+	ProductType        models.ProductType    `json:"product_type"`
+	Region             string                `json:"region"`
+	LifecycleStage     models.LifecycleStage `json:"lifecycle_stage"`
+	Similarity         int                   `json:"similarity"` // 0-3, one point per shared attribute
+	MatchedOn          []string              `json:"matched_on"` // product_type, region, lifecycle_stage
+	ReadinessScore     *float64              `json:"readiness_score,omitempty"`
+	RiskBand           *models.RiskBand      `json:"risk_band,omitempty"`
+	SuccessProbability *float64              `json:"success_probability,omitempty"`
+}
+
+// rankNeighbors scores candidates by shared type, region and lifecycle stage
+// and returns the top limit, ordered by similarity then readiness. The target
+// itself and candidates sharing nothing are excluded.
+func rankNeighbors(target models.Product, candidates []models.Product, limit int) []ProductNeighbor {
+	neighbors := []ProductNeighbor{}
+	for _, p := range candidates {
+		if p.ID == target.ID {
+			continue
+		}
+
+		var matched []string
+		if p.ProductType == target.ProductType {
+			matched = append(matched, "product_type")
+		}
+		if p.Region == target.Region {
+			matched = append(matched, "region")
+		}
+		if p.LifecycleStage == target.LifecycleStage {
+			matched = append(matched, "lifecycle_stage")
+		}
+		if len(matched) == 0 {
+			continue
+		}
+
+		neighbor := ProductNeighbor{
+			ID:             p.ID,
+			Name:           p.Name,
+			ProductType:    p.ProductType,
+			Region:         p.Region,
+			LifecycleStage: p.LifecycleStage,
+			Similarity:     len(matched),
+			MatchedOn:      matched,
+		}
+		if p.Readiness != nil {
+			score := p.Readiness.ReadinessScore
+			band := p.Readiness.RiskBand
+			neighbor.ReadinessScore = &score
+			neighbor.RiskBand = &band
+		}
+		if p.Prediction != nil {
+			neighbor.SuccessProbability = p.Prediction.SuccessProbability
+		}
+		neighbors = append(neighbors, neighbor)
+	}
+
+	readiness := func(n ProductNeighbor) float64 {
+		if n.ReadinessScore == nil {
+			return -1
+		}
+		return *n.ReadinessScore
+	}
+	sort.SliceStable(neighbors, func(i, j int) bool {
+		if neighbors[i].Similarity != neighbors[j].Similarity {
+			return neighbors[i].Similarity > neighbors[j].Similarity
+		}
+		return readiness(neighbors[i]) > readiness(neighbors[j])
+	})
+
+	if len(neighbors) > limit {
+		neighbors = neighbors[:limit]
+	}
+	return neighbors
+}
+
+// GetProductNeighbors returns the most similar products by type, region and
+// lifecycle stage with their readiness and success probability
+func (h *ProductHandler) GetProductNeighbors(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	limit := defaultNeighborLimit
+	if raw := c.Query("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > maxNeighborLimit {
+			respondWithError(c, http.StatusBadRequest, "limit must be an integer between 1 and 20")
+			return
+		}
+		limit = n
+	}
+
+	var product models.Product
+	if result := database.DB.First(&product, "id = ?", id); result.Error != nil {
+		respondWithError(c, http.StatusNotFound, "Product not found")
+		return
+	}
+
+	var candidates []models.Product
+	result := database.DB.
+		Preload("Readiness").
+		Preload("Prediction").
+		Where("id <> ?", id).
+		Scopes(models.ExcludeDrafts, models.ExcludeArchived).
+		Where("product_type = ? OR region = ? OR lifecycle_stage = ?",
+			product.ProductType, product.Region, product.LifecycleStage).
+		Find(&candidates)
+
+	if result.Error != nil {
+		respondWithError(c, http.StatusInternalServerError, result.Error.Error())
+		return
+	}
+
+	respondWithData(c, http.StatusOK, rankNeighbors(product, candidates, limit))
+}
+
+// ProductCloneResponse is the new product from CloneProduct. Copied and Reset
+// name the fields and associations carried over from the source and the ones
+// started afresh.
+type ProductCloneResponse struct {
+	SourceProductID uuid.UUID      `json:"source_product_id"`
+	Product         models.Product `json:"product"`
+	Copied          []string       `json:"copied"`
+	Reset           []string       `json:"reset"`
+}
+
+var (
+	// productCloneCopied are carried over from the source product
+	productCloneCopied = []string{
+		"product_type", "owner_email", "revenue_target", "success_metric",
+		"governance_tier", "budget_code", "pii_flag", "business_sponsor",
+		"engineering_lead", "ttm_target_days", "tags", "transition_items",
+	}
+
+	// productCloneReset start afresh on the copy: the lifecycle goes back to
+	// concept, transition items lose their progress and readiness is
+	// recreated at its defaults. Metrics and the other associations are not
+	// copied.
+	productCloneReset = []string{
+		"lifecycle_stage", "launch_date", "gating_status", "confidence",
+		"ttm_actual_days", "transition_item_progress", "readiness", "metrics",
+		"compliance", "partners", "training", "feedback", "actions",
+		"dependencies", "predictions", "market_evidence",
+	}
+)
+
+// cloneProduct copies source into a new concept-stage product named name in
+// region, along with its transition items and a default readiness row, in
+// one transaction
+func cloneProduct(db *gorm.DB, source models.Product, name, region string) (models.Product, error) {
+	clone := models.Product{
+		Name:            name,
+		ProductType:     source.ProductType,
+		Region:          region,
+		LifecycleStage:  models.LifecycleConcept,
+		RevenueTarget:   source.RevenueTarget,
+		OwnerEmail:      source.OwnerEmail,
+		SuccessMetric:   source.SuccessMetric,
+		GovernanceTier:  source.GovernanceTier,
+		BudgetCode:      source.BudgetCode,
+		PIIFlag:         source.PIIFlag,
+		BusinessSponsor: source.BusinessSponsor,
+		EngineeringLead: source.EngineeringLead,
+		IsDraft:         source.IsDraft,
+		TTMTargetDays:   source.TTMTargetDays,
+		Tags:            source.Tags,
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&clone).Error; err != nil {
+			return err
+		}
+
+		var items []models.TransitionItem
+		if err := tx.Where("product_id = ?", source.ID).Order("created_at ASC").Find(&items).Error; err != nil {
+			return err
+		}
+		for _, item := range items {
+			copied := models.TransitionItem{
+				ProductID:   clone.ID,
+				Category:    item.Category,
+				Name:        item.Name,
+				Description: item.Description,
+				Owner:       item.Owner,
+			}
+			if err := tx.Create(&copied).Error; err != nil {
+				return err
+			}
+		}
+
+		readiness := models.ProductReadiness{ProductID: clone.ID}
+		readiness.ReadinessScore = readiness.ComputeReadinessScore()
+		readiness.RiskBand = models.RiskBandForScore(readiness.ReadinessScore)
+		if err := tx.Create(&readiness).Error; err != nil {
+			return err
+		}
+		clone.Readiness = &readiness
+		return nil
+	})
+	if err != nil {
+		return models.Product{}, err
+	}
+	return clone, nil
+}
+
+// CloneProduct sets up a new pilot from an existing product: its core fields
+// and transition checklist are copied under the given name and region, while
+// the lifecycle, launch date, readiness and metrics start afresh
+func (h *ProductHandler) CloneProduct(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	var source models.Product
+	if result := database.DB.First(&source, "id = ?", id); result.Error != nil {
+		respondWithError(c, http.StatusNotFound, "Product not found")
+		return
+	}
+
+	var req models.CloneProductRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	clone, err := cloneProduct(database.DB, source, req.Name, req.Region)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	middleware.LogAdminAction(c, "Product cloned", productAuditDetails(clone, map[string]interface{}{
+		"source_product_id": source.ID.String(),
+	}))
+
+	respondWithData(c, http.StatusCreated, ProductCloneResponse{
+		SourceProductID: source.ID,
+		Product:         clone,
+		Copied:          productCloneCopied,
+		Reset:           productCloneReset,
+	})
+}
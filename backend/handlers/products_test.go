@@ -0,0 +1,521 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/google/uuid"
+	"github.com/pauly7610/studio-pilot-vision/backend/database"
+	"github.com/pauly7610/studio-pilot-vision/backend/middleware"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+	"gorm.io/gorm"
+)
+
+func TestRankNeighbors(t *testing.T) {
+	withReadiness := func(p models.Product, score float64) models.Product {
+		p.Readiness = &models.ProductReadiness{ReadinessScore: score, RiskBand: models.RiskBandLow}
+		return p
+	}
+	product := func(name string, pt models.ProductType, region string, stage models.LifecycleStage) models.Product {
+		return models.Product{ID: uuid.New(), Name: name, ProductType: pt, Region: region, LifecycleStage: stage}
+	}
+
+	target := product("target", "data_services", "EMEA", "pilot")
+	candidates := []models.Product{
+		target,
+		withReadiness(product("type-only", "data_services", "APAC", "commercial"), 90),
+		withReadiness(product("all-three-low", "data_services", "EMEA", "pilot"), 40),
+		withReadiness(product("all-three-high", "data_services", "EMEA", "pilot"), 80),
+		product("type-region-no-readiness", "data_services", "EMEA", "commercial"),
+		withReadiness(product("type-region", "data_services", "EMEA", "concept"), 70),
+		product("unrelated", "payment_flows", "APAC", "commercial"),
+	}
+
+	got := rankNeighbors(target, candidates, 10)
+
+	want := []string{"all-three-high", "all-three-low", "type-region", "type-region-no-readiness", "type-only"}
+	if len(got) != len(want) {
+		t.Fatalf("got %d neighbors, want %d", len(got), len(want))
+	}
+	for i, name := range want {
+		if got[i].Name != name {
+			t.Errorf("position %d: got %q, want %q", i, got[i].Name, name)
+		}
+	}
+	if got[0].Similarity != 3 || len(got[0].MatchedOn) != 3 {
+		t.Errorf("expected full match for first neighbor, got %+v", got[0])
+	}
+
+	if limited := rankNeighbors(target, candidates, 2); len(limited) != 2 {
+		t.Errorf("limit not applied: got %d", len(limited))
+	}
+}
+
+func TestGetProducts_IncludeDeletedRequiresAdmin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	for _, role := range []string{"", "viewer", "sales"} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/products?include_deleted=true", nil)
+		if role != "" {
+			c.Set("role", role)
+		}
+
+		NewProductHandler("secret", 30).GetProducts(c)
+		if w.Code != http.StatusForbidden {
+			t.Errorf("role %q: status = %d, want 403", role, w.Code)
+		}
+	}
+}
+
+func TestGetProducts_ArchivedOptIn(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := openTestDB(t, productCloneDDL...)
+	useTestDB(t, db)
+	for _, name := range []string{"Live", "Retired"} {
+		product := models.Product{Name: name, ProductType: "payment_flows", LifecycleStage: models.LifecyclePilot, OwnerEmail: "a@example.com"}
+		if err := db.Create(&product).Error; err != nil {
+			t.Fatalf("create product: %v", err)
+		}
+	}
+	db.Exec(`UPDATE products SET archived_at = CURRENT_TIMESTAMP WHERE name = 'Retired'`)
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"", []string{"Live"}},
+		{"&include_archived=true", []string{"Live", "Retired"}},
+		{"&archived=true", []string{"Retired"}},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/products?fields=name"+tt.query, nil)
+
+		NewProductHandler("secret", 30).GetProducts(c)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%q: status = %d, body %s", tt.query, w.Code, w.Body.String())
+		}
+		var body struct {
+			Data []struct {
+				Name string `json:"name"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("decode %s: %v", w.Body.String(), err)
+		}
+		var names []string
+		for _, product := range body.Data {
+			names = append(names, product.Name)
+		}
+		sort.Strings(names)
+		if !reflect.DeepEqual(names, tt.want) {
+			t.Errorf("%q: got %v, want %v", tt.query, names, tt.want)
+		}
+	}
+}
+
+// productCloneDDL holds the products table and the associations cloneProduct
+// writes
+var productCloneDDL = []string{
+	`CREATE TABLE products (
+		id TEXT PRIMARY KEY, name TEXT NOT NULL, product_type TEXT NOT NULL,
+		region TEXT DEFAULT 'North America', lifecycle_stage TEXT NOT NULL,
+		launch_date DATETIME, revenue_target REAL, owner_email TEXT NOT NULL,
+		success_metric TEXT, gating_status TEXT, gating_status_since DATETIME,
+		governance_tier TEXT, budget_code TEXT, pii_flag BOOLEAN,
+		business_sponsor TEXT, engineering_lead TEXT,
+		is_draft BOOLEAN NOT NULL DEFAULT false, archived_at DATETIME, tags TEXT NOT NULL DEFAULT '{}',
+		revenue_confidence INTEGER DEFAULT 50, revenue_confidence_justification TEXT,
+		timeline_confidence INTEGER DEFAULT 50, timeline_confidence_justification TEXT,
+		ttm_target_days INTEGER, ttm_actual_days INTEGER, ttm_delta_vs_last_week INTEGER DEFAULT 0,
+		created_at DATETIME, updated_at DATETIME,
+		version INTEGER NOT NULL DEFAULT 1, deleted_at DATETIME)`,
+	transitionItemsDDL,
+	versionedReadinessDDL,
+}
+
+func TestCloneProduct(t *testing.T) {
+	db := openTestDB(t, productCloneDDL...)
+
+	launch := models.Now()
+	budget, gating := "B-42", "blocked"
+	target, actual := 90, 120
+	source := models.Product{
+		Name: "Wallet EU", ProductType: "payment_flows", Region: "EMEA",
+		LifecycleStage: models.LifecyclePilot, LaunchDate: &launch, OwnerEmail: "owner@example.com",
+		BudgetCode: &budget, GatingStatus: &gating, TTMTargetDays: &target, TTMActualDays: &actual,
+		Tags: models.TagArray{"board-watch"},
+	}
+	if err := db.Create(&source).Error; err != nil {
+		t.Fatalf("create source: %v", err)
+	}
+	items, err := createDefaultTransitionItems(db, source.ID)
+	if err != nil {
+		t.Fatalf("create transition items: %v", err)
+	}
+	doneBy := "ana@example.com"
+	if err := db.Model(&items[0]).Updates(map[string]interface{}{"complete": true, "completed_by": doneBy}).Error; err != nil {
+		t.Fatalf("complete item: %v", err)
+	}
+
+	clone, err := cloneProduct(db, source, "Wallet APAC", "APAC")
+	if err != nil {
+		t.Fatalf("clone: %v", err)
+	}
+	if clone.ID == source.ID || clone.Name != "Wallet APAC" || clone.Region != "APAC" {
+		t.Errorf("unexpected clone identity %+v", clone)
+	}
+	if clone.LifecycleStage != models.LifecycleConcept || clone.LaunchDate != nil || clone.GatingStatus != nil || clone.TTMActualDays != nil {
+		t.Errorf("lifecycle fields not reset: %+v", clone)
+	}
+	if clone.ProductType != source.ProductType || clone.OwnerEmail != source.OwnerEmail ||
+		clone.BudgetCode == nil || *clone.BudgetCode != budget || clone.TTMTargetDays == nil || *clone.TTMTargetDays != target ||
+		!reflect.DeepEqual(clone.Tags, source.Tags) {
+		t.Errorf("core fields not copied: %+v", clone)
+	}
+	if clone.Readiness == nil || clone.Readiness.ReadinessScore != 0 || clone.Readiness.RiskBand != models.RiskBandHigh {
+		t.Errorf("readiness = %+v, want default high-risk readiness", clone.Readiness)
+	}
+
+	var copied []models.TransitionItem
+	db.Where("product_id = ?", clone.ID).Find(&copied)
+	if len(copied) != len(items) {
+		t.Fatalf("copied %d transition items, want %d", len(copied), len(items))
+	}
+	for _, item := range copied {
+		if item.Complete || item.CompletedBy != nil {
+			t.Errorf("transition item %q kept its progress", item.Name)
+		}
+	}
+
+	// A failed readiness insert rolls back the whole clone
+	db.Exec("DROP TABLE product_readiness")
+	if _, err := cloneProduct(db, source, "Wallet LATAM", "LATAM"); err == nil {
+		t.Fatal("expected an error without a readiness table")
+	}
+	var products int64
+	db.Model(&models.Product{}).Where("name = ?", "Wallet LATAM").Count(&products)
+	if products != 0 {
+		t.Errorf("failed clone left %d products behind", products)
+	}
+}
+
+func TestRegionScopedProducts(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := openTestDB(t, `CREATE TABLE products (id TEXT PRIMARY KEY, region TEXT, deleted_at DATETIME)`)
+	emea, apac := uuid.New(), uuid.New()
+	db.Exec(`INSERT INTO products (id, region) VALUES (?, 'EMEA'), (?, 'APAC')`, emea, apac)
+
+	scoped, _ := gin.CreateTestContext(httptest.NewRecorder())
+	scoped.Set(middleware.RegionScopeKey, "EMEA")
+	unscoped, _ := gin.CreateTestContext(httptest.NewRecorder())
+
+	if err := checkProductRegion(scoped, db, emea); err != nil {
+		t.Errorf("own region: %v", err)
+	}
+	if err := checkProductRegion(scoped, db, apac); !errors.Is(err, errOutsideRegion) {
+		t.Errorf("other region: got %v, want errOutsideRegion", err)
+	}
+	if err := checkProductRegion(scoped, db, uuid.New()); err != nil {
+		t.Errorf("missing product: %v, want it left to the caller", err)
+	}
+	if err := checkProductRegion(unscoped, db, apac); err != nil {
+		t.Errorf("unscoped caller: %v", err)
+	}
+
+	var ids []string
+	db.Model(&models.Product{}).Scopes(regionScoped(scoped)).Pluck("id", &ids)
+	if len(ids) != 1 || ids[0] != emea.String() {
+		t.Errorf("scoped list = %v, want only the EMEA product", ids)
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set(middleware.RegionScopeKey, "EMEA")
+	c.Params = gin.Params{{Key: "region", Value: "APAC"}}
+	NewProductHandler("secret", 30).GetProductsByRegion(c)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("listing another region: status = %d, want 403", w.Code)
+	}
+}
+
+func TestCreateForProduct(t *testing.T) {
+	db := openTestDB(t,
+		`CREATE TABLE products (id TEXT PRIMARY KEY, deleted_at DATETIME)`,
+		productActionsDDL,
+	)
+	productID := uuid.New()
+	db.Exec(`INSERT INTO products (id) VALUES (?)`, productID)
+
+	newAction := func(title string) *models.ProductAction {
+		return &models.ProductAction{ProductID: productID, ActionType: models.ActionTypeReview, Title: title,
+			Status: models.ActionStatusPending, Priority: models.ActionPriorityMedium}
+	}
+	countActions := func() int64 {
+		var n int64
+		db.Model(&models.ProductAction{}).Where("product_id = ?", productID).Count(&n)
+		return n
+	}
+
+	if err := createForProduct(db, productID, func(tx *gorm.DB) error { return tx.Create(newAction("kept")).Error }); err != nil {
+		t.Fatalf("create for live product: %v", err)
+	}
+
+	// The product is deleted while the action is being written
+	err := createForProduct(db, productID, func(tx *gorm.DB) error {
+		if err := tx.Create(newAction("orphan")).Error; err != nil {
+			return err
+		}
+		return tx.Exec(`UPDATE products SET deleted_at = CURRENT_TIMESTAMP WHERE id = ?`, productID).Error
+	})
+	if !errors.Is(err, errProductNotFound) {
+		t.Fatalf("delete mid-flow: got %v, want errProductNotFound", err)
+	}
+	if n := countActions(); n != 1 {
+		t.Errorf("found %d actions, want only the one created before the delete", n)
+	}
+
+	// The delete was rolled back with the action; delete it for real
+	db.Exec(`UPDATE products SET deleted_at = CURRENT_TIMESTAMP WHERE id = ?`, productID)
+	called := false
+	err = createForProduct(db, productID, func(tx *gorm.DB) error { called = true; return nil })
+	if !errors.Is(err, errProductNotFound) || called {
+		t.Errorf("deleted product: err = %v, create called = %v; want errProductNotFound without writing", err, called)
+	}
+}
+
+// productCascadeDDL mirrors the foreign keys Migrate creates for a sample of
+// a product's children
+var productCascadeDDL = []string{
+	`CREATE TABLE products (id TEXT PRIMARY KEY, deleted_at DATETIME)`,
+	`CREATE TABLE product_readiness (id TEXT PRIMARY KEY,
+		product_id TEXT NOT NULL REFERENCES products(id) ON DELETE CASCADE)`,
+	`CREATE TABLE product_metrics (id TEXT PRIMARY KEY,
+		product_id TEXT NOT NULL REFERENCES products(id) ON DELETE CASCADE)`,
+	`CREATE TABLE product_feedback (id TEXT PRIMARY KEY,
+		product_id TEXT NOT NULL REFERENCES products(id) ON DELETE CASCADE)`,
+	`CREATE TABLE product_actions (id TEXT PRIMARY KEY,
+		product_id TEXT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+		linked_feedback_id TEXT REFERENCES product_feedback(id) ON DELETE SET NULL)`,
+	`CREATE TABLE action_comments (id TEXT PRIMARY KEY,
+		action_id TEXT NOT NULL REFERENCES product_actions(id) ON DELETE CASCADE)`,
+}
+
+func TestHardDeleteProductCascades(t *testing.T) {
+	db := openTestDB(t, productCascadeDDL...)
+	if err := db.Exec(`PRAGMA foreign_keys = ON`).Error; err != nil {
+		t.Fatalf("enable foreign keys: %v", err)
+	}
+
+	productID, linked, actionID := uuid.New(), uuid.New(), uuid.New()
+	for _, stmt := range []struct {
+		sql  string
+		args []interface{}
+	}{
+		{`INSERT INTO products (id) VALUES (?)`, []interface{}{productID}},
+		{`INSERT INTO product_readiness VALUES (?, ?)`, []interface{}{uuid.New(), productID}},
+		{`INSERT INTO product_metrics VALUES (?, ?)`, []interface{}{uuid.New(), productID}},
+		{`INSERT INTO product_feedback VALUES (?, ?)`, []interface{}{uuid.New(), productID}},
+		{`INSERT INTO product_feedback VALUES (?, ?)`, []interface{}{linked, productID}},
+		{`INSERT INTO product_actions VALUES (?, ?, ?)`, []interface{}{actionID, productID, linked}},
+		{`INSERT INTO action_comments VALUES (?, ?)`, []interface{}{uuid.New(), actionID}},
+	} {
+		if err := db.Exec(stmt.sql, stmt.args...).Error; err != nil {
+			t.Fatalf("seed: %v", err)
+		}
+	}
+
+	count := func(table string) int64 {
+		var n int64
+		db.Table(table).Count(&n)
+		return n
+	}
+
+	// Deleting linked feedback unlinks the action but keeps it
+	db.Exec(`DELETE FROM product_feedback WHERE id = ?`, linked)
+	var link *string
+	db.Raw(`SELECT linked_feedback_id FROM product_actions WHERE id = ?`, actionID).Scan(&link)
+	if count("product_actions") != 1 || link != nil {
+		t.Errorf("after feedback delete: %d actions, link %v; want the action kept and unlinked", count("product_actions"), link)
+	}
+
+	// A soft delete leaves every child in place for a restore
+	if err := db.Delete(&models.Product{ID: productID}).Error; err != nil {
+		t.Fatalf("soft delete: %v", err)
+	}
+	for _, table := range []string{"product_readiness", "product_metrics", "product_feedback", "product_actions", "action_comments"} {
+		if n := count(table); n != 1 {
+			t.Errorf("after soft delete: %s has %d rows, want 1", table, n)
+		}
+	}
+
+	if err := db.Unscoped().Delete(&models.Product{ID: productID}).Error; err != nil {
+		t.Fatalf("hard delete: %v", err)
+	}
+	for _, table := range []string{"products", "product_readiness", "product_metrics", "product_feedback", "product_actions", "action_comments"} {
+		if n := count(table); n != 0 {
+			t.Errorf("after hard delete: %s has %d rows, want 0", table, n)
+		}
+	}
+}
+
+func TestRecordLifecycleChange(t *testing.T) {
+	db := openTestDB(t, `CREATE TABLE product_lifecycle_events (
+		id TEXT PRIMARY KEY, product_id TEXT NOT NULL, from_stage TEXT NOT NULL,
+		to_stage TEXT NOT NULL, changed_by TEXT, changed_at DATETIME)`)
+	product := models.Product{ID: uuid.New(), LifecycleStage: models.LifecyclePilot}
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Set("userID", "user-1")
+	if err := recordLifecycleChange(db, c, product, models.LifecycleCommercial); err != nil {
+		t.Fatalf("recordLifecycleChange: %v", err)
+	}
+
+	var events []models.ProductLifecycleEvent
+	db.Where("product_id = ?", product.ID).Find(&events)
+	if len(events) != 1 {
+		t.Fatalf("got %d events, want 1", len(events))
+	}
+	e := events[0]
+	if e.FromStage != models.LifecyclePilot || e.ToStage != models.LifecycleCommercial || e.ChangedBy == nil || *e.ChangedBy != "user-1" {
+		t.Errorf("event = %s -> %s by %v, want pilot -> commercial by user-1", e.FromStage, e.ToStage, e.ChangedBy)
+	}
+}
+
+func TestTransferOwnership(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ddl := append([]string{
+		`CREATE TABLE profiles (id TEXT PRIMARY KEY, email TEXT NOT NULL, full_name TEXT, role TEXT, region TEXT, created_at DATETIME, updated_at DATETIME)`,
+		`CREATE TABLE product_ownership_changes (
+			id TEXT PRIMARY KEY, product_id TEXT NOT NULL,
+			from_owner TEXT NOT NULL, to_owner TEXT NOT NULL, changed_by TEXT, reason TEXT, changed_at DATETIME)`,
+	}, productCloneDDL...)
+	db := openTestDB(t, ddl...)
+
+	product := models.Product{Name: "Wallet", ProductType: "payment_flows", LifecycleStage: models.LifecyclePilot, OwnerEmail: "old@example.com"}
+	if err := db.Create(&product).Error; err != nil {
+		t.Fatalf("create product: %v", err)
+	}
+	if err := db.Create(&models.Profile{Email: "New.Owner@example.com", Role: "viewer"}).Error; err != nil {
+		t.Fatalf("create profile: %v", err)
+	}
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Set("userID", "admin-1")
+
+	t.Run("unknown profile", func(t *testing.T) {
+		err := transferOwnership(db, c, &product, models.TransferOwnershipRequest{OwnerEmail: "nobody@example.com"})
+		if !errors.Is(err, errNoOwnerProfile) {
+			t.Fatalf("err = %v, want errNoOwnerProfile", err)
+		}
+		var count int64
+		db.Model(&models.ProductOwnershipChange{}).Count(&count)
+		if count != 0 || product.OwnerEmail != "old@example.com" {
+			t.Errorf("failed transfer wrote %d history rows, owner %q", count, product.OwnerEmail)
+		}
+	})
+
+	t.Run("known profile", func(t *testing.T) {
+		reason := "reorg"
+		err := transferOwnership(db, c, &product, models.TransferOwnershipRequest{OwnerEmail: "new.owner@example.com", Reason: &reason})
+		if err != nil {
+			t.Fatalf("transfer: %v", err)
+		}
+
+		var stored models.Product
+		db.First(&stored, "id = ?", product.ID)
+		if stored.OwnerEmail != "New.Owner@example.com" || product.OwnerEmail != stored.OwnerEmail || stored.Version != 2 {
+			t.Errorf("stored owner %q version %d, returned owner %q", stored.OwnerEmail, stored.Version, product.OwnerEmail)
+		}
+
+		var changes []models.ProductOwnershipChange
+		db.Find(&changes)
+		if len(changes) != 1 {
+			t.Fatalf("got %d history rows, want 1", len(changes))
+		}
+		change := changes[0]
+		if change.ProductID != product.ID || change.FromOwner != "old@example.com" || change.ToOwner != "New.Owner@example.com" ||
+			change.Reason == nil || *change.Reason != reason || change.ChangedBy == nil || *change.ChangedBy != "admin-1" {
+			t.Errorf("history row = %+v", change)
+		}
+	})
+}
+
+func TestArchiveProduct_RedactsPIIProductNameInAudit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	useTestDB(t, openTestDB(t, productCloneDDL...))
+
+	var buf bytes.Buffer
+	previous := log.Writer()
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(previous) })
+	middleware.GetAuditLogger().SetRedactPII(true)
+	t.Cleanup(func() { middleware.GetAuditLogger().SetRedactPII(false) })
+
+	flagged := true
+	for _, product := range []models.Product{
+		{Name: "Cardholder Wallet", ProductType: "payment_flows", LifecycleStage: models.LifecyclePilot, OwnerEmail: "a@example.com", PIIFlag: &flagged},
+		{Name: "Rates Feed", ProductType: "data_services", LifecycleStage: models.LifecyclePilot, OwnerEmail: "a@example.com"},
+	} {
+		if err := database.DB.Create(&product).Error; err != nil {
+			t.Fatalf("create product: %v", err)
+		}
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/products/"+product.ID.String()+"/archive", nil)
+		c.Params = gin.Params{{Key: "id", Value: product.ID.String()}}
+		NewProductHandler("secret", 30).ArchiveProduct(c)
+		if w.Code != http.StatusOK {
+			t.Fatalf("archive %s: status = %d, body %s", product.Name, w.Code, w.Body.String())
+		}
+	}
+
+	out := buf.String()
+	if strings.Contains(out, "Cardholder Wallet") {
+		t.Errorf("PII-flagged product name logged in clear: %s", out)
+	}
+	if !strings.Contains(out, "Rates Feed") {
+		t.Errorf("unflagged product name missing from the audit log: %s", out)
+	}
+}
+
+func TestRequireProductRegion_GuardsChildRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := openTestDB(t, `CREATE TABLE products (id TEXT PRIMARY KEY, region TEXT, deleted_at DATETIME)`, productMetricsDDL)
+	useTestDB(t, db)
+	emea, apac := uuid.New(), uuid.New()
+	db.Exec(`INSERT INTO products (id, region) VALUES (?, 'EMEA'), (?, 'APAC')`, emea, apac)
+
+	router := gin.New()
+	scopeToEMEA := func(c *gin.Context) { c.Set(middleware.RegionScopeKey, "EMEA") }
+	router.GET("/products/:id/metrics", scopeToEMEA, RequireProductRegion, NewMetricsHandler().GetProductMetrics)
+
+	for _, tt := range []struct {
+		name string
+		id   uuid.UUID
+		want int
+	}{
+		{"own region", emea, http.StatusOK},
+		{"other region", apac, http.StatusForbidden},
+	} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/products/"+tt.id.String()+"/metrics", nil))
+		if w.Code != tt.want {
+			t.Errorf("%s: status = %d, want %d (body %s)", tt.name, w.Code, tt.want, w.Body.String())
+		}
+	}
+}
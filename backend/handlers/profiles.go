@@ -134,9 +134,11 @@ func (h *ProfilesHandler) GetAllProfiles(c *gin.Context) {
 	var profiles []models.Profile
 
 	query := database.DB.Order("created_at DESC")
+	meta := newListMeta("-created_at")
 
 	if role := c.Query("role"); role != "" {
 		query = query.Where("role = ?", role)
+		meta.filter("role", role)
 	}
 
 	result := query.Find(&profiles)
@@ -145,7 +147,7 @@ func (h *ProfilesHandler) GetAllProfiles(c *gin.Context) {
 		return
 	}
 
-	respondWithData(c, http.StatusOK, profiles)
+	respondWithList(c, profiles, meta)
 }
 
 // IsAdmin checks if a user has admin privileges
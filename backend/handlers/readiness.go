@@ -1,12 +1,18 @@
 package handlers
 
 import (
+	"errors"
+	"math"
 	"net/http"
+	"sort"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/pauly7610/studio-pilot-vision/backend/database"
+	"github.com/pauly7610/studio-pilot-vision/backend/middleware"
 	"github.com/pauly7610/studio-pilot-vision/backend/models"
+	"gorm.io/gorm"
 )
 
 type ReadinessHandler struct{}
@@ -17,7 +23,7 @@ func NewReadinessHandler() *ReadinessHandler {
 
 // GetProductReadiness retrieves readiness data for a specific product
 func (h *ReadinessHandler) GetProductReadiness(c *gin.Context) {
-	productID, err := uuid.Parse(c.Param("productId"))
+	productID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
 		return
@@ -36,7 +42,7 @@ func (h *ReadinessHandler) GetProductReadiness(c *gin.Context) {
 
 // CreateOrUpdateReadiness creates or updates readiness data for a product
 func (h *ReadinessHandler) CreateOrUpdateReadiness(c *gin.Context) {
-	productID, err := uuid.Parse(c.Param("productId"))
+	productID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
 		return
@@ -50,11 +56,23 @@ func (h *ReadinessHandler) CreateOrUpdateReadiness(c *gin.Context) {
 	}
 
 	var req models.CreateProductReadinessRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		respondWithError(c, http.StatusBadRequest, err.Error())
+	if !bindRequest(c, &req) {
 		return
 	}
 
+	// Partner enablement follows the partner rows unless the caller sets it;
+	// with no partner rows an existing value is left alone
+	if req.PartnerEnabledPct == nil {
+		enablement, err := partnerEnablement(database.DB, productID)
+		if err != nil {
+			respondWithError(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if enablement.Total > 0 {
+			req.PartnerEnabledPct = &enablement.EnabledPct
+		}
+	}
+
 	var existingReadiness models.ProductReadiness
 	result := database.DB.Where("product_id = ?", productID).First(&existingReadiness)
 
@@ -67,12 +85,17 @@ func (h *ReadinessHandler) CreateOrUpdateReadiness(c *gin.Context) {
 			PartnerEnabledPct:  req.PartnerEnabledPct,
 			OnboardingComplete: req.OnboardingComplete,
 			DocumentationScore: req.DocumentationScore,
-			ReadinessScore:     req.ReadinessScore,
-			RiskBand:           req.RiskBand,
 		}
-
-		if result := database.DB.Create(&readiness); result.Error != nil {
-			respondWithError(c, http.StatusInternalServerError, result.Error.Error())
+		deriveReadinessScore(&readiness, req.ReadinessScore, req.RiskBand)
+
+		err := database.DB.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Create(&readiness).Error; err != nil {
+				return err
+			}
+			return recordReadinessHistory(tx, readiness)
+		})
+		if err != nil {
+			respondWithError(c, http.StatusInternalServerError, err.Error())
 			return
 		}
 
@@ -81,6 +104,9 @@ func (h *ReadinessHandler) CreateOrUpdateReadiness(c *gin.Context) {
 	}
 
 	// Update existing readiness
+	if !checkVersion(c, "Readiness", req.Version, existingReadiness.Version) {
+		return
+	}
 	updates := make(map[string]interface{})
 	if req.ComplianceComplete != nil {
 		updates["compliance_complete"] = *req.ComplianceComplete
@@ -97,17 +123,257 @@ func (h *ReadinessHandler) CreateOrUpdateReadiness(c *gin.Context) {
 	if req.DocumentationScore != nil {
 		updates["documentation_score"] = *req.DocumentationScore
 	}
-	updates["readiness_score"] = req.ReadinessScore
-	updates["risk_band"] = req.RiskBand
 
-	if result := database.DB.Model(&existingReadiness).Updates(updates); result.Error != nil {
-		respondWithError(c, http.StatusInternalServerError, result.Error.Error())
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := versionedUpdates(tx, &existingReadiness, req.Version, updates); err != nil {
+			return err
+		}
+		if err := tx.First(&existingReadiness, "id = ?", existingReadiness.ID).Error; err != nil {
+			return err
+		}
+		if err := saveReadinessScore(tx, &existingReadiness, req.ReadinessScore, req.RiskBand); err != nil {
+			return err
+		}
+		return recordReadinessHistory(tx, existingReadiness)
+	})
+	if errors.Is(err, errVersionConflict) {
+		respondVersionConflict(c, "Readiness", *req.Version)
+		return
+	}
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
 	respondWithData(c, http.StatusOK, existingReadiness)
 }
 
+// deriveReadinessScore sets the score from the components and the risk band
+// from the score, keeping whichever of the two the caller supplied
+func deriveReadinessScore(readiness *models.ProductReadiness, score *float64, band *models.RiskBand) {
+	if score != nil {
+		readiness.ReadinessScore = *score
+	} else {
+		readiness.ReadinessScore = readiness.ComputeReadinessScore()
+	}
+	if band != nil {
+		readiness.RiskBand = *band
+	} else {
+		readiness.RiskBand = models.RiskBandForScore(readiness.ReadinessScore)
+	}
+}
+
+// saveReadinessScore derives the score and band for a stored readiness row
+// and persists them
+func saveReadinessScore(tx *gorm.DB, readiness *models.ProductReadiness, score *float64, band *models.RiskBand) error {
+	deriveReadinessScore(readiness, score, band)
+	return tx.Model(readiness).Updates(map[string]interface{}{
+		"readiness_score": readiness.ReadinessScore,
+		"risk_band":       readiness.RiskBand,
+	}).Error
+}
+
+// recordReadinessHistory appends a history row for the readiness state,
+// tagged with the ISO week it was recorded in. It is called inside the same
+// transaction as the readiness write.
+func recordReadinessHistory(tx *gorm.DB, readiness models.ProductReadiness) error {
+	history := newReadinessHistory(readiness, time.Now())
+	return tx.Create(&history).Error
+}
+
+// newReadinessHistory builds a history row capturing the readiness state at
+// recordedAt. The ISO week and year are derived from it on create.
+func newReadinessHistory(readiness models.ProductReadiness, recordedAt time.Time) models.ProductReadinessHistory {
+	riskBand := string(readiness.RiskBand)
+	return models.ProductReadinessHistory{
+		ProductID:          readiness.ProductID,
+		ReadinessScore:     int(math.Round(readiness.ReadinessScore)),
+		RiskBand:           &riskBand,
+		RecordedAt:         models.NewTimestamp(recordedAt),
+		ComplianceComplete: readiness.ComplianceComplete,
+		SalesTrainingPct:   readiness.SalesTrainingPct,
+		PartnerEnabledPct:  readiness.PartnerEnabledPct,
+		OnboardingComplete: readiness.OnboardingComplete,
+		DocumentationScore: readiness.DocumentationScore,
+	}
+}
+
+// ReadinessSnapshotResult reports a weekly readiness history snapshot
+type ReadinessSnapshotResult struct {
+	Year       int `json:"year"`
+	WeekNumber int `json:"week_number"`
+	Created    int `json:"created"`
+	Skipped    int `json:"skipped"`
+}
+
+// snapshotReadinessHistory writes a history row for every product with
+// readiness that has none yet for now's ISO week, so the trend line has a
+// point each week even when nobody edits the product. Running it again in
+// the same week skips every product.
+func snapshotReadinessHistory(db *gorm.DB, now time.Time) (ReadinessSnapshotResult, error) {
+	year, week := now.UTC().ISOWeek()
+	result := ReadinessSnapshotResult{Year: year, WeekNumber: week}
+
+	var readinesses []models.ProductReadiness
+	if err := db.Select("product_readiness.*").
+		Joins("JOIN products ON products.id = product_readiness.product_id").
+		Scopes(models.ExcludeDrafts, models.ExcludeArchived, models.ExcludeDeleted).
+		Find(&readinesses).Error; err != nil {
+		return result, err
+	}
+
+	for _, readiness := range readinesses {
+		created := false
+		err := db.Transaction(func(tx *gorm.DB) error {
+			var existing int64
+			if err := tx.Model(&models.ProductReadinessHistory{}).
+				Where("product_id = ? AND year = ? AND week_number = ?", readiness.ProductID, year, week).
+				Count(&existing).Error; err != nil {
+				return err
+			}
+			if existing > 0 {
+				return nil
+			}
+			history := newReadinessHistory(readiness, now)
+			created = true
+			return tx.Create(&history).Error
+		})
+		if err != nil {
+			return result, err
+		}
+		if created {
+			result.Created++
+		} else {
+			result.Skipped++
+		}
+	}
+
+	return result, nil
+}
+
+// SnapshotReadinessHistory records this ISO week's readiness history for
+// products that have no row for it yet. Meant for an external weekly
+// scheduler; safe to re-run.
+func (h *ReadinessHandler) SnapshotReadinessHistory(c *gin.Context) {
+	result, err := snapshotReadinessHistory(database.DB, time.Now())
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithData(c, http.StatusOK, result)
+}
+
+// readinessRecomputeBatchSize is how many readiness rows one recompute
+// transaction rewrites, so a large portfolio never holds every row locked
+const readinessRecomputeBatchSize = 100
+
+// ReadinessRecomputeSkip is a readiness row a recompute left alone
+type ReadinessRecomputeSkip struct {
+	ReadinessID uuid.UUID `json:"readiness_id"`
+	ProductID   uuid.UUID `json:"product_id"`
+	Reason      string    `json:"reason"`
+}
+
+// ReadinessRecomputeResult reports a bulk readiness recompute
+type ReadinessRecomputeResult struct {
+	Evaluated int                      `json:"evaluated"`
+	Updated   int                      `json:"updated"`
+	Unchanged int                      `json:"unchanged"`
+	Skipped   []ReadinessRecomputeSkip `json:"skipped"`
+}
+
+// hasReadinessComponents reports whether any component is recorded; a row
+// with none would score 0 from nothing, so it is not recomputed
+func hasReadinessComponents(readiness models.ProductReadiness) bool {
+	return readiness.ComplianceComplete != nil || readiness.SalesTrainingPct != nil ||
+		readiness.PartnerEnabledPct != nil || readiness.OnboardingComplete != nil ||
+		readiness.DocumentationScore != nil
+}
+
+// recomputeReadiness re-derives every readiness row's score and risk band
+// from its components, batchSize rows per transaction. Rows whose score or
+// band changes are updated and get a history snapshot. Batches committed
+// before an error stay committed; the result counts them.
+func recomputeReadiness(db *gorm.DB, batchSize int) (ReadinessRecomputeResult, error) {
+	result := ReadinessRecomputeResult{Skipped: []ReadinessRecomputeSkip{}}
+
+	lastID := uuid.Nil
+	for {
+		var batch []models.ProductReadiness
+		if err := db.Where("id > ?", lastID).Order("id ASC").Limit(batchSize).Find(&batch).Error; err != nil {
+			return result, err
+		}
+		if len(batch) == 0 {
+			return result, nil
+		}
+		lastID = batch[len(batch)-1].ID
+
+		var counts ReadinessRecomputeResult
+		err := db.Transaction(func(tx *gorm.DB) error {
+			counts = ReadinessRecomputeResult{}
+			for i := range batch {
+				readiness := batch[i]
+				counts.Evaluated++
+				if !hasReadinessComponents(readiness) {
+					counts.Skipped = append(counts.Skipped, ReadinessRecomputeSkip{
+						ReadinessID: readiness.ID,
+						ProductID:   readiness.ProductID,
+						Reason:      "no components recorded",
+					})
+					continue
+				}
+
+				score := readiness.ComputeReadinessScore()
+				band := models.RiskBandForScore(score)
+				if score == readiness.ReadinessScore && band == readiness.RiskBand {
+					counts.Unchanged++
+					continue
+				}
+				if err := versionedUpdates(tx, &readiness, nil, map[string]interface{}{
+					"readiness_score": score,
+					"risk_band":       band,
+				}); err != nil {
+					return err
+				}
+				readiness.ReadinessScore, readiness.RiskBand = score, band
+				if err := recordReadinessHistory(tx, readiness); err != nil {
+					return err
+				}
+				counts.Updated++
+			}
+			return nil
+		})
+		if err != nil {
+			return result, err
+		}
+
+		result.Evaluated += counts.Evaluated
+		result.Updated += counts.Updated
+		result.Unchanged += counts.Unchanged
+		result.Skipped = append(result.Skipped, counts.Skipped...)
+	}
+}
+
+// RecomputeReadiness re-derives every product's readiness score and risk
+// band from its current components, e.g. after the weighting changes.
+// Explicit score overrides are replaced. Safe to re-run.
+func (h *ReadinessHandler) RecomputeReadiness(c *gin.Context) {
+	result, err := recomputeReadiness(database.DB, readinessRecomputeBatchSize)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	middleware.LogAdminAction(c, "Readiness recomputed", map[string]interface{}{
+		"evaluated": result.Evaluated,
+		"updated":   result.Updated,
+		"skipped":   len(result.Skipped),
+	})
+
+	respondWithData(c, http.StatusOK, result)
+}
+
 // UpdateReadiness updates readiness data
 func (h *ReadinessHandler) UpdateReadiness(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
@@ -123,8 +389,10 @@ func (h *ReadinessHandler) UpdateReadiness(c *gin.Context) {
 	}
 
 	var req models.UpdateProductReadinessRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		respondWithError(c, http.StatusBadRequest, err.Error())
+	if !bindRequest(c, &req) {
+		return
+	}
+	if !checkVersion(c, "Readiness", req.Version, readiness.Version) {
 		return
 	}
 
@@ -144,16 +412,33 @@ func (h *ReadinessHandler) UpdateReadiness(c *gin.Context) {
 	if req.DocumentationScore != nil {
 		updates["documentation_score"] = *req.DocumentationScore
 	}
-	if req.ReadinessScore != nil {
-		updates["readiness_score"] = *req.ReadinessScore
-	}
-	if req.RiskBand != nil {
+	// The score is only re-derived when something it depends on changed, so
+	// an earlier explicit override survives unrelated edits
+	rescore := len(updates) > 0 || req.ReadinessScore != nil
+	if !rescore && req.RiskBand != nil {
 		updates["risk_band"] = *req.RiskBand
 	}
 
-	result := database.DB.Model(&readiness).Updates(updates)
-	if result.Error != nil {
-		respondWithError(c, http.StatusInternalServerError, result.Error.Error())
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := versionedUpdates(tx, &readiness, req.Version, updates); err != nil {
+			return err
+		}
+		if err := tx.First(&readiness, "id = ?", id).Error; err != nil {
+			return err
+		}
+		if rescore {
+			if err := saveReadinessScore(tx, &readiness, req.ReadinessScore, req.RiskBand); err != nil {
+				return err
+			}
+		}
+		return recordReadinessHistory(tx, readiness)
+	})
+	if errors.Is(err, errVersionConflict) {
+		respondVersionConflict(c, "Readiness", *req.Version)
+		return
+	}
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -182,22 +467,490 @@ func (h *ReadinessHandler) DeleteReadiness(c *gin.Context) {
 	respondWithSuccess(c, http.StatusOK, "Readiness data deleted successfully", nil)
 }
 
-// GetAllReadiness retrieves all readiness data
+// ReadinessProduct is the minimal product context embedded with ?include=product
+type ReadinessProduct struct {
+	Name           string                `json:"name"`
+	Region         string                `json:"region"`
+	LifecycleStage models.LifecycleStage `json:"lifecycle_stage"`
+}
+
+// ReadinessWithProduct is a readiness row with its product embedded
+type ReadinessWithProduct struct {
+	models.ProductReadiness
+	Product ReadinessProduct `json:"product"`
+}
+
+// GetAllReadiness retrieves all readiness data. ?include=product embeds the
+// product's name, region and lifecycle stage; ?region= filters by product region.
 func (h *ReadinessHandler) GetAllReadiness(c *gin.Context) {
-	var readinessData []models.ProductReadiness
+	include := c.Query("include")
+	if include != "" && include != "product" {
+		respondWithError(c, http.StatusBadRequest, "include must be 'product'")
+		return
+	}
+	region := c.Query("region")
 
-	query := database.DB
+	query := database.DB.Model(&models.ProductReadiness{})
+	meta := newListMeta("")
+	if include != "" {
+		meta.accept("include")
+	}
+	if region != "" {
+		meta.filter("region", region)
+	}
 
 	// Optional filtering by risk band
 	if riskBand := c.Query("risk_band"); riskBand != "" {
-		query = query.Where("risk_band = ?", riskBand)
+		query = query.Where("product_readiness.risk_band = ?", riskBand)
+		meta.filter("risk_band", riskBand)
+	}
+
+	if include == "" && region == "" {
+		var readinessData []models.ProductReadiness
+		if result := query.Find(&readinessData); result.Error != nil {
+			respondWithError(c, http.StatusInternalServerError, result.Error.Error())
+			return
+		}
+		respondWithList(c, readinessData, meta)
+		return
+	}
+
+	query = query.Joins("JOIN products ON products.id = product_readiness.product_id").
+		Scopes(models.ExcludeDeleted)
+	if region != "" {
+		query = query.Where("products.region = ?", region)
+	}
+
+	var rows []struct {
+		models.ProductReadiness
+		ProductName           string
+		ProductRegion         string
+		ProductLifecycleStage models.LifecycleStage
+	}
+	result := query.
+		Select("product_readiness.*, products.name AS product_name, products.region AS product_region, products.lifecycle_stage AS product_lifecycle_stage").
+		Scan(&rows)
+	if result.Error != nil {
+		respondWithError(c, http.StatusInternalServerError, result.Error.Error())
+		return
+	}
+
+	if include == "" {
+		readinessData := make([]models.ProductReadiness, len(rows))
+		for i, row := range rows {
+			readinessData[i] = row.ProductReadiness
+		}
+		respondWithList(c, readinessData, meta)
+		return
+	}
+
+	readinessData := make([]ReadinessWithProduct, len(rows))
+	for i, row := range rows {
+		readinessData[i] = ReadinessWithProduct{
+			ProductReadiness: row.ProductReadiness,
+			Product: ReadinessProduct{
+				Name:           row.ProductName,
+				Region:         row.ProductRegion,
+				LifecycleStage: row.ProductLifecycleStage,
+			},
+		}
+	}
+
+	respondWithList(c, readinessData, meta)
+}
+
+// Full readiness score = Σ weight × component score, giving 0-100. Each
+// component is 0-100:
+//
+//   - compliance (30):         complete certifications / all certifications
+//   - sales_training (25):     trained reps / total reps
+//   - partner_enablement (25): enabled partners / all partners
+//   - operational (20):        mean of onboarding complete (0 or 100) and documentation score
+//
+// A component falls back to the manual value on the readiness row when its
+// detail table has no rows for the product.
+const (
+	readinessWeightCompliance  = models.ReadinessWeightCompliance
+	readinessWeightTraining    = models.ReadinessWeightSalesTraining
+	readinessWeightPartners    = models.ReadinessWeightPartners
+	readinessWeightOperational = models.ReadinessWeightOnboarding + models.ReadinessWeightDocumentation
+)
+
+// ReadinessComponent is one weighted area of full readiness
+type ReadinessComponent struct {
+	Name         string                 `json:"name"`
+	Weight       float64                `json:"weight"`
+	Score        float64                `json:"score"`
+	Contribution float64                `json:"contribution"`
+	Source       string                 `json:"source"`
+	Details      map[string]interface{} `json:"details"`
+}
+
+// FullReadiness is the canonical readiness view: every component with the
+// overall score derived from them
+type FullReadiness struct {
+	ProductID    uuid.UUID                  `json:"product_id"`
+	OverallScore float64                    `json:"overall_score"`
+	Components   []ReadinessComponent       `json:"components"`
+	Stored       *models.ProductReadiness   `json:"stored_readiness,omitempty"`
+	StoredDelta  *float64                   `json:"stored_score_delta,omitempty"`
+	Training     *models.SalesTraining      `json:"training,omitempty"`
+	Partners     []models.ProductPartner    `json:"partners"`
+	Compliance   []models.ProductCompliance `json:"compliance"`
+}
+
+func boolScore(b *bool) float64 {
+	if b != nil && *b {
+		return 100
+	}
+	return 0
+}
+
+func floatOrZero(f *float64) float64 {
+	if f == nil {
+		return 0
+	}
+	return *f
+}
+
+// computeFullReadiness derives the component scores and overall score.
+// readiness and training may be nil.
+func computeFullReadiness(productID uuid.UUID, readiness *models.ProductReadiness, training *models.SalesTraining,
+	partners []models.ProductPartner, compliance []models.ProductCompliance) FullReadiness {
+	var stored models.ProductReadiness
+	if readiness != nil {
+		stored = *readiness
+	}
+
+	complianceComponent := ReadinessComponent{Name: "compliance", Weight: readinessWeightCompliance}
+	if len(compliance) > 0 {
+		complete := 0
+		for _, cert := range compliance {
+			if cert.Status == models.ComplianceStatusComplete {
+				complete++
+			}
+		}
+		complianceComponent.Score = float64(complete) * 100 / float64(len(compliance))
+		complianceComponent.Source = "product_compliance"
+		complianceComponent.Details = map[string]interface{}{"complete": complete, "total": len(compliance)}
+	} else {
+		complianceComponent.Score = boolScore(stored.ComplianceComplete)
+		complianceComponent.Source = "product_readiness"
+		complianceComponent.Details = map[string]interface{}{"compliance_complete": stored.ComplianceComplete}
+	}
+
+	trainingComponent := ReadinessComponent{Name: "sales_training", Weight: readinessWeightTraining}
+	if training != nil {
+		if training.TotalReps > 0 {
+			trainingComponent.Score = float64(training.TrainedReps) * 100 / float64(training.TotalReps)
+		}
+		trainingComponent.Source = "sales_training"
+		trainingComponent.Details = map[string]interface{}{"trained_reps": training.TrainedReps, "total_reps": training.TotalReps}
+	} else {
+		trainingComponent.Score = floatOrZero(stored.SalesTrainingPct)
+		trainingComponent.Source = "product_readiness"
+		trainingComponent.Details = map[string]interface{}{"sales_training_pct": stored.SalesTrainingPct}
+	}
+
+	partnerComponent := ReadinessComponent{Name: "partner_enablement", Weight: readinessWeightPartners}
+	if len(partners) > 0 {
+		enabled := 0
+		for _, partner := range partners {
+			if partner.Enabled != nil && *partner.Enabled {
+				enabled++
+			}
+		}
+		partnerComponent.Score = enabledPct(int64(enabled), int64(len(partners)))
+		partnerComponent.Source = "product_partners"
+		partnerComponent.Details = map[string]interface{}{"enabled": enabled, "total": len(partners)}
+	} else {
+		partnerComponent.Score = floatOrZero(stored.PartnerEnabledPct)
+		partnerComponent.Source = "product_readiness"
+		partnerComponent.Details = map[string]interface{}{"partner_enabled_pct": stored.PartnerEnabledPct}
+	}
+
+	operationalComponent := ReadinessComponent{
+		Name:   "operational",
+		Weight: readinessWeightOperational,
+		Score:  (boolScore(stored.OnboardingComplete) + floatOrZero(stored.DocumentationScore)) / 2,
+		Source: "product_readiness",
+		Details: map[string]interface{}{
+			"onboarding_complete": stored.OnboardingComplete,
+			"documentation_score": stored.DocumentationScore,
+		},
+	}
+
+	full := FullReadiness{
+		ProductID:  productID,
+		Training:   training,
+		Partners:   partners,
+		Compliance: compliance,
+	}
+	for _, component := range []ReadinessComponent{complianceComponent, trainingComponent, partnerComponent, operationalComponent} {
+		contribution := component.Weight * component.Score / 100
+		full.OverallScore += contribution
+		component.Score = roundTo2(component.Score)
+		component.Contribution = roundTo2(contribution)
+		full.Components = append(full.Components, component)
+	}
+	full.OverallScore = roundTo2(full.OverallScore)
+
+	if readiness != nil {
+		delta := roundTo2(full.OverallScore - readiness.ReadinessScore)
+		full.Stored = readiness
+		full.StoredDelta = &delta
+	}
+
+	return full
+}
+
+// GetFullReadiness returns readiness, training, partner enablement and
+// compliance for a product with the overall score derived from them
+func (h *ReadinessHandler) GetFullReadiness(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	var product models.Product
+	if result := database.DB.First(&product, "id = ?", productID); result.Error != nil {
+		respondWithError(c, http.StatusNotFound, "Product not found")
+		return
+	}
+
+	var readiness *models.ProductReadiness
+	var stored models.ProductReadiness
+	if database.DB.Where("product_id = ?", productID).First(&stored).Error == nil {
+		readiness = &stored
+	}
+
+	var training *models.SalesTraining
+	var storedTraining models.SalesTraining
+	if database.DB.Where("product_id = ?", productID).First(&storedTraining).Error == nil {
+		training = &storedTraining
+	}
+
+	partners := []models.ProductPartner{}
+	if err := database.DB.Where("product_id = ?", productID).Find(&partners).Error; err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	compliance := []models.ProductCompliance{}
+	if err := database.DB.Where("product_id = ?", productID).Find(&compliance).Error; err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithData(c, http.StatusOK, computeFullReadiness(productID, readiness, training, partners, compliance))
+}
+
+// Readiness components tracked in history
+const (
+	ComponentCompliance    = "compliance"
+	ComponentSalesTraining = "sales_training"
+	ComponentPartners      = "partner_enablement"
+	ComponentOnboarding    = "onboarding"
+	ComponentDocumentation = "documentation"
+)
+
+// ComponentChange is how far one component moved since the previous
+// snapshot, on a 0-100 scale (booleans count as 0 or 100)
+type ComponentChange struct {
+	Component string  `json:"component"`
+	From      float64 `json:"from"`
+	To        float64 `json:"to"`
+	Delta     float64 `json:"delta"`
+}
+
+// ReadinessComponentPoint is one readiness snapshot with its component
+// values. Components are null on snapshots recorded before they were captured.
+type ReadinessComponentPoint struct {
+	RecordedAt         models.Timestamp  `json:"recorded_at"`
+	ReadinessScore     int               `json:"readiness_score"`
+	ScoreChange        *int              `json:"score_change"`
+	RiskBand           *string           `json:"risk_band,omitempty"`
+	ComplianceComplete *bool             `json:"compliance_complete"`
+	SalesTrainingPct   *float64          `json:"sales_training_pct"`
+	PartnerEnabledPct  *float64          `json:"partner_enabled_pct"`
+	OnboardingComplete *bool             `json:"onboarding_complete"`
+	DocumentationScore *float64          `json:"documentation_score"`
+	Changes            []ComponentChange `json:"changes"`
+}
+
+func boolComponent(b *bool) *float64 {
+	if b == nil {
+		return nil
+	}
+	v := 0.0
+	if *b {
+		v = 100
+	}
+	return &v
+}
+
+// componentValues returns the history row's components on a 0-100 scale
+func componentValues(row models.ProductReadinessHistory) map[string]*float64 {
+	return map[string]*float64{
+		ComponentCompliance:    boolComponent(row.ComplianceComplete),
+		ComponentSalesTraining: row.SalesTrainingPct,
+		ComponentPartners:      row.PartnerEnabledPct,
+		ComponentOnboarding:    boolComponent(row.OnboardingComplete),
+		ComponentDocumentation: row.DocumentationScore,
+	}
+}
+
+// componentHistory turns history rows, oldest first, into points that list
+// which components moved since the previous snapshot, largest move first
+func componentHistory(rows []models.ProductReadinessHistory) []ReadinessComponentPoint {
+	points := make([]ReadinessComponentPoint, len(rows))
+	for i, row := range rows {
+		point := ReadinessComponentPoint{
+			RecordedAt:         row.RecordedAt,
+			ReadinessScore:     row.ReadinessScore,
+			RiskBand:           row.RiskBand,
+			ComplianceComplete: row.ComplianceComplete,
+			SalesTrainingPct:   row.SalesTrainingPct,
+			PartnerEnabledPct:  row.PartnerEnabledPct,
+			OnboardingComplete: row.OnboardingComplete,
+			DocumentationScore: row.DocumentationScore,
+			Changes:            []ComponentChange{},
+		}
+
+		if i > 0 {
+			previous := rows[i-1]
+			change := row.ReadinessScore - previous.ReadinessScore
+			point.ScoreChange = &change
+
+			before := componentValues(previous)
+			for name, to := range componentValues(row) {
+				from := before[name]
+				if from == nil || to == nil || *from == *to {
+					continue
+				}
+				point.Changes = append(point.Changes, ComponentChange{
+					Component: name,
+					From:      *from,
+					To:        *to,
+					Delta:     roundTo2(*to - *from),
+				})
+			}
+			sort.Slice(point.Changes, func(a, b int) bool {
+				da, db := math.Abs(point.Changes[a].Delta), math.Abs(point.Changes[b].Delta)
+				if da != db {
+					return da > db
+				}
+				return point.Changes[a].Component < point.Changes[b].Component
+			})
+		}
+		points[i] = point
+	}
+	return points
+}
+
+// GetReadinessHistory returns a product's readiness snapshots, oldest first,
+// for drawing the score trend
+func (h *ReadinessHandler) GetReadinessHistory(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	var history []models.ProductReadinessHistory
+	result := database.DB.
+		Where("product_id = ?", productID).
+		Order("recorded_at ASC").
+		Find(&history)
+	if result.Error != nil {
+		respondWithError(c, http.StatusInternalServerError, result.Error.Error())
+		return
+	}
+
+	respondWithData(c, http.StatusOK, history)
+}
+
+// GetReadinessComponentsHistory returns each readiness snapshot with its
+// component values, oldest first, and which components drove each change
+func (h *ReadinessHandler) GetReadinessComponentsHistory(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	var rows []models.ProductReadinessHistory
+	result := database.DB.
+		Where("product_id = ?", productID).
+		Order("recorded_at ASC").
+		Find(&rows)
+	if result.Error != nil {
+		respondWithError(c, http.StatusInternalServerError, result.Error.Error())
+		return
+	}
+
+	respondWithData(c, http.StatusOK, componentHistory(rows))
+}
+
+// ReadinessWeeklyPoint is a product's readiness as of the last snapshot in
+// an ISO week
+type ReadinessWeeklyPoint struct {
+	Year           int              `json:"year"`
+	WeekNumber     int              `json:"week_number"`
+	ReadinessScore int              `json:"readiness_score"`
+	RiskBand       *string          `json:"risk_band,omitempty"`
+	RecordedAt     models.Timestamp `json:"recorded_at"`
+	Snapshots      int              `json:"snapshots"` // recorded that week
+}
+
+// weeklyReadiness collapses history rows, oldest first, to one point per ISO
+// week holding the week's latest snapshot. Rows written before the week was
+// stored fall back to their recorded_at week.
+func weeklyReadiness(rows []models.ProductReadinessHistory) []ReadinessWeeklyPoint {
+	points := []ReadinessWeeklyPoint{}
+	for _, row := range rows {
+		year, week := row.RecordedAt.UTC().ISOWeek()
+		if row.Year != nil && row.WeekNumber != nil {
+			year, week = *row.Year, *row.WeekNumber
+		}
+		point := ReadinessWeeklyPoint{
+			Year:           year,
+			WeekNumber:     week,
+			ReadinessScore: row.ReadinessScore,
+			RiskBand:       row.RiskBand,
+			RecordedAt:     row.RecordedAt,
+			Snapshots:      1,
+		}
+		if last := len(points) - 1; last >= 0 && points[last].Year == year && points[last].WeekNumber == week {
+			point.Snapshots += points[last].Snapshots
+			points[last] = point
+			continue
+		}
+		points = append(points, point)
+	}
+	return points
+}
+
+// GetWeeklyReadiness returns one readiness point per ISO week, oldest first,
+// so the trend chart gets clean weekly buckets however often the product
+// was edited
+func (h *ReadinessHandler) GetWeeklyReadiness(c *gin.Context) {
+	productID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
+		return
 	}
 
-	result := query.Find(&readinessData)
+	var rows []models.ProductReadinessHistory
+	result := database.DB.
+		Where("product_id = ?", productID).
+		Order("recorded_at ASC").
+		Find(&rows)
 	if result.Error != nil {
 		respondWithError(c, http.StatusInternalServerError, result.Error.Error())
 		return
 	}
 
-	respondWithData(c, http.StatusOK, readinessData)
+	respondWithData(c, http.StatusOK, weeklyReadiness(rows))
 }
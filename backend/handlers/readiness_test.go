@@ -0,0 +1,326 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+)
+
+func TestComputeFullReadiness(t *testing.T) {
+	yes, no := true, false
+	docs := 60.0
+	readiness := &models.ProductReadiness{
+		ComplianceComplete: &no,
+		OnboardingComplete: &yes,
+		DocumentationScore: &docs,
+		ReadinessScore:     70,
+	}
+	training := &models.SalesTraining{TotalReps: 10, TrainedReps: 8}
+	partners := []models.ProductPartner{{Enabled: &yes}, {Enabled: &no}}
+	compliance := []models.ProductCompliance{
+		{Status: models.ComplianceStatusComplete},
+		{Status: models.ComplianceStatusComplete},
+		{Status: models.ComplianceStatusComplete},
+		{Status: models.ComplianceStatusPending},
+	}
+
+	full := computeFullReadiness(uuid.New(), readiness, training, partners, compliance)
+
+	// compliance 75 × 0.30 + training 80 × 0.25 + partners 50 × 0.25 + operational 80 × 0.20
+	if full.OverallScore != 71 {
+		t.Errorf("OverallScore = %v, want 71", full.OverallScore)
+	}
+
+	var sum float64
+	for _, component := range full.Components {
+		sum += component.Contribution
+	}
+	if roundTo2(sum) != full.OverallScore {
+		t.Errorf("components sum to %v, overall is %v", sum, full.OverallScore)
+	}
+	if full.StoredDelta == nil || *full.StoredDelta != 1 {
+		t.Errorf("StoredDelta = %v, want 1", full.StoredDelta)
+	}
+	if full.Components[0].Source != "product_compliance" {
+		t.Errorf("expected compliance from certification rows, got %s", full.Components[0].Source)
+	}
+}
+
+func TestComputeFullReadiness_FallsBackToReadinessRow(t *testing.T) {
+	yes := true
+	training, partners := 40.0, 20.0
+	readiness := &models.ProductReadiness{
+		ComplianceComplete: &yes,
+		SalesTrainingPct:   &training,
+		PartnerEnabledPct:  &partners,
+	}
+
+	full := computeFullReadiness(uuid.New(), readiness, nil, nil, nil)
+
+	// compliance 100 × 0.30 + training 40 × 0.25 + partners 20 × 0.25 + operational 0
+	if full.OverallScore != 45 {
+		t.Errorf("OverallScore = %v, want 45", full.OverallScore)
+	}
+	for _, component := range full.Components {
+		if component.Source != "product_readiness" {
+			t.Errorf("%s: expected fallback source, got %s", component.Name, component.Source)
+		}
+	}
+}
+
+func TestComponentHistory(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+	yes, no := true, false
+
+	rows := []models.ProductReadinessHistory{
+		// recorded before components were captured
+		{ReadinessScore: 70},
+		{ReadinessScore: 72, ComplianceComplete: &yes, SalesTrainingPct: f(80), PartnerEnabledPct: f(90), DocumentationScore: f(60)},
+		{ReadinessScore: 61, ComplianceComplete: &yes, SalesTrainingPct: f(85), PartnerEnabledPct: f(40), DocumentationScore: f(60)},
+		{ReadinessScore: 50, ComplianceComplete: &no, SalesTrainingPct: f(85), PartnerEnabledPct: f(40), DocumentationScore: f(60)},
+	}
+
+	points := componentHistory(rows)
+	if len(points) != 4 {
+		t.Fatalf("got %d points, want 4", len(points))
+	}
+	if points[0].ScoreChange != nil || points[0].SalesTrainingPct != nil || len(points[0].Changes) != 0 {
+		t.Errorf("first point = %+v, want no change and null components", points[0])
+	}
+	if len(points[1].Changes) != 0 {
+		t.Errorf("changes against a row without components = %+v, want none", points[1].Changes)
+	}
+
+	dip := points[2]
+	if dip.ScoreChange == nil || *dip.ScoreChange != -11 {
+		t.Fatalf("score change = %v, want -11", dip.ScoreChange)
+	}
+	if len(dip.Changes) != 2 || dip.Changes[0].Component != ComponentPartners || dip.Changes[0].Delta != -50 {
+		t.Errorf("changes = %+v, want partner_enablement -50 first", dip.Changes)
+	}
+
+	if got := points[3].Changes; len(got) != 1 || got[0].Component != ComponentCompliance || got[0].Delta != -100 {
+		t.Errorf("changes = %+v, want compliance -100", got)
+	}
+}
+
+const readinessHistoryDDL = `CREATE TABLE product_readiness_history (
+	id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(16)))),
+	product_id TEXT NOT NULL,
+	readiness_score INTEGER NOT NULL,
+	risk_band TEXT,
+	recorded_at DATETIME,
+	week_number INTEGER,
+	year INTEGER,
+	compliance_complete BOOLEAN,
+	sales_training_pct REAL,
+	partner_enabled_pct REAL,
+	onboarding_complete BOOLEAN,
+	documentation_score REAL
+)`
+
+func TestRecordReadinessHistory_TagsISOWeek(t *testing.T) {
+	db := openTestDB(t, readinessHistoryDDL)
+	readiness := models.ProductReadiness{
+		ProductID:      uuid.New(),
+		ReadinessScore: 72.6,
+		RiskBand:       models.RiskBandLow,
+	}
+	if err := recordReadinessHistory(db, readiness); err != nil {
+		t.Fatalf("recordReadinessHistory: %v", err)
+	}
+
+	var history models.ProductReadinessHistory
+	if err := db.First(&history, "product_id = ?", readiness.ProductID).Error; err != nil {
+		t.Fatalf("load history: %v", err)
+	}
+	if history.ReadinessScore != 73 || history.RiskBand == nil || *history.RiskBand != "low" {
+		t.Errorf("snapshot = %d/%v, want 73/low", history.ReadinessScore, history.RiskBand)
+	}
+	year, week := time.Now().UTC().ISOWeek()
+	if history.WeekNumber == nil || *history.WeekNumber != week || history.Year == nil || *history.Year != year {
+		t.Errorf("week/year = %v/%v, want %d/%d", history.WeekNumber, history.Year, week, year)
+	}
+}
+
+func TestSnapshotReadinessHistory_OncePerWeek(t *testing.T) {
+	db := openTestDB(t, readinessHistoryDDL,
+		`CREATE TABLE products (id TEXT PRIMARY KEY, is_draft BOOLEAN, archived_at DATETIME, deleted_at DATETIME)`,
+		`CREATE TABLE product_readiness (id TEXT PRIMARY KEY, product_id TEXT, readiness_score REAL, risk_band TEXT)`,
+	)
+	edited, idle, archived, noReadiness := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	stmts := []struct {
+		sql  string
+		args []interface{}
+	}{
+		{`INSERT INTO products VALUES (?, false, NULL, NULL), (?, false, NULL, NULL), (?, false, '2025-01-01', NULL), (?, false, NULL, NULL)`,
+			[]interface{}{edited, idle, archived, noReadiness}},
+		{`INSERT INTO product_readiness VALUES (?, ?, 80, 'low'), (?, ?, 55.4, 'medium'), (?, ?, 30, 'high')`,
+			[]interface{}{uuid.NewString(), edited, uuid.NewString(), idle, uuid.NewString(), archived}},
+		// edited already has a row this week from a readiness write
+		{`INSERT INTO product_readiness_history (product_id, readiness_score, week_number, year) VALUES (?, 80, 11, 2025), (?, 50, 10, 2025)`,
+			[]interface{}{edited, idle}},
+	}
+	for _, stmt := range stmts {
+		if err := db.Exec(stmt.sql, stmt.args...).Error; err != nil {
+			t.Fatalf("insert: %v", err)
+		}
+	}
+
+	now := time.Date(2025, 3, 12, 9, 0, 0, 0, time.UTC) // ISO week 11
+	result, err := snapshotReadinessHistory(db, now)
+	if err != nil {
+		t.Fatalf("snapshotReadinessHistory: %v", err)
+	}
+	if result != (ReadinessSnapshotResult{Year: 2025, WeekNumber: 11, Created: 1, Skipped: 1}) {
+		t.Errorf("first run = %+v, want 1 created, 1 skipped in 2025-W11", result)
+	}
+
+	var history models.ProductReadinessHistory
+	if err := db.First(&history, "product_id = ? AND week_number = 11", idle).Error; err != nil {
+		t.Fatalf("load snapshot: %v", err)
+	}
+	if history.ReadinessScore != 55 || *history.RiskBand != "medium" {
+		t.Errorf("snapshot = %d/%v, want 55/medium", history.ReadinessScore, *history.RiskBand)
+	}
+
+	result, err = snapshotReadinessHistory(db, now.AddDate(0, 0, 2))
+	if err != nil {
+		t.Fatalf("second run: %v", err)
+	}
+	if result.Created != 0 || result.Skipped != 2 {
+		t.Errorf("second run in the same week = %+v, want everything skipped", result)
+	}
+
+	var total int64
+	db.Model(&models.ProductReadinessHistory{}).Count(&total)
+	if total != 3 {
+		t.Errorf("history rows = %d, want 3", total)
+	}
+}
+
+func TestReadinessHistory_WeekFromRecordedAt(t *testing.T) {
+	db := openTestDB(t, readinessHistoryDDL)
+	// Sunday 2025-01-05 is still ISO week 1; 2024-12-30 is week 1 of 2025
+	for _, recorded := range []string{"2025-01-05T23:30:00Z", "2024-12-30T08:00:00Z"} {
+		at, _ := time.Parse(time.RFC3339, recorded)
+		history := newReadinessHistory(models.ProductReadiness{ProductID: uuid.New(), ReadinessScore: 50}, at)
+		if err := db.Create(&history).Error; err != nil {
+			t.Fatalf("create: %v", err)
+		}
+		if *history.Year != 2025 || *history.WeekNumber != 1 {
+			t.Errorf("%s: week/year = %d/%d, want 1/2025", recorded, *history.WeekNumber, *history.Year)
+		}
+	}
+}
+
+func TestWeeklyReadiness(t *testing.T) {
+	at := func(s string) models.Timestamp {
+		parsed, _ := time.Parse(time.RFC3339, s)
+		return models.NewTimestamp(parsed)
+	}
+	ten, eleven := 10, 11
+	year := 2025
+	rows := []models.ProductReadinessHistory{
+		{ReadinessScore: 40, RecordedAt: at("2025-03-03T09:00:00Z"), WeekNumber: &ten, Year: &year},
+		{ReadinessScore: 45, RecordedAt: at("2025-03-05T09:00:00Z"), WeekNumber: &ten, Year: &year},
+		{ReadinessScore: 48, RecordedAt: at("2025-03-09T22:00:00Z"), WeekNumber: &ten, Year: &year},
+		{ReadinessScore: 60, RecordedAt: at("2025-03-12T09:00:00Z"), WeekNumber: &eleven, Year: &year},
+		// written before week and year were stored
+		{ReadinessScore: 70, RecordedAt: at("2025-03-20T09:00:00Z")},
+		{ReadinessScore: 72, RecordedAt: at("2025-03-21T09:00:00Z")},
+	}
+
+	points := weeklyReadiness(rows)
+	want := []struct{ week, score, snapshots int }{{10, 48, 3}, {11, 60, 1}, {12, 72, 2}}
+	if len(points) != len(want) {
+		t.Fatalf("got %d points, want %d: %+v", len(points), len(want), points)
+	}
+	for i, w := range want {
+		p := points[i]
+		if p.Year != 2025 || p.WeekNumber != w.week || p.ReadinessScore != w.score || p.Snapshots != w.snapshots {
+			t.Errorf("point %d = %+v, want week %d score %d from %d snapshots", i, p, w.week, w.score, w.snapshots)
+		}
+	}
+
+	if points := weeklyReadiness(nil); points == nil || len(points) != 0 {
+		t.Errorf("no history = %#v, want an empty list", points)
+	}
+}
+
+func TestRecomputeReadiness(t *testing.T) {
+	db := openTestDB(t, readinessHistoryDDL,
+		`CREATE TABLE product_readiness (
+			id TEXT PRIMARY KEY, product_id TEXT, compliance_complete BOOLEAN, sales_training_pct REAL,
+			partner_enabled_pct REAL, onboarding_complete BOOLEAN, documentation_score REAL,
+			readiness_score REAL, risk_band TEXT, evaluated_at DATETIME, updated_at DATETIME, version INTEGER DEFAULT 1)`,
+	)
+	stale, current, empty, overridden := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	rows := []struct {
+		sql  string
+		args []interface{}
+	}{
+		// Scored under an older weighting: 30 + 25×0.8 = 50, medium
+		{`INSERT INTO product_readiness (id, product_id, compliance_complete, sales_training_pct, readiness_score, risk_band)
+			VALUES ('00000000-0000-0000-0000-000000000001', ?, true, 80, 62, 'medium')`, []interface{}{stale}},
+		{`INSERT INTO product_readiness (id, product_id, compliance_complete, sales_training_pct, partner_enabled_pct, onboarding_complete, documentation_score, readiness_score, risk_band)
+			VALUES ('00000000-0000-0000-0000-000000000002', ?, true, 100, 100, true, 100, 100, 'low')`, []interface{}{current}},
+		{`INSERT INTO product_readiness (id, product_id, readiness_score, risk_band)
+			VALUES ('00000000-0000-0000-0000-000000000003', ?, 55, 'medium')`, []interface{}{empty}},
+		// An explicit override: the components say 10, high
+		{`INSERT INTO product_readiness (id, product_id, documentation_score, readiness_score, risk_band)
+			VALUES ('00000000-0000-0000-0000-000000000004', ?, 100, 75, 'low')`, []interface{}{overridden}},
+	}
+	for _, row := range rows {
+		if err := db.Exec(row.sql, row.args...).Error; err != nil {
+			t.Fatalf("insert: %v", err)
+		}
+	}
+
+	result, err := recomputeReadiness(db, 3)
+	if err != nil {
+		t.Fatalf("recomputeReadiness: %v", err)
+	}
+	if result.Evaluated != 4 || result.Updated != 2 || result.Unchanged != 1 || len(result.Skipped) != 1 {
+		t.Fatalf("result = %+v, want 4 evaluated, 2 updated, 1 unchanged, 1 skipped", result)
+	}
+	if skip := result.Skipped[0]; skip.ProductID != empty || skip.Reason == "" {
+		t.Errorf("skipped = %+v, want the product with no components", skip)
+	}
+
+	for product, want := range map[uuid.UUID]struct {
+		score   float64
+		band    models.RiskBand
+		version int
+	}{
+		stale:      {50, models.RiskBandMedium, 2},
+		overridden: {10, models.RiskBandHigh, 2},
+		current:    {100, models.RiskBandLow, 1},
+		empty:      {55, models.RiskBandMedium, 1},
+	} {
+		var readiness models.ProductReadiness
+		if err := db.First(&readiness, "product_id = ?", product).Error; err != nil {
+			t.Fatalf("load readiness: %v", err)
+		}
+		if readiness.ReadinessScore != want.score || readiness.RiskBand != want.band || readiness.Version != want.version {
+			t.Errorf("readiness = %v/%s v%d, want %v/%s v%d", readiness.ReadinessScore, readiness.RiskBand,
+				readiness.Version, want.score, want.band, want.version)
+		}
+	}
+
+	var history int64
+	db.Model(&models.ProductReadinessHistory{}).Count(&history)
+	if history != 2 {
+		t.Errorf("history rows = %d, want one per updated row", history)
+	}
+
+	result, err = recomputeReadiness(db, 3)
+	if err != nil {
+		t.Fatalf("second run: %v", err)
+	}
+	if result.Updated != 0 || result.Unchanged != 3 {
+		t.Errorf("second run = %+v, want nothing updated", result)
+	}
+}
@@ -2,8 +2,13 @@ package handlers
 
 import (
 	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type ErrorResponse struct {
@@ -17,36 +22,238 @@ type SuccessResponse struct {
 }
 
 type PaginatedResponse struct {
-	Data       interface{} `json:"data"`
-	Total      int64       `json:"total"`
-	Page       int         `json:"page"`
-	PageSize   int         `json:"page_size"`
-	TotalPages int         `json:"total_pages"`
+	Data interface{} `json:"data"`
+	Pagination
+	Meta *ListMeta `json:"meta,omitempty"`
+}
+
+// Pagination locates one page within a list
+type Pagination struct {
+	Total      int64 `json:"total"`
+	Page       int   `json:"page"`
+	PageSize   int   `json:"page_size"`
+	TotalPages int   `json:"total_pages"`
+}
+
+// envelopeMediaType opts a request into the Envelope response shape. Until
+// clients have moved over, responses keep their legacy shape unless Accept
+// lists it.
+const envelopeMediaType = "application/vnd.studio-pilot.v2+json"
+
+// Envelope is the one response shape handlers use for clients accepting
+// envelopeMediaType: the payload under data, anything describing it under
+// meta, and on failure a null data and the error
+type Envelope struct {
+	Data  interface{}    `json:"data"`
+	Meta  *EnvelopeMeta  `json:"meta,omitempty"`
+	Error *EnvelopeError `json:"error,omitempty"`
+}
+
+// EnvelopeMeta describes an enveloped payload: lists carry the ListMeta
+// fields, pages add Pagination, and writes confirmed with a message carry it
+type EnvelopeMeta struct {
+	*ListMeta
+	Pagination *Pagination `json:"pagination,omitempty"`
+	Message    string      `json:"message,omitempty"`
+}
+
+// EnvelopeError is a failed request's status and what went wrong
+type EnvelopeError struct {
+	Status  int    `json:"status"`
+	Code    string `json:"code"` // the status text, e.g. "Not Found"
+	Message string `json:"message,omitempty"`
+}
+
+// ListMeta echoes how a list request was interpreted: the filters and sort
+// that were applied and any query parameters the endpoint does not support
+type ListMeta struct {
+	Filters map[string]string `json:"filters"`
+	Sort    string            `json:"sort,omitempty"`
+	Ignored []string          `json:"ignored,omitempty"`
+	Count   int               `json:"count"`
+
+	// recognized holds non-filter parameters the endpoint honored
+	recognized map[string]bool
+}
+
+// ListResponse wraps a list with its ListMeta when the client asks for ?meta=true
+type ListResponse struct {
+	Data interface{} `json:"data"`
+	Meta ListMeta    `json:"meta"`
+}
+
+// listControlParams are understood by every list endpoint
+var listControlParams = map[string]bool{"meta": true, "page": true, "page_size": true}
+
+func newListMeta(sort string) *ListMeta {
+	return &ListMeta{Filters: map[string]string{}, Sort: sort, recognized: map[string]bool{}}
+}
+
+// filter records a filter that was applied to the query
+func (m *ListMeta) filter(name, value string) {
+	m.Filters[name] = value
+}
+
+// accept records a non-filter parameter the endpoint honored, like ?include=
+func (m *ListMeta) accept(name string) {
+	m.recognized[name] = true
+}
+
+func (m *ListMeta) finish(c *gin.Context, data interface{}) {
+	if v := reflect.ValueOf(data); v.Kind() == reflect.Slice {
+		m.Count = v.Len()
+	}
+	for name := range c.Request.URL.Query() {
+		if _, applied := m.Filters[name]; applied || m.recognized[name] || listControlParams[name] {
+			continue
+		}
+		m.Ignored = append(m.Ignored, name)
+	}
+	sort.Strings(m.Ignored)
+}
+
+func wantsListMeta(c *gin.Context) bool {
+	return c.Query("meta") == "true"
+}
+
+// wantsEnvelope reports whether the request's Accept header lists
+// envelopeMediaType. Every response varies on Accept, so it is noted for
+// caches either way.
+func wantsEnvelope(c *gin.Context) bool {
+	c.Writer.Header().Add("Vary", "Accept")
+	if c.Request == nil {
+		return false
+	}
+	for _, accept := range strings.Split(c.GetHeader("Accept"), ",") {
+		mediaType, _, _ := strings.Cut(accept, ";")
+		if strings.EqualFold(strings.TrimSpace(mediaType), envelopeMediaType) {
+			return true
+		}
+	}
+	return false
 }
 
 func respondWithError(c *gin.Context, code int, message string) {
+	if wantsEnvelope(c) {
+		c.JSON(code, Envelope{Error: &EnvelopeError{Status: code, Code: http.StatusText(code), Message: message}})
+		return
+	}
 	c.JSON(code, ErrorResponse{Error: http.StatusText(code), Message: message})
 }
 
 func respondWithSuccess(c *gin.Context, code int, message string, data interface{}) {
+	if wantsEnvelope(c) {
+		c.JSON(code, Envelope{Data: data, Meta: &EnvelopeMeta{Message: message}})
+		return
+	}
 	c.JSON(code, SuccessResponse{Message: message, Data: data})
 }
 
 func respondWithData(c *gin.Context, code int, data interface{}) {
+	if wantsEnvelope(c) {
+		c.JSON(code, Envelope{Data: data})
+		return
+	}
 	c.JSON(code, data)
 }
 
-func respondWithPagination(c *gin.Context, data interface{}, total int64, page, pageSize int) {
+// respondWithList sends a bare list, or a ListResponse with ?meta=true. An
+// enveloped list always carries its meta.
+func respondWithList(c *gin.Context, data interface{}, meta *ListMeta) {
+	if wantsEnvelope(c) {
+		meta.finish(c, data)
+		c.JSON(http.StatusOK, Envelope{Data: data, Meta: &EnvelopeMeta{ListMeta: meta}})
+		return
+	}
+	if !wantsListMeta(c) {
+		c.JSON(http.StatusOK, data)
+		return
+	}
+	meta.finish(c, data)
+	c.JSON(http.StatusOK, ListResponse{Data: data, Meta: *meta})
+}
+
+// respondWithPagination sends one page of a list; with ?meta=true the
+// applied filters are echoed alongside the pagination. An enveloped page
+// always carries both in its meta.
+func respondWithPagination(c *gin.Context, data interface{}, total int64, page, pageSize int, meta *ListMeta) {
 	totalPages := int(total) / pageSize
 	if int(total)%pageSize > 0 {
 		totalPages++
 	}
+	pagination := Pagination{Total: total, Page: page, PageSize: pageSize, TotalPages: totalPages}
+
+	if wantsEnvelope(c) {
+		meta.finish(c, data)
+		c.JSON(http.StatusOK, Envelope{Data: data, Meta: &EnvelopeMeta{ListMeta: meta, Pagination: &pagination}})
+		return
+	}
+
+	response := PaginatedResponse{Data: data, Pagination: pagination}
+	if wantsListMeta(c) {
+		meta.finish(c, data)
+		response.Meta = meta
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+
+	// Top-level list endpoints use larger pages
+	defaultListPageSize = 50
+	maxListPageSize     = 200
+)
 
-	c.JSON(http.StatusOK, PaginatedResponse{
-		Data:       data,
-		Total:      total,
-		Page:       page,
-		PageSize:   pageSize,
-		TotalPages: totalPages,
-	})
+// parsePagination reads ?page= and ?page_size=, clamping out-of-range or
+// unparseable values to the defaults and bounds rather than erroring
+func parsePagination(c *gin.Context, defaultSize, maxSize int) (int, int) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	pageSize, err := strconv.Atoi(c.DefaultQuery("page_size", strconv.Itoa(defaultSize)))
+	if err != nil || pageSize < 1 {
+		pageSize = defaultSize
+	}
+	if pageSize > maxSize {
+		pageSize = maxSize
+	}
+
+	return page, pageSize
+}
+
+// paginate counts the rows query matches and returns it limited to one page.
+// query must already carry its filters; model names the table to count.
+func paginate(query *gorm.DB, model interface{}, page, pageSize int) (*gorm.DB, int64, error) {
+	base := query.Session(&gorm.Session{})
+
+	var total int64
+	if err := base.Model(model).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	return base.Offset((page - 1) * pageSize).Limit(pageSize), total, nil
+}
+
+// validatable is a request that checks its own fields after binding
+type validatable interface {
+	Validate() error
+}
+
+// bindRequest binds the JSON body into req and runs its Validate method, if
+// it has one. On failure it responds 400 and returns false.
+func bindRequest(c *gin.Context, req interface{}) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return false
+	}
+	if v, ok := req.(validatable); ok {
+		if err := v.Validate(); err != nil {
+			respondWithError(c, http.StatusBadRequest, err.Error())
+			return false
+		}
+	}
+	return true
 }
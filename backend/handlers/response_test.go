@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+)
+
+func TestRespondWithList(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	run := func(url string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, url, nil)
+
+		meta := newListMeta("-created_at")
+		meta.filter("status", "open")
+		respondWithList(c, []string{"a", "b"}, meta)
+		return w
+	}
+
+	w := run("/actions?status=open&colour=red")
+	if got := w.Body.String(); got != `["a","b"]` {
+		t.Errorf("without meta got %s, want bare array", got)
+	}
+
+	w = run("/actions?status=open&colour=red&sort=due_date&meta=true")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d", w.Code)
+	}
+	var body struct {
+		Data []string `json:"data"`
+		Meta ListMeta `json:"meta"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Meta.Count != 2 || body.Meta.Sort != "-created_at" {
+		t.Errorf("meta = %+v", body.Meta)
+	}
+	if !reflect.DeepEqual(body.Meta.Filters, map[string]string{"status": "open"}) {
+		t.Errorf("filters = %v", body.Meta.Filters)
+	}
+	if !reflect.DeepEqual(body.Meta.Ignored, []string{"colour", "sort"}) {
+		t.Errorf("ignored = %v, want [colour sort]", body.Meta.Ignored)
+	}
+}
+
+func TestRespondWithEnvelope(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	run := func(accept string, respond func(c *gin.Context)) (*httptest.ResponseRecorder, map[string]json.RawMessage) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/products?region=EMEA", nil)
+		c.Request.Header.Set("Accept", accept)
+		respond(c)
+
+		var body map[string]json.RawMessage
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("%s: %v", w.Body.String(), err)
+		}
+		return w, body
+	}
+	accept := "application/json, " + envelopeMediaType + ";q=0.9"
+
+	t.Run("legacy by default", func(t *testing.T) {
+		w, body := run("application/json", func(c *gin.Context) {
+			respondWithData(c, http.StatusOK, gin.H{"id": "p1"})
+		})
+		if string(body["id"]) != `"p1"` || w.Header().Get("Vary") != "Accept" {
+			t.Errorf("body = %s, Vary = %q", w.Body.String(), w.Header().Get("Vary"))
+		}
+	})
+
+	t.Run("data", func(t *testing.T) {
+		_, body := run(accept, func(c *gin.Context) {
+			respondWithData(c, http.StatusOK, gin.H{"id": "p1"})
+		})
+		if string(body["data"]) != `{"id":"p1"}` || body["meta"] != nil || body["error"] != nil {
+			t.Errorf("body = %v", body)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		_, body := run(accept, func(c *gin.Context) {
+			respondWithSuccess(c, http.StatusCreated, "Product created", gin.H{"id": "p1"})
+		})
+		if string(body["data"]) != `{"id":"p1"}` || string(body["meta"]) != `{"message":"Product created"}` {
+			t.Errorf("body = %v", body)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		w, body := run(accept, func(c *gin.Context) {
+			respondWithError(c, http.StatusNotFound, "Product not found")
+		})
+		if w.Code != http.StatusNotFound || string(body["data"]) != "null" {
+			t.Errorf("status = %d, body = %v", w.Code, body)
+		}
+		if string(body["error"]) != `{"status":404,"code":"Not Found","message":"Product not found"}` {
+			t.Errorf("error = %s", body["error"])
+		}
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		_, body := run(accept, func(c *gin.Context) {
+			meta := newListMeta("name")
+			meta.filter("region", "EMEA")
+			respondWithPagination(c, []string{"a", "b"}, 5, 1, 2, meta)
+		})
+		var meta struct {
+			Count      int               `json:"count"`
+			Filters    map[string]string `json:"filters"`
+			Pagination Pagination        `json:"pagination"`
+		}
+		if err := json.Unmarshal(body["meta"], &meta); err != nil {
+			t.Fatal(err)
+		}
+		if meta.Count != 2 || meta.Filters["region"] != "EMEA" {
+			t.Errorf("meta = %+v", meta)
+		}
+		if meta.Pagination != (Pagination{Total: 5, Page: 1, PageSize: 2, TotalPages: 3}) {
+			t.Errorf("pagination = %+v", meta.Pagination)
+		}
+		if body["total"] != nil {
+			t.Errorf("pagination leaked to the top level: %v", body)
+		}
+	})
+}
+
+func TestParsePagination_Clamps(t *testing.T) {
+	tests := []struct {
+		query      string
+		page, size int
+	}{
+		{"", 1, defaultListPageSize},
+		{"?page=3&page_size=10", 3, 10},
+		{"?page=0&page_size=0", 1, defaultListPageSize},
+		{"?page=-2&page_size=5000", 1, maxListPageSize},
+		{"?page=abc&page_size=xyz", 1, defaultListPageSize},
+	}
+	for _, tt := range tests {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest("GET", "/products"+tt.query, nil)
+		page, size := parsePagination(c, defaultListPageSize, maxListPageSize)
+		if page != tt.page || size != tt.size {
+			t.Errorf("%q: got page %d size %d, want %d %d", tt.query, page, size, tt.page, tt.size)
+		}
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	db := openTestDB(t, productActionsDDL)
+	productID := uuid.New()
+	for _, title := range []string{"a", "b", "c", "d", "e"} {
+		status := models.ActionStatusPending
+		if title == "e" {
+			status = models.ActionStatusCompleted
+		}
+		action := models.ProductAction{ProductID: productID, ActionType: models.ActionTypeReview, Title: title, Status: status}
+		if err := db.Create(&action).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	query := db.Order("title DESC").Where("status = ?", models.ActionStatusPending)
+	pageQuery, total, err := paginate(query, &models.ProductAction{}, 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var actions []models.ProductAction
+	if err := pageQuery.Find(&actions).Error; err != nil {
+		t.Fatal(err)
+	}
+	if total != 4 {
+		t.Errorf("total = %d, want 4", total)
+	}
+	if len(actions) != 1 || actions[0].Title != "a" {
+		t.Errorf("page 2 = %+v, want only action a", actions)
+	}
+}
+
+func TestBindRequest_RejectsInvalidEnum(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	run := func(body string) (*httptest.ResponseRecorder, bool) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/products", strings.NewReader(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		var req models.CreateProductRequest
+		return w, bindRequest(c, &req)
+	}
+
+	w, ok := run(`{"name": "Wallet", "product_type": "payment_flows", "lifecycle_stage": "pilott", "region": "Europe", "owner_email": "a@x.com"}`)
+	if ok || w.Code != http.StatusBadRequest {
+		t.Fatalf("bindRequest = %v, status %d; want false, 400", ok, w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "must be one of concept") {
+		t.Errorf("body %s should list the allowed lifecycle stages", w.Body.String())
+	}
+
+	w, ok = run(`{"name": "Wallet", "product_type": "payment_flows", "lifecycle_stage": "pilot", "region": "Europe", "owner_email": "a@x.com"}`)
+	if !ok {
+		t.Errorf("valid request rejected: %s", w.Body.String())
+	}
+}
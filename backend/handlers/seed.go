@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pauly7610/studio-pilot-vision/backend/database"
+)
+
+// SeedHandler loads demo data into fresh, non-production environments
+type SeedHandler struct {
+	production bool
+}
+
+func NewSeedHandler(production bool) *SeedHandler {
+	return &SeedHandler{production: production}
+}
+
+// SeedDemoData inserts the demo portfolio. It is refused in production and
+// does nothing when the database has already been seeded.
+func (h *SeedHandler) SeedDemoData(c *gin.Context) {
+	if h.production {
+		respondWithError(c, http.StatusForbidden, "Seeding is disabled in production")
+		return
+	}
+
+	seeded, err := database.Seed(c.Request.Context())
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	status := http.StatusCreated
+	if !seeded {
+		status = http.StatusOK
+	}
+	respondWithData(c, status, gin.H{"seeded": seeded})
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/pauly7610/studio-pilot-vision/backend/database"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultStalePredictionDays = 30
+	maxStalePredictionDays     = 365
+)
+
+// StalePrediction is a product whose latest prediction predates the cutoff,
+// or that has never been scored
+type StalePrediction struct {
+	ProductID       uuid.UUID             `json:"product_id"`
+	Name            string                `json:"name"`
+	Region          string                `json:"region"`
+	LifecycleStage  models.LifecycleStage `json:"lifecycle_stage"`
+	OwnerEmail      string                `json:"owner_email"`
+	LastScoredAt    *models.Timestamp     `json:"last_scored_at"` // null if never scored
+	DaysSinceScored *int                  `json:"days_since_scored"`
+}
+
+// stalePredictions returns the live products whose latest prediction was
+// scored before cutoff or that have none, never-scored first and then
+// oldest first, in one grouped query
+func stalePredictions(db *gorm.DB, cutoff, now time.Time) ([]StalePrediction, error) {
+	var stale []StalePrediction
+	err := db.Model(&models.Product{}).
+		Select(`products.id AS product_id, products.name, products.region, products.lifecycle_stage,
+			products.owner_email, MAX(product_predictions.scored_at) AS last_scored_at`).
+		Joins("LEFT JOIN product_predictions ON product_predictions.product_id = products.id").
+		Scopes(models.ExcludeDrafts, models.ExcludeArchived).
+		Group("products.id, products.name, products.region, products.lifecycle_stage, products.owner_email").
+		Having("MAX(product_predictions.scored_at) IS NULL OR MAX(product_predictions.scored_at) < ?", cutoff).
+		Order("last_scored_at ASC NULLS FIRST, products.name ASC").
+		Scan(&stale).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range stale {
+		if scored := stale[i].LastScoredAt; scored != nil && !scored.IsZero() {
+			days := int(now.Sub(scored.Time).Hours() / 24)
+			stale[i].DaysSinceScored = &days
+		} else {
+			stale[i].LastScoredAt = nil
+		}
+	}
+	if stale == nil {
+		stale = []StalePrediction{}
+	}
+	return stale, nil
+}
+
+// GetStalePredictions lists products whose latest prediction is older than
+// ?older_than_days= days (default 30) or that have never been scored, so the
+// scoring pipeline knows what to rescore
+func (h *PredictionsHandler) GetStalePredictions(c *gin.Context) {
+	days := defaultStalePredictionDays
+	if raw := c.Query("older_than_days"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > maxStalePredictionDays {
+			respondWithError(c, http.StatusBadRequest, fmt.Sprintf("older_than_days must be between 1 and %d", maxStalePredictionDays))
+			return
+		}
+		days = parsed
+	}
+
+	now := time.Now()
+	stale, err := stalePredictions(database.DB, now.AddDate(0, 0, -days), now)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithData(c, http.StatusOK, stale)
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStalePredictions(t *testing.T) {
+	db := openTestDB(t,
+		`CREATE TABLE products (id TEXT PRIMARY KEY, name TEXT, region TEXT, lifecycle_stage TEXT, owner_email TEXT,
+			is_draft BOOLEAN DEFAULT false, archived_at DATETIME, deleted_at DATETIME)`,
+		`CREATE TABLE product_predictions (id TEXT PRIMARY KEY, product_id TEXT, model_version TEXT, scored_at DATETIME)`,
+		`INSERT INTO products (id, name, region, lifecycle_stage, owner_email) VALUES
+			('8d1e5a42-1f6b-4c55-9b39-0a4c1b0e0001', 'Fresh', 'EU', 'pilot', 'a@example.com'),
+			('8d1e5a42-1f6b-4c55-9b39-0a4c1b0e0002', 'Old', 'EU', 'pilot', 'b@example.com'),
+			('8d1e5a42-1f6b-4c55-9b39-0a4c1b0e0003', 'Never', 'LATAM', 'concept', 'c@example.com'),
+			('8d1e5a42-1f6b-4c55-9b39-0a4c1b0e0004', 'Older', 'APAC', 'scaling', 'd@example.com')`,
+		`INSERT INTO products (id, name, archived_at) VALUES ('8d1e5a42-1f6b-4c55-9b39-0a4c1b0e0005', 'Archived', '2025-01-01')`,
+		`INSERT INTO products (id, name, deleted_at) VALUES ('8d1e5a42-1f6b-4c55-9b39-0a4c1b0e0006', 'Deleted', '2025-01-01')`,
+		`INSERT INTO product_predictions (id, product_id, model_version, scored_at) VALUES
+			('p1', '8d1e5a42-1f6b-4c55-9b39-0a4c1b0e0001', 'v1', '2025-01-10 00:00:00'),
+			('p2', '8d1e5a42-1f6b-4c55-9b39-0a4c1b0e0001', 'v2', '2025-03-25 00:00:00'),
+			('p3', '8d1e5a42-1f6b-4c55-9b39-0a4c1b0e0002', 'v1', '2025-02-19 12:00:00'),
+			('p4', '8d1e5a42-1f6b-4c55-9b39-0a4c1b0e0004', 'v1', '2024-12-31 12:00:00')`,
+	)
+	now := time.Date(2025, 3, 31, 12, 0, 0, 0, time.UTC)
+
+	stale, err := stalePredictions(db, now.AddDate(0, 0, -30), now)
+	if err != nil {
+		t.Fatalf("stalePredictions: %v", err)
+	}
+	want := []struct {
+		name string
+		days int // -1 for never scored
+	}{{"Never", -1}, {"Older", 90}, {"Old", 40}}
+	if len(stale) != len(want) {
+		t.Fatalf("got %d stale products, want %d: %+v", len(stale), len(want), stale)
+	}
+	for i, w := range want {
+		got := stale[i]
+		if got.Name != w.name {
+			t.Errorf("stale[%d] = %s, want %s", i, got.Name, w.name)
+			continue
+		}
+		if w.days < 0 {
+			if got.LastScoredAt != nil || got.DaysSinceScored != nil {
+				t.Errorf("%s: last scored %v, want never", got.Name, got.LastScoredAt)
+			}
+		} else if got.DaysSinceScored == nil || *got.DaysSinceScored != w.days {
+			t.Errorf("%s: days since scored = %v, want %d", got.Name, got.DaysSinceScored, w.days)
+		}
+	}
+
+	stale, err = stalePredictions(db, now.AddDate(0, 0, -365), now)
+	if err != nil {
+		t.Fatalf("stalePredictions: %v", err)
+	}
+	if len(stale) != 1 || stale[0].Name != "Never" {
+		t.Errorf("a year's threshold = %+v, want only the never-scored product", stale)
+	}
+}
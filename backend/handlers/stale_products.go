@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/pauly7610/studio-pilot-vision/backend/database"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+)
+
+// Activity types reported as a product's most recent touch
+const (
+	ActivityProductUpdate = "product_update"
+	ActivityMetric        = "metric"
+	ActivityFeedback      = "feedback"
+	ActivityAction        = "action"
+)
+
+const maxStaleDays = 365
+
+// productActivitySQL yields the latest timestamp of each activity type per
+// product in a single pass over the product and child tables
+const productActivitySQL = `SELECT product_id, activity_type, MAX(activity_at) AS last_activity_at FROM (
+	SELECT id AS product_id, 'product_update' AS activity_type, updated_at AS activity_at FROM products
+	UNION ALL SELECT product_id, 'metric', created_at FROM product_metrics
+	UNION ALL SELECT product_id, 'feedback', created_at FROM product_feedback
+	UNION ALL SELECT product_id, 'action', updated_at FROM product_actions
+) activity
+GROUP BY product_id, activity_type`
+
+// productActivity is the latest touch of one type on one product
+type productActivity struct {
+	ProductID      uuid.UUID
+	ActivityType   string
+	LastActivityAt time.Time
+}
+
+// StaleProduct is a product with no activity since the cutoff
+type StaleProduct struct {
+	ProductID        string                `json:"product_id"`
+	Name             string                `json:"name"`
+	Region           string                `json:"region"`
+	LifecycleStage   models.LifecycleStage `json:"lifecycle_stage"`
+	OwnerEmail       string                `json:"owner_email"`
+	LastActivityAt   models.Timestamp      `json:"last_activity_at"`
+	LastActivityType string                `json:"last_activity_type"`
+	DaysInactive     int                   `json:"days_inactive"`
+}
+
+// latestActivity reduces per-type activity rows to each product's most
+// recent one
+func latestActivity(rows []productActivity) map[uuid.UUID]productActivity {
+	latest := make(map[uuid.UUID]productActivity, len(rows))
+	for _, row := range rows {
+		if current, ok := latest[row.ProductID]; !ok || row.LastActivityAt.After(current.LastActivityAt) {
+			latest[row.ProductID] = row
+		}
+	}
+	return latest
+}
+
+// staleProducts returns the products whose latest activity predates cutoff,
+// longest inactive first
+func staleProducts(products []models.Product, latest map[uuid.UUID]productActivity, cutoff, now time.Time) []StaleProduct {
+	stale := []StaleProduct{}
+	for _, product := range products {
+		activity, ok := latest[product.ID]
+		if !ok {
+			activity = productActivity{ActivityType: ActivityProductUpdate, LastActivityAt: product.UpdatedAt.Time}
+		}
+		if !activity.LastActivityAt.Before(cutoff) {
+			continue
+		}
+		stale = append(stale, StaleProduct{
+			ProductID:        product.ID.String(),
+			Name:             product.Name,
+			Region:           product.Region,
+			LifecycleStage:   product.LifecycleStage,
+			OwnerEmail:       product.OwnerEmail,
+			LastActivityAt:   models.NewTimestamp(activity.LastActivityAt),
+			LastActivityType: activity.ActivityType,
+			DaysInactive:     int(now.Sub(activity.LastActivityAt).Hours() / 24),
+		})
+	}
+
+	sort.SliceStable(stale, func(i, j int) bool {
+		return stale[i].LastActivityAt.Before(stale[j].LastActivityAt.Time)
+	})
+	return stale
+}
+
+// GetStaleProducts lists products with no update, metric, feedback or action
+// activity in the last ?days= days (default STALE_PRODUCT_DAYS). Unlike data
+// freshness this ignores field completeness and looks only at activity.
+func (h *ProductHandler) GetStaleProducts(c *gin.Context) {
+	days := h.staleDays
+	if raw := c.Query("days"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > maxStaleDays {
+			respondWithError(c, http.StatusBadRequest, "days must be between 1 and 365")
+			return
+		}
+		days = parsed
+	}
+
+	var rows []productActivity
+	if err := database.DB.Raw(productActivitySQL).Scan(&rows).Error; err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	var products []models.Product
+	if err := database.DB.Scopes(models.ExcludeDrafts, models.ExcludeArchived).Find(&products).Error; err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	now := time.Now()
+	cutoff := now.AddDate(0, 0, -days)
+	respondWithData(c, http.StatusOK, staleProducts(products, latestActivity(rows), cutoff, now))
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+)
+
+func TestStaleProducts(t *testing.T) {
+	now := time.Date(2025, 3, 31, 12, 0, 0, 0, time.UTC)
+	cutoff := now.AddDate(0, 0, -30)
+	daysAgo := func(n int) time.Time { return now.AddDate(0, 0, -n) }
+
+	abandoned := models.Product{ID: uuid.New(), Name: "Abandoned", UpdatedAt: models.NewTimestamp(daysAgo(90))}
+	quiet := models.Product{ID: uuid.New(), Name: "Quiet", UpdatedAt: models.NewTimestamp(daysAgo(60))}
+	busy := models.Product{ID: uuid.New(), Name: "Busy", UpdatedAt: models.NewTimestamp(daysAgo(60))}
+
+	rows := []productActivity{
+		{abandoned.ID, ActivityProductUpdate, daysAgo(90)},
+		{quiet.ID, ActivityProductUpdate, daysAgo(60)},
+		{quiet.ID, ActivityFeedback, daysAgo(45)},
+		{quiet.ID, ActivityMetric, daysAgo(50)},
+		{busy.ID, ActivityProductUpdate, daysAgo(60)},
+		{busy.ID, ActivityAction, daysAgo(3)},
+	}
+
+	stale := staleProducts([]models.Product{busy, quiet, abandoned}, latestActivity(rows), cutoff, now)
+	if len(stale) != 2 {
+		t.Fatalf("got %d stale products, want 2: %+v", len(stale), stale)
+	}
+	if stale[0].Name != "Abandoned" || stale[0].DaysInactive != 90 || stale[0].LastActivityType != ActivityProductUpdate {
+		t.Errorf("first = %+v, want Abandoned, 90 days, product_update", stale[0])
+	}
+	if stale[1].Name != "Quiet" || stale[1].DaysInactive != 45 || stale[1].LastActivityType != ActivityFeedback {
+		t.Errorf("second = %+v, want Quiet, 45 days, feedback", stale[1])
+	}
+}
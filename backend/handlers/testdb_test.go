@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/pauly7610/studio-pilot-vision/backend/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// openTestDB returns an in-memory SQLite database with the given tables. The
+// tables are created by hand because the production schema relies on
+// Postgres-only defaults such as gen_random_uuid().
+func openTestDB(t *testing.T, ddl ...string) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	// Every connection to :memory: is a separate database
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("sql db: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+
+	for _, stmt := range ddl {
+		if err := db.Exec(stmt).Error; err != nil {
+			t.Fatalf("create table: %v", err)
+		}
+	}
+	return db
+}
+
+// useTestDB points database.DB, which handlers query directly, at db for the
+// rest of the test
+func useTestDB(t *testing.T, db *gorm.DB) {
+	t.Helper()
+	previous := database.DB
+	database.DB = db
+	t.Cleanup(func() { database.DB = previous })
+}
@@ -1,7 +1,10 @@
 package handlers
 
 import (
+	"math"
 	"net/http"
+	"sort"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -17,7 +20,7 @@ func NewTrainingHandler() *TrainingHandler {
 
 // GetProductTraining retrieves training data for a product
 func (h *TrainingHandler) GetProductTraining(c *gin.Context) {
-	productID, err := uuid.Parse(c.Param("productId"))
+	productID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
 		return
@@ -36,7 +39,7 @@ func (h *TrainingHandler) GetProductTraining(c *gin.Context) {
 
 // CreateOrUpdateTraining creates or updates training data
 func (h *TrainingHandler) CreateOrUpdateTraining(c *gin.Context) {
-	productID, err := uuid.Parse(c.Param("productId"))
+	productID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
 		return
@@ -104,7 +107,7 @@ func (h *TrainingHandler) GetAllTraining(c *gin.Context) {
 		return
 	}
 
-	respondWithData(c, http.StatusOK, training)
+	respondWithList(c, training, newListMeta(""))
 }
 
 // DeleteTraining deletes training data
@@ -128,3 +131,129 @@ func (h *TrainingHandler) DeleteTraining(c *gin.Context) {
 
 	respondWithSuccess(c, http.StatusOK, "Training data deleted successfully", nil)
 }
+
+const (
+	defaultTrainingCoverageThreshold = 80.0
+	staleTrainingDays                = 90
+)
+
+// trainingRow is a product's training record with the product context the
+// gap list needs
+type trainingRow struct {
+	ProductID        uuid.UUID
+	ProductName      string
+	Region           string
+	LifecycleStage   models.LifecycleStage
+	TotalReps        int
+	TrainedReps      int
+	LastTrainingDate *models.Date
+}
+
+// TrainingGap is one product's sales training coverage against the target
+type TrainingGap struct {
+	ProductID         string                `json:"product_id"`
+	ProductName       string                `json:"product_name"`
+	Region            string                `json:"region"`
+	LifecycleStage    models.LifecycleStage `json:"lifecycle_stage"`
+	TrainedReps       int                   `json:"trained_reps"`
+	TotalReps         int                   `json:"total_reps"`
+	CoveragePct       float64               `json:"coverage_pct"`
+	GapPct            float64               `json:"gap_pct"`
+	LastTrainingDate  *models.Date          `json:"last_training_date"`
+	DaysSinceTraining *int                  `json:"days_since_training"`
+	Stale             bool                  `json:"stale"`
+}
+
+// TrainingGaps is the enablement worklist: products under the coverage
+// threshold, and separately every product whose training has gone stale
+type TrainingGaps struct {
+	Threshold      float64       `json:"threshold"`
+	StaleAfterDays int           `json:"stale_after_days"`
+	Gaps           []TrainingGap `json:"gaps"`
+	Stale          []TrainingGap `json:"stale"`
+}
+
+// trainingGaps builds the worklist. Coverage is derived from the rep counts
+// rather than the stored coverage_pct. Gaps are sorted widest first; stale
+// training, including products never trained, oldest first.
+func trainingGaps(rows []trainingRow, threshold float64, today models.Date) TrainingGaps {
+	result := TrainingGaps{
+		Threshold:      threshold,
+		StaleAfterDays: staleTrainingDays,
+		Gaps:           []TrainingGap{},
+		Stale:          []TrainingGap{},
+	}
+
+	for _, row := range rows {
+		coverage := 0.0
+		if row.TotalReps > 0 {
+			coverage = roundTo2(float64(row.TrainedReps) * 100 / float64(row.TotalReps))
+		}
+		gap := TrainingGap{
+			ProductID:        row.ProductID.String(),
+			ProductName:      row.ProductName,
+			Region:           row.Region,
+			LifecycleStage:   row.LifecycleStage,
+			TrainedReps:      row.TrainedReps,
+			TotalReps:        row.TotalReps,
+			CoveragePct:      coverage,
+			GapPct:           roundTo2(math.Max(threshold-coverage, 0)),
+			LastTrainingDate: row.LastTrainingDate,
+			Stale:            true,
+		}
+		if row.LastTrainingDate != nil {
+			days := int(today.Sub(row.LastTrainingDate.Time).Hours() / 24)
+			gap.DaysSinceTraining = &days
+			gap.Stale = days > staleTrainingDays
+		}
+
+		if coverage < threshold {
+			result.Gaps = append(result.Gaps, gap)
+		}
+		if gap.Stale {
+			result.Stale = append(result.Stale, gap)
+		}
+	}
+
+	sort.SliceStable(result.Gaps, func(i, j int) bool {
+		return result.Gaps[i].GapPct > result.Gaps[j].GapPct
+	})
+	sort.SliceStable(result.Stale, func(i, j int) bool {
+		a, b := result.Stale[i].DaysSinceTraining, result.Stale[j].DaysSinceTraining
+		if a == nil || b == nil {
+			return a == nil && b != nil
+		}
+		return *a > *b
+	})
+	return result
+}
+
+// GetTrainingGaps lists products whose sales training coverage is below
+// ?threshold= percent (default 80), widest gap first, and separately those
+// with no training in the last 90 days
+func (h *TrainingHandler) GetTrainingGaps(c *gin.Context) {
+	threshold := defaultTrainingCoverageThreshold
+	if raw := c.Query("threshold"); raw != "" {
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil || parsed < 0 || parsed > 100 {
+			respondWithError(c, http.StatusBadRequest, "threshold must be a percentage between 0 and 100")
+			return
+		}
+		threshold = parsed
+	}
+
+	var rows []trainingRow
+	err := database.DB.Model(&models.SalesTraining{}).
+		Select(`sales_trainings.product_id, products.name AS product_name, products.region,
+			products.lifecycle_stage, sales_trainings.total_reps, sales_trainings.trained_reps,
+			sales_trainings.last_training_date`).
+		Joins("JOIN products ON products.id = sales_trainings.product_id").
+		Scopes(models.ExcludeDrafts, models.ExcludeArchived, models.ExcludeDeleted).
+		Scan(&rows).Error
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithData(c, http.StatusOK, trainingGaps(rows, threshold, models.Today()))
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+)
+
+func TestTrainingGaps(t *testing.T) {
+	date := func(s string) *models.Date {
+		d := mustDate(t, s)
+		return &d
+	}
+	rows := []trainingRow{
+		{ProductID: uuid.New(), ProductName: "Wallet", TotalReps: 40, TrainedReps: 30, LastTrainingDate: date("2025-03-01")},
+		{ProductID: uuid.New(), ProductName: "Payouts", TotalReps: 50, TrainedReps: 10, LastTrainingDate: date("2024-10-01")},
+		{ProductID: uuid.New(), ProductName: "Rewards", TotalReps: 20, TrainedReps: 19, LastTrainingDate: date("2024-11-01")},
+		{ProductID: uuid.New(), ProductName: "Lending", TotalReps: 0, TrainedReps: 0},
+	}
+
+	gaps := trainingGaps(rows, 80, mustDate(t, "2025-03-15"))
+
+	wantGaps := []struct {
+		name     string
+		coverage float64
+		gap      float64
+		stale    bool
+	}{
+		{"Lending", 0, 80, true},
+		{"Payouts", 20, 60, true},
+		{"Wallet", 75, 5, false},
+	}
+	if len(gaps.Gaps) != len(wantGaps) {
+		t.Fatalf("got %d gaps, want %d: %+v", len(gaps.Gaps), len(wantGaps), gaps.Gaps)
+	}
+	for i, w := range wantGaps {
+		got := gaps.Gaps[i]
+		if got.ProductName != w.name || got.CoveragePct != w.coverage || got.GapPct != w.gap || got.Stale != w.stale {
+			t.Errorf("gap %d = %+v, want %+v", i, got, w)
+		}
+	}
+	if gaps.Gaps[2].DaysSinceTraining == nil || *gaps.Gaps[2].DaysSinceTraining != 14 {
+		t.Errorf("Wallet days_since_training = %v, want 14", gaps.Gaps[2].DaysSinceTraining)
+	}
+
+	// Rewards is above the threshold but still stale; never-trained products
+	// lead the stale list
+	var stale []string
+	for _, s := range gaps.Stale {
+		stale = append(stale, s.ProductName)
+	}
+	if len(stale) != 3 || stale[0] != "Lending" || stale[1] != "Payouts" || stale[2] != "Rewards" {
+		t.Errorf("stale = %v, want [Lending Payouts Rewards]", stale)
+	}
+}
@@ -8,17 +8,22 @@ import (
 	"github.com/google/uuid"
 	"github.com/pauly7610/studio-pilot-vision/backend/database"
 	"github.com/pauly7610/studio-pilot-vision/backend/models"
+	"gorm.io/gorm"
 )
 
-type TransitionHandler struct{}
+type TransitionHandler struct {
+	bauReadyPercent int
+}
 
-func NewTransitionHandler() *TransitionHandler {
-	return &TransitionHandler{}
+// NewTransitionHandler creates the handler; a product is ready for BAU
+// handover once bauReadyPercent of its transition items are complete
+func NewTransitionHandler(bauReadyPercent int) *TransitionHandler {
+	return &TransitionHandler{bauReadyPercent: bauReadyPercent}
 }
 
 // GetProductTransitionReadiness returns transition readiness for a product
 func (h *TransitionHandler) GetProductTransitionReadiness(c *gin.Context) {
-	productID, err := uuid.Parse(c.Param("productId"))
+	productID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
 		return
@@ -35,7 +40,11 @@ func (h *TransitionHandler) GetProductTransitionReadiness(c *gin.Context) {
 
 	// If no items exist, create default ones
 	if len(items) == 0 {
-		items = createDefaultTransitionItems(productID)
+		items, err = createDefaultTransitionItems(database.DB, productID)
+		if err != nil {
+			respondWithError(c, http.StatusInternalServerError, err.Error())
+			return
+		}
 	}
 
 	// Calculate stats
@@ -79,7 +88,7 @@ func (h *TransitionHandler) GetProductTransitionReadiness(c *gin.Context) {
 		ProductID:      productID.String(),
 		ProductName:    product.Name,
 		OverallPercent: overallPercent,
-		IsReadyForBAU:  overallPercent >= 80,
+		IsReadyForBAU:  overallPercent >= h.bauReadyPercent,
 		SalesComplete:  salesComplete,
 		SalesTotal:     salesTotal,
 		TechComplete:   techComplete,
@@ -94,7 +103,7 @@ func (h *TransitionHandler) GetProductTransitionReadiness(c *gin.Context) {
 
 // GetTransitionItems returns all transition items for a product
 func (h *TransitionHandler) GetTransitionItems(c *gin.Context) {
-	productID, err := uuid.Parse(c.Param("productId"))
+	productID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		respondWithError(c, http.StatusBadRequest, "Invalid product ID")
 		return
@@ -223,8 +232,9 @@ func (h *TransitionHandler) DeleteTransitionItem(c *gin.Context) {
 	respondWithSuccess(c, http.StatusOK, "Item deleted successfully", nil)
 }
 
-// Helper to create default transition items
-func createDefaultTransitionItems(productID uuid.UUID) []models.TransitionItem {
+// Helper to create default transition items. The checklist is created in a
+// single transaction so a failure never leaves a partial set behind.
+func createDefaultTransitionItems(db *gorm.DB, productID uuid.UUID) ([]models.TransitionItem, error) {
 	defaults := []struct {
 		Category    models.TransitionCategory
 		Name        string
@@ -251,17 +261,26 @@ func createDefaultTransitionItems(productID uuid.UUID) []models.TransitionItem {
 	}
 
 	var items []models.TransitionItem
-	for _, d := range defaults {
-		item := models.TransitionItem{
-			ProductID:   productID,
-			Category:    d.Category,
-			Name:        d.Name,
-			Description: &d.Description,
-			Complete:    false,
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, d := range defaults {
+			description := d.Description
+			item := models.TransitionItem{
+				ProductID:   productID,
+				Category:    d.Category,
+				Name:        d.Name,
+				Description: &description,
+				Complete:    false,
+			}
+			if err := tx.Create(&item).Error; err != nil {
+				return err
+			}
+			items = append(items, item)
 		}
-		database.DB.Create(&item)
-		items = append(items, item)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return items
+	return items, nil
 }
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+	"gorm.io/gorm"
+)
+
+const transitionItemsDDL = `CREATE TABLE transition_items (
+	id TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(16)))),
+	product_id TEXT NOT NULL,
+	category TEXT NOT NULL,
+	name TEXT NOT NULL,
+	description TEXT,
+	complete BOOLEAN NOT NULL DEFAULT false,
+	completed_at DATETIME,
+	completed_by TEXT,
+	owner TEXT,
+	due_date DATETIME,
+	created_at DATETIME,
+	updated_at DATETIME
+)`
+
+func TestCreateDefaultTransitionItems(t *testing.T) {
+	db := openTestDB(t, transitionItemsDDL)
+	productID := uuid.New()
+
+	items, err := createDefaultTransitionItems(db, productID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var count int64
+	db.Model(&models.TransitionItem{}).Where("product_id = ?", productID).Count(&count)
+	if count != int64(len(items)) || count != 15 {
+		t.Errorf("persisted %d items, returned %d, want 15", count, len(items))
+	}
+}
+
+func TestCreateDefaultTransitionItems_RollsBackOnFailure(t *testing.T) {
+	db := openTestDB(t, transitionItemsDDL)
+	productID := uuid.New()
+
+	// Fail the 8th insert, midway through the tech checklist
+	inserts := 0
+	injected := errors.New("injected failure")
+	err := db.Callback().Create().Before("gorm:create").Register("test:fail_midway", func(tx *gorm.DB) {
+		inserts++
+		if inserts == 8 {
+			tx.AddError(injected)
+		}
+	})
+	if err != nil {
+		t.Fatalf("register callback: %v", err)
+	}
+
+	items, err := createDefaultTransitionItems(db, productID)
+	if !errors.Is(err, injected) {
+		t.Fatalf("expected injected error, got %v", err)
+	}
+	if items != nil {
+		t.Errorf("expected no items on failure, got %d", len(items))
+	}
+
+	var count int64
+	db.Model(&models.TransitionItem{}).Where("product_id = ?", productID).Count(&count)
+	if count != 0 {
+		t.Errorf("expected rollback to leave 0 items, found %d", count)
+	}
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// errVersionConflict reports an update based on a version of the row that is
+// no longer current
+var errVersionConflict = errors.New("version conflict")
+
+// versionedUpdates applies updates to the row behind model and bumps its
+// version, reading the new version back into model. With expected set the
+// write only lands while the stored version still matches, so of two edits
+// made from the same version the second gets errVersionConflict instead of
+// silently overwriting the first.
+func versionedUpdates(tx *gorm.DB, model interface{}, expected *int, updates map[string]interface{}) error {
+	values := make(map[string]interface{}, len(updates)+1)
+	for column, value := range updates {
+		values[column] = value
+	}
+	values["version"] = gorm.Expr("version + 1")
+
+	query := tx.Model(model).Clauses(clause.Returning{Columns: []clause.Column{{Name: "version"}}})
+	if expected != nil {
+		query = query.Where("version = ?", *expected)
+	}
+	result := query.Updates(values)
+	if result.Error != nil {
+		return result.Error
+	}
+	if expected != nil && result.RowsAffected == 0 {
+		return errVersionConflict
+	}
+	return nil
+}
+
+// checkVersion responds 409 and returns false when the client's expected
+// version is set and differs from the stored one
+func checkVersion(c *gin.Context, resource string, expected *int, current int) bool {
+	if expected == nil || *expected == current {
+		return true
+	}
+	respondVersionConflict(c, resource, *expected)
+	return false
+}
+
+func respondVersionConflict(c *gin.Context, resource string, expected int) {
+	respondWithError(c, http.StatusConflict,
+		fmt.Sprintf("%s has changed since version %d; reload it and retry", resource, expected))
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+)
+
+const versionedReadinessDDL = `CREATE TABLE product_readiness (
+	id TEXT PRIMARY KEY, product_id TEXT NOT NULL, compliance_complete BOOLEAN,
+	sales_training_pct REAL, partner_enabled_pct REAL, onboarding_complete BOOLEAN,
+	documentation_score REAL, readiness_score REAL NOT NULL, risk_band TEXT NOT NULL,
+	evaluated_at DATETIME, updated_at DATETIME, version INTEGER NOT NULL DEFAULT 1)`
+
+func TestVersionedUpdates(t *testing.T) {
+	db := openTestDB(t, versionedReadinessDDL)
+
+	readiness := models.ProductReadiness{ProductID: uuid.New(), ReadinessScore: 50, RiskBand: models.RiskBandMedium}
+	if err := db.Create(&readiness).Error; err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if readiness.Version != 1 {
+		t.Fatalf("new row version = %d, want 1", readiness.Version)
+	}
+
+	// Two clients both read version 1
+	first, second := readiness, readiness
+	expected := 1
+
+	if err := versionedUpdates(db, &first, &expected, map[string]interface{}{"readiness_score": 60}); err != nil {
+		t.Fatalf("first update: %v", err)
+	}
+	if first.Version != 2 {
+		t.Errorf("version after update = %d, want 2", first.Version)
+	}
+
+	err := versionedUpdates(db, &second, &expected, map[string]interface{}{"readiness_score": 70})
+	if !errors.Is(err, errVersionConflict) {
+		t.Fatalf("stale update err = %v, want errVersionConflict", err)
+	}
+
+	var stored models.ProductReadiness
+	db.First(&stored, "id = ?", readiness.ID)
+	if stored.ReadinessScore != 60 || stored.Version != 2 {
+		t.Errorf("stored score %v version %d; the stale write should not land", stored.ReadinessScore, stored.Version)
+	}
+
+	// Without an expected version the write always lands and still bumps
+	if err := versionedUpdates(db, &stored, nil, map[string]interface{}{"risk_band": models.RiskBandLow}); err != nil {
+		t.Fatalf("unguarded update: %v", err)
+	}
+	if stored.Version != 3 {
+		t.Errorf("version after unguarded update = %d, want 3", stored.Version)
+	}
+}
+
+func TestCheckVersion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	stale, current := 2, 3
+	for _, tt := range []struct {
+		name     string
+		expected *int
+		ok       bool
+	}{
+		{"omitted", nil, true},
+		{"current", &current, true},
+		{"stale", &stale, false},
+	} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		if ok := checkVersion(c, "Product", tt.expected, current); ok != tt.ok {
+			t.Errorf("%s: checkVersion = %v, want %v", tt.name, ok, tt.ok)
+		}
+		if !tt.ok && (w.Code != http.StatusConflict || !strings.Contains(w.Body.String(), "since version 2")) {
+			t.Errorf("%s: got %d %s, want 409 naming the stale version", tt.name, w.Code, w.Body.String())
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/pauly7610/studio-pilot-vision/backend/database"
+	"github.com/pauly7610/studio-pilot-vision/backend/middleware"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+	"gorm.io/gorm"
+)
+
+// WebhookSubscriptionsHandler manages outbound webhook subscriptions. Events
+// are delivered by notify.Dispatcher.
+type WebhookSubscriptionsHandler struct{}
+
+func NewWebhookSubscriptionsHandler() *WebhookSubscriptionsHandler {
+	return &WebhookSubscriptionsHandler{}
+}
+
+// GetSubscriptions lists subscriptions, newest first (?active=true|false)
+func (h *WebhookSubscriptionsHandler) GetSubscriptions(c *gin.Context) {
+	query := database.DB.Order("created_at DESC")
+	meta := newListMeta("-created_at")
+
+	if active := c.Query("active"); active != "" {
+		query = query.Where("active = ?", active == "true")
+		meta.filter("active", strconv.FormatBool(active == "true"))
+	}
+
+	var subscriptions []models.WebhookSubscription
+	if result := query.Find(&subscriptions); result.Error != nil {
+		respondWithError(c, http.StatusInternalServerError, result.Error.Error())
+		return
+	}
+
+	respondWithList(c, subscriptions, meta)
+}
+
+// GetSubscription retrieves a single subscription
+func (h *WebhookSubscriptionsHandler) GetSubscription(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "Invalid subscription ID")
+		return
+	}
+
+	var subscription models.WebhookSubscription
+	if result := database.DB.First(&subscription, "id = ?", id); result.Error != nil {
+		respondWithError(c, http.StatusNotFound, "Subscription not found")
+		return
+	}
+
+	respondWithData(c, http.StatusOK, subscription)
+}
+
+// CreateSubscription registers an endpoint for the listed event types
+func (h *WebhookSubscriptionsHandler) CreateSubscription(c *gin.Context) {
+	var req models.CreateWebhookSubscriptionRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	subscription := models.WebhookSubscription{
+		URL:         req.URL,
+		EventTypes:  req.EventTypes,
+		Secret:      req.Secret,
+		Description: req.Description,
+		Active:      true,
+	}
+	if req.Active != nil {
+		subscription.Active = *req.Active
+	}
+	if userID := currentUserID(c); userID != "" {
+		subscription.CreatedBy = &userID
+	}
+
+	if result := database.DB.Create(&subscription); result.Error != nil {
+		respondWithError(c, http.StatusInternalServerError, result.Error.Error())
+		return
+	}
+
+	middleware.LogAdminAction(c, "Webhook subscription created", map[string]interface{}{
+		"subscription_id": subscription.ID.String(),
+		"url":             subscription.URL,
+	})
+
+	respondWithData(c, http.StatusCreated, subscription)
+}
+
+// UpdateSubscription changes a subscription's URL, events, secret or state
+func (h *WebhookSubscriptionsHandler) UpdateSubscription(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "Invalid subscription ID")
+		return
+	}
+
+	var subscription models.WebhookSubscription
+	if result := database.DB.First(&subscription, "id = ?", id); result.Error != nil {
+		respondWithError(c, http.StatusNotFound, "Subscription not found")
+		return
+	}
+
+	var req models.UpdateWebhookSubscriptionRequest
+	if !bindRequest(c, &req) {
+		return
+	}
+
+	updates := make(map[string]interface{})
+	if req.URL != nil {
+		updates["url"] = *req.URL
+	}
+	if req.EventTypes != nil {
+		updates["event_types"] = req.EventTypes
+	}
+	if req.Secret != nil {
+		updates["secret"] = *req.Secret
+	}
+	if req.Description != nil {
+		updates["description"] = *req.Description
+	}
+	if req.Active != nil {
+		updates["active"] = *req.Active
+	}
+
+	if result := database.DB.Model(&subscription).Updates(updates); result.Error != nil {
+		respondWithError(c, http.StatusInternalServerError, result.Error.Error())
+		return
+	}
+
+	middleware.LogAdminAction(c, "Webhook subscription updated", map[string]interface{}{
+		"subscription_id": subscription.ID.String(),
+		"secret_rotated":  req.Secret != nil,
+	})
+
+	respondWithData(c, http.StatusOK, subscription)
+}
+
+// DeleteSubscription removes a subscription along with its delivery history
+func (h *WebhookSubscriptionsHandler) DeleteSubscription(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "Invalid subscription ID")
+		return
+	}
+
+	var deleted int64
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("subscription_id = ?", id).Delete(&models.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&models.WebhookSubscription{}, "id = ?", id)
+		deleted = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if deleted == 0 {
+		respondWithError(c, http.StatusNotFound, "Subscription not found")
+		return
+	}
+
+	middleware.LogAdminAction(c, "Webhook subscription deleted", map[string]interface{}{
+		"subscription_id": id.String(),
+	})
+
+	respondWithSuccess(c, http.StatusOK, "Subscription deleted successfully", nil)
+}
+
+// GetDeliveries lists a subscription's delivery attempts, newest first
+// (?succeeded=true|false)
+func (h *WebhookSubscriptionsHandler) GetDeliveries(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "Invalid subscription ID")
+		return
+	}
+
+	var subscription models.WebhookSubscription
+	if result := database.DB.First(&subscription, "id = ?", id); result.Error != nil {
+		respondWithError(c, http.StatusNotFound, "Subscription not found")
+		return
+	}
+
+	query := database.DB.Where("subscription_id = ?", id).Order("attempted_at DESC")
+	meta := newListMeta("-attempted_at")
+	if succeeded := c.Query("succeeded"); succeeded != "" {
+		query = query.Where("succeeded = ?", succeeded == "true")
+		meta.filter("succeeded", strconv.FormatBool(succeeded == "true"))
+	}
+
+	page, pageSize := parsePagination(c, defaultListPageSize, maxListPageSize)
+	pageQuery, total, err := paginate(query, &models.WebhookDelivery{}, page, pageSize)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	var deliveries []models.WebhookDelivery
+	if err := pageQuery.Find(&deliveries).Error; err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithPagination(c, deliveries, total, page, pageSize, meta)
+}
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+	"github.com/pauly7610/studio-pilot-vision/backend/services/sentiment"
+)
+
+// WebhooksHandler ingests data pushed by external tools. Requests are
+// authenticated by middleware.WebhookSignature, not a user token.
+type WebhooksHandler struct {
+	analyzer sentiment.Analyzer
+	aliases  models.ThemeAliases
+}
+
+func NewWebhooksHandler(analyzer sentiment.Analyzer, aliases models.ThemeAliases) *WebhooksHandler {
+	return &WebhooksHandler{analyzer: analyzer, aliases: aliases}
+}
+
+// ReceiveFeedback stores feedback pushed by a survey or support platform
+func (h *WebhooksHandler) ReceiveFeedback(c *gin.Context) {
+	var payload models.FeedbackWebhookPayload
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	feedback, err := createFeedback(c.Request.Context(), h.analyzer, h.aliases, payload.ToCreateRequest())
+	if errors.Is(err, errProductNotFound) {
+		respondWithError(c, http.StatusNotFound, "Product not found")
+		return
+	}
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithData(c, http.StatusCreated, feedback)
+}
+
+// ReceiveMetrics records a day of metrics pushed by an analytics platform.
+// Like CreateMetric, re-sending a day updates it.
+func (h *WebhooksHandler) ReceiveMetrics(c *gin.Context) {
+	var payload models.MetricsWebhookPayload
+	if !bindRequest(c, &payload) {
+		return
+	}
+
+	metric, created, err := recordMetric(payload.ToCreateRequest())
+	if errors.Is(err, errProductNotFound) {
+		respondWithError(c, http.StatusNotFound, "Product not found")
+		return
+	}
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	respondWithData(c, status, metric)
+}
@@ -0,0 +1,25 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestReceiveMetrics_RequiresPeriod(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	body := `{"product_id": "8d1e5a42-1f6b-4c55-9b39-0a4c1b0e0001", "revenue": 100}`
+	c.Request = httptest.NewRequest(http.MethodPost, "/webhooks/metrics", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	NewWebhooksHandler(nil, nil).ReceiveMetrics(c)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "period is required") {
+		t.Errorf("status = %d, body %s; want 400 requiring the period", w.Code, w.Body.String())
+	}
+}
@@ -1,35 +1,91 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/pauly7610/studio-pilot-vision/backend/config"
 	"github.com/pauly7610/studio-pilot-vision/backend/database"
+	"github.com/pauly7610/studio-pilot-vision/backend/handlers"
+	"github.com/pauly7610/studio-pilot-vision/backend/middleware"
+	"github.com/pauly7610/studio-pilot-vision/backend/notify"
 	"github.com/pauly7610/studio-pilot-vision/backend/routes"
+	"github.com/pauly7610/studio-pilot-vision/backend/scheduler"
+	"github.com/pauly7610/studio-pilot-vision/backend/startup"
 )
 
 func main() {
 	// Load configuration
 	cfg := config.Load()
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("Refusing to start: %v", err)
+	}
 
 	log.Printf("Starting Studio Pilot Vision API in %s mode", cfg.Environment)
 
-	// Connect to database
-	if err := database.Connect(cfg.DatabaseURL); err != nil {
+	// Connect to database, waiting for it if it is still starting
+	retry := database.RetryPolicy{MaxAttempts: cfg.DBConnectMaxAttempts, Timeout: cfg.DBConnectTimeout}
+	sqlLog := database.LogOptions{Level: cfg.DBLogLevel, SlowThreshold: cfg.DBSlowQueryThreshold, RedactPII: cfg.RedactPII}
+	if err := database.ConnectWithRetry(cfg.DatabaseURL, sqlLog, retry); err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 	defer database.Close()
 
+	middleware.GetAuditLogger().SetRedactPII(cfg.RedactPII)
+
 	// Run migrations
 	if err := database.Migrate(); err != nil {
 		log.Fatalf("Failed to run migrations: %v", err)
 	}
 
+	// Persist audit records; stdout stays the fallback if writes fail
+	middleware.GetAuditLogger().PersistTo(database.DB)
+	defer middleware.GetAuditLogger().Close()
+
+	// Webhook deliveries run in the background; those still retrying at
+	// shutdown get up to ShutdownTimeout to finish before the database
+	// closes, and are logged and abandoned after that
+	notifier := notify.NewDispatcher(database.DB)
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+		defer cancel()
+		if err := notifier.Wait(ctx); err != nil {
+			log.Printf("Webhook deliveries did not finish within %s", cfg.ShutdownTimeout)
+		}
+	}()
+
+	// Background jobs
+	jobs := scheduler.New()
+	jobs.Add("portfolio-risk-snapshot", 24*time.Hour, func() error {
+		_, err := handlers.RecordPortfolioRiskSnapshot(cfg.Escalation)
+		return err
+	})
+	jobs.Add("escalation-snapshot", time.Hour, func() error {
+		_, err := handlers.RecordEscalationSnapshot(cfg.Escalation, cfg.AutoEscalationActions, notifier)
+		return err
+	})
+	jobs.Start()
+	defer jobs.Stop()
+
 	// Setup router
-	router := routes.SetupRouter(cfg)
+	router := routes.SetupRouter(cfg, notifier)
+
+	// Self-check wiring before taking traffic; failures are logged and
+	// reported on /health/startup
+	if report := startup.Run(cfg, router.Routes()); !report.Passed {
+		log.Println("Startup self-check failed; see /health/startup")
+	}
+
+	srv := &http.Server{
+		Addr:    ":" + cfg.Port,
+		Handler: router,
+	}
 
 	// Graceful shutdown
 	quit := make(chan os.Signal, 1)
@@ -37,11 +93,26 @@ func main() {
 
 	go func() {
 		log.Printf("Server starting on port %s", cfg.Port)
-		if err := router.Run(":" + cfg.Port); err != nil {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			log.Fatalf("Failed to start server: %v", err)
 		}
 	}()
 
 	<-quit
 	log.Println("Shutting down server...")
+
+	// Fail readiness first so load balancers stop sending new requests
+	startup.SetDraining()
+	time.Sleep(cfg.ShutdownDrainDelay)
+
+	// Stop accepting connections and let in-flight requests finish; the
+	// deferred calls then stop the jobs, wait for webhook deliveries, flush
+	// audit records and close the database
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Printf("Server did not drain within %s: %v", cfg.ShutdownTimeout, err)
+	} else {
+		log.Println("Server stopped")
+	}
 }
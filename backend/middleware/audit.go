@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // AuditAction represents types of actions that should be audited
@@ -28,6 +29,8 @@ const (
 	// Security events
 	AuditSecurityRateLimit    AuditAction = "security.rate_limit"
 	AuditSecurityUnauthorized AuditAction = "security.unauthorized"
+	AuditSecurityBadSignature AuditAction = "security.bad_signature"
+	AuditSecurityTokenReuse   AuditAction = "security.token_reuse"
 )
 
 // AuditRecord represents a single audit log entry
@@ -48,7 +51,11 @@ type AuditRecord struct {
 
 // AuditLogger provides methods for logging audit events
 type AuditLogger struct {
-	// In production, this would be a structured logger or send to a logging service
+	redactPII bool
+
+	// writer persists records to the database once PersistTo is called;
+	// until then records only go to stdout
+	writer *auditWriter
 }
 
 // NewAuditLogger creates a new audit logger instance
@@ -56,8 +63,40 @@ func NewAuditLogger() *AuditLogger {
 	return &AuditLogger{}
 }
 
-// Log writes an audit record to the log
+// SetRedactPII enables hashing of personal data in audit details
+func (al *AuditLogger) SetRedactPII(enabled bool) {
+	al.redactPII = enabled
+}
+
+// PersistTo starts writing audit records to the audit_logs table in batches.
+// Call it once at startup, before serving traffic.
+func (al *AuditLogger) PersistTo(db *gorm.DB) {
+	al.writer = newAuditWriter(db, DefaultAuditBufferSize, DefaultAuditBatchSize, DefaultAuditFlushInterval)
+}
+
+// Close flushes queued audit records to the database
+func (al *AuditLogger) Close() {
+	if al.writer != nil {
+		al.writer.close()
+	}
+}
+
+// Log records an audit entry. With a database attached the record is queued
+// for a batched insert; if the queue is full it is logged to stdout instead.
 func (al *AuditLogger) Log(record AuditRecord) {
+	if al.redactPII {
+		record.Details = RedactDetails(record.Details)
+	}
+
+	if al.writer != nil && al.writer.enqueue(record) {
+		return
+	}
+	logAuditRecord(record)
+}
+
+// logAuditRecord writes a record to stdout, prefixed with AUDIT: for easy
+// filtering
+func logAuditRecord(record AuditRecord) {
 	// Serialize to JSON for structured logging
 	data, err := json.Marshal(record)
 	if err != nil {
@@ -65,8 +104,6 @@ func (al *AuditLogger) Log(record AuditRecord) {
 		return
 	}
 
-	// In production, this would go to a dedicated audit log
-	// For now, prefix with AUDIT: for easy filtering
 	log.Printf("AUDIT: %s", string(data))
 }
 
@@ -105,8 +142,8 @@ func AuditMiddleware() gin.HandlerFunc {
 		// Process request
 		c.Next()
 
-		// Skip audit for health checks and static files
-		if path == "/health" || path == "/favicon.ico" {
+		// Skip audit for health checks, metric scrapes and static files
+		if path == "/health" || path == "/health/ready" || path == "/metrics" || path == "/favicon.ico" {
 			return
 		}
 
@@ -0,0 +1,150 @@
+package middleware
+
+import (
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+	"gorm.io/gorm"
+)
+
+// Audit writer defaults: records queue on a buffered channel and are inserted
+// in batches, so a request never waits on the database
+const (
+	DefaultAuditBufferSize    = 1000
+	DefaultAuditBatchSize     = 100
+	DefaultAuditFlushInterval = 2 * time.Second
+)
+
+// auditWriter persists audit records in batches on a background goroutine.
+// Records it cannot store, because the buffer is full or the insert failed,
+// go to stdout instead so none are lost.
+type auditWriter struct {
+	db            *gorm.DB
+	records       chan AuditRecord
+	batchSize     int
+	flushInterval time.Duration
+	done          chan struct{}
+
+	// mu guards records against being sent on after close closes it
+	mu     sync.RWMutex
+	closed bool
+}
+
+func newAuditWriter(db *gorm.DB, bufferSize, batchSize int, flushInterval time.Duration) *auditWriter {
+	w := &auditWriter{
+		db:            db,
+		records:       make(chan AuditRecord, bufferSize),
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+		done:          make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+// enqueue hands a record to the writer without blocking. It reports false
+// when the buffer is full or the writer is closed.
+func (w *auditWriter) enqueue(record AuditRecord) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.closed {
+		return false
+	}
+
+	select {
+	case w.records <- record:
+		return true
+	default:
+		return false
+	}
+}
+
+func (w *auditWriter) run() {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]AuditRecord, 0, w.batchSize)
+	for {
+		select {
+		case record, ok := <-w.records:
+			if !ok {
+				w.flush(batch)
+				return
+			}
+			batch = append(batch, record)
+			if len(batch) >= w.batchSize {
+				w.flush(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			w.flush(batch)
+			batch = batch[:0]
+		}
+	}
+}
+
+// flush inserts the batch, falling back to stdout when the insert fails
+func (w *auditWriter) flush(batch []AuditRecord) {
+	if len(batch) == 0 {
+		return
+	}
+
+	rows := make([]models.AuditLog, len(batch))
+	for i, record := range batch {
+		rows[i] = auditLogRow(record)
+	}
+	if err := w.db.Create(&rows).Error; err != nil {
+		log.Printf("AUDIT_ERROR: Failed to persist %d audit records: %v", len(batch), err)
+		for _, record := range batch {
+			logAuditRecord(record)
+		}
+	}
+}
+
+// close stops accepting records and waits for the queued ones to be written.
+// Records logged afterwards go to stdout.
+func (w *auditWriter) close() {
+	w.mu.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.records)
+	}
+	w.mu.Unlock()
+	<-w.done
+}
+
+// auditLogRow converts a record to its database row. Details that cannot be
+// serialized are dropped rather than losing the whole record.
+func auditLogRow(record AuditRecord) models.AuditLog {
+	timestamp := time.Now()
+	if parsed, err := time.Parse(time.RFC3339, record.Timestamp); err == nil {
+		timestamp = parsed
+	}
+
+	row := models.AuditLog{
+		ID:         uuid.New(),
+		Timestamp:  models.NewTimestamp(timestamp),
+		Action:     string(record.Action),
+		Resource:   record.Resource,
+		Method:     record.Method,
+		IP:         record.IP,
+		UserID:     record.UserID,
+		UserEmail:  record.UserEmail,
+		StatusCode: record.StatusCode,
+		DurationMs: record.DurationMs,
+		Success:    record.Success,
+		Error:      record.Error,
+	}
+	if len(record.Details) > 0 {
+		if details, err := json.Marshal(record.Details); err == nil {
+			row.Details = details
+		}
+	}
+	return row
+}
@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func openAuditTestDB(t *testing.T, withTable bool) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("sql db: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+
+	if withTable {
+		err := db.Exec(`CREATE TABLE audit_logs (
+			id TEXT PRIMARY KEY, occurred_at DATETIME NOT NULL, action TEXT NOT NULL,
+			resource TEXT NOT NULL, method TEXT, ip TEXT, user_id TEXT, user_email TEXT,
+			status_code INTEGER, duration_ms INTEGER, success BOOLEAN NOT NULL,
+			details TEXT, error TEXT)`).Error
+		if err != nil {
+			t.Fatalf("create table: %v", err)
+		}
+	}
+	return db
+}
+
+func captureLog(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	previous := log.Writer()
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(previous) })
+	return &buf
+}
+
+func TestAuditLogger_PersistsInBatches(t *testing.T) {
+	db := openAuditTestDB(t, true)
+	out := captureLog(t)
+
+	al := NewAuditLogger()
+	al.writer = newAuditWriter(db, 10, 2, time.Hour)
+	for i := 0; i < 3; i++ {
+		al.LogEvent(AuditAdminAction, "/api/v1/products", "10.0.0.1", true, map[string]interface{}{"n": i})
+	}
+	al.Close()
+
+	var logs []models.AuditLog
+	if err := db.Order("occurred_at").Find(&logs).Error; err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	if len(logs) != 3 {
+		t.Fatalf("persisted %d records, want 3", len(logs))
+	}
+	if logs[0].Action != string(AuditAdminAction) || !strings.Contains(string(logs[0].Details), `"n"`) {
+		t.Errorf("unexpected row %+v", logs[0])
+	}
+	if strings.Contains(out.String(), "AUDIT:") {
+		t.Errorf("persisted records should not go to stdout, got %q", out.String())
+	}
+}
+
+func TestAuditLogger_FallsBackToStdoutWhenInsertFails(t *testing.T) {
+	db := openAuditTestDB(t, false)
+	out := captureLog(t)
+
+	al := NewAuditLogger()
+	al.writer = newAuditWriter(db, 10, 10, time.Hour)
+	al.LogEvent(AuditSecurityRateLimit, "security", "10.0.0.1", false, nil)
+	al.Close()
+
+	if !strings.Contains(out.String(), "AUDIT_ERROR") || !strings.Contains(out.String(), `"action":"security.rate_limit"`) {
+		t.Errorf("expected failed record on stdout, got %q", out.String())
+	}
+}
+
+func TestAuditLogger_FallsBackToStdoutWhenBufferFull(t *testing.T) {
+	out := captureLog(t)
+
+	// A writer that never drains: its buffer holds one record
+	al := NewAuditLogger()
+	al.writer = &auditWriter{records: make(chan AuditRecord, 1)}
+	al.LogEvent(AuditDataAccess, "/first", "10.0.0.1", true, nil)
+	al.LogEvent(AuditDataAccess, "/second", "10.0.0.1", true, nil)
+
+	if strings.Contains(out.String(), "/first") || !strings.Contains(out.String(), "/second") {
+		t.Errorf("expected only the overflow record on stdout, got %q", out.String())
+	}
+}
+
+func TestAuditLogger_FallsBackToStdoutAfterClose(t *testing.T) {
+	db := openAuditTestDB(t, true)
+	out := captureLog(t)
+
+	al := NewAuditLogger()
+	al.writer = newAuditWriter(db, 10, 10, time.Hour)
+	al.Close()
+	al.LogEvent(AuditDataAccess, "/after-close", "10.0.0.1", true, nil)
+	al.Close()
+
+	if !strings.Contains(out.String(), "/after-close") {
+		t.Errorf("expected record logged after close on stdout, got %q", out.String())
+	}
+	var count int64
+	db.Model(&models.AuditLog{}).Count(&count)
+	if count != 0 {
+		t.Errorf("stored %d records after close, want 0", count)
+	}
+}
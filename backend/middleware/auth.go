@@ -1,8 +1,10 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
@@ -12,9 +14,58 @@ type Claims struct {
 	UserID string `json:"sub"`
 	Email  string `json:"email"`
 	Role   string `json:"role"`
+	// SessionID is the refresh token an access token was refreshed from.
+	// Sign-in tokens, issued elsewhere, have none.
+	SessionID string `json:"sid,omitempty"`
+	// TokenType is AccessTokenType on tokens minted here; sign-in tokens
+	// issued elsewhere leave it empty. Tokens of any other type, signed for
+	// other purposes, are never accepted as access tokens.
+	TokenType string `json:"typ,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// AccessTokenType marks the tokens NewAccessToken signs
+const AccessTokenType = "access"
+
+var errNotAccessToken = errors.New("not an access token")
+
+// parseAccessToken validates a bearer token and returns its claims, rejecting
+// tokens that are not access tokens
+func parseAccessToken(jwtSecret, tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return []byte(jwtSecret), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, jwt.ErrTokenInvalidClaims
+	}
+	if claims.TokenType != "" && claims.TokenType != AccessTokenType {
+		return nil, errNotAccessToken
+	}
+	return claims, nil
+}
+
+// NewAccessToken signs a short-lived HS256 access token for the user,
+// refreshed from the refresh token with id sessionID
+func NewAccessToken(secret, userID, email, role, sessionID string, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims := Claims{
+		UserID:    userID,
+		Email:     email,
+		Role:      role,
+		SessionID: sessionID,
+		TokenType: AccessTokenType,
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+}
+
 func AuthMiddleware(jwtSecret string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
@@ -35,26 +86,18 @@ func AuthMiddleware(jwtSecret string) gin.HandlerFunc {
 		tokenString := parts[1]
 
 		// Parse and validate token
-		token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-			return []byte(jwtSecret), nil
-		})
-
+		claims, err := parseAccessToken(jwtSecret, tokenString)
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
 			c.Abort()
 			return
 		}
 
-		if claims, ok := token.Claims.(*Claims); ok && token.Valid {
-			c.Set("userID", claims.UserID)
-			c.Set("email", claims.Email)
-			c.Set("role", claims.Role)
-			c.Next()
-		} else {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
-			c.Abort()
-			return
-		}
+		c.Set("userID", claims.UserID)
+		c.Set("email", claims.Email)
+		c.Set("role", claims.Role)
+		c.Set("sessionID", claims.SessionID)
+		c.Next()
 	}
 }
 
@@ -75,16 +118,11 @@ func OptionalAuth(jwtSecret string) gin.HandlerFunc {
 
 		tokenString := parts[1]
 
-		token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-			return []byte(jwtSecret), nil
-		})
-
-		if err == nil {
-			if claims, ok := token.Claims.(*Claims); ok && token.Valid {
-				c.Set("userID", claims.UserID)
-				c.Set("email", claims.Email)
-				c.Set("role", claims.Role)
-			}
+		if claims, err := parseAccessToken(jwtSecret, tokenString); err == nil {
+			c.Set("userID", claims.UserID)
+			c.Set("email", claims.Email)
+			c.Set("role", claims.Role)
+			c.Set("sessionID", claims.SessionID)
 		}
 
 		c.Next()
@@ -1,35 +1,119 @@
 package middleware
 
 import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/gin-gonic/gin"
 )
 
-func CORS(allowedOrigins []string) gin.HandlerFunc {
+// DefaultCORSHeaders are the request headers browsers may always send
+var DefaultCORSHeaders = []string{
+	"Content-Type", "Content-Length", "Accept-Encoding", "X-CSRF-Token", "Authorization",
+	"accept", "origin", "Cache-Control", "X-Requested-With", "X-Confirmation-Token",
+	"If-None-Match", "Idempotency-Key",
+}
+
+// DefaultCORSMethods are the methods allowed when none are configured
+var DefaultCORSMethods = []string{
+	http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete,
+}
+
+// CORSOptions configures the CORS middleware
+type CORSOptions struct {
+	// Origins are exact origins, "*" for any, or "https://*.example.com"
+	// for any subdomain of example.com over https
+	Origins []string
+	// Headers are allowed on top of DefaultCORSHeaders
+	Headers []string
+	// Methods default to DefaultCORSMethods
+	Methods []string
+	// MaxAge is how long browsers may cache a preflight response
+	MaxAge time.Duration
+	// Strict rejects state-changing requests that carry an Origin outside
+	// Origins with 403 instead of processing them without CORS headers
+	Strict bool
+}
+
+// CORS sets the cross-origin headers for allowed origins and answers
+// preflight OPTIONS requests with 204. Requests with no Origin header
+// (server-to-server) are always let through.
+func CORS(opts CORSOptions) gin.HandlerFunc {
+	methods := opts.Methods
+	if len(methods) == 0 {
+		methods = DefaultCORSMethods
+	}
+	allowMethods := strings.Join(append(append([]string{}, methods...), http.MethodOptions), ", ")
+	allowHeaders := strings.Join(append(append([]string{}, DefaultCORSHeaders...), opts.Headers...), ", ")
+	maxAge := strconv.Itoa(int(opts.MaxAge.Seconds()))
+
 	return func(c *gin.Context) {
 		origin := c.Request.Header.Get("Origin")
+		allowed := originAllowed(origin, opts.Origins)
 
-		// Check if origin is in allowed list
-		allowed := false
-		for _, o := range allowedOrigins {
-			if o == origin || o == "*" {
-				allowed = true
-				break
-			}
-		}
-
+		header := c.Writer.Header()
 		if allowed {
-			c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
+			header.Set("Access-Control-Allow-Origin", origin)
 		}
+		header.Add("Vary", "Origin")
+
+		header.Set("Access-Control-Allow-Credentials", "true")
+		header.Set("Access-Control-Allow-Headers", allowHeaders)
+		header.Set("Access-Control-Allow-Methods", allowMethods)
+		header.Set("Access-Control-Expose-Headers", "X-RateLimit-Limit, X-RateLimit-Remaining, X-RateLimit-Reset, Retry-After, ETag")
 
-		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, PATCH, DELETE")
+		if c.Request.Method == http.MethodOptions {
+			header.Set("Access-Control-Max-Age", maxAge)
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
 
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(204)
+		if opts.Strict && origin != "" && !allowed && isStateChanging(c.Request.Method) {
+			LogSecurityEvent(AuditSecurityUnauthorized, c.ClientIP(), map[string]interface{}{
+				"reason": "disallowed origin",
+				"origin": origin,
+				"method": c.Request.Method,
+				"path":   c.Request.URL.Path,
+			})
+			c.JSON(http.StatusForbidden, gin.H{
+				"error":   "Forbidden",
+				"message": "Origin not allowed",
+			})
+			c.Abort()
 			return
 		}
 
 		c.Next()
 	}
 }
+
+// originAllowed matches origin against the allowlist. A "scheme://*.domain"
+// entry matches any subdomain of domain, at any depth, but not domain itself.
+func originAllowed(origin string, allowed []string) bool {
+	if origin == "" {
+		return false
+	}
+	for _, o := range allowed {
+		if o == "*" || o == origin {
+			return true
+		}
+		scheme, domain, ok := strings.Cut(o, "://*.")
+		if !ok {
+			continue
+		}
+		if host, ok := strings.CutPrefix(origin, scheme+"://"); ok && strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+func isStateChanging(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
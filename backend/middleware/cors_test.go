@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func corsRouter(strict bool) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(CORS(CORSOptions{Origins: []string{"http://allowed.test"}, Strict: strict}))
+	router.POST("/thing", func(c *gin.Context) { c.Status(http.StatusCreated) })
+	router.GET("/thing", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return router
+}
+
+func TestCORS_StrictMode(t *testing.T) {
+	tests := []struct {
+		name     string
+		strict   bool
+		method   string
+		origin   string
+		expected int
+	}{
+		{"lenient allows disallowed origin", false, "POST", "http://evil.test", http.StatusCreated},
+		{"strict rejects disallowed origin", true, "POST", "http://evil.test", http.StatusForbidden},
+		{"strict allows listed origin", true, "POST", "http://allowed.test", http.StatusCreated},
+		{"strict allows missing origin", true, "POST", "", http.StatusCreated},
+		{"strict ignores safe methods", true, "GET", "http://evil.test", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/thing", nil)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			w := httptest.NewRecorder()
+			corsRouter(tt.strict).ServeHTTP(w, req)
+
+			if w.Code != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, w.Code)
+			}
+		})
+	}
+}
+
+func TestCORS_Preflight(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(CORS(CORSOptions{
+		Origins: []string{"https://*.ourcompany.com"},
+		Headers: []string{"X-Trace-Id"},
+		MaxAge:  10 * time.Minute,
+	}))
+	router.POST("/thing", func(c *gin.Context) { c.Status(http.StatusCreated) })
+
+	req := httptest.NewRequest(http.MethodOptions, "/thing", nil)
+	req.Header.Set("Origin", "https://app.ourcompany.com")
+	req.Header.Set("Access-Control-Request-Method", "PATCH")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("preflight status = %d, want 204", w.Code)
+	}
+	h := w.Header()
+	if got := h.Get("Access-Control-Allow-Origin"); got != "https://app.ourcompany.com" {
+		t.Errorf("allow origin = %q", got)
+	}
+	for _, want := range []string{"GET", "POST", "PUT", "PATCH", "DELETE"} {
+		if !strings.Contains(h.Get("Access-Control-Allow-Methods"), want) {
+			t.Errorf("allow methods %q lacks %s", h.Get("Access-Control-Allow-Methods"), want)
+		}
+	}
+	for _, want := range []string{"Authorization", "Content-Type", "Idempotency-Key", "X-Trace-Id"} {
+		if !strings.Contains(h.Get("Access-Control-Allow-Headers"), want) {
+			t.Errorf("allow headers %q lacks %s", h.Get("Access-Control-Allow-Headers"), want)
+		}
+	}
+	if got := h.Get("Access-Control-Max-Age"); got != "600" {
+		t.Errorf("max age = %q, want 600", got)
+	}
+}
+
+func TestOriginAllowed(t *testing.T) {
+	allowed := []string{"https://app.example.com", "https://*.ourcompany.com"}
+	tests := []struct {
+		origin string
+		want   bool
+	}{
+		{"https://app.example.com", true},
+		{"https://other.example.com", false},
+		{"https://app.ourcompany.com", true},
+		{"https://eu.app.ourcompany.com", true},
+		{"https://ourcompany.com", false},
+		{"https://evilourcompany.com", false},
+		{"http://app.ourcompany.com", false},
+		{"https://app.ourcompany.com.evil.test", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := originAllowed(tt.origin, allowed); got != tt.want {
+			t.Errorf("originAllowed(%q) = %v, want %v", tt.origin, got, tt.want)
+		}
+	}
+}
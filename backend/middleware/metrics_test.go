@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestRequestMetrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	metrics := NewRequestMetrics()
+	router := gin.New()
+	router.Use(metrics.Middleware())
+	router.GET("/products/:id", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/metrics", metrics.Handler())
+
+	for _, path := range []string{"/products/1", "/products/2", "/nowhere/3"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	if got := testutil.ToFloat64(metrics.requests.WithLabelValues("/products/:id", "GET", "200")); got != 2 {
+		t.Errorf("route pattern count = %v, want both product IDs in one series", got)
+	}
+	if got := testutil.ToFloat64(metrics.requests.WithLabelValues(unmatchedRoute, "GET", "404")); got != 1 {
+		t.Errorf("unmatched count = %v, want 1", got)
+	}
+	if n := testutil.CollectAndCount(metrics.duration); n != 2 {
+		t.Errorf("duration series = %d, want 2", n)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := w.Body.String()
+	for _, want := range []string{
+		`http_requests_total{method="GET",route="/products/:id",status="200"} 2`,
+		`http_request_duration_seconds_bucket{method="GET",route="/products/:id",status="200",le="+Inf"} 2`,
+		"go_goroutines",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("exposition missing %q", want)
+		}
+	}
+	if strings.Contains(body, "/products/1") {
+		t.Error("raw path leaked into a label")
+	}
+}
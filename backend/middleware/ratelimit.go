@@ -1,19 +1,45 @@
 package middleware
 
 import (
+	"math"
 	"net/http"
+	"sort"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+// DefaultMaxTrackedKeys bounds how many distinct clients the limiter remembers
+const DefaultMaxTrackedKeys = 10000
+
+// Expired entries are swept every maxSweepInterval while the limiter is under
+// half its key cap. Past that the interval shrinks with the remaining
+// headroom, down to minSweepInterval, so a growing map sheds idle clients
+// before the cap forces least-recently-seen eviction of live ones.
+const (
+	maxSweepInterval = 5 * time.Minute
+	minSweepInterval = 10 * time.Second
+)
+
+// DefaultAdminRateMultiplier raises the ceiling for admin roles, who drive
+// bulk operations from the admin views
+const DefaultAdminRateMultiplier = 5
+
 // RateLimiter implements a sliding window rate limiter
 type RateLimiter struct {
 	requests map[string][]time.Time
 	mu       sync.RWMutex
 	limit    int
 	window   time.Duration
+	maxKeys  int
+	evicted  int64
+
+	// keyFunc picks the bucket a request counts against
+	keyFunc func(c *gin.Context) string
+	// roleMultipliers scale the limit for authenticated roles
+	roleMultipliers map[string]int
 }
 
 // NewRateLimiter creates a new rate limiter with the specified limit and window
@@ -22,42 +48,161 @@ func NewRateLimiter(limit int, window time.Duration) *RateLimiter {
 		requests: make(map[string][]time.Time),
 		limit:    limit,
 		window:   window,
+		maxKeys:  DefaultMaxTrackedKeys,
+		keyFunc:  RateLimitKey,
 	}
 	go rl.cleanup()
 	return rl
 }
 
-// cleanup removes old request timestamps periodically to prevent memory leaks
+// WithMaxKeys caps the number of tracked clients. When the cap is reached,
+// expired entries are pruned and then the least-recently-seen are evicted.
+func (rl *RateLimiter) WithMaxKeys(maxKeys int) *RateLimiter {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	rl.maxKeys = maxKeys
+	return rl
+}
+
+// WithKeyFunc replaces how requests are grouped into buckets
+func (rl *RateLimiter) WithKeyFunc(keyFunc func(c *gin.Context) string) *RateLimiter {
+	rl.keyFunc = keyFunc
+	return rl
+}
+
+// WithRoleMultipliers scales the limit for requests authenticated with the
+// given roles. Roles without an entry get the base limit.
+func (rl *RateLimiter) WithRoleMultipliers(multipliers map[string]int) *RateLimiter {
+	rl.roleMultipliers = multipliers
+	return rl
+}
+
+// RateLimitKey buckets authenticated requests by user, so clients sharing a
+// NAT don't share a limit, and anonymous requests by IP
+func RateLimitKey(c *gin.Context) string {
+	if userID := c.GetString("userID"); userID != "" {
+		return "user:" + userID
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// limitFor returns the request ceiling for the caller's role
+func (rl *RateLimiter) limitFor(c *gin.Context) int {
+	if multiplier := rl.roleMultipliers[c.GetString("role")]; multiplier > 1 {
+		return rl.limit * multiplier
+	}
+	return rl.limit
+}
+
+// cleanup removes old request timestamps periodically to prevent memory
+// leaks, sweeping more often as the map fills
 func (rl *RateLimiter) cleanup() {
-	ticker := time.NewTicker(5 * time.Minute)
-	for range ticker.C {
+	for {
+		rl.mu.RLock()
+		interval := sweepInterval(len(rl.requests), rl.maxKeys)
+		rl.mu.RUnlock()
+
+		time.Sleep(interval)
 		rl.mu.Lock()
-		now := time.Now()
-		for ip, timestamps := range rl.requests {
-			// Keep only timestamps within the window
-			valid := make([]time.Time, 0)
-			for _, t := range timestamps {
-				if now.Sub(t) < rl.window {
-					valid = append(valid, t)
-				}
-			}
-			if len(valid) == 0 {
-				delete(rl.requests, ip)
-			} else {
-				rl.requests[ip] = valid
+		rl.pruneLocked(time.Now())
+		rl.mu.Unlock()
+	}
+}
+
+// sweepInterval is how long to wait before the next sweep with tracked keys
+// held against a cap of maxKeys
+func sweepInterval(tracked, maxKeys int) time.Duration {
+	half := maxKeys / 2
+	if maxKeys <= 0 || tracked < half {
+		return maxSweepInterval
+	}
+	headroom := maxKeys - tracked
+	if headroom <= 0 {
+		return minSweepInterval
+	}
+	interval := maxSweepInterval * time.Duration(headroom) / time.Duration(maxKeys-half)
+	if interval < minSweepInterval {
+		return minSweepInterval
+	}
+	return interval
+}
+
+// pruneLocked drops timestamps outside the window and forgets idle keys.
+// Callers must hold the write lock.
+func (rl *RateLimiter) pruneLocked(now time.Time) {
+	for ip, timestamps := range rl.requests {
+		// Keep only timestamps within the window
+		valid := make([]time.Time, 0)
+		for _, t := range timestamps {
+			if now.Sub(t) < rl.window {
+				valid = append(valid, t)
 			}
 		}
-		rl.mu.Unlock()
+		if len(valid) == 0 {
+			delete(rl.requests, ip)
+		} else {
+			rl.requests[ip] = valid
+		}
 	}
 }
 
-// allow checks if a request from the given IP should be allowed
-func (rl *RateLimiter) allow(ip string) bool {
+// evictLocked makes room for a new key once the cap is hit: expired entries go
+// first, then the least-recently-seen keys until the map is at 90% of the cap.
+// Callers must hold the write lock.
+func (rl *RateLimiter) evictLocked(now time.Time) {
+	if rl.maxKeys <= 0 || len(rl.requests) < rl.maxKeys {
+		return
+	}
+
+	rl.pruneLocked(now)
+
+	excess := len(rl.requests) - rl.maxKeys*9/10
+	if excess <= 0 {
+		return
+	}
+
+	keys := make([]string, 0, len(rl.requests))
+	for ip := range rl.requests {
+		keys = append(keys, ip)
+	}
+	lastSeen := func(ip string) time.Time {
+		timestamps := rl.requests[ip]
+		return timestamps[len(timestamps)-1]
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return lastSeen(keys[i]).Before(lastSeen(keys[j]))
+	})
+
+	for _, ip := range keys[:excess] {
+		delete(rl.requests, ip)
+	}
+	rl.evicted += int64(excess)
+}
+
+// TrackedKeys returns the number of clients currently held in memory
+func (rl *RateLimiter) TrackedKeys() int {
+	rl.mu.RLock()
+	defer rl.mu.RUnlock()
+	return len(rl.requests)
+}
+
+// Evictions returns how many clients have been evicted to respect the cap
+func (rl *RateLimiter) Evictions() int64 {
+	rl.mu.RLock()
+	defer rl.mu.RUnlock()
+	return rl.evicted
+}
+
+// allow checks if a request in the given bucket is within limit
+func (rl *RateLimiter) allow(key string, limit int) bool {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
 	now := time.Now()
-	timestamps := rl.requests[ip]
+	timestamps, tracked := rl.requests[key]
+	if !tracked {
+		rl.evictLocked(now)
+	}
 
 	// Filter out old timestamps
 	valid := make([]time.Time, 0)
@@ -68,55 +213,64 @@ func (rl *RateLimiter) allow(ip string) bool {
 	}
 
 	// Check if limit exceeded
-	if len(valid) >= rl.limit {
-		rl.requests[ip] = valid
+	if len(valid) >= limit {
+		rl.requests[key] = valid
 		return false
 	}
 
 	// Add current request
-	rl.requests[ip] = append(valid, now)
+	rl.requests[key] = append(valid, now)
 	return true
 }
 
-// remaining returns the number of requests remaining for the IP
-func (rl *RateLimiter) remaining(ip string) int {
+// status returns the requests remaining in the bucket for the current window
+// and how long until the oldest counted request leaves it
+func (rl *RateLimiter) status(key string, limit int) (int, time.Duration) {
 	rl.mu.RLock()
 	defer rl.mu.RUnlock()
 
 	now := time.Now()
-	timestamps := rl.requests[ip]
+	timestamps := rl.requests[key]
 
 	count := 0
+	reset := time.Duration(0)
 	for _, t := range timestamps {
 		if now.Sub(t) < rl.window {
+			if count == 0 {
+				reset = t.Add(rl.window).Sub(now)
+			}
 			count++
 		}
 	}
 
-	remaining := rl.limit - count
+	remaining := limit - count
 	if remaining < 0 {
-		return 0
+		remaining = 0
 	}
-	return remaining
+	return remaining, reset
 }
 
 // RateLimit returns a Gin middleware handler for rate limiting
 func (rl *RateLimiter) RateLimit() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Skip rate limiting for health checks
-		if c.Request.URL.Path == "/health" {
+		// Skip rate limiting for health checks and metric scrapes
+		if path := c.Request.URL.Path; path == "/health" || path == "/health/ready" || path == "/metrics" {
 			c.Next()
 			return
 		}
 
-		ip := c.ClientIP()
+		key, limit := rl.keyFunc(c), rl.limitFor(c)
+		allowed := rl.allow(key, limit)
 
-		// Add rate limit headers
-		c.Header("X-RateLimit-Limit", string(rune(rl.limit)))
-		c.Header("X-RateLimit-Remaining", string(rune(rl.remaining(ip))))
+		// Add rate limit headers; reset is whole seconds, rounded up
+		remaining, reset := rl.status(key, limit)
+		resetSeconds := strconv.Itoa(int(math.Ceil(reset.Seconds())))
+		c.Header("X-RateLimit-Limit", strconv.Itoa(limit))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(remaining))
+		c.Header("X-RateLimit-Reset", resetSeconds)
 
-		if !rl.allow(ip) {
-			c.Header("Retry-After", "60")
+		if !allowed {
+			c.Header("Retry-After", resetSeconds)
 			c.JSON(http.StatusTooManyRequests, gin.H{
 				"error":   "Rate limit exceeded",
 				"message": "Too many requests. Please try again later.",
@@ -130,7 +284,16 @@ func (rl *RateLimiter) RateLimit() gin.HandlerFunc {
 }
 
 // DefaultRateLimiter returns a rate limiter with sensible defaults
-// 60 requests per minute
+// 60 requests per minute, with admin roles allowed DefaultAdminRateMultiplier times that
 func DefaultRateLimiter() *RateLimiter {
-	return NewRateLimiter(60, time.Minute)
+	return NewRateLimiter(60, time.Minute).WithRoleMultipliers(map[string]int{
+		"vp_product":        DefaultAdminRateMultiplier,
+		"studio_ambassador": DefaultAdminRateMultiplier,
+	})
+}
+
+// TokenIssueRateLimiter limits how often each user may start a refresh-token
+// session: 5 per hour, whatever their role
+func TokenIssueRateLimiter() *RateLimiter {
+	return NewRateLimiter(5, time.Hour)
 }
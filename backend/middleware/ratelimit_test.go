@@ -0,0 +1,193 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRateLimiter_EvictsWhenCapReached(t *testing.T) {
+	rl := NewRateLimiter(5, time.Minute).WithMaxKeys(10)
+
+	for i := 0; i < 25; i++ {
+		rl.allow(fmt.Sprintf("10.0.0.%d", i), 5)
+	}
+
+	if n := rl.TrackedKeys(); n > 10 {
+		t.Errorf("expected at most 10 tracked keys, got %d", n)
+	}
+	if rl.Evictions() == 0 {
+		t.Error("expected evictions to be recorded")
+	}
+
+	// The most recent client must survive eviction
+	if _, ok := rl.requests["10.0.0.24"]; !ok {
+		t.Error("expected most recent client to be retained")
+	}
+}
+
+func TestSweepInterval_ShrinksAsKeysGrow(t *testing.T) {
+	tests := []struct {
+		tracked, maxKeys int
+		want             time.Duration
+	}{
+		{0, 10000, maxSweepInterval},
+		{4999, 10000, maxSweepInterval},
+		{5000, 10000, maxSweepInterval},
+		{7500, 10000, maxSweepInterval / 2},
+		{9000, 10000, time.Minute},
+		{9990, 10000, minSweepInterval},
+		{12000, 10000, minSweepInterval},
+		{50000, 0, maxSweepInterval},
+	}
+	previous := maxSweepInterval
+	for _, tt := range tests {
+		got := sweepInterval(tt.tracked, tt.maxKeys)
+		if got != tt.want {
+			t.Errorf("sweepInterval(%d, %d) = %s, want %s", tt.tracked, tt.maxKeys, got, tt.want)
+		}
+		if tt.maxKeys > 0 && got > previous {
+			t.Errorf("sweepInterval(%d, %d) = %s grew from %s", tt.tracked, tt.maxKeys, got, previous)
+		}
+		previous = got
+	}
+}
+
+func TestRateLimiter_EnforcesLimit(t *testing.T) {
+	rl := NewRateLimiter(2, time.Minute)
+
+	if !rl.allow("1.1.1.1", 2) || !rl.allow("1.1.1.1", 2) {
+		t.Fatal("expected first two requests to be allowed")
+	}
+	if rl.allow("1.1.1.1", 2) {
+		t.Error("expected third request to be rejected")
+	}
+}
+
+func TestRateLimit_Headers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(NewRateLimiter(2, time.Minute).RateLimit())
+	router.GET("/ping", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	header := func(w *httptest.ResponseRecorder, name string) int {
+		t.Helper()
+		n, err := strconv.Atoi(w.Header().Get(name))
+		if err != nil {
+			t.Fatalf("%s = %q is not an integer", name, w.Header().Get(name))
+		}
+		return n
+	}
+
+	tests := []struct {
+		wantStatus    int
+		wantRemaining int
+	}{
+		{http.StatusOK, 1},
+		{http.StatusOK, 0},
+		{http.StatusTooManyRequests, 0},
+	}
+	for i, tt := range tests {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ping", nil))
+
+		if w.Code != tt.wantStatus {
+			t.Fatalf("request %d: status = %d, want %d", i+1, w.Code, tt.wantStatus)
+		}
+		if got := header(w, "X-RateLimit-Limit"); got != 2 {
+			t.Errorf("request %d: limit = %d, want 2", i+1, got)
+		}
+		if got := header(w, "X-RateLimit-Remaining"); got != tt.wantRemaining {
+			t.Errorf("request %d: remaining = %d, want %d", i+1, got, tt.wantRemaining)
+		}
+		if got := header(w, "X-RateLimit-Reset"); got < 1 || got > 60 {
+			t.Errorf("request %d: reset = %d, want 1-60 seconds", i+1, got)
+		}
+	}
+}
+
+// syntheticContext builds a request context from the given client IP and, if
+// userID is set, the identity AuthMiddleware would store
+func syntheticContext(ip, userID, role string) *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/ping", nil)
+	c.Request.RemoteAddr = ip + ":1234"
+	if userID != "" {
+		c.Set("userID", userID)
+		c.Set("role", role)
+	}
+	return c
+}
+
+func TestRateLimitKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rl := NewRateLimiter(2, time.Minute)
+
+	tests := []struct {
+		name, ip, userID, want string
+	}{
+		{"anonymous keys on IP", "10.1.1.1", "", "ip:10.1.1.1"},
+		{"authenticated keys on user", "10.1.1.1", "user-a", "user:user-a"},
+		{"same user from another IP", "10.2.2.2", "user-a", "user:user-a"},
+	}
+	for _, tt := range tests {
+		if got := rl.keyFunc(syntheticContext(tt.ip, tt.userID, "viewer")); got != tt.want {
+			t.Errorf("%s: key = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRateLimit_UsersBehindOneIPHaveSeparateBuckets(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rl := NewRateLimiter(1, time.Minute)
+
+	for _, userID := range []string{"user-a", "user-b"} {
+		c := syntheticContext("10.0.0.1", userID, "viewer")
+		rl.RateLimit()(c)
+		if c.IsAborted() {
+			t.Errorf("%s: first request behind shared IP was rejected", userID)
+		}
+	}
+
+	c := syntheticContext("10.0.0.1", "user-a", "viewer")
+	rl.RateLimit()(c)
+	if !c.IsAborted() {
+		t.Error("expected user-a's second request to be rejected")
+	}
+}
+
+func TestRateLimiter_RoleMultiplier(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rl := NewRateLimiter(2, time.Minute).WithRoleMultipliers(map[string]int{"vp_product": 3})
+
+	tests := []struct {
+		role string
+		want int
+	}{
+		{"vp_product", 6},
+		{"viewer", 2},
+		{"", 2},
+	}
+	for _, tt := range tests {
+		if got := rl.limitFor(syntheticContext("10.0.0.1", "user", tt.role)); got != tt.want {
+			t.Errorf("role %q: limit = %d, want %d", tt.role, got, tt.want)
+		}
+	}
+}
+
+func TestRateLimiter_WithKeyFunc(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rl := NewRateLimiter(1, time.Minute).WithKeyFunc(func(c *gin.Context) string { return "shared" })
+
+	first, second := syntheticContext("10.0.0.1", "", ""), syntheticContext("10.0.0.2", "", "")
+	rl.RateLimit()(first)
+	rl.RateLimit()(second)
+	if first.IsAborted() || !second.IsAborted() {
+		t.Error("expected both IPs to share the custom key's single slot")
+	}
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Audit detail keys holding personal data. Feedback raw_text is always
+// sensitive; the product fields only when the details carry pii_flag=true,
+// which callers set from the product's own PII flag.
+var (
+	piiDetailKeys  = map[string]bool{"raw_text": true}
+	piiProductKeys = map[string]bool{
+		"name":             true,
+		"owner_email":      true,
+		"business_sponsor": true,
+		"engineering_lead": true,
+		"from_owner":       true,
+		"to_owner":         true,
+	}
+)
+
+// RedactDetails returns a copy of audit details with personal data replaced.
+// Strings are hashed so records can still be correlated; other values are
+// dropped.
+func RedactDetails(details map[string]interface{}) map[string]interface{} {
+	if details == nil {
+		return nil
+	}
+
+	piiProduct, _ := details["pii_flag"].(bool)
+	redacted := make(map[string]interface{}, len(details))
+	for key, value := range details {
+		if !piiDetailKeys[key] && !(piiProduct && piiProductKeys[key]) {
+			redacted[key] = value
+			continue
+		}
+		if s, ok := value.(string); ok {
+			redacted[key] = hashPII(s)
+		} else {
+			redacted[key] = "[redacted]"
+		}
+	}
+	return redacted
+}
+
+func hashPII(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return "sha256:" + hex.EncodeToString(sum[:])[:16]
+}
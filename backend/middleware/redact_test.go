@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRedactDetails(t *testing.T) {
+	details := map[string]interface{}{
+		"raw_text":    "Jane Doe called about her card",
+		"owner_email": "owner@example.com",
+		"product_id":  "abc",
+	}
+
+	redacted := RedactDetails(details)
+
+	if s, _ := redacted["raw_text"].(string); !strings.HasPrefix(s, "sha256:") {
+		t.Errorf("expected raw_text to be hashed, got %v", redacted["raw_text"])
+	}
+	if redacted["owner_email"] != "owner@example.com" {
+		t.Error("expected owner_email kept when product is not flagged PII")
+	}
+	if redacted["product_id"] != "abc" {
+		t.Error("expected non-sensitive keys to be kept")
+	}
+	if details["raw_text"] != "Jane Doe called about her card" {
+		t.Error("expected input details to be left untouched")
+	}
+
+	details["pii_flag"] = true
+	redacted = RedactDetails(details)
+	if redacted["owner_email"] == "owner@example.com" {
+		t.Error("expected owner_email redacted for a PII-flagged product")
+	}
+	if RedactDetails(details)["owner_email"] != redacted["owner_email"] {
+		t.Error("expected hashing to be stable so records can be correlated")
+	}
+}
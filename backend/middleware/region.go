@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+	"gorm.io/gorm"
+)
+
+// RegionScopeKey is the context key RegionScope stores the caller's region under
+const RegionScopeKey = "regionScope"
+
+// RegionScope limits the routes it is attached to to the caller's own region.
+// Authenticated non-admin callers whose profile has a region get it stored
+// under RegionScopeKey for handlers to filter on; a regional lead without one
+// is refused. Admins and anonymous callers are left unscoped, so public
+// routes stay open.
+func RegionScope(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, _ := c.Get("role")
+		roleStr, _ := role.(string)
+		if roleStr == "" {
+			c.Next()
+			return
+		}
+		caller := models.Profile{Role: models.UserRole(roleStr)}
+		if caller.IsAdmin() {
+			c.Next()
+			return
+		}
+
+		userID, _ := c.Get("userID")
+		var profile models.Profile
+		err := db.Select("region").First(&profile, "id = ?", userID).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load profile"})
+			c.Abort()
+			return
+		}
+
+		if profile.Region == nil || *profile.Region == "" {
+			if caller.Role == models.UserRoleRegionalLead {
+				c.JSON(http.StatusForbidden, gin.H{"error": "No region assigned to your profile"})
+				c.Abort()
+				return
+			}
+			c.Next()
+			return
+		}
+
+		c.Set(RegionScopeKey, *profile.Region)
+		c.Next()
+	}
+}
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRegionScope(t *testing.T) {
+	db := openAuditTestDB(t, false)
+	err := db.Exec(`CREATE TABLE profiles (
+		id TEXT PRIMARY KEY, email TEXT NOT NULL, full_name TEXT, role TEXT NOT NULL,
+		region TEXT, created_at DATETIME, updated_at DATETIME)`).Error
+	if err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	err = db.Exec(`INSERT INTO profiles (id, email, role, region) VALUES
+		('lead-emea', 'a@x.com', 'regional_lead', 'EMEA'),
+		('lead-none', 'b@x.com', 'regional_lead', NULL),
+		('sales-apac', 'c@x.com', 'sales', 'APAC'),
+		('viewer-none', 'd@x.com', 'viewer', NULL)`).Error
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		userID     string
+		role       string
+		wantStatus int
+		wantRegion string
+	}{
+		{"anonymous stays open", "", "", http.StatusOK, ""},
+		{"admin bypasses", "admin", "vp_product", http.StatusOK, ""},
+		{"regional lead scoped", "lead-emea", "regional_lead", http.StatusOK, "EMEA"},
+		{"regional lead without region refused", "lead-none", "regional_lead", http.StatusForbidden, ""},
+		{"other role scoped", "sales-apac", "sales", http.StatusOK, "APAC"},
+		{"other role without region unscoped", "viewer-none", "viewer", http.StatusOK, ""},
+	}
+
+	gin.SetMode(gin.TestMode)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotRegion string
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				if tt.role != "" {
+					c.Set("userID", tt.userID)
+					c.Set("role", tt.role)
+				}
+			})
+			router.GET("/products", RegionScope(db), func(c *gin.Context) {
+				gotRegion = c.GetString(RegionScopeKey)
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/products", nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if gotRegion != tt.wantRegion {
+				t.Errorf("region = %q, want %q", gotRegion, tt.wantRegion)
+			}
+		})
+	}
+}
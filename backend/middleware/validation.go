@@ -1,6 +1,9 @@
 package middleware
 
 import (
+	"bytes"
+	"encoding/json"
+	"io"
 	"net/http"
 	"regexp"
 	"strings"
@@ -95,14 +98,30 @@ func SanitizeInput(input string, maxLength int) string {
 	return sanitized
 }
 
-// ValidateRequired checks that required fields are present in the request
+// ValidateRequired checks that required fields are present in the request.
+// The body is buffered and restored, so the handler can still bind it.
 func ValidateRequired(fields ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		raw, err := io.ReadAll(io.LimitReader(c.Request.Body, MaxBodySize+1))
+		if err != nil || int64(len(raw)) > MaxBodySize {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid request body",
+				"message": "Could not read request body",
+			})
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(raw))
+
 		var body map[string]interface{}
-		if err := c.ShouldBindJSON(&body); err != nil {
+		if err := json.Unmarshal(raw, &body); err != nil || body == nil {
+			message := "Request body must be a JSON object"
+			if err != nil {
+				message = err.Error()
+			}
 			c.JSON(http.StatusBadRequest, gin.H{
 				"error":   "Invalid request body",
-				"message": err.Error(),
+				"message": message,
 			})
 			c.Abort()
 			return
@@ -124,7 +143,6 @@ func ValidateRequired(fields ...string) gin.HandlerFunc {
 			return
 		}
 
-		// Re-bind the body for the handler (since we consumed it)
 		c.Set("validatedBody", body)
 		c.Next()
 	}
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestWebhookSignature(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	const secret = "webhook-secret"
+	body := `{"product_id":"p1","text":"great"}`
+
+	router := gin.New()
+	router.POST("/hook", WebhookSignature(secret), func(c *gin.Context) {
+		// The body must still be readable after verification
+		got, _ := io.ReadAll(c.Request.Body)
+		c.String(http.StatusOK, string(got))
+	})
+
+	tests := []struct {
+		name      string
+		signature string
+		want      int
+	}{
+		{"valid", SignWebhook(secret, []byte(body)), http.StatusOK},
+		{"missing", "", http.StatusUnauthorized},
+		{"wrong secret", SignWebhook("other", []byte(body)), http.StatusUnauthorized},
+		{"other body", SignWebhook(secret, []byte(body+" ")), http.StatusUnauthorized},
+		{"no prefix", strings.TrimPrefix(SignWebhook(secret, []byte(body)), "sha256="), http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/hook", strings.NewReader(body))
+			if tt.signature != "" {
+				req.Header.Set(WebhookSignatureHeader, tt.signature)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d", w.Code, tt.want)
+			}
+			if tt.want == http.StatusOK && w.Body.String() != body {
+				t.Errorf("handler saw body %q, want %q", w.Body.String(), body)
+			}
+		})
+	}
+}
+
+func TestWebhookSignature_DisabledWithoutSecret(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/hook", WebhookSignature(""), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/hook", strings.NewReader("{}")))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want 503", w.Code)
+	}
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MaxActionCommentLength caps the size of a single progress note
+const MaxActionCommentLength = 4000
+
+type ActionComment struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ActionID  uuid.UUID `json:"action_id" gorm:"type:uuid;not null;index"`
+	Author    string    `json:"author" gorm:"not null"`
+	Body      string    `json:"body" gorm:"not null"`
+	CreatedAt Timestamp `json:"created_at" gorm:"autoCreateTime"`
+
+	// Relationships
+	Action ProductAction `json:"-" gorm:"foreignKey:ActionID;constraint:OnDelete:CASCADE"`
+}
+
+func (ac *ActionComment) BeforeCreate(tx *gorm.DB) error {
+	if ac.ID == uuid.Nil {
+		ac.ID = uuid.New()
+	}
+	return nil
+}
+
+type CreateActionCommentRequest struct {
+	Body string `json:"body" binding:"required"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+// AuditLog is a persisted audit record, written in batches by the audit
+// logger so the trail can be queried
+type AuditLog struct {
+	ID         uuid.UUID       `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	Timestamp  Timestamp       `gorm:"column:occurred_at;not null;index" json:"timestamp"`
+	Action     string          `gorm:"size:50;not null;index" json:"action"`
+	Resource   string          `gorm:"not null" json:"resource"`
+	Method     string          `gorm:"size:10" json:"method,omitempty"`
+	IP         string          `gorm:"size:64" json:"ip"`
+	UserID     string          `gorm:"size:255;index" json:"user_id,omitempty"`
+	UserEmail  string          `gorm:"size:255" json:"user_email,omitempty"`
+	StatusCode int             `json:"status_code"`
+	DurationMs int64           `json:"duration_ms"`
+	Success    bool            `gorm:"not null" json:"success"`
+	Details    json.RawMessage `gorm:"type:jsonb" json:"details,omitempty"`
+	Error      string          `json:"error,omitempty"`
+}
+
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
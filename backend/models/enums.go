@@ -1,15 +1,107 @@
 package models
 
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// EnumError reports a value outside an enum's allowed set
+type EnumError struct {
+	Field   string
+	Value   string
+	Allowed []string
+}
+
+func (e *EnumError) Error() string {
+	return fmt.Sprintf("invalid %s %q: must be one of %s", e.Field, e.Value, strings.Join(e.Allowed, ", "))
+}
+
+// checkEnum returns an *EnumError naming the allowed values when value is
+// not one of them
+func checkEnum[T ~string](field string, value T, allowed []T) error {
+	if slices.Contains(allowed, value) {
+		return nil
+	}
+	names := make([]string, len(allowed))
+	for i, v := range allowed {
+		names[i] = string(v)
+	}
+	return &EnumError{Field: field, Value: string(value), Allowed: names}
+}
+
+// checkable is an enum that can check its own value
+type checkable interface {
+	~string
+	Check() error
+}
+
+// checkOptional checks v when it is set; nil means the field was omitted
+func checkOptional[T checkable](v *T) error {
+	if v == nil {
+		return nil
+	}
+	return (*v).Check()
+}
+
+// firstError returns the first non-nil error
+func firstError(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 type LifecycleStage string
 
 const (
 	LifecycleConcept    LifecycleStage = "concept"
 	LifecycleEarlyPilot LifecycleStage = "early_pilot"
 	LifecyclePilot      LifecycleStage = "pilot"
+	LifecycleScaling    LifecycleStage = "scaling"
 	LifecycleCommercial LifecycleStage = "commercial"
+	LifecycleMature     LifecycleStage = "mature"
 	LifecycleSunset     LifecycleStage = "sunset"
 )
 
+// LifecycleStages are the allowed LifecycleStage values, in the order of the
+// database enum
+var LifecycleStages = []LifecycleStage{
+	LifecycleConcept, LifecycleEarlyPilot, LifecyclePilot, LifecycleScaling,
+	LifecycleCommercial, LifecycleMature, LifecycleSunset,
+}
+
+func (s LifecycleStage) IsValid() bool {
+	return slices.Contains(LifecycleStages, s)
+}
+
+// Check returns an *EnumError listing the allowed values if s is invalid
+func (s LifecycleStage) Check() error {
+	return checkEnum("lifecycle_stage", s, LifecycleStages)
+}
+
+// lifecycleTransitions lists the stages each stage may move on to. Products
+// only move forward, pilots may skip scaling, and sunset is final.
+var lifecycleTransitions = map[LifecycleStage][]LifecycleStage{
+	LifecycleConcept:    {LifecycleEarlyPilot, LifecycleSunset},
+	LifecycleEarlyPilot: {LifecyclePilot, LifecycleSunset},
+	LifecyclePilot:      {LifecycleScaling, LifecycleCommercial, LifecycleSunset},
+	LifecycleScaling:    {LifecycleCommercial, LifecycleSunset},
+	LifecycleCommercial: {LifecycleMature, LifecycleSunset},
+	LifecycleMature:     {LifecycleSunset},
+}
+
+// CanTransitionLifecycle reports whether a product may move from one
+// lifecycle stage to another
+func CanTransitionLifecycle(from, to LifecycleStage) bool {
+	if from == to {
+		return to.IsValid()
+	}
+	return slices.Contains(lifecycleTransitions[from], to)
+}
+
 type ProductType string
 
 const (
@@ -19,6 +111,18 @@ const (
 	ProductTypePartnerships ProductType = "partnerships"
 )
 
+// ProductTypes are the allowed ProductType values
+var ProductTypes = []ProductType{ProductTypeDataServices, ProductTypePaymentFlows, ProductTypeCoreProducts, ProductTypePartnerships}
+
+func (t ProductType) IsValid() bool {
+	return slices.Contains(ProductTypes, t)
+}
+
+// Check returns an *EnumError listing the allowed values if t is invalid
+func (t ProductType) Check() error {
+	return checkEnum("product_type", t, ProductTypes)
+}
+
 type RiskBand string
 
 const (
@@ -27,6 +131,18 @@ const (
 	RiskBandHigh   RiskBand = "high"
 )
 
+// RiskBands are the allowed RiskBand values
+var RiskBands = []RiskBand{RiskBandLow, RiskBandMedium, RiskBandHigh}
+
+func (b RiskBand) IsValid() bool {
+	return slices.Contains(RiskBands, b)
+}
+
+// Check returns an *EnumError listing the allowed values if b is invalid
+func (b RiskBand) Check() error {
+	return checkEnum("risk_band", b, RiskBands)
+}
+
 type ComplianceStatus string
 
 const (
@@ -35,6 +151,18 @@ const (
 	ComplianceStatusComplete   ComplianceStatus = "complete"
 )
 
+// ComplianceStatuses are the allowed ComplianceStatus values
+var ComplianceStatuses = []ComplianceStatus{ComplianceStatusPending, ComplianceStatusInProgress, ComplianceStatusComplete}
+
+func (s ComplianceStatus) IsValid() bool {
+	return slices.Contains(ComplianceStatuses, s)
+}
+
+// Check returns an *EnumError listing the allowed values if s is invalid
+func (s ComplianceStatus) Check() error {
+	return checkEnum("status", s, ComplianceStatuses)
+}
+
 type UserRole string
 
 const (
@@ -66,6 +194,34 @@ const (
 	ActionStatusCancelled  ActionStatus = "cancelled"
 )
 
+// ActionStatuses are the allowed ActionStatus values
+var ActionStatuses = []ActionStatus{ActionStatusPending, ActionStatusInProgress, ActionStatusCompleted, ActionStatusCancelled}
+
+func (s ActionStatus) IsValid() bool {
+	return slices.Contains(ActionStatuses, s)
+}
+
+// Check returns an *EnumError listing the allowed values if s is invalid
+func (s ActionStatus) Check() error {
+	return checkEnum("status", s, ActionStatuses)
+}
+
+// actionTransitions lists where each status may move besides staying put.
+// Cancelled is final; a completed action can be reopened or cancelled.
+var actionTransitions = map[ActionStatus][]ActionStatus{
+	ActionStatusPending:    {ActionStatusInProgress, ActionStatusCompleted, ActionStatusCancelled},
+	ActionStatusInProgress: {ActionStatusPending, ActionStatusCompleted, ActionStatusCancelled},
+	ActionStatusCompleted:  {ActionStatusInProgress, ActionStatusCancelled},
+}
+
+// CanTransition reports whether an action may move from one status to another
+func CanTransition(from, to ActionStatus) bool {
+	if from == to {
+		return to.IsValid()
+	}
+	return slices.Contains(actionTransitions[from], to)
+}
+
 type ActionPriority string
 
 const (
@@ -74,3 +230,15 @@ const (
 	ActionPriorityHigh     ActionPriority = "high"
 	ActionPriorityCritical ActionPriority = "critical"
 )
+
+// ActionPriorities are the allowed ActionPriority values
+var ActionPriorities = []ActionPriority{ActionPriorityLow, ActionPriorityMedium, ActionPriorityHigh, ActionPriorityCritical}
+
+func (p ActionPriority) IsValid() bool {
+	return slices.Contains(ActionPriorities, p)
+}
+
+// Check returns an *EnumError listing the allowed values if p is invalid
+func (p ActionPriority) Check() error {
+	return checkEnum("priority", p, ActionPriorities)
+}
@@ -0,0 +1,178 @@
+package models
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestEnumIsValid(t *testing.T) {
+	tests := []struct {
+		name    string
+		valid   bool
+		isValid func() bool
+	}{
+		{"lifecycle stage pilot", true, LifecyclePilot.IsValid},
+		{"lifecycle stage mature", true, LifecycleMature.IsValid},
+		{"lifecycle stage typo", false, LifecycleStage("pilott").IsValid},
+		{"product type", true, ProductTypePaymentFlows.IsValid},
+		{"product type typo", false, ProductType("payments").IsValid},
+		{"risk band", true, RiskBandMedium.IsValid},
+		{"risk band wrong case", false, RiskBand("HIGH").IsValid},
+		{"action status", true, ActionStatusInProgress.IsValid},
+		{"action status typo", false, ActionStatus("done").IsValid},
+		{"action priority", true, ActionPriorityCritical.IsValid},
+		{"action priority typo", false, ActionPriority("urgent").IsValid},
+		{"dependency status", true, DependencyStatusBlocked.IsValid},
+		{"dependency status typo", false, DependencyStatus("stuck").IsValid},
+		{"integration status", true, IntegrationStatusTesting.IsValid},
+		{"integration status free text", false, IntegrationStatus("In Progress").IsValid},
+		{"compliance status", true, ComplianceStatusInProgress.IsValid},
+		{"compliance status typo", false, ComplianceStatus("completed").IsValid},
+		{"empty", false, LifecycleStage("").IsValid},
+	}
+	for _, tt := range tests {
+		if got := tt.isValid(); got != tt.valid {
+			t.Errorf("%s: IsValid() = %v, want %v", tt.name, got, tt.valid)
+		}
+	}
+}
+
+func TestEnumCheck_ListsAllowedValues(t *testing.T) {
+	err := LifecycleStage("pilott").Check()
+
+	var enumErr *EnumError
+	if !errors.As(err, &enumErr) {
+		t.Fatalf("err = %v, want *EnumError", err)
+	}
+	if enumErr.Field != "lifecycle_stage" || enumErr.Value != "pilott" {
+		t.Errorf("unexpected error fields: %+v", enumErr)
+	}
+	want := `invalid lifecycle_stage "pilott": must be one of concept, early_pilot, pilot, scaling, commercial, mature, sunset`
+	if err.Error() != want {
+		t.Errorf("message = %q, want %q", err.Error(), want)
+	}
+
+	if err := LifecyclePilot.Check(); err != nil {
+		t.Errorf("valid value: unexpected error %v", err)
+	}
+}
+
+func TestRequestValidate(t *testing.T) {
+	badStage := LifecycleStage("pilott")
+	badPriority := ActionPriority("urgent")
+	badBand := RiskBand("severe")
+	badStatus := DependencyStatus("stuck")
+	goodStatus := ActionStatusCompleted
+
+	tests := []struct {
+		name      string
+		err       error
+		wantField string
+	}{
+		{"draft product without enums", CreateProductRequest{Name: "Draft", Draft: true}.Validate(), ""},
+		{"product with bad type", CreateProductRequest{Name: "P", ProductType: "payments"}.Validate(), "product_type"},
+		{"product update bad stage", UpdateProductRequest{LifecycleStage: &badStage}.Validate(), "lifecycle_stage"},
+		{"action with good status", UpdateProductActionRequest{Status: &goodStatus}.Validate(), ""},
+		{"action with bad priority", CreateProductActionRequest{Priority: &badPriority}.Validate(), "priority"},
+		{"readiness bad band", UpdateProductReadinessRequest{RiskBand: &badBand}.Validate(), "risk_band"},
+		{"dependency bad category", CreateProductDependencyRequest{Type: DependencyTypeInternal, Category: "legl"}.Validate(), "category"},
+		{"dependency update bad status", UpdateProductDependencyRequest{Status: &badStatus}.Validate(), "status"},
+	}
+	for _, tt := range tests {
+		if tt.wantField == "" {
+			if tt.err != nil {
+				t.Errorf("%s: unexpected error %v", tt.name, tt.err)
+			}
+			continue
+		}
+		var enumErr *EnumError
+		if !errors.As(tt.err, &enumErr) || enumErr.Field != tt.wantField {
+			t.Errorf("%s: err = %v, want an EnumError for %s", tt.name, tt.err, tt.wantField)
+			continue
+		}
+		if !strings.Contains(tt.err.Error(), "must be one of") {
+			t.Errorf("%s: message %q should list allowed values", tt.name, tt.err)
+		}
+	}
+}
+
+func TestCanTransition(t *testing.T) {
+	const (
+		p = ActionStatusPending
+		i = ActionStatusInProgress
+		d = ActionStatusCompleted
+		x = ActionStatusCancelled
+	)
+	// allowed[from] lists every status reachable from it
+	allowed := map[ActionStatus][]ActionStatus{
+		p: {p, i, d, x},
+		i: {p, i, d, x},
+		d: {i, d, x},
+		x: {x},
+	}
+	for _, from := range ActionStatuses {
+		for _, to := range ActionStatuses {
+			want := slices.Contains(allowed[from], to)
+			if got := CanTransition(from, to); got != want {
+				t.Errorf("CanTransition(%s, %s) = %v, want %v", from, to, got, want)
+			}
+		}
+	}
+	if CanTransition(p, "done") || CanTransition("done", "done") {
+		t.Error("transition to an unknown status allowed")
+	}
+}
+
+func TestCanTransitionLifecycle(t *testing.T) {
+	tests := []struct {
+		from, to LifecycleStage
+		want     bool
+	}{
+		{LifecycleConcept, LifecycleEarlyPilot, true},
+		{LifecycleEarlyPilot, LifecyclePilot, true},
+		{LifecyclePilot, LifecycleScaling, true},
+		{LifecyclePilot, LifecycleCommercial, true},
+		{LifecycleScaling, LifecycleCommercial, true},
+		{LifecycleCommercial, LifecycleMature, true},
+		{LifecyclePilot, LifecyclePilot, true},
+		{LifecycleConcept, LifecyclePilot, false},
+		{LifecycleCommercial, LifecycleConcept, false},
+		{LifecycleMature, LifecycleCommercial, false},
+		{LifecycleSunset, LifecycleConcept, false},
+	}
+	for _, tt := range tests {
+		if got := CanTransitionLifecycle(tt.from, tt.to); got != tt.want {
+			t.Errorf("CanTransitionLifecycle(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+	for _, from := range LifecycleStages {
+		if !CanTransitionLifecycle(from, LifecycleSunset) {
+			t.Errorf("%s cannot be sunset", from)
+		}
+	}
+}
+
+func TestIntegrationStatusFromText(t *testing.T) {
+	tests := map[string]IntegrationStatus{
+		"":                     IntegrationStatusNotStarted,
+		"live":                 IntegrationStatusLive,
+		"In-Progress":          IntegrationStatusInProgress,
+		"  not   started ":     IntegrationStatusNotStarted,
+		"not yet started":      IntegrationStatusNotStarted,
+		"Pending":              IntegrationStatusNotStarted,
+		"UAT":                  IntegrationStatusTesting,
+		"Certification":        IntegrationStatusTesting,
+		"Live in production":   IntegrationStatusLive,
+		"enabled":              IntegrationStatusLive,
+		"Blocked on contract":  IntegrationStatusFailed,
+		"integration underway": IntegrationStatusInProgress,
+		"unknown":              IntegrationStatusNotStarted,
+	}
+	for raw, want := range tests {
+		if got := IntegrationStatusFromText(raw); got != want {
+			t.Errorf("IntegrationStatusFromText(%q) = %s, want %s", raw, got, want)
+		}
+	}
+}
@@ -1,8 +1,6 @@
 package models
 
 import (
-	"time"
-
 	"github.com/google/uuid"
 )
 
@@ -15,6 +13,20 @@ const (
 	EscalationLevelCritical         EscalationLevel = "critical"
 )
 
+// Rank orders levels by severity, none lowest
+func (l EscalationLevel) Rank() int {
+	switch l {
+	case EscalationLevelAmbassadorReview:
+		return 1
+	case EscalationLevelExecSteerCo:
+		return 2
+	case EscalationLevelCritical:
+		return 3
+	default:
+		return 0
+	}
+}
+
 type ProductEscalation struct {
 	ID             uuid.UUID       `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
 	ProductID      uuid.UUID       `gorm:"type:uuid;not null" json:"product_id"`
@@ -23,14 +35,16 @@ type ProductEscalation struct {
 	Owner          string          `gorm:"not null" json:"owner"`
 	NextMilestone  string          `json:"next_milestone,omitempty"`
 	CyclesInStatus int             `gorm:"default:0" json:"cycles_in_status"`
-	TriggeredAt    time.Time       `gorm:"autoCreateTime" json:"triggered_at"`
-	ResolvedAt     *time.Time      `json:"resolved_at,omitempty"`
+	TriggeredAt    Timestamp       `gorm:"autoCreateTime" json:"triggered_at"`
+	ResolvedAt     *Timestamp      `json:"resolved_at,omitempty"`
 	Notes          *string         `json:"notes,omitempty"`
-	CreatedAt      time.Time       `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt      time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
+	AcknowledgedAt *Timestamp      `json:"acknowledged_at,omitempty"`
+	AcknowledgedBy *string         `gorm:"size:255" json:"acknowledged_by,omitempty"`
+	CreatedAt      Timestamp       `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      Timestamp       `gorm:"autoUpdateTime" json:"updated_at"`
 
 	// Relationships
-	Product Product `gorm:"foreignKey:ProductID" json:"-"`
+	Product Product `gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE" json:"-"`
 }
 
 func (ProductEscalation) TableName() string {
@@ -53,10 +67,15 @@ type UpdateEscalationRequest struct {
 	Owner          *string          `json:"owner,omitempty"`
 	NextMilestone  *string          `json:"next_milestone,omitempty"`
 	CyclesInStatus *int             `json:"cycles_in_status,omitempty"`
-	ResolvedAt     *time.Time       `json:"resolved_at,omitempty"`
+	ResolvedAt     *Timestamp       `json:"resolved_at,omitempty"`
 	Notes          *string          `json:"notes,omitempty"`
 }
 
+// ResolveEscalationRequest closes an escalation with optional notes
+type ResolveEscalationRequest struct {
+	Notes *string `json:"notes,omitempty"`
+}
+
 // EscalationResponse includes calculated fields
 type EscalationResponse struct {
 	ProductID      string `json:"product_id"`
@@ -68,4 +87,9 @@ type EscalationResponse struct {
 	CyclesInStatus int    `json:"cycles_in_status"`
 	RequiresAction bool   `json:"requires_action"`
 	TriggeredAt    string `json:"triggered_at,omitempty"`
+
+	// Link to the open escalation record when it is at the current level
+	EscalationID   string     `json:"escalation_id,omitempty"`
+	Acknowledged   bool       `json:"acknowledged"`
+	AcknowledgedAt *Timestamp `json:"acknowledged_at,omitempty"`
 }
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// PortfolioRiskSnapshot stores the portfolio risk index once per ISO week so
+// the headline number can be trended
+type PortfolioRiskSnapshot struct {
+	ID                     uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	Index                  float64   `gorm:"type:decimal(5,2);not null" json:"index"`
+	RiskBandScore          float64   `gorm:"type:decimal(5,2);not null" json:"risk_band_score"`
+	EscalationScore        float64   `gorm:"type:decimal(5,2);not null" json:"escalation_score"`
+	BlockedDependencyScore float64   `gorm:"type:decimal(5,2);not null" json:"blocked_dependency_score"`
+	StaleDataScore         float64   `gorm:"type:decimal(5,2);not null" json:"stale_data_score"`
+	ProductCount           int       `gorm:"not null" json:"product_count"`
+	WeekNumber             int       `gorm:"not null;uniqueIndex:idx_portfolio_risk_week" json:"week_number"`
+	Year                   int       `gorm:"not null;uniqueIndex:idx_portfolio_risk_week" json:"year"`
+	RecordedAt             Timestamp `gorm:"autoUpdateTime" json:"recorded_at"`
+}
+
+func (PortfolioRiskSnapshot) TableName() string {
+	return "portfolio_risk_snapshots"
+}
@@ -1,8 +1,6 @@
 package models
 
 import (
-	"time"
-
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -13,17 +11,23 @@ type Product struct {
 	ProductType       ProductType    `json:"product_type" gorm:"type:varchar(50);not null"`
 	Region            string         `json:"region" gorm:"default:'North America'"`
 	LifecycleStage    LifecycleStage `json:"lifecycle_stage" gorm:"type:varchar(50);not null"`
-	LaunchDate        *time.Time     `json:"launch_date,omitempty"`
+	LaunchDate        *Timestamp     `json:"launch_date,omitempty"`
 	RevenueTarget     *float64       `json:"revenue_target,omitempty" gorm:"type:decimal(10,2)"`
 	OwnerEmail        string         `json:"owner_email" gorm:"not null"`
 	SuccessMetric     *string        `json:"success_metric,omitempty"`
 	GatingStatus      *string        `json:"gating_status,omitempty"`
-	GatingStatusSince *time.Time     `json:"gating_status_since,omitempty"`
+	GatingStatusSince *Timestamp     `json:"gating_status_since,omitempty"`
 	GovernanceTier    *string        `json:"governance_tier,omitempty"`
 	BudgetCode        *string        `json:"budget_code,omitempty"`
 	PIIFlag           *bool          `json:"pii_flag,omitempty"`
 	BusinessSponsor   *string        `json:"business_sponsor,omitempty"`
 	EngineeringLead   *string        `json:"engineering_lead,omitempty"`
+	IsDraft           bool           `json:"is_draft" gorm:"not null;default:false;index"`
+	ArchivedAt        *Timestamp     `json:"archived_at,omitempty" gorm:"index"`
+
+	// Tags are free-form labels, see NormalizeTag. Add and remove them
+	// through the tag endpoints so they stay normalized.
+	Tags TagArray `json:"tags" gorm:"not null;default:'{}';index:,type:gin"`
 
 	// Confidence Scores (0-100)
 	RevenueConfidence               *int    `json:"revenue_confidence,omitempty" gorm:"default:50"`
@@ -36,42 +40,112 @@ type Product struct {
 	TTMActualDays      *int `json:"ttm_actual_days,omitempty"`
 	TTMDeltaVsLastWeek *int `json:"ttm_delta_vs_last_week,omitempty" gorm:"default:0"`
 
-	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
-
-	// Relationships
-	Readiness        *ProductReadiness         `json:"readiness,omitempty" gorm:"foreignKey:ProductID"`
-	Prediction       *ProductPrediction        `json:"prediction,omitempty" gorm:"foreignKey:ProductID"`
-	Compliance       []ProductCompliance       `json:"compliance,omitempty" gorm:"foreignKey:ProductID"`
-	MarketEvidence   []ProductMarketEvidence   `json:"market_evidence,omitempty" gorm:"foreignKey:ProductID"`
-	Partners         []ProductPartner          `json:"partners,omitempty" gorm:"foreignKey:ProductID"`
-	Metrics          []ProductMetric           `json:"metrics,omitempty" gorm:"foreignKey:ProductID"`
-	Training         *SalesTraining            `json:"training,omitempty" gorm:"foreignKey:ProductID"`
-	Feedback         []ProductFeedback         `json:"feedback,omitempty" gorm:"foreignKey:ProductID"`
-	Actions          []ProductAction           `json:"actions,omitempty" gorm:"foreignKey:ProductID"`
-	Dependencies     []ProductDependency       `json:"dependencies,omitempty" gorm:"foreignKey:ProductID"`
-	ReadinessHistory []ProductReadinessHistory `json:"readiness_history,omitempty" gorm:"foreignKey:ProductID"`
+	CreatedAt Timestamp `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt Timestamp `json:"updated_at" gorm:"autoUpdateTime"`
+
+	// Version counts writes to the product, starting at 1. Clients send the
+	// version they edited to have a stale update rejected.
+	Version int `json:"version" gorm:"not null;default:1"`
+
+	// DeletedAt soft-deletes the product: GORM hides it from queries on
+	// Product unless they are Unscoped. Related records are kept.
+	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+
+	// Relationships. Their rows cascade when the product row itself is
+	// deleted; a soft delete leaves them in place.
+	Readiness        *ProductReadiness         `json:"readiness,omitempty" gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE"`
+	Prediction       *ProductPrediction        `json:"prediction,omitempty" gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE"`
+	Compliance       []ProductCompliance       `json:"compliance,omitempty" gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE"`
+	MarketEvidence   []ProductMarketEvidence   `json:"market_evidence,omitempty" gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE"`
+	Partners         []ProductPartner          `json:"partners,omitempty" gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE"`
+	Metrics          []ProductMetric           `json:"metrics,omitempty" gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE"`
+	Training         *SalesTraining            `json:"training,omitempty" gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE"`
+	Feedback         []ProductFeedback         `json:"feedback,omitempty" gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE"`
+	Actions          []ProductAction           `json:"actions,omitempty" gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE"`
+	Dependencies     []ProductDependency       `json:"dependencies,omitempty" gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE"`
+	ReadinessHistory []ProductReadinessHistory `json:"readiness_history,omitempty" gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE"`
 }
 
 func (p *Product) BeforeCreate(tx *gorm.DB) error {
 	if p.ID == uuid.Nil {
 		p.ID = uuid.New()
 	}
+	if p.Version == 0 {
+		p.Version = 1
+	}
 	return nil
 }
 
+// ExcludeDrafts is a scope limiting a product query to non-draft products
+func ExcludeDrafts(db *gorm.DB) *gorm.DB {
+	return db.Where("products.is_draft = ?", false)
+}
+
+// ExcludeArchived is a scope limiting a product query to products that have
+// not been archived. Archived products are finished but kept for reference;
+// unlike a sunset lifecycle stage they drop out of active views and stats.
+func ExcludeArchived(db *gorm.DB) *gorm.DB {
+	return db.Where("products.archived_at IS NULL")
+}
+
+// ExcludeDeleted hides soft-deleted products from queries that join the
+// products table by hand, where GORM does not apply soft deletes itself
+func ExcludeDeleted(db *gorm.DB) *gorm.DB {
+	return db.Where("products.deleted_at IS NULL")
+}
+
+// MissingRequiredFields lists the fields a non-draft product must have.
+// Drafts may be saved without them and are re-checked on promotion.
+func (p Product) MissingRequiredFields() []string {
+	var missing []string
+	if p.ProductType == "" {
+		missing = append(missing, "product_type")
+	}
+	if p.LifecycleStage == "" {
+		missing = append(missing, "lifecycle_stage")
+	}
+	if p.OwnerEmail == "" {
+		missing = append(missing, "owner_email")
+	}
+	return missing
+}
+
+// CreateProductRequest only requires a name when Draft is set; the other
+// required fields are checked with MissingRequiredFields
 type CreateProductRequest struct {
 	Name           string         `json:"name" binding:"required"`
-	ProductType    ProductType    `json:"product_type" binding:"required"`
+	ProductType    ProductType    `json:"product_type"`
 	Region         string         `json:"region"`
-	LifecycleStage LifecycleStage `json:"lifecycle_stage" binding:"required"`
-	LaunchDate     *time.Time     `json:"launch_date,omitempty"`
+	LifecycleStage LifecycleStage `json:"lifecycle_stage"`
+	LaunchDate     *Timestamp     `json:"launch_date,omitempty"`
 	RevenueTarget  *float64       `json:"revenue_target,omitempty"`
-	OwnerEmail     string         `json:"owner_email" binding:"required,email"`
+	OwnerEmail     string         `json:"owner_email" binding:"omitempty,email"`
 	SuccessMetric  *string        `json:"success_metric,omitempty"`
 	GovernanceTier *string        `json:"governance_tier,omitempty"`
 	BudgetCode     *string        `json:"budget_code,omitempty"`
 	PIIFlag        *bool          `json:"pii_flag,omitempty"`
+	Draft          bool           `json:"draft"`
+}
+
+// Validate checks the enum fields. Empty ones are left to
+// MissingRequiredFields, since drafts may omit them.
+func (r CreateProductRequest) Validate() error {
+	var productType *ProductType
+	if r.ProductType != "" {
+		productType = &r.ProductType
+	}
+	var stage *LifecycleStage
+	if r.LifecycleStage != "" {
+		stage = &r.LifecycleStage
+	}
+	return firstError(checkOptional(productType), checkOptional(stage))
+}
+
+// CloneProductRequest names the copy of a product and the region it is
+// piloted in
+type CloneProductRequest struct {
+	Name   string `json:"name" binding:"required"`
+	Region string `json:"region" binding:"required"`
 }
 
 type UpdateProductRequest struct {
@@ -79,7 +153,7 @@ type UpdateProductRequest struct {
 	ProductType     *ProductType    `json:"product_type,omitempty"`
 	Region          *string         `json:"region,omitempty"`
 	LifecycleStage  *LifecycleStage `json:"lifecycle_stage,omitempty"`
-	LaunchDate      *time.Time      `json:"launch_date,omitempty"`
+	LaunchDate      *Timestamp      `json:"launch_date,omitempty"`
 	RevenueTarget   *float64        `json:"revenue_target,omitempty"`
 	OwnerEmail      *string         `json:"owner_email,omitempty"`
 	SuccessMetric   *string         `json:"success_metric,omitempty"`
@@ -89,4 +163,14 @@ type UpdateProductRequest struct {
 	PIIFlag         *bool           `json:"pii_flag,omitempty"`
 	BusinessSponsor *string         `json:"business_sponsor,omitempty"`
 	EngineeringLead *string         `json:"engineering_lead,omitempty"`
+	// Draft=false promotes a draft once its required fields are present
+	Draft *bool `json:"draft,omitempty"`
+	// Version, when set, must match the stored version or the update is
+	// rejected with 409
+	Version *int `json:"version,omitempty"`
+}
+
+// Validate checks the enum fields that are set
+func (r UpdateProductRequest) Validate() error {
+	return firstError(checkOptional(r.ProductType), checkOptional(r.LifecycleStage))
 }
@@ -1,27 +1,32 @@
 package models
 
 import (
-	"time"
-
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 type ProductAction struct {
-	ID               uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	ProductID        uuid.UUID      `json:"product_id" gorm:"type:uuid;not null;index"`
-	LinkedFeedbackID *uuid.UUID     `json:"linked_feedback_id,omitempty" gorm:"type:uuid;index"`
-	ActionType       ActionType     `json:"action_type" gorm:"type:varchar(50);not null"`
-	Title            string         `json:"title" gorm:"not null"`
-	Description      *string        `json:"description,omitempty"`
-	AssignedTo       *string        `json:"assigned_to,omitempty"`
-	Status           ActionStatus   `json:"status" gorm:"type:varchar(20);not null;default:'pending'"`
-	Priority         ActionPriority `json:"priority" gorm:"type:varchar(20);not null;default:'medium'"`
-	DueDate          *time.Time     `json:"due_date,omitempty" gorm:"type:date"`
-	CompletedAt      *time.Time     `json:"completed_at,omitempty"`
-	CreatedBy        *string        `json:"created_by,omitempty"`
-	CreatedAt        time.Time      `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt        time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	ID                 uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ProductID          uuid.UUID      `json:"product_id" gorm:"type:uuid;not null;index"`
+	LinkedFeedbackID   *uuid.UUID     `json:"linked_feedback_id,omitempty" gorm:"type:uuid;index"`
+	LinkedEscalationID *uuid.UUID     `json:"linked_escalation_id,omitempty" gorm:"type:uuid;index"`
+	ActionType         ActionType     `json:"action_type" gorm:"type:varchar(50);not null"`
+	Title              string         `json:"title" gorm:"not null"`
+	Description        *string        `json:"description,omitempty"`
+	AssignedTo         *string        `json:"assigned_to,omitempty"`
+	Status             ActionStatus   `json:"status" gorm:"type:varchar(20);not null;default:'pending'"`
+	Priority           ActionPriority `json:"priority" gorm:"type:varchar(20);not null;default:'medium'"`
+	DueDate            *Date          `json:"due_date,omitempty" gorm:"type:date"`
+	CompletedAt        *Timestamp     `json:"completed_at,omitempty"`
+	CreatedBy          *string        `json:"created_by,omitempty"`
+	SourceRef          *string        `json:"source_ref,omitempty" gorm:"index"` // set on system-generated actions, e.g. "escalation:<id>"
+	CreatedAt          Timestamp      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt          Timestamp      `json:"updated_at" gorm:"autoUpdateTime"`
+
+	// Relationships. Deleting the linked feedback or escalation unlinks
+	// the action rather than removing it.
+	LinkedFeedback   *ProductFeedback   `json:"-" gorm:"foreignKey:LinkedFeedbackID;constraint:OnDelete:SET NULL"`
+	LinkedEscalation *ProductEscalation `json:"-" gorm:"foreignKey:LinkedEscalationID;constraint:OnDelete:SET NULL"`
 }
 
 func (pa *ProductAction) BeforeCreate(tx *gorm.DB) error {
@@ -32,25 +37,46 @@ func (pa *ProductAction) BeforeCreate(tx *gorm.DB) error {
 }
 
 type CreateProductActionRequest struct {
-	ProductID        uuid.UUID       `json:"product_id" binding:"required"`
-	LinkedFeedbackID *uuid.UUID      `json:"linked_feedback_id,omitempty"`
-	ActionType       ActionType      `json:"action_type" binding:"required"`
-	Title            string          `json:"title" binding:"required"`
-	Description      *string         `json:"description,omitempty"`
-	AssignedTo       *string         `json:"assigned_to,omitempty"`
-	Status           *ActionStatus   `json:"status,omitempty"`
-	Priority         *ActionPriority `json:"priority,omitempty"`
-	DueDate          *time.Time      `json:"due_date,omitempty"`
+	ProductID          uuid.UUID       `json:"product_id" binding:"required"`
+	LinkedFeedbackID   *uuid.UUID      `json:"linked_feedback_id,omitempty"`
+	LinkedEscalationID *uuid.UUID      `json:"linked_escalation_id,omitempty"`
+	ActionType         ActionType      `json:"action_type" binding:"required"`
+	Title              string          `json:"title" binding:"required"`
+	Description        *string         `json:"description,omitempty"`
+	AssignedTo         *string         `json:"assigned_to,omitempty"`
+	Status             *ActionStatus   `json:"status,omitempty"`
+	Priority           *ActionPriority `json:"priority,omitempty"`
+	DueDate            *Date           `json:"due_date,omitempty"`
+}
+
+// Validate checks the enum fields that are set
+func (r CreateProductActionRequest) Validate() error {
+	return firstError(checkOptional(r.Status), checkOptional(r.Priority))
 }
 
 type UpdateProductActionRequest struct {
-	LinkedFeedbackID *uuid.UUID      `json:"linked_feedback_id,omitempty"`
-	ActionType       *ActionType     `json:"action_type,omitempty"`
-	Title            *string         `json:"title,omitempty"`
-	Description      *string         `json:"description,omitempty"`
-	AssignedTo       *string         `json:"assigned_to,omitempty"`
-	Status           *ActionStatus   `json:"status,omitempty"`
-	Priority         *ActionPriority `json:"priority,omitempty"`
-	DueDate          *time.Time      `json:"due_date,omitempty"`
-	CompletedAt      *time.Time      `json:"completed_at,omitempty"`
+	LinkedFeedbackID   *uuid.UUID      `json:"linked_feedback_id,omitempty"`
+	LinkedEscalationID *uuid.UUID      `json:"linked_escalation_id,omitempty"`
+	ActionType         *ActionType     `json:"action_type,omitempty"`
+	Title              *string         `json:"title,omitempty"`
+	Description        *string         `json:"description,omitempty"`
+	AssignedTo         *string         `json:"assigned_to,omitempty"`
+	Status             *ActionStatus   `json:"status,omitempty"`
+	Priority           *ActionPriority `json:"priority,omitempty"`
+	DueDate            *Date           `json:"due_date,omitempty"`
+	CompletedAt        *Timestamp      `json:"completed_at,omitempty"`
+}
+
+// Validate checks the enum fields that are set
+func (r UpdateProductActionRequest) Validate() error {
+	return firstError(checkOptional(r.Status), checkOptional(r.Priority))
+}
+
+// MaxActionBatchSize caps the status changes in one batch request
+const MaxActionBatchSize = 100
+
+// ActionStatusChange is one item of a batch status update
+type ActionStatusChange struct {
+	ID     uuid.UUID    `json:"id"`
+	Status ActionStatus `json:"status"`
 }
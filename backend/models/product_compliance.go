@@ -1,8 +1,6 @@
 package models
 
 import (
-	"time"
-
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -12,11 +10,11 @@ type ProductCompliance struct {
 	ProductID         uuid.UUID        `json:"product_id" gorm:"type:uuid;not null;index"`
 	CertificationType string           `json:"certification_type" gorm:"not null"`
 	Status            ComplianceStatus `json:"status" gorm:"type:varchar(20);not null"`
-	CompletedDate     *time.Time       `json:"completed_date,omitempty" gorm:"type:date"`
-	ExpiryDate        *time.Time       `json:"expiry_date,omitempty" gorm:"type:date"`
+	CompletedDate     *Date            `json:"completed_date,omitempty" gorm:"type:date"`
+	ExpiryDate        *Date            `json:"expiry_date,omitempty" gorm:"type:date"`
 	Notes             *string          `json:"notes,omitempty"`
-	CreatedAt         time.Time        `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt         time.Time        `json:"updated_at" gorm:"autoUpdateTime"`
+	CreatedAt         Timestamp        `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt         Timestamp        `json:"updated_at" gorm:"autoUpdateTime"`
 }
 
 func (pc *ProductCompliance) BeforeCreate(tx *gorm.DB) error {
@@ -30,15 +28,15 @@ type CreateProductComplianceRequest struct {
 	ProductID         uuid.UUID        `json:"product_id" binding:"required"`
 	CertificationType string           `json:"certification_type" binding:"required"`
 	Status            ComplianceStatus `json:"status" binding:"required"`
-	CompletedDate     *time.Time       `json:"completed_date,omitempty"`
-	ExpiryDate        *time.Time       `json:"expiry_date,omitempty"`
+	CompletedDate     *Date            `json:"completed_date,omitempty"`
+	ExpiryDate        *Date            `json:"expiry_date,omitempty"`
 	Notes             *string          `json:"notes,omitempty"`
 }
 
 type UpdateProductComplianceRequest struct {
 	CertificationType *string           `json:"certification_type,omitempty"`
 	Status            *ComplianceStatus `json:"status,omitempty"`
-	CompletedDate     *time.Time        `json:"completed_date,omitempty"`
-	ExpiryDate        *time.Time        `json:"expiry_date,omitempty"`
+	CompletedDate     *Date             `json:"completed_date,omitempty"`
+	ExpiryDate        *Date             `json:"expiry_date,omitempty"`
 	Notes             *string           `json:"notes,omitempty"`
 }
@@ -1,7 +1,7 @@
 package models
 
 import (
-	"time"
+	"slices"
 
 	"github.com/google/uuid"
 )
@@ -37,6 +37,46 @@ const (
 	DependencyCategoryRegulatory  DependencyCategory = "regulatory"
 )
 
+// DependencyTypes, DependencyStatuses and DependencyCategories are the
+// allowed values of each enum
+var (
+	DependencyTypes      = []DependencyType{DependencyTypeInternal, DependencyTypeExternal}
+	DependencyStatuses   = []DependencyStatus{DependencyStatusBlocked, DependencyStatusPending, DependencyStatusResolved}
+	DependencyCategories = []DependencyCategory{
+		DependencyCategoryLegal, DependencyCategoryCyber, DependencyCategoryCompliance,
+		DependencyCategoryPrivacy, DependencyCategoryEngineering, DependencyCategoryOps,
+		DependencyCategoryPartnerRail, DependencyCategoryVendor, DependencyCategoryAPI,
+		DependencyCategoryIntegration, DependencyCategoryRegulatory,
+	}
+)
+
+func (t DependencyType) IsValid() bool {
+	return slices.Contains(DependencyTypes, t)
+}
+
+// Check returns an *EnumError listing the allowed values if t is invalid
+func (t DependencyType) Check() error {
+	return checkEnum("type", t, DependencyTypes)
+}
+
+func (s DependencyStatus) IsValid() bool {
+	return slices.Contains(DependencyStatuses, s)
+}
+
+// Check returns an *EnumError listing the allowed values if s is invalid
+func (s DependencyStatus) Check() error {
+	return checkEnum("status", s, DependencyStatuses)
+}
+
+func (c DependencyCategory) IsValid() bool {
+	return slices.Contains(DependencyCategories, c)
+}
+
+// Check returns an *EnumError listing the allowed values if c is invalid
+func (c DependencyCategory) Check() error {
+	return checkEnum("category", c, DependencyCategories)
+}
+
 type ProductDependency struct {
 	ID           uuid.UUID          `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
 	ProductID    uuid.UUID          `gorm:"type:uuid;not null" json:"product_id"`
@@ -44,14 +84,22 @@ type ProductDependency struct {
 	Type         DependencyType     `gorm:"type:varchar(20);not null" json:"type"`
 	Category     DependencyCategory `gorm:"type:varchar(50);not null" json:"category"`
 	Status       DependencyStatus   `gorm:"type:varchar(20);not null;default:'pending'" json:"status"`
-	BlockedSince *time.Time         `json:"blocked_since,omitempty"`
-	ResolvedAt   *time.Time         `json:"resolved_at,omitempty"`
+	BlockedSince *Timestamp         `json:"blocked_since,omitempty"`
+	ResolvedAt   *Timestamp         `json:"resolved_at,omitempty"`
 	Notes        *string            `json:"notes,omitempty"`
-	CreatedAt    time.Time          `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt    time.Time          `gorm:"autoUpdateTime" json:"updated_at"`
+	CreatedAt    Timestamp          `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    Timestamp          `gorm:"autoUpdateTime" json:"updated_at"`
+
+	// BlocksProductIDs lists other products held up by the same blocker,
+	// such as a partner rail several products integrate with
+	BlocksProductIDs UUIDArray `json:"blocks_product_ids,omitempty"`
+
+	// ArchivedAt hides the dependency from the default dependency lists; it
+	// is kept for reference and can be reactivated
+	ArchivedAt *Timestamp `json:"archived_at,omitempty"`
 
 	// Relationships
-	Product Product `gorm:"foreignKey:ProductID" json:"-"`
+	Product Product `gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE" json:"-"`
 }
 
 func (ProductDependency) TableName() string {
@@ -65,6 +113,13 @@ type CreateProductDependencyRequest struct {
 	Category  DependencyCategory `json:"category" binding:"required"`
 	Status    *DependencyStatus  `json:"status,omitempty"`
 	Notes     *string            `json:"notes,omitempty"`
+
+	BlocksProductIDs []uuid.UUID `json:"blocks_product_ids,omitempty"`
+}
+
+// Validate checks the enum fields
+func (r CreateProductDependencyRequest) Validate() error {
+	return firstError(r.Type.Check(), r.Category.Check(), checkOptional(r.Status))
 }
 
 type UpdateProductDependencyRequest struct {
@@ -72,7 +127,15 @@ type UpdateProductDependencyRequest struct {
 	Type         *DependencyType     `json:"type,omitempty"`
 	Category     *DependencyCategory `json:"category,omitempty"`
 	Status       *DependencyStatus   `json:"status,omitempty"`
-	BlockedSince *time.Time          `json:"blocked_since,omitempty"`
-	ResolvedAt   *time.Time          `json:"resolved_at,omitempty"`
+	BlockedSince *Timestamp          `json:"blocked_since,omitempty"`
+	ResolvedAt   *Timestamp          `json:"resolved_at,omitempty"`
 	Notes        *string             `json:"notes,omitempty"`
+
+	// Replaces the whole list; send [] to clear it
+	BlocksProductIDs *[]uuid.UUID `json:"blocks_product_ids,omitempty"`
+}
+
+// Validate checks the enum fields that are set
+func (r UpdateProductDependencyRequest) Validate() error {
+	return firstError(checkOptional(r.Type), checkOptional(r.Category), checkOptional(r.Status))
 }
@@ -1,7 +1,7 @@
 package models
 
 import (
-	"time"
+	"strings"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -9,14 +9,20 @@ import (
 
 type ProductFeedback struct {
 	ID             uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	ProductID      uuid.UUID `json:"product_id" gorm:"type:uuid;not null;index"`
+	ProductID      uuid.UUID `json:"product_id" gorm:"type:uuid;not null;index;index:idx_feedback_product_theme,priority:1"`
 	Source         string    `json:"source" gorm:"not null"`
 	RawText        string    `json:"raw_text" gorm:"not null"`
-	Theme          *string   `json:"theme,omitempty"`
+	Theme          *string   `json:"theme,omitempty" gorm:"index;index:idx_feedback_product_theme,priority:2"`
+	RawTheme       *string   `json:"raw_theme,omitempty"` // as submitted, kept for auditing; Theme is its canonical form
 	SentimentScore *float64  `json:"sentiment_score,omitempty" gorm:"type:decimal(5,2)"`
 	ImpactLevel    *string   `json:"impact_level,omitempty"`
 	Volume         *int      `json:"volume,omitempty" gorm:"default:1"`
-	CreatedAt      time.Time `json:"created_at" gorm:"autoCreateTime"`
+	CreatedAt      Timestamp `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      Timestamp `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+func (ProductFeedback) TableName() string {
+	return "product_feedback"
 }
 
 func (pf *ProductFeedback) BeforeCreate(tx *gorm.DB) error {
@@ -26,6 +32,50 @@ func (pf *ProductFeedback) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+// DefaultFeedbackVolume is the weight of a feedback entry with no volume set.
+// A volume below it is treated as the default.
+const DefaultFeedbackVolume = 1
+
+// EffectiveVolumeSQL is the SQL counterpart of EffectiveVolume for aggregates.
+// It avoids GREATEST, which SQLite lacks, so the aggregates can be tested.
+const EffectiveVolumeSQL = "CASE WHEN volume > 1 THEN volume ELSE 1 END"
+
+// EffectiveVolume is the weight this entry contributes to volume-weighted stats
+func (pf ProductFeedback) EffectiveVolume() int {
+	if pf.Volume == nil || *pf.Volume < DefaultFeedbackVolume {
+		return DefaultFeedbackVolume
+	}
+	return *pf.Volume
+}
+
+// NormalizeTheme lowercases a theme and collapses its whitespace so that
+// "Onboarding" and " onboarding " aggregate together
+func NormalizeTheme(theme string) string {
+	return strings.ToLower(strings.Join(strings.Fields(theme), " "))
+}
+
+// ThemeAliases maps normalized theme variants, such as "on-boarding", onto
+// the canonical theme they are stored as
+type ThemeAliases map[string]string
+
+// NewThemeAliases normalizes both sides of each alias
+func NewThemeAliases(aliases map[string]string) ThemeAliases {
+	normalized := make(ThemeAliases, len(aliases))
+	for variant, canonical := range aliases {
+		normalized[NormalizeTheme(variant)] = NormalizeTheme(canonical)
+	}
+	return normalized
+}
+
+// Canonical normalizes theme and resolves it through the aliases
+func (a ThemeAliases) Canonical(theme string) string {
+	theme = NormalizeTheme(theme)
+	if canonical, ok := a[theme]; ok {
+		return canonical
+	}
+	return theme
+}
+
 type CreateProductFeedbackRequest struct {
 	ProductID      uuid.UUID `json:"product_id" binding:"required"`
 	Source         string    `json:"source" binding:"required"`
@@ -33,7 +83,7 @@ type CreateProductFeedbackRequest struct {
 	Theme          *string   `json:"theme,omitempty"`
 	SentimentScore *float64  `json:"sentiment_score,omitempty"`
 	ImpactLevel    *string   `json:"impact_level,omitempty"`
-	Volume         *int      `json:"volume,omitempty"`
+	Volume         *int      `json:"volume,omitempty" binding:"omitempty,min=1"`
 }
 
 type UpdateProductFeedbackRequest struct {
@@ -42,5 +92,5 @@ type UpdateProductFeedbackRequest struct {
 	Theme          *string  `json:"theme,omitempty"`
 	SentimentScore *float64 `json:"sentiment_score,omitempty"`
 	ImpactLevel    *string  `json:"impact_level,omitempty"`
-	Volume         *int     `json:"volume,omitempty"`
+	Volume         *int     `json:"volume,omitempty" binding:"omitempty,min=1"`
 }
@@ -0,0 +1,43 @@
+package models
+
+import "testing"
+
+func TestEffectiveVolume(t *testing.T) {
+	intPtr := func(v int) *int { return &v }
+
+	tests := []struct {
+		name   string
+		volume *int
+		want   int
+	}{
+		{"nil defaults to 1", nil, 1},
+		{"zero clamps to 1", intPtr(0), 1},
+		{"negative clamps to 1", intPtr(-5), 1},
+		{"positive kept", intPtr(12), 12},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (ProductFeedback{Volume: tt.volume}).EffectiveVolume(); got != tt.want {
+				t.Errorf("EffectiveVolume() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestThemeAliasesCanonical(t *testing.T) {
+	aliases := NewThemeAliases(map[string]string{"On-Boarding": "Onboarding", "ux": "usability"})
+
+	tests := map[string]string{
+		"Onboarding":    "onboarding",
+		" on-boarding ": "onboarding",
+		"ON-BOARDING":   "onboarding",
+		"UX":            "usability",
+		"  api   docs ": "api docs",
+	}
+	for theme, want := range tests {
+		if got := aliases.Canonical(theme); got != want {
+			t.Errorf("Canonical(%q) = %q, want %q", theme, got, want)
+		}
+	}
+}
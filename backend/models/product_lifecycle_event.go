@@ -0,0 +1,30 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ProductLifecycleEvent records each move of a product between lifecycle stages
+type ProductLifecycleEvent struct {
+	ID        uuid.UUID      `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	ProductID uuid.UUID      `gorm:"type:uuid;not null;index" json:"product_id"`
+	FromStage LifecycleStage `gorm:"type:varchar(50);not null" json:"from_stage"`
+	ToStage   LifecycleStage `gorm:"type:varchar(50);not null" json:"to_stage"`
+	ChangedBy *string        `json:"changed_by,omitempty"`
+	ChangedAt Timestamp      `gorm:"autoCreateTime" json:"changed_at"`
+
+	// Relationships
+	Product Product `gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+func (e *ProductLifecycleEvent) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
+
+func (ProductLifecycleEvent) TableName() string {
+	return "product_lifecycle_events"
+}
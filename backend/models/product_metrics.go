@@ -1,7 +1,7 @@
 package models
 
 import (
-	"time"
+	"errors"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -9,14 +9,15 @@ import (
 
 type ProductMetric struct {
 	ID                uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	ProductID         uuid.UUID `json:"product_id" gorm:"type:uuid;not null;index"`
-	Date              time.Time `json:"date" gorm:"type:date;not null"`
+	ProductID         uuid.UUID `json:"product_id" gorm:"type:uuid;not null;index;uniqueIndex:idx_metrics_product_date,priority:1"`
+	Date              Date      `json:"date" gorm:"type:date;not null;uniqueIndex:idx_metrics_product_date,priority:2"`
 	ActualRevenue     *float64  `json:"actual_revenue,omitempty" gorm:"type:decimal(10,2)"`
 	AdoptionRate      *float64  `json:"adoption_rate,omitempty" gorm:"type:decimal(5,2)"`
 	ActiveUsers       *int      `json:"active_users,omitempty"`
 	TransactionVolume *int      `json:"transaction_volume,omitempty"`
 	ChurnRate         *float64  `json:"churn_rate,omitempty" gorm:"type:decimal(5,2)"`
-	CreatedAt         time.Time `json:"created_at" gorm:"autoCreateTime"`
+	CreatedAt         Timestamp `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt         Timestamp `json:"updated_at" gorm:"autoUpdateTime"`
 }
 
 func (pm *ProductMetric) BeforeCreate(tx *gorm.DB) error {
@@ -28,7 +29,7 @@ func (pm *ProductMetric) BeforeCreate(tx *gorm.DB) error {
 
 type CreateProductMetricRequest struct {
 	ProductID         uuid.UUID `json:"product_id" binding:"required"`
-	Date              time.Time `json:"date" binding:"required"`
+	Date              Date      `json:"date" binding:"required"`
 	ActualRevenue     *float64  `json:"actual_revenue,omitempty"`
 	AdoptionRate      *float64  `json:"adoption_rate,omitempty"`
 	ActiveUsers       *int      `json:"active_users,omitempty"`
@@ -36,11 +37,20 @@ type CreateProductMetricRequest struct {
 	ChurnRate         *float64  `json:"churn_rate,omitempty"`
 }
 
+// Validate requires the date. Date is a struct, so binding:"required"
+// cannot tell a missing one from the zero value.
+func (r CreateProductMetricRequest) Validate() error {
+	if r.Date.IsZero() {
+		return errors.New("date is required")
+	}
+	return nil
+}
+
 type UpdateProductMetricRequest struct {
-	Date              *time.Time `json:"date,omitempty"`
-	ActualRevenue     *float64   `json:"actual_revenue,omitempty"`
-	AdoptionRate      *float64   `json:"adoption_rate,omitempty"`
-	ActiveUsers       *int       `json:"active_users,omitempty"`
-	TransactionVolume *int       `json:"transaction_volume,omitempty"`
-	ChurnRate         *float64   `json:"churn_rate,omitempty"`
+	Date              *Date    `json:"date,omitempty"`
+	ActualRevenue     *float64 `json:"actual_revenue,omitempty"`
+	AdoptionRate      *float64 `json:"adoption_rate,omitempty"`
+	ActiveUsers       *int     `json:"active_users,omitempty"`
+	TransactionVolume *int     `json:"transaction_volume,omitempty"`
+	ChurnRate         *float64 `json:"churn_rate,omitempty"`
 }
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ProductOwnershipChange records each handover of a product between owners
+type ProductOwnershipChange struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	ProductID uuid.UUID `gorm:"type:uuid;not null;index" json:"product_id"`
+	FromOwner string    `gorm:"not null" json:"from_owner"`
+	ToOwner   string    `gorm:"not null" json:"to_owner"`
+	ChangedBy *string   `json:"changed_by,omitempty"`
+	Reason    *string   `json:"reason,omitempty"`
+	ChangedAt Timestamp `gorm:"autoCreateTime" json:"changed_at"`
+
+	// Relationships
+	Product Product `gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+func (oc *ProductOwnershipChange) BeforeCreate(tx *gorm.DB) error {
+	if oc.ID == uuid.Nil {
+		oc.ID = uuid.New()
+	}
+	return nil
+}
+
+func (ProductOwnershipChange) TableName() string {
+	return "product_ownership_changes"
+}
+
+type TransferOwnershipRequest struct {
+	OwnerEmail string  `json:"owner_email" binding:"required,email"`
+	Reason     *string `json:"reason,omitempty"`
+}
@@ -1,22 +1,98 @@
 package models
 
 import (
-	"time"
+	"slices"
+	"strings"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+// IntegrationStatus is where a partner is in its integration, in funnel
+// order; failed can be reached from any stage
+type IntegrationStatus string
+
+const (
+	IntegrationStatusNotStarted IntegrationStatus = "not_started"
+	IntegrationStatusInProgress IntegrationStatus = "in_progress"
+	IntegrationStatusTesting    IntegrationStatus = "testing"
+	IntegrationStatusLive       IntegrationStatus = "live"
+	IntegrationStatusFailed     IntegrationStatus = "failed"
+)
+
+// IntegrationStatuses are the allowed IntegrationStatus values, in funnel order
+var IntegrationStatuses = []IntegrationStatus{
+	IntegrationStatusNotStarted, IntegrationStatusInProgress, IntegrationStatusTesting,
+	IntegrationStatusLive, IntegrationStatusFailed,
+}
+
+func (s IntegrationStatus) IsValid() bool {
+	return slices.Contains(IntegrationStatuses, s)
+}
+
+// Check returns an *EnumError listing the allowed values if s is invalid
+func (s IntegrationStatus) Check() error {
+	return checkEnum("integration_status", s, IntegrationStatuses)
+}
+
+// legacyIntegrationStatuses maps free-text statuses written before the enum,
+// lowercased with separators collapsed to single spaces
+var legacyIntegrationStatuses = map[string]IntegrationStatus{
+	"":            IntegrationStatusNotStarted,
+	"not started": IntegrationStatusNotStarted,
+	"planned":     IntegrationStatusNotStarted,
+	"pending":     IntegrationStatusNotStarted,
+	"todo":        IntegrationStatusNotStarted,
+	"in progress": IntegrationStatusInProgress,
+	"started":     IntegrationStatusInProgress,
+	"wip":         IntegrationStatusInProgress,
+	"uat":         IntegrationStatusTesting,
+	"qa":          IntegrationStatusTesting,
+	"active":      IntegrationStatusLive,
+	"enabled":     IntegrationStatusLive,
+	"done":        IntegrationStatusLive,
+	"blocked":     IntegrationStatusFailed,
+}
+
+// IntegrationStatusFromText maps a legacy free-text status onto the enum, best
+// effort: known phrases first, then keywords, defaulting to not_started
+func IntegrationStatusFromText(raw string) IntegrationStatus {
+	text := strings.Join(strings.Fields(strings.NewReplacer("_", " ", "-", " ").Replace(strings.ToLower(raw))), " ")
+	if status := IntegrationStatus(text); status.IsValid() {
+		return status
+	}
+	if status, ok := legacyIntegrationStatuses[text]; ok {
+		return status
+	}
+
+	contains := func(words ...string) bool {
+		return slices.ContainsFunc(words, func(word string) bool { return strings.Contains(text, word) })
+	}
+	switch {
+	case strings.HasPrefix(text, "not "):
+		return IntegrationStatusNotStarted
+	case contains("fail", "block", "error", "reject", "cancel"):
+		return IntegrationStatusFailed
+	case contains("test", "certif", "sandbox", "pilot"):
+		return IntegrationStatusTesting
+	case contains("live", "production", "complete", "launched"):
+		return IntegrationStatusLive
+	case contains("progress", "integrat", "onboard", "develop", "build", "start"):
+		return IntegrationStatusInProgress
+	}
+	return IntegrationStatusNotStarted
+}
+
 type ProductPartner struct {
-	ID                uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	ProductID         uuid.UUID  `json:"product_id" gorm:"type:uuid;not null;index"`
-	PartnerName       string     `json:"partner_name" gorm:"not null"`
-	Enabled           *bool      `json:"enabled,omitempty" gorm:"default:false"`
-	OnboardedDate     *time.Time `json:"onboarded_date,omitempty" gorm:"type:date"`
-	IntegrationStatus *string    `json:"integration_status,omitempty"`
-	RailType          *string    `json:"rail_type,omitempty"`
-	CreatedAt         time.Time  `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt         time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+	ID                uuid.UUID          `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ProductID         uuid.UUID          `json:"product_id" gorm:"type:uuid;not null;index"`
+	PartnerName       string             `json:"partner_name" gorm:"not null"`
+	Enabled           *bool              `json:"enabled,omitempty" gorm:"default:false"`
+	OnboardedDate     *Date              `json:"onboarded_date,omitempty" gorm:"type:date"`
+	IntegrationStatus *IntegrationStatus `json:"integration_status,omitempty" gorm:"type:varchar(20)"`
+	RailType          *string            `json:"rail_type,omitempty"`
+	CreatedAt         Timestamp          `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt         Timestamp          `json:"updated_at" gorm:"autoUpdateTime"`
 }
 
 func (pp *ProductPartner) BeforeCreate(tx *gorm.DB) error {
@@ -27,18 +103,26 @@ func (pp *ProductPartner) BeforeCreate(tx *gorm.DB) error {
 }
 
 type CreateProductPartnerRequest struct {
-	ProductID         uuid.UUID  `json:"product_id" binding:"required"`
-	PartnerName       string     `json:"partner_name" binding:"required"`
-	Enabled           *bool      `json:"enabled,omitempty"`
-	OnboardedDate     *time.Time `json:"onboarded_date,omitempty"`
-	IntegrationStatus *string    `json:"integration_status,omitempty"`
-	RailType          *string    `json:"rail_type,omitempty"`
+	ProductID         uuid.UUID          `json:"product_id" binding:"required"`
+	PartnerName       string             `json:"partner_name" binding:"required"`
+	Enabled           *bool              `json:"enabled,omitempty"`
+	OnboardedDate     *Date              `json:"onboarded_date,omitempty"`
+	IntegrationStatus *IntegrationStatus `json:"integration_status,omitempty"`
+	RailType          *string            `json:"rail_type,omitempty"`
+}
+
+func (r CreateProductPartnerRequest) Validate() error {
+	return checkOptional(r.IntegrationStatus)
 }
 
 type UpdateProductPartnerRequest struct {
-	PartnerName       *string    `json:"partner_name,omitempty"`
-	Enabled           *bool      `json:"enabled,omitempty"`
-	OnboardedDate     *time.Time `json:"onboarded_date,omitempty"`
-	IntegrationStatus *string    `json:"integration_status,omitempty"`
-	RailType          *string    `json:"rail_type,omitempty"`
+	PartnerName       *string            `json:"partner_name,omitempty"`
+	Enabled           *bool              `json:"enabled,omitempty"`
+	OnboardedDate     *Date              `json:"onboarded_date,omitempty"`
+	IntegrationStatus *IntegrationStatus `json:"integration_status,omitempty"`
+	RailType          *string            `json:"rail_type,omitempty"`
+}
+
+func (r UpdateProductPartnerRequest) Validate() error {
+	return checkOptional(r.IntegrationStatus)
 }
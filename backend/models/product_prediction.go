@@ -1,8 +1,9 @@
 package models
 
 import (
+	"bytes"
 	"encoding/json"
-	"time"
+	"fmt"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -16,7 +17,8 @@ type ProductPrediction struct {
 	FailureRisk        *float64        `json:"failure_risk,omitempty" gorm:"type:decimal(5,2)"`
 	ModelVersion       string          `json:"model_version" gorm:"not null"`
 	Features           json.RawMessage `json:"features,omitempty" gorm:"type:jsonb"`
-	ScoredAt           time.Time       `json:"scored_at" gorm:"autoCreateTime"`
+	ScoredAt           Timestamp       `json:"scored_at" gorm:"autoCreateTime"`
+	UpdatedAt          Timestamp       `json:"updated_at" gorm:"autoUpdateTime"`
 }
 
 func (pp *ProductPrediction) BeforeCreate(tx *gorm.DB) error {
@@ -35,6 +37,12 @@ type CreateProductPredictionRequest struct {
 	Features           json.RawMessage `json:"features,omitempty"`
 }
 
+// Normalize puts the probabilities on the stored 0-1 scale and checks the
+// features. An error means the request is well-formed but unprocessable.
+func (r *CreateProductPredictionRequest) Normalize() error {
+	return normalizePrediction(r.SuccessProbability, r.RevenueProbability, r.FailureRisk, r.Features)
+}
+
 type UpdateProductPredictionRequest struct {
 	SuccessProbability *float64        `json:"success_probability,omitempty"`
 	RevenueProbability *float64        `json:"revenue_probability,omitempty"`
@@ -42,3 +50,54 @@ type UpdateProductPredictionRequest struct {
 	ModelVersion       *string         `json:"model_version,omitempty"`
 	Features           json.RawMessage `json:"features,omitempty"`
 }
+
+// Normalize puts the probabilities that are set on the stored 0-1 scale and
+// checks the features, when sent
+func (r *UpdateProductPredictionRequest) Normalize() error {
+	return normalizePrediction(r.SuccessProbability, r.RevenueProbability, r.FailureRisk, r.Features)
+}
+
+// NormalizeProbability puts a probability on the stored 0-1 scale. Model
+// outputs come in either convention, so values up to 1 are taken as a
+// fraction and values above 1, up to 100, as a percentage. Anything outside
+// 0-100 is an error.
+func NormalizeProbability(field string, value float64) (float64, error) {
+	switch {
+	case value < 0 || value > 100:
+		return 0, fmt.Errorf("%s must be between 0 and 1, or 0 and 100 as a percentage; got %g", field, value)
+	case value > 1:
+		return value / 100, nil
+	}
+	return value, nil
+}
+
+// normalizePrediction rewrites the set probabilities in place and checks the
+// features, when sent, are a JSON object
+func normalizePrediction(success, revenue, failure *float64, features json.RawMessage) error {
+	for _, p := range []struct {
+		field string
+		value *float64
+	}{
+		{"success_probability", success},
+		{"revenue_probability", revenue},
+		{"failure_risk", failure},
+	} {
+		if p.value == nil {
+			continue
+		}
+		normalized, err := NormalizeProbability(p.field, *p.value)
+		if err != nil {
+			return err
+		}
+		*p.value = normalized
+	}
+
+	if len(features) == 0 || bytes.Equal(bytes.TrimSpace(features), []byte("null")) {
+		return nil
+	}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(features, &object); err != nil {
+		return fmt.Errorf("features must be a JSON object")
+	}
+	return nil
+}
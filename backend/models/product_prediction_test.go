@@ -0,0 +1,59 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNormalizeProbability(t *testing.T) {
+	tests := []struct {
+		value   float64
+		want    float64
+		wantErr bool
+	}{
+		{0, 0, false},
+		{0.42, 0.42, false},
+		{1, 1, false},
+		{42, 0.42, false},
+		{100, 1, false},
+		{150, 0, true},
+		{-3, 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := NormalizeProbability("success_probability", tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("NormalizeProbability(%g) = %g, %v; want %g, error %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestPredictionRequestNormalize(t *testing.T) {
+	floatPtr := func(v float64) *float64 { return &v }
+
+	req := CreateProductPredictionRequest{
+		SuccessProbability: floatPtr(85),
+		RevenueProbability: floatPtr(0.6),
+		Features:           json.RawMessage(`{"readiness_score": 72}`),
+	}
+	if err := req.Normalize(); err != nil {
+		t.Fatalf("Normalize: %v", err)
+	}
+	if *req.SuccessProbability != 0.85 || *req.RevenueProbability != 0.6 || req.FailureRisk != nil {
+		t.Errorf("normalized = %v/%v/%v, want 0.85/0.6/nil", *req.SuccessProbability, *req.RevenueProbability, req.FailureRisk)
+	}
+
+	for name, update := range map[string]UpdateProductPredictionRequest{
+		"out of range":    {FailureRisk: floatPtr(-3)},
+		"array features":  {Features: json.RawMessage(`[1, 2]`)},
+		"string features": {Features: json.RawMessage(`"high"`)},
+	} {
+		if err := update.Normalize(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	if err := (&UpdateProductPredictionRequest{Features: json.RawMessage(`null`)}).Normalize(); err != nil {
+		t.Errorf("null features: %v", err)
+	}
+}
@@ -1,7 +1,7 @@
 package models
 
 import (
-	"time"
+	"math"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -17,16 +17,89 @@ type ProductReadiness struct {
 	DocumentationScore *float64  `json:"documentation_score,omitempty" gorm:"type:decimal(5,2);default:0"`
 	ReadinessScore     float64   `json:"readiness_score" gorm:"type:decimal(5,2);not null"`
 	RiskBand           RiskBand  `json:"risk_band" gorm:"type:varchar(20);not null"`
-	EvaluatedAt        time.Time `json:"evaluated_at" gorm:"autoCreateTime"`
+	EvaluatedAt        Timestamp `json:"evaluated_at" gorm:"autoCreateTime"`
+	UpdatedAt          Timestamp `json:"updated_at" gorm:"autoUpdateTime"`
+
+	// Version counts writes to the row, starting at 1; see Product.Version
+	Version int `json:"version" gorm:"not null;default:1"`
+}
+
+func (ProductReadiness) TableName() string {
+	return "product_readiness"
 }
 
 func (pr *ProductReadiness) BeforeCreate(tx *gorm.DB) error {
 	if pr.ID == uuid.Nil {
 		pr.ID = uuid.New()
 	}
+	if pr.Version == 0 {
+		pr.Version = 1
+	}
 	return nil
 }
 
+// Readiness score weights; they sum to 100. Onboarding and documentation
+// together make up the 20-point operational share used by full readiness.
+const (
+	ReadinessWeightCompliance    = 30.0
+	ReadinessWeightSalesTraining = 25.0
+	ReadinessWeightPartners      = 25.0
+	ReadinessWeightOnboarding    = 10.0
+	ReadinessWeightDocumentation = 10.0
+)
+
+// Risk band thresholds: a score below HighRiskBelow is high risk, below
+// MediumRiskBelow is medium, anything else is low
+const (
+	HighRiskBelow   = 40.0
+	MediumRiskBelow = 70.0
+)
+
+// ComputeReadinessScore derives a 0-100 score from the components:
+//
+//	compliance complete (0 or 100)  × 30%
+//	sales training pct              × 25%
+//	partner enabled pct             × 25%
+//	onboarding complete (0 or 100)  × 10%
+//	documentation score             × 10%
+//
+// Missing components count as 0 and percentages are clamped to 0-100.
+func (pr ProductReadiness) ComputeReadinessScore() float64 {
+	score := ReadinessWeightCompliance*readinessFlag(pr.ComplianceComplete) +
+		ReadinessWeightSalesTraining*readinessPct(pr.SalesTrainingPct) +
+		ReadinessWeightPartners*readinessPct(pr.PartnerEnabledPct) +
+		ReadinessWeightOnboarding*readinessFlag(pr.OnboardingComplete) +
+		ReadinessWeightDocumentation*readinessPct(pr.DocumentationScore)
+	return math.Round(score*100) / 100
+}
+
+// RiskBandForScore maps a readiness score onto its risk band
+func RiskBandForScore(score float64) RiskBand {
+	switch {
+	case score < HighRiskBelow:
+		return RiskBandHigh
+	case score < MediumRiskBelow:
+		return RiskBandMedium
+	}
+	return RiskBandLow
+}
+
+// readinessFlag is 1 for a completed component and 0 otherwise
+func readinessFlag(b *bool) float64 {
+	if b != nil && *b {
+		return 1
+	}
+	return 0
+}
+
+// readinessPct is a 0-100 component as a 0-1 fraction
+func readinessPct(f *float64) float64 {
+	if f == nil {
+		return 0
+	}
+	return math.Min(math.Max(*f, 0), 100) / 100
+}
+
 type CreateProductReadinessRequest struct {
 	ProductID          uuid.UUID `json:"product_id" binding:"required"`
 	ComplianceComplete *bool     `json:"compliance_complete,omitempty"`
@@ -34,8 +107,19 @@ type CreateProductReadinessRequest struct {
 	PartnerEnabledPct  *float64  `json:"partner_enabled_pct,omitempty"`
 	OnboardingComplete *bool     `json:"onboarding_complete,omitempty"`
 	DocumentationScore *float64  `json:"documentation_score,omitempty"`
-	ReadinessScore     float64   `json:"readiness_score" binding:"required"`
-	RiskBand           RiskBand  `json:"risk_band" binding:"required"`
+
+	// Omit to derive from the components; set to override
+	ReadinessScore *float64  `json:"readiness_score,omitempty"`
+	RiskBand       *RiskBand `json:"risk_band,omitempty"`
+
+	// Version, when set and the product already has readiness, must match
+	// the stored version or the update is rejected with 409
+	Version *int `json:"version,omitempty"`
+}
+
+// Validate checks the risk band when one is set
+func (r CreateProductReadinessRequest) Validate() error {
+	return checkOptional(r.RiskBand)
 }
 
 type UpdateProductReadinessRequest struct {
@@ -46,4 +130,12 @@ type UpdateProductReadinessRequest struct {
 	DocumentationScore *float64  `json:"documentation_score,omitempty"`
 	ReadinessScore     *float64  `json:"readiness_score,omitempty"`
 	RiskBand           *RiskBand `json:"risk_band,omitempty"`
+	// Version, when set, must match the stored version or the update is
+	// rejected with 409
+	Version *int `json:"version,omitempty"`
+}
+
+// Validate checks the risk band when one is set
+func (r UpdateProductReadinessRequest) Validate() error {
+	return checkOptional(r.RiskBand)
 }
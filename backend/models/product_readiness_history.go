@@ -1,9 +1,8 @@
 package models
 
 import (
-	"time"
-
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type ProductReadinessHistory struct {
@@ -11,18 +10,43 @@ type ProductReadinessHistory struct {
 	ProductID      uuid.UUID `gorm:"type:uuid;not null" json:"product_id"`
 	ReadinessScore int       `gorm:"not null" json:"readiness_score"`
 	RiskBand       *string   `gorm:"size:20" json:"risk_band,omitempty"`
-	RecordedAt     time.Time `gorm:"autoCreateTime" json:"recorded_at"`
+	RecordedAt     Timestamp `gorm:"autoCreateTime" json:"recorded_at"`
 	WeekNumber     *int      `json:"week_number,omitempty"`
 	Year           *int      `json:"year,omitempty"`
 
+	// Component values at the time of the snapshot. Rows recorded before
+	// components were captured leave these null.
+	ComplianceComplete *bool    `json:"compliance_complete"`
+	SalesTrainingPct   *float64 `gorm:"type:decimal(5,2)" json:"sales_training_pct"`
+	PartnerEnabledPct  *float64 `gorm:"type:decimal(5,2)" json:"partner_enabled_pct"`
+	OnboardingComplete *bool    `json:"onboarding_complete"`
+	DocumentationScore *float64 `gorm:"type:decimal(5,2)" json:"documentation_score"`
+
 	// Relationships
-	Product Product `gorm:"foreignKey:ProductID" json:"-"`
+	Product Product `gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE" json:"-"`
 }
 
 func (ProductReadinessHistory) TableName() string {
 	return "product_readiness_history"
 }
 
+// BeforeCreate stamps RecordedAt if unset and fills any missing WeekNumber
+// and Year with RecordedAt's ISO week, in UTC, so weekly buckets line up
+// however the row was written
+func (h *ProductReadinessHistory) BeforeCreate(tx *gorm.DB) error {
+	if h.RecordedAt.IsZero() {
+		h.RecordedAt = Now()
+	}
+	year, week := h.RecordedAt.UTC().ISOWeek()
+	if h.WeekNumber == nil {
+		h.WeekNumber = &week
+	}
+	if h.Year == nil {
+		h.Year = &year
+	}
+	return nil
+}
+
 type CreateReadinessHistoryRequest struct {
 	ProductID      uuid.UUID `json:"product_id" binding:"required"`
 	ReadinessScore int       `json:"readiness_score" binding:"required"`
@@ -0,0 +1,62 @@
+package models
+
+import "testing"
+
+func TestComputeReadinessScore(t *testing.T) {
+	yes, no := true, false
+	pct := func(f float64) *float64 { return &f }
+
+	tests := []struct {
+		name      string
+		readiness ProductReadiness
+		want      float64
+	}{
+		{"all nil", ProductReadiness{}, 0},
+		{"all complete", ProductReadiness{
+			ComplianceComplete: &yes,
+			SalesTrainingPct:   pct(100),
+			PartnerEnabledPct:  pct(100),
+			OnboardingComplete: &yes,
+			DocumentationScore: pct(100),
+		}, 100},
+		{"compliance only", ProductReadiness{ComplianceComplete: &yes}, 30},
+		{"onboarding only", ProductReadiness{OnboardingComplete: &yes}, 10},
+		{"flags false", ProductReadiness{ComplianceComplete: &no, OnboardingComplete: &no}, 0},
+		{"mixed", ProductReadiness{
+			ComplianceComplete: &yes,
+			SalesTrainingPct:   pct(50),
+			PartnerEnabledPct:  pct(20),
+			DocumentationScore: pct(80),
+		}, 30 + 12.5 + 5 + 8},
+		{"fractional rounds to cents", ProductReadiness{SalesTrainingPct: pct(33.333)}, 8.33},
+		{"clamped", ProductReadiness{SalesTrainingPct: pct(150), PartnerEnabledPct: pct(-20)}, 25},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.readiness.ComputeReadinessScore(); got != tt.want {
+				t.Errorf("ComputeReadinessScore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRiskBandForScore(t *testing.T) {
+	tests := []struct {
+		score float64
+		want  RiskBand
+	}{
+		{0, RiskBandHigh},
+		{39.99, RiskBandHigh},
+		{40, RiskBandMedium},
+		{69.99, RiskBandMedium},
+		{70, RiskBandLow},
+		{100, RiskBandLow},
+	}
+
+	for _, tt := range tests {
+		if got := RiskBandForScore(tt.score); got != tt.want {
+			t.Errorf("RiskBandForScore(%v) = %q, want %q", tt.score, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Tags are free-form labels grouping products across the fixed enums, e.g.
+// board-watch. They are lowercase words joined by single hyphens.
+const (
+	MaxTagLength   = 40
+	MaxProductTags = 20
+)
+
+var tagPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// NormalizeTag trims and lowercases a tag, rejecting it unless what is left
+// is lowercase letters and digits in hyphen-separated words of at most
+// MaxTagLength characters
+func NormalizeTag(raw string) (string, error) {
+	tag := strings.ToLower(strings.TrimSpace(raw))
+	if tag == "" || len(tag) > MaxTagLength || !tagPattern.MatchString(tag) {
+		return "", fmt.Errorf("invalid tag %q: tags are lowercase letters, digits and single hyphens, at most %d characters", raw, MaxTagLength)
+	}
+	return tag, nil
+}
+
+// NormalizeTags normalizes each tag, dropping repeats and keeping the first
+// occurrence's position
+func NormalizeTags(raw []string) (TagArray, error) {
+	tags := TagArray{}
+	for _, r := range raw {
+		tag, err := NormalizeTag(r)
+		if err != nil {
+			return nil, err
+		}
+		if !tags.Contains(tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags, nil
+}
+
+// TagArray is a Postgres text[] column of tags. Tags never need quoting, so
+// elements are never quoted.
+type TagArray []string
+
+func (a TagArray) GormDataType() string {
+	return "text[]"
+}
+
+// Value encodes the array as a Postgres array literal
+func (a TagArray) Value() (driver.Value, error) {
+	return "{" + strings.Join(a, ",") + "}", nil
+}
+
+// Scan decodes a Postgres array literal such as {a,b}
+func (a *TagArray) Scan(value interface{}) error {
+	var literal string
+	switch v := value.(type) {
+	case nil:
+		*a = TagArray{}
+		return nil
+	case string:
+		literal = v
+	case []byte:
+		literal = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into TagArray", value)
+	}
+
+	literal = strings.TrimSpace(literal)
+	if !strings.HasPrefix(literal, "{") || !strings.HasSuffix(literal, "}") {
+		return fmt.Errorf("invalid tag array %q", literal)
+	}
+	literal = literal[1 : len(literal)-1]
+
+	tags := TagArray{}
+	if literal != "" {
+		for _, raw := range strings.Split(literal, ",") {
+			tags = append(tags, strings.Trim(strings.TrimSpace(raw), `"`))
+		}
+	}
+	*a = tags
+	return nil
+}
+
+// MarshalJSON encodes a nil array as [] so products always list their tags
+func (a TagArray) MarshalJSON() ([]byte, error) {
+	if a == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]string(a))
+}
+
+// Contains reports whether tag is in the array
+func (a TagArray) Contains(tag string) bool {
+	for _, existing := range a {
+		if existing == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// ProductTagsRequest lists tags to add to a product
+type ProductTagsRequest struct {
+	Tags []string `json:"tags" binding:"required,min=1"`
+}
+
+// Validate checks each tag's format
+func (r ProductTagsRequest) Validate() error {
+	_, err := NormalizeTags(r.Tags)
+	return err
+}
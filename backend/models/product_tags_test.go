@@ -0,0 +1,59 @@
+package models
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeTag(t *testing.T) {
+	valid := map[string]string{
+		"board-watch":       "board-watch",
+		" 2024-Initiative ": "2024-initiative",
+		"q3":                "q3",
+	}
+	for raw, want := range valid {
+		if got, err := NormalizeTag(raw); err != nil || got != want {
+			t.Errorf("NormalizeTag(%q) = %q, %v; want %q", raw, got, err, want)
+		}
+	}
+
+	for _, raw := range []string{"", "board watch", "board_watch", "-board", "board-", "board--watch", "café", strings.Repeat("a", MaxTagLength+1)} {
+		if _, err := NormalizeTag(raw); err == nil {
+			t.Errorf("NormalizeTag(%q) accepted", raw)
+		}
+	}
+}
+
+func TestNormalizeTags_Dedupes(t *testing.T) {
+	tags, err := NormalizeTags([]string{"Board-Watch", "q3", "board-watch"})
+	if err != nil || !reflect.DeepEqual(tags, TagArray{"board-watch", "q3"}) {
+		t.Errorf("NormalizeTags = %v, %v", tags, err)
+	}
+}
+
+func TestTagArray(t *testing.T) {
+	tags := TagArray{"board-watch", "q3"}
+	value, err := tags.Value()
+	if err != nil || value != "{board-watch,q3}" {
+		t.Fatalf("Value = %v, %v", value, err)
+	}
+	var scanned TagArray
+	if err := scanned.Scan([]byte(`{"board-watch",q3}`)); err != nil || !reflect.DeepEqual(scanned, tags) {
+		t.Errorf("Scan = %v, %v; want %v", scanned, err, tags)
+	}
+
+	var null TagArray
+	if err := null.Scan(nil); err != nil || null == nil || len(null) != 0 {
+		t.Errorf("Scan(nil) = %#v, %v; want empty", null, err)
+	}
+	if value, _ := TagArray(nil).Value(); value != "{}" {
+		t.Errorf("nil Value = %v, want {}", value)
+	}
+
+	encoded, _ := json.Marshal(struct{ Tags TagArray }{})
+	if string(encoded) != `{"Tags":[]}` {
+		t.Errorf("nil marshals as %s", encoded)
+	}
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProductMissingRequiredFields(t *testing.T) {
+	draft := Product{Name: "Early idea"}
+	want := []string{"product_type", "lifecycle_stage", "owner_email"}
+	if got := draft.MissingRequiredFields(); !reflect.DeepEqual(got, want) {
+		t.Errorf("MissingRequiredFields() = %v, want %v", got, want)
+	}
+
+	complete := Product{
+		Name:           "Ready",
+		ProductType:    ProductTypeDataServices,
+		LifecycleStage: LifecyclePilot,
+		OwnerEmail:     "owner@example.com",
+	}
+	if got := complete.MissingRequiredFields(); len(got) != 0 {
+		t.Errorf("expected no missing fields, got %v", got)
+	}
+}
@@ -1,8 +1,6 @@
 package models
 
 import (
-	"time"
-
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -13,8 +11,8 @@ type Profile struct {
 	FullName  *string   `json:"full_name,omitempty"`
 	Role      UserRole  `json:"role" gorm:"type:varchar(30);not null;default:'viewer'"`
 	Region    *string   `json:"region,omitempty"`
-	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	CreatedAt Timestamp `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt Timestamp `json:"updated_at" gorm:"autoUpdateTime"`
 }
 
 func (p *Profile) BeforeCreate(tx *gorm.DB) error {
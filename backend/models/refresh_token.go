@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RefreshToken is a long-lived credential exchanged for new access tokens.
+// Only a SHA-256 hash of the token is stored. Tokens rotate on every
+// refresh: the old one is revoked and points at its replacement.
+type RefreshToken struct {
+	ID         uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID     string     `gorm:"size:255;not null;index" json:"user_id"`
+	Email      string     `gorm:"size:255" json:"email"`
+	Role       string     `gorm:"size:30" json:"role"`
+	TokenHash  string     `gorm:"size:64;not null;uniqueIndex" json:"-"`
+	ExpiresAt  Timestamp  `gorm:"not null" json:"expires_at"`
+	RevokedAt  *Timestamp `json:"revoked_at,omitempty"`
+	ReplacedBy *uuid.UUID `gorm:"type:uuid" json:"replaced_by,omitempty"`
+	CreatedAt  Timestamp  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
+
+// Active reports whether the token can still be exchanged at now
+func (t RefreshToken) Active(now time.Time) bool {
+	return t.RevokedAt == nil && now.Before(t.ExpiresAt.Time)
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// TokenResponse is returned whenever a new access token is issued
+type TokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	TokenType        string `json:"token_type"`
+	ExpiresIn        int    `json:"expires_in"`
+	RefreshToken     string `json:"refresh_token"`
+	RefreshExpiresAt string `json:"refresh_expires_at"`
+}
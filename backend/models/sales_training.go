@@ -1,20 +1,18 @@
 package models
 
 import (
-	"time"
-
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 type SalesTraining struct {
-	ID               uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	ProductID        uuid.UUID  `json:"product_id" gorm:"type:uuid;not null;uniqueIndex"`
-	TotalReps        int        `json:"total_reps" gorm:"not null;default:0"`
-	TrainedReps      int        `json:"trained_reps" gorm:"not null;default:0"`
-	CoveragePct      *float64   `json:"coverage_pct,omitempty" gorm:"type:decimal(5,2)"`
-	LastTrainingDate *time.Time `json:"last_training_date,omitempty" gorm:"type:date"`
-	UpdatedAt        time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+	ID               uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ProductID        uuid.UUID `json:"product_id" gorm:"type:uuid;not null;uniqueIndex"`
+	TotalReps        int       `json:"total_reps" gorm:"not null;default:0"`
+	TrainedReps      int       `json:"trained_reps" gorm:"not null;default:0"`
+	CoveragePct      *float64  `json:"coverage_pct,omitempty" gorm:"type:decimal(5,2)"`
+	LastTrainingDate *Date     `json:"last_training_date,omitempty" gorm:"type:date"`
+	UpdatedAt        Timestamp `json:"updated_at" gorm:"autoUpdateTime"`
 }
 
 func (st *SalesTraining) BeforeCreate(tx *gorm.DB) error {
@@ -41,14 +39,14 @@ func (st *SalesTraining) calculateCoverage() {
 }
 
 type CreateSalesTrainingRequest struct {
-	ProductID        uuid.UUID  `json:"product_id" binding:"required"`
-	TotalReps        int        `json:"total_reps"`
-	TrainedReps      int        `json:"trained_reps"`
-	LastTrainingDate *time.Time `json:"last_training_date,omitempty"`
+	ProductID        uuid.UUID `json:"product_id" binding:"required"`
+	TotalReps        int       `json:"total_reps"`
+	TrainedReps      int       `json:"trained_reps"`
+	LastTrainingDate *Date     `json:"last_training_date,omitempty"`
 }
 
 type UpdateSalesTrainingRequest struct {
-	TotalReps        *int       `json:"total_reps,omitempty"`
-	TrainedReps      *int       `json:"trained_reps,omitempty"`
-	LastTrainingDate *time.Time `json:"last_training_date,omitempty"`
+	TotalReps        *int  `json:"total_reps,omitempty"`
+	TrainedReps      *int  `json:"trained_reps,omitempty"`
+	LastTrainingDate *Date `json:"last_training_date,omitempty"`
 }
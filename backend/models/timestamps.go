@@ -0,0 +1,177 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Serialization formats used for every time value in API responses.
+//
+//   - Timestamp columns are rendered in UTC as RFC3339, e.g. "2025-03-01T14:05:00Z"
+//   - Date columns are rendered date-only, e.g. "2025-03-01"
+//
+// Date inputs accept either form; a full timestamp keeps the calendar date in
+// the offset it was sent with so "2025-03-01T00:00:00-05:00" stays March 1st.
+const (
+	TimestampFormat = time.RFC3339
+	DateFormat      = "2006-01-02"
+)
+
+// Timestamp is a time.Time that is stored and serialized in UTC
+type Timestamp struct {
+	time.Time
+}
+
+// NewTimestamp wraps t, normalized to UTC
+func NewTimestamp(t time.Time) Timestamp {
+	return Timestamp{t.UTC()}
+}
+
+// Now returns the current time as a Timestamp
+func Now() Timestamp {
+	return NewTimestamp(time.Now())
+}
+
+func (t Timestamp) String() string {
+	return t.UTC().Format(TimestampFormat)
+}
+
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + t.String() + `"`), nil
+}
+
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "null" || s == "" {
+		*t = Timestamp{}
+		return nil
+	}
+	parsed, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp %q: expected RFC3339", s)
+	}
+	*t = NewTimestamp(parsed)
+	return nil
+}
+
+func (t Timestamp) Value() (driver.Value, error) {
+	return t.UTC(), nil
+}
+
+func (t *Timestamp) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*t = Timestamp{}
+	case time.Time:
+		*t = NewTimestamp(v)
+	case string:
+		// Drivers return text for computed columns such as MAX(created_at)
+		parsed, err := parseTimestamp(v)
+		if err != nil {
+			return err
+		}
+		*t = parsed
+	default:
+		return fmt.Errorf("cannot scan %T into Timestamp", value)
+	}
+	return nil
+}
+
+// timestampLayouts are the text forms a timestamp column may come back in
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+}
+
+func parseTimestamp(s string) (Timestamp, error) {
+	for _, layout := range timestampLayouts {
+		if parsed, err := time.Parse(layout, s); err == nil {
+			return NewTimestamp(parsed), nil
+		}
+	}
+	return Timestamp{}, fmt.Errorf("cannot scan %q into Timestamp", s)
+}
+
+// Date is a calendar date with no time-of-day or timezone component
+type Date struct {
+	time.Time
+}
+
+// NewDate truncates t to its calendar date in t's own location
+func NewDate(t time.Time) Date {
+	y, m, d := t.Date()
+	return Date{time.Date(y, m, d, 0, 0, 0, 0, time.UTC)}
+}
+
+// Today returns the current UTC calendar date
+func Today() Date {
+	return NewDate(time.Now().UTC())
+}
+
+func (d Date) String() string {
+	return d.Format(DateFormat)
+}
+
+func (d Date) GormDataType() string {
+	return "date"
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + d.String() + `"`), nil
+}
+
+func (d *Date) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "null" || s == "" {
+		*d = Date{}
+		return nil
+	}
+	parsed, err := ParseDate(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// ParseDate accepts "2006-01-02" or an RFC3339 timestamp
+func ParseDate(s string) (Date, error) {
+	if parsed, err := time.Parse(DateFormat, s); err == nil {
+		return NewDate(parsed), nil
+	}
+	if parsed, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return NewDate(parsed), nil
+	}
+	return Date{}, fmt.Errorf("invalid date %q: expected YYYY-MM-DD", s)
+}
+
+func (d Date) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+func (d *Date) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*d = Date{}
+	case time.Time:
+		*d = NewDate(v)
+	case string:
+		parsed, err := ParseDate(v)
+		if err != nil {
+			return err
+		}
+		*d = parsed
+	case []byte:
+		parsed, err := ParseDate(string(v))
+		if err != nil {
+			return err
+		}
+		*d = parsed
+	default:
+		return fmt.Errorf("cannot scan %T into Date", value)
+	}
+	return nil
+}
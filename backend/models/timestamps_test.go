@@ -0,0 +1,67 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTimestamp_MarshalsUTC(t *testing.T) {
+	est := time.FixedZone("EST", -5*60*60)
+	ts := NewTimestamp(time.Date(2025, 3, 1, 20, 30, 0, 0, est))
+
+	data, err := json.Marshal(ts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `"2025-03-02T01:30:00Z"` {
+		t.Errorf("expected UTC RFC3339, got %s", data)
+	}
+}
+
+func TestDate_RoundTrip(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`"2025-03-01"`, `"2025-03-01"`},
+		{`"2025-03-01T00:00:00-05:00"`, `"2025-03-01"`},
+		{`"2025-03-01T23:30:00+09:00"`, `"2025-03-01"`},
+	}
+
+	for _, tt := range tests {
+		var d Date
+		if err := json.Unmarshal([]byte(tt.input), &d); err != nil {
+			t.Fatalf("unmarshal %s: %v", tt.input, err)
+		}
+		data, _ := json.Marshal(d)
+		if string(data) != tt.expected {
+			t.Errorf("%s: expected %s, got %s", tt.input, tt.expected, data)
+		}
+	}
+}
+
+func TestDate_RejectsGarbage(t *testing.T) {
+	var d Date
+	if err := json.Unmarshal([]byte(`"next tuesday"`), &d); err == nil {
+		t.Error("expected error for unparseable date")
+	}
+}
+
+func TestTimestamp_ScansText(t *testing.T) {
+	want := time.Date(2025, 3, 1, 14, 5, 0, 0, time.UTC)
+	for _, text := range []string{"2025-03-01T14:05:00Z", "2025-03-01 09:05:00-05:00", "2025-03-01 14:05:00"} {
+		var ts Timestamp
+		if err := ts.Scan(text); err != nil {
+			t.Fatalf("scan %q: %v", text, err)
+		}
+		if !ts.Equal(want) {
+			t.Errorf("scan %q = %v, want %v", text, ts, want)
+		}
+	}
+
+	var ts Timestamp
+	if err := ts.Scan("yesterday"); err == nil {
+		t.Error("expected error for unparseable timestamp")
+	}
+}
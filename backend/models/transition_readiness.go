@@ -1,8 +1,6 @@
 package models
 
 import (
-	"time"
-
 	"github.com/google/uuid"
 )
 
@@ -21,15 +19,15 @@ type TransitionItem struct {
 	Name        string             `gorm:"not null" json:"name"`
 	Description *string            `json:"description,omitempty"`
 	Complete    bool               `gorm:"default:false" json:"complete"`
-	CompletedAt *time.Time         `json:"completed_at,omitempty"`
+	CompletedAt *Timestamp         `json:"completed_at,omitempty"`
 	CompletedBy *string            `json:"completed_by,omitempty"`
 	Owner       *string            `json:"owner,omitempty"`
-	DueDate     *time.Time         `json:"due_date,omitempty"`
-	CreatedAt   time.Time          `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt   time.Time          `gorm:"autoUpdateTime" json:"updated_at"`
+	DueDate     *Timestamp         `json:"due_date,omitempty"`
+	CreatedAt   Timestamp          `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   Timestamp          `gorm:"autoUpdateTime" json:"updated_at"`
 
 	// Relationships
-	Product Product `gorm:"foreignKey:ProductID" json:"-"`
+	Product Product `gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE" json:"-"`
 }
 
 func (TransitionItem) TableName() string {
@@ -42,7 +40,7 @@ type CreateTransitionItemRequest struct {
 	Name        string             `json:"name" binding:"required"`
 	Description *string            `json:"description,omitempty"`
 	Owner       *string            `json:"owner,omitempty"`
-	DueDate     *time.Time         `json:"due_date,omitempty"`
+	DueDate     *Timestamp         `json:"due_date,omitempty"`
 }
 
 type UpdateTransitionItemRequest struct {
@@ -51,7 +49,7 @@ type UpdateTransitionItemRequest struct {
 	Complete    *bool      `json:"complete,omitempty"`
 	CompletedBy *string    `json:"completed_by,omitempty"`
 	Owner       *string    `json:"owner,omitempty"`
-	DueDate     *time.Time `json:"due_date,omitempty"`
+	DueDate     *Timestamp `json:"due_date,omitempty"`
 }
 
 // TransitionReadinessResponse for API
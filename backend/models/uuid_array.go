@@ -0,0 +1,73 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// UUIDArray is a Postgres uuid[] column
+type UUIDArray []uuid.UUID
+
+func (a UUIDArray) GormDataType() string {
+	return "uuid[]"
+}
+
+// Value encodes the array as a Postgres array literal
+func (a UUIDArray) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	ids := make([]string, len(a))
+	for i, id := range a {
+		ids[i] = id.String()
+	}
+	return "{" + strings.Join(ids, ",") + "}", nil
+}
+
+// Scan decodes a Postgres array literal such as {a,b}
+func (a *UUIDArray) Scan(value interface{}) error {
+	var literal string
+	switch v := value.(type) {
+	case nil:
+		*a = nil
+		return nil
+	case string:
+		literal = v
+	case []byte:
+		literal = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into UUIDArray", value)
+	}
+
+	literal = strings.TrimSpace(literal)
+	if !strings.HasPrefix(literal, "{") || !strings.HasSuffix(literal, "}") {
+		return fmt.Errorf("invalid uuid array %q", literal)
+	}
+	literal = literal[1 : len(literal)-1]
+
+	ids := UUIDArray{}
+	if literal != "" {
+		for _, raw := range strings.Split(literal, ",") {
+			id, err := uuid.Parse(strings.Trim(strings.TrimSpace(raw), `"`))
+			if err != nil {
+				return fmt.Errorf("invalid uuid array element %q: %w", raw, err)
+			}
+			ids = append(ids, id)
+		}
+	}
+	*a = ids
+	return nil
+}
+
+// Contains reports whether id is in the array
+func (a UUIDArray) Contains(id uuid.UUID) bool {
+	for _, existing := range a {
+		if existing == id {
+			return true
+		}
+	}
+	return false
+}
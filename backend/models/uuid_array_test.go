@@ -0,0 +1,48 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestUUIDArray_RoundTrip(t *testing.T) {
+	ids := UUIDArray{uuid.New(), uuid.New()}
+
+	value, err := ids.Value()
+	if err != nil {
+		t.Fatalf("Value: %v", err)
+	}
+	var scanned UUIDArray
+	if err := scanned.Scan(value); err != nil {
+		t.Fatalf("Scan(%v): %v", value, err)
+	}
+	if !reflect.DeepEqual(scanned, ids) {
+		t.Errorf("round trip = %v, want %v", scanned, ids)
+	}
+}
+
+func TestUUIDArray_Scan(t *testing.T) {
+	id := uuid.New()
+
+	var empty UUIDArray
+	if err := empty.Scan([]byte("{}")); err != nil || empty == nil || len(empty) != 0 {
+		t.Errorf("Scan({}) = %v, %v; want empty array", empty, err)
+	}
+
+	var null UUIDArray
+	if err := null.Scan(nil); err != nil || null != nil {
+		t.Errorf("Scan(nil) = %v, %v; want nil", null, err)
+	}
+
+	var quoted UUIDArray
+	if err := quoted.Scan(`{"` + id.String() + `"}`); err != nil || len(quoted) != 1 || quoted[0] != id {
+		t.Errorf("Scan(quoted) = %v, %v", quoted, err)
+	}
+
+	var bad UUIDArray
+	if err := bad.Scan("{not-a-uuid}"); err == nil {
+		t.Error("expected an error for an invalid element")
+	}
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// FeedbackWebhookPayload is what an external survey or support tool posts to
+// /webhooks/feedback
+type FeedbackWebhookPayload struct {
+	ProductID uuid.UUID `json:"product_id" binding:"required"`
+	Source    string    `json:"source" binding:"required"`
+	Text      string    `json:"text" binding:"required"`
+	Topic     *string   `json:"topic,omitempty"`
+	Sentiment *float64  `json:"sentiment,omitempty"`
+	Impact    *string   `json:"impact,omitempty"`
+	Responses *int      `json:"responses,omitempty" binding:"omitempty,min=1"`
+}
+
+// ToCreateRequest maps the payload onto a feedback create request
+func (p FeedbackWebhookPayload) ToCreateRequest() CreateProductFeedbackRequest {
+	return CreateProductFeedbackRequest{
+		ProductID:      p.ProductID,
+		Source:         p.Source,
+		RawText:        p.Text,
+		Theme:          p.Topic,
+		SentimentScore: p.Sentiment,
+		ImpactLevel:    p.Impact,
+		Volume:         p.Responses,
+	}
+}
+
+// MetricsWebhookPayload is what an external analytics tool posts to
+// /webhooks/metrics for one product and day
+type MetricsWebhookPayload struct {
+	ProductID    uuid.UUID `json:"product_id" binding:"required"`
+	Period       Date      `json:"period" binding:"required"`
+	Revenue      *float64  `json:"revenue,omitempty"`
+	AdoptionRate *float64  `json:"adoption_rate,omitempty"`
+	ActiveUsers  *int      `json:"active_users,omitempty"`
+	Transactions *int      `json:"transactions,omitempty"`
+	ChurnRate    *float64  `json:"churn_rate,omitempty"`
+}
+
+// Validate requires the period, which binding:"required" cannot check on a
+// Date
+func (p MetricsWebhookPayload) Validate() error {
+	if p.Period.IsZero() {
+		return errors.New("period is required")
+	}
+	return nil
+}
+
+// ToCreateRequest maps the payload onto a metric create request
+func (p MetricsWebhookPayload) ToCreateRequest() CreateProductMetricRequest {
+	return CreateProductMetricRequest{
+		ProductID:         p.ProductID,
+		Date:              p.Period,
+		ActualRevenue:     p.Revenue,
+		AdoptionRate:      p.AdoptionRate,
+		ActiveUsers:       p.ActiveUsers,
+		TransactionVolume: p.Transactions,
+		ChurnRate:         p.ChurnRate,
+	}
+}
@@ -0,0 +1,194 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// WebhookEventType names an outbound notification subscribers can receive
+type WebhookEventType string
+
+// Escalation events fire when a product is raised to the named level
+const (
+	WebhookEventEscalationAmbassadorReview WebhookEventType = "escalation.ambassador_review"
+	WebhookEventEscalationExecSteerCo      WebhookEventType = "escalation.exec_steerco"
+	WebhookEventEscalationCritical         WebhookEventType = "escalation.critical"
+)
+
+// WebhookEventTypes are the allowed WebhookEventType values
+var WebhookEventTypes = []WebhookEventType{
+	WebhookEventEscalationAmbassadorReview,
+	WebhookEventEscalationExecSteerCo,
+	WebhookEventEscalationCritical,
+}
+
+// EscalationWebhookEvent is the event sent when a product reaches level
+func EscalationWebhookEvent(level EscalationLevel) WebhookEventType {
+	return WebhookEventType("escalation." + string(level))
+}
+
+func (e WebhookEventType) IsValid() bool {
+	return slices.Contains(WebhookEventTypes, e)
+}
+
+// Check returns an *EnumError listing the allowed values if e is invalid
+func (e WebhookEventType) Check() error {
+	return checkEnum("event_type", e, WebhookEventTypes)
+}
+
+// WebhookEventTypeArray is a Postgres text[] column of event types. Event
+// types are plain tokens, so elements are never quoted.
+type WebhookEventTypeArray []WebhookEventType
+
+func (a WebhookEventTypeArray) GormDataType() string {
+	return "text[]"
+}
+
+// Value encodes the array as a Postgres array literal
+func (a WebhookEventTypeArray) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	events := make([]string, len(a))
+	for i, event := range a {
+		events[i] = string(event)
+	}
+	return "{" + strings.Join(events, ",") + "}", nil
+}
+
+// Scan decodes a Postgres array literal such as {a,b}
+func (a *WebhookEventTypeArray) Scan(value interface{}) error {
+	var literal string
+	switch v := value.(type) {
+	case nil:
+		*a = nil
+		return nil
+	case string:
+		literal = v
+	case []byte:
+		literal = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into WebhookEventTypeArray", value)
+	}
+
+	literal = strings.TrimSpace(literal)
+	if !strings.HasPrefix(literal, "{") || !strings.HasSuffix(literal, "}") {
+		return fmt.Errorf("invalid event type array %q", literal)
+	}
+	literal = literal[1 : len(literal)-1]
+
+	events := WebhookEventTypeArray{}
+	if literal != "" {
+		for _, raw := range strings.Split(literal, ",") {
+			events = append(events, WebhookEventType(strings.Trim(strings.TrimSpace(raw), `"`)))
+		}
+	}
+	*a = events
+	return nil
+}
+
+// Contains reports whether event is in the array
+func (a WebhookEventTypeArray) Contains(event WebhookEventType) bool {
+	for _, existing := range a {
+		if existing == event {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookSubscription is an outbound endpoint notified of the events it lists.
+// Payloads are signed with Secret, which is never returned by the API.
+type WebhookSubscription struct {
+	ID          uuid.UUID             `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	URL         string                `gorm:"not null" json:"url"`
+	EventTypes  WebhookEventTypeArray `gorm:"not null" json:"event_types"`
+	Secret      string                `gorm:"not null" json:"-"`
+	Description *string               `json:"description,omitempty"`
+	Active      bool                  `gorm:"not null" json:"active"`
+	CreatedBy   *string               `gorm:"size:255" json:"created_by,omitempty"`
+	CreatedAt   Timestamp             `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   Timestamp             `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (WebhookSubscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// WebhookDelivery records one attempt to deliver an event to a subscription.
+// Retries of the same event share its EventID.
+type WebhookDelivery struct {
+	ID             uuid.UUID        `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	SubscriptionID uuid.UUID        `gorm:"type:uuid;not null;index" json:"subscription_id"`
+	EventID        uuid.UUID        `gorm:"type:uuid;not null;index" json:"event_id"`
+	EventType      WebhookEventType `gorm:"type:varchar(50);not null" json:"event_type"`
+	Attempt        int              `gorm:"not null" json:"attempt"`
+	StatusCode     *int             `json:"status_code,omitempty"`
+	Error          *string          `json:"error,omitempty"`
+	Succeeded      bool             `gorm:"not null" json:"succeeded"`
+	DurationMs     int64            `json:"duration_ms"`
+	AttemptedAt    Timestamp        `gorm:"not null" json:"attempted_at"`
+
+	// Relationships
+	Subscription WebhookSubscription `gorm:"foreignKey:SubscriptionID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+type CreateWebhookSubscriptionRequest struct {
+	URL         string                `json:"url" binding:"required,url"`
+	EventTypes  WebhookEventTypeArray `json:"event_types" binding:"required,min=1"`
+	Secret      string                `json:"secret" binding:"required,min=16"`
+	Description *string               `json:"description,omitempty"`
+	Active      *bool                 `json:"active,omitempty"`
+}
+
+// Validate checks the URL scheme and event types
+func (r CreateWebhookSubscriptionRequest) Validate() error {
+	if err := checkWebhookURL(r.URL); err != nil {
+		return err
+	}
+	return checkEventTypes(r.EventTypes)
+}
+
+type UpdateWebhookSubscriptionRequest struct {
+	URL         *string               `json:"url,omitempty" binding:"omitempty,url"`
+	EventTypes  WebhookEventTypeArray `json:"event_types,omitempty" binding:"omitempty,min=1"`
+	Secret      *string               `json:"secret,omitempty" binding:"omitempty,min=16"`
+	Description *string               `json:"description,omitempty"`
+	Active      *bool                 `json:"active,omitempty"`
+}
+
+// Validate checks the URL scheme and event types when they are being changed
+func (r UpdateWebhookSubscriptionRequest) Validate() error {
+	if r.URL != nil {
+		if err := checkWebhookURL(*r.URL); err != nil {
+			return err
+		}
+	}
+	return checkEventTypes(r.EventTypes)
+}
+
+func checkWebhookURL(raw string) error {
+	parsed, err := url.Parse(raw)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return fmt.Errorf("url must be an http or https URL")
+	}
+	return nil
+}
+
+func checkEventTypes(events WebhookEventTypeArray) error {
+	for _, event := range events {
+		if err := event.Check(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
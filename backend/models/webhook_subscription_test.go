@@ -0,0 +1,73 @@
+package models
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestWebhookEventTypeArray_RoundTrip(t *testing.T) {
+	events := WebhookEventTypeArray{WebhookEventEscalationExecSteerCo, WebhookEventEscalationCritical}
+
+	value, err := events.Value()
+	if err != nil {
+		t.Fatalf("Value: %v", err)
+	}
+	if value != "{escalation.exec_steerco,escalation.critical}" {
+		t.Errorf("Value = %v", value)
+	}
+
+	var scanned WebhookEventTypeArray
+	if err := scanned.Scan([]byte(value.(string))); err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if !reflect.DeepEqual(scanned, events) {
+		t.Errorf("Scan = %v, want %v", scanned, events)
+	}
+	if !scanned.Contains(WebhookEventEscalationCritical) || scanned.Contains(WebhookEventEscalationAmbassadorReview) {
+		t.Errorf("Contains mismatch for %v", scanned)
+	}
+
+	if err := scanned.Scan("not an array"); err == nil {
+		t.Error("expected an error for a malformed literal")
+	}
+}
+
+func TestEscalationWebhookEvent_CoversRaisedLevels(t *testing.T) {
+	for _, level := range []EscalationLevel{EscalationLevelAmbassadorReview, EscalationLevelExecSteerCo, EscalationLevelCritical} {
+		if event := EscalationWebhookEvent(level); !event.IsValid() {
+			t.Errorf("level %s maps to unknown event %s", level, event)
+		}
+	}
+	if EscalationLevelCritical.Rank() <= EscalationLevelExecSteerCo.Rank() ||
+		EscalationLevelExecSteerCo.Rank() <= EscalationLevelAmbassadorReview.Rank() ||
+		EscalationLevelAmbassadorReview.Rank() <= EscalationLevelNone.Rank() {
+		t.Error("escalation levels should rank none < ambassador_review < exec_steerco < critical")
+	}
+}
+
+func TestWebhookSubscriptionRequestValidate(t *testing.T) {
+	ftp := "ftp://hooks.example.com/in"
+	tests := []struct {
+		name    string
+		err     error
+		wantErr bool
+	}{
+		{"valid", CreateWebhookSubscriptionRequest{URL: "https://hooks.example.com/in", EventTypes: WebhookEventTypeArray{WebhookEventEscalationCritical}}.Validate(), false},
+		{"bad scheme", CreateWebhookSubscriptionRequest{URL: "ftp://hooks.example.com/in", EventTypes: WebhookEventTypeArray{WebhookEventEscalationCritical}}.Validate(), true},
+		{"unknown event", CreateWebhookSubscriptionRequest{URL: "https://hooks.example.com/in", EventTypes: WebhookEventTypeArray{"escalation.none"}}.Validate(), true},
+		{"empty update", UpdateWebhookSubscriptionRequest{}.Validate(), false},
+		{"update bad scheme", UpdateWebhookSubscriptionRequest{URL: &ftp}.Validate(), true},
+	}
+	for _, tt := range tests {
+		if (tt.err != nil) != tt.wantErr {
+			t.Errorf("%s: err = %v, wantErr %v", tt.name, tt.err, tt.wantErr)
+		}
+	}
+
+	var enumErr *EnumError
+	err := CreateWebhookSubscriptionRequest{URL: "https://x.io", EventTypes: WebhookEventTypeArray{"escalation.none"}}.Validate()
+	if !errors.As(err, &enumErr) || enumErr.Field != "event_type" {
+		t.Errorf("unknown event should be an EnumError, got %v", err)
+	}
+}
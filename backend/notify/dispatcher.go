@@ -0,0 +1,227 @@
+// Package notify delivers signed event notifications to outbound webhook
+// subscribers.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pauly7610/studio-pilot-vision/backend/middleware"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+	"gorm.io/gorm"
+)
+
+// Headers sent with every delivery. The signature is
+// "sha256=<hex HMAC-SHA256 of the raw body>" under the subscription's secret,
+// the same scheme inbound webhooks use.
+const (
+	SignatureHeader = "X-Signature"
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+)
+
+// Delivery defaults: an event is attempted up to DefaultMaxAttempts times,
+// waiting DefaultRetryBackoff before the first retry and doubling after that
+const (
+	DefaultMaxAttempts     = 5
+	DefaultRetryBackoff    = 2 * time.Second
+	DefaultDeliveryTimeout = 10 * time.Second
+)
+
+// Event is the JSON body POSTed to subscribers. Retries resend the same ID.
+type Event struct {
+	ID         uuid.UUID               `json:"id"`
+	Type       models.WebhookEventType `json:"type"`
+	OccurredAt models.Timestamp        `json:"occurred_at"`
+	Data       interface{}             `json:"data"`
+}
+
+// Dispatcher sends events to the active subscriptions that list them. Each
+// delivery runs on its own goroutine and every attempt is recorded as a
+// WebhookDelivery. A nil Dispatcher drops events.
+type Dispatcher struct {
+	db          *gorm.DB
+	client      *http.Client
+	maxAttempts int
+	backoff     time.Duration
+	wg          sync.WaitGroup
+
+	// stopping is cancelled when Wait gives up, abandoning in-flight
+	// requests and retries
+	stopping context.Context
+	stop     context.CancelFunc
+
+	mu      sync.Mutex
+	pending map[pendingDelivery]struct{}
+}
+
+// pendingDelivery identifies a delivery that has not yet succeeded or given up
+type pendingDelivery struct {
+	SubscriptionID uuid.UUID
+	EventID        uuid.UUID
+	EventType      models.WebhookEventType
+}
+
+// NewDispatcher creates a dispatcher with the default retry policy
+func NewDispatcher(db *gorm.DB) *Dispatcher {
+	stopping, stop := context.WithCancel(context.Background())
+	return &Dispatcher{
+		db:          db,
+		client:      &http.Client{Timeout: DefaultDeliveryTimeout},
+		maxAttempts: DefaultMaxAttempts,
+		backoff:     DefaultRetryBackoff,
+		stopping:    stopping,
+		stop:        stop,
+		pending:     make(map[pendingDelivery]struct{}),
+	}
+}
+
+// Publish queues data as an event of the given type for every subscriber. It
+// returns once the subscribers are found; delivery happens in the background.
+func (d *Dispatcher) Publish(eventType models.WebhookEventType, data interface{}) error {
+	if d == nil {
+		return nil
+	}
+
+	var subscriptions []models.WebhookSubscription
+	if err := d.db.Where("active = ?", true).Find(&subscriptions).Error; err != nil {
+		return err
+	}
+
+	event := Event{ID: uuid.New(), Type: eventType, OccurredAt: models.Now(), Data: data}
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	for _, subscription := range subscriptions {
+		if !subscription.EventTypes.Contains(eventType) {
+			continue
+		}
+		key := pendingDelivery{SubscriptionID: subscription.ID, EventID: event.ID, EventType: eventType}
+		d.mu.Lock()
+		d.pending[key] = struct{}{}
+		d.mu.Unlock()
+
+		d.wg.Add(1)
+		go func(subscription models.WebhookSubscription) {
+			defer d.wg.Done()
+			defer func() {
+				d.mu.Lock()
+				delete(d.pending, key)
+				d.mu.Unlock()
+			}()
+			d.deliver(subscription, event, body)
+		}(subscription)
+	}
+	return nil
+}
+
+// Wait blocks until every queued delivery has succeeded or given up, or ctx
+// is done. In that case the deliveries still pending are logged and
+// abandoned: their in-flight attempt is cancelled and recorded as failed, and
+// no retries follow. Wait returns once they have stopped.
+func (d *Dispatcher) Wait(ctx context.Context) error {
+	if d == nil {
+		return nil
+	}
+
+	finished := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+		return nil
+	case <-ctx.Done():
+	}
+
+	d.mu.Lock()
+	for delivery := range d.pending {
+		log.Printf("Webhook %s: abandoning %s event %s at shutdown", delivery.SubscriptionID, delivery.EventType, delivery.EventID)
+	}
+	d.mu.Unlock()
+	d.stop()
+	<-finished
+	return ctx.Err()
+}
+
+// deliver attempts the event until the subscriber answers 2xx or the attempts
+// run out, backing off exponentially between tries
+func (d *Dispatcher) deliver(subscription models.WebhookSubscription, event Event, body []byte) {
+	delay := d.backoff
+	for attempt := 1; attempt <= d.maxAttempts; attempt++ {
+		delivery := d.attempt(subscription, event, body, attempt)
+		if err := d.db.Create(&delivery).Error; err != nil {
+			log.Printf("Failed to record webhook delivery %s attempt %d: %v", event.ID, attempt, err)
+		}
+		if delivery.Succeeded {
+			return
+		}
+		if d.stopping.Err() != nil {
+			return
+		}
+		if attempt < d.maxAttempts {
+			select {
+			case <-time.After(delay):
+			case <-d.stopping.Done():
+				return
+			}
+			delay *= 2
+		}
+	}
+	log.Printf("Webhook %s: giving up on event %s after %d attempts", subscription.ID, event.ID, d.maxAttempts)
+}
+
+// attempt POSTs the signed body once and describes the outcome
+func (d *Dispatcher) attempt(subscription models.WebhookSubscription, event Event, body []byte, attempt int) models.WebhookDelivery {
+	start := time.Now()
+	delivery := models.WebhookDelivery{
+		ID:             uuid.New(),
+		SubscriptionID: subscription.ID,
+		EventID:        event.ID,
+		EventType:      event.Type,
+		Attempt:        attempt,
+		AttemptedAt:    models.NewTimestamp(start),
+	}
+	fail := func(message string) models.WebhookDelivery {
+		delivery.Error = &message
+		delivery.DurationMs = time.Since(start).Milliseconds()
+		return delivery
+	}
+
+	req, err := http.NewRequestWithContext(d.stopping, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return fail(err.Error())
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, middleware.SignWebhook(subscription.Secret, body))
+	req.Header.Set(EventHeader, string(event.Type))
+	req.Header.Set(DeliveryHeader, event.ID.String())
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return fail(err.Error())
+	}
+	// Drain a bounded amount so the connection can be reused
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	resp.Body.Close()
+
+	code := resp.StatusCode
+	delivery.StatusCode = &code
+	delivery.Succeeded = code >= 200 && code < 300
+	if !delivery.Succeeded {
+		return fail("unexpected status " + resp.Status)
+	}
+	delivery.DurationMs = time.Since(start).Milliseconds()
+	return delivery
+}
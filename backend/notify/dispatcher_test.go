@@ -0,0 +1,240 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/google/uuid"
+	"github.com/pauly7610/studio-pilot-vision/backend/middleware"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const testSecret = "0123456789abcdef0123"
+
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("sql db: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+
+	for _, stmt := range []string{
+		`CREATE TABLE webhook_subscriptions (
+			id TEXT PRIMARY KEY, url TEXT NOT NULL, event_types TEXT NOT NULL,
+			secret TEXT NOT NULL, description TEXT, active BOOLEAN NOT NULL,
+			created_by TEXT, created_at DATETIME, updated_at DATETIME)`,
+		`CREATE TABLE webhook_deliveries (
+			id TEXT PRIMARY KEY, subscription_id TEXT NOT NULL, event_id TEXT NOT NULL,
+			event_type TEXT NOT NULL, attempt INTEGER NOT NULL, status_code INTEGER,
+			error TEXT, succeeded BOOLEAN NOT NULL, duration_ms INTEGER,
+			attempted_at DATETIME NOT NULL)`,
+	} {
+		if err := db.Exec(stmt).Error; err != nil {
+			t.Fatalf("create table: %v", err)
+		}
+	}
+	return db
+}
+
+func addSubscription(t *testing.T, db *gorm.DB, url string, active bool, events ...models.WebhookEventType) models.WebhookSubscription {
+	t.Helper()
+	subscription := models.WebhookSubscription{
+		ID:         uuid.New(),
+		URL:        url,
+		EventTypes: events,
+		Secret:     testSecret,
+		Active:     active,
+	}
+	if err := db.Create(&subscription).Error; err != nil {
+		t.Fatalf("create subscription: %v", err)
+	}
+	return subscription
+}
+
+func testDispatcher(db *gorm.DB) *Dispatcher {
+	d := NewDispatcher(db)
+	d.maxAttempts = 3
+	d.backoff = time.Millisecond
+	return d
+}
+
+func TestPublish_SignsAndRetriesUntilSuccess(t *testing.T) {
+	db := openTestDB(t)
+
+	var calls atomic.Int32
+	var mu sync.Mutex
+	var body []byte
+	var signature, eventHeader string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		body, _ = io.ReadAll(r.Body)
+		signature = r.Header.Get(SignatureHeader)
+		eventHeader = r.Header.Get(EventHeader)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	subscription := addSubscription(t, db, server.URL, true, models.WebhookEventEscalationCritical)
+
+	d := testDispatcher(db)
+	if err := d.Publish(models.WebhookEventEscalationCritical, map[string]string{"product_id": "p1"}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	d.Wait(context.Background())
+
+	mu.Lock()
+	defer mu.Unlock()
+	if calls.Load() != 3 {
+		t.Fatalf("calls = %d, want 3", calls.Load())
+	}
+	if signature != middleware.SignWebhook(testSecret, body) {
+		t.Errorf("signature %q does not match the body", signature)
+	}
+	if eventHeader != string(models.WebhookEventEscalationCritical) {
+		t.Errorf("event header = %q", eventHeader)
+	}
+
+	var event Event
+	if err := json.Unmarshal(body, &event); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if event.Type != models.WebhookEventEscalationCritical || event.Data.(map[string]interface{})["product_id"] != "p1" {
+		t.Errorf("unexpected event %+v", event)
+	}
+
+	var deliveries []models.WebhookDelivery
+	db.Order("attempt ASC").Find(&deliveries)
+	if len(deliveries) != 3 {
+		t.Fatalf("recorded %d deliveries, want 3", len(deliveries))
+	}
+	for i, delivery := range deliveries {
+		if delivery.SubscriptionID != subscription.ID || delivery.EventID != event.ID || delivery.Attempt != i+1 {
+			t.Errorf("delivery %d: unexpected %+v", i, delivery)
+		}
+	}
+	if deliveries[0].Succeeded || deliveries[0].StatusCode == nil || *deliveries[0].StatusCode != http.StatusBadGateway || deliveries[0].Error == nil {
+		t.Errorf("first attempt should record the 502: %+v", deliveries[0])
+	}
+	if !deliveries[2].Succeeded || *deliveries[2].StatusCode != http.StatusNoContent || deliveries[2].Error != nil {
+		t.Errorf("last attempt should succeed: %+v", deliveries[2])
+	}
+}
+
+func TestPublish_GivesUpAfterMaxAttempts(t *testing.T) {
+	db := openTestDB(t)
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	addSubscription(t, db, server.URL, true, models.WebhookEventEscalationExecSteerCo)
+
+	d := testDispatcher(db)
+	d.Publish(models.WebhookEventEscalationExecSteerCo, nil)
+	d.Wait(context.Background())
+
+	var failed int64
+	db.Model(&models.WebhookDelivery{}).Where("succeeded = ?", false).Count(&failed)
+	if calls.Load() != 3 || failed != 3 {
+		t.Errorf("calls = %d, failed deliveries = %d; want 3 and 3", calls.Load(), failed)
+	}
+}
+
+func TestPublish_OnlyActiveSubscribersOfTheEvent(t *testing.T) {
+	db := openTestDB(t)
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer server.Close()
+
+	addSubscription(t, db, server.URL, true, models.WebhookEventEscalationCritical, models.WebhookEventEscalationExecSteerCo)
+	addSubscription(t, db, server.URL, true, models.WebhookEventEscalationAmbassadorReview)
+	addSubscription(t, db, server.URL, false, models.WebhookEventEscalationCritical)
+
+	d := testDispatcher(db)
+	d.Publish(models.WebhookEventEscalationCritical, nil)
+	d.Wait(context.Background())
+
+	if calls.Load() != 1 {
+		t.Errorf("calls = %d, want only the active critical subscriber", calls.Load())
+	}
+}
+
+func TestWait_AbandonsPendingDeliveriesWhenContextExpires(t *testing.T) {
+	db := openTestDB(t)
+
+	var buf strings.Builder
+	previous := log.Writer()
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(previous) })
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	subscription := addSubscription(t, db, server.URL, true, models.WebhookEventEscalationCritical)
+
+	// Retries back off far longer than the wait allows
+	d := testDispatcher(db)
+	d.backoff = time.Hour
+	d.Publish(models.WebhookEventEscalationCritical, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := d.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Wait took %s after its context expired", elapsed)
+	}
+
+	if calls.Load() != 1 {
+		t.Errorf("calls = %d, want no retries after abandoning", calls.Load())
+	}
+	if !strings.Contains(buf.String(), "abandoning") || !strings.Contains(buf.String(), subscription.ID.String()) {
+		t.Errorf("pending delivery not logged: %q", buf.String())
+	}
+}
+
+func TestPublish_NilDispatcherDropsEvents(t *testing.T) {
+	var d *Dispatcher
+	if err := d.Publish(models.WebhookEventEscalationCritical, nil); err != nil {
+		t.Errorf("nil dispatcher: %v", err)
+	}
+	d.Wait(context.Background())
+}
@@ -0,0 +1,51 @@
+package openapi
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const swaggerUIVersion = "5.17.14"
+
+// docsScript starts Swagger UI on the spec served at /openapi.json
+const docsScript = `window.ui = SwaggerUIBundle({url: "/openapi.json", dom_id: "#swagger-ui"});`
+
+var docsPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Studio Pilot Vision API</title>
+<link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@` + swaggerUIVersion + `/swagger-ui.css">
+</head>
+<body>
+<div id="swagger-ui"></div>
+<script src="https://unpkg.com/swagger-ui-dist@` + swaggerUIVersion + `/swagger-ui-bundle.js"></script>
+<script>` + docsScript + `</script>
+</body>
+</html>`
+
+// docsPolicy relaxes the API's default Content-Security-Policy just enough
+// for Swagger UI: its assets from unpkg and the one inline start-up script
+var docsPolicy = func() string {
+	sum := sha256.Sum256([]byte(docsScript))
+	return "default-src 'self'; script-src https://unpkg.com 'sha256-" + base64.StdEncoding.EncodeToString(sum[:]) + "'; " +
+		"style-src https://unpkg.com; img-src 'self' data:; connect-src 'self'; frame-ancestors 'none'"
+}()
+
+// SpecHandler serves the document as JSON
+func SpecHandler(doc *Document) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, doc)
+	}
+}
+
+// DocsHandler serves Swagger UI for the document at /openapi.json
+func DocsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Content-Security-Policy", docsPolicy)
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(docsPage))
+	}
+}
@@ -0,0 +1,256 @@
+// Package openapi describes the API as an OpenAPI 3 document. Paths are
+// listed by hand; request and response schemas are derived from the models
+// so their fields and binding rules cannot drift from what the handlers bind.
+package openapi
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/pauly7610/studio-pilot-vision/backend/handlers"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+)
+
+// Document is an OpenAPI 3 document
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Servers    []Server            `json:"servers"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type Server struct {
+	URL string `json:"url"`
+}
+
+// PathItem maps lower-case HTTP methods to their operations
+type PathItem map[string]*Operation
+
+type Operation struct {
+	Summary     string                `json:"summary"`
+	Tags        []string              `json:"tags"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]Response   `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes"`
+}
+
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme"`
+	BearerFormat string `json:"bearerFormat"`
+}
+
+// access is who may call an operation, mirroring the route groups
+type access int
+
+const (
+	public access = iota
+	authenticated
+	admin
+)
+
+// route is one hand-listed operation
+type route struct {
+	method  string
+	path    string // gin syntax, e.g. /products/:id
+	tag     string
+	summary string
+	access  access
+	body    interface{} // request body, nil for none
+	status  int         // success status
+	result  interface{} // success body, nil for none
+}
+
+func productRoutes() []route {
+	return []route{
+		{http.MethodGet, "/products", "Products", "List products, paginated", public, nil, http.StatusOK, page[models.Product]{}},
+		{http.MethodPost, "/products", "Products", "Create a product", admin, models.CreateProductRequest{}, http.StatusCreated, models.Product{}},
+		{http.MethodGet, "/products/:id", "Products", "Get a product with its associations", public, nil, http.StatusOK, models.Product{}},
+		{http.MethodPut, "/products/:id", "Products", "Update a product", admin, models.UpdateProductRequest{}, http.StatusOK, models.Product{}},
+		{http.MethodPatch, "/products/:id", "Products", "Update a product", admin, models.UpdateProductRequest{}, http.StatusOK, models.Product{}},
+		{http.MethodDelete, "/products/:id", "Products", "Soft-delete a product; requires X-Confirmation-Token", admin, nil, http.StatusOK, handlers.SuccessResponse{}},
+		{http.MethodPost, "/products/:id/clone", "Products", "Clone a product as a new concept", admin, models.CloneProductRequest{}, http.StatusCreated, handlers.ProductCloneResponse{}},
+		{http.MethodPost, "/products/:id/transfer-ownership", "Products", "Hand a product to another owner", admin, models.TransferOwnershipRequest{}, http.StatusOK, models.Product{}},
+		{http.MethodPost, "/products/:id/tags", "Products", "Tag a product", admin, models.ProductTagsRequest{}, http.StatusOK, models.Product{}},
+		{http.MethodDelete, "/products/:id/tags/:tag", "Products", "Remove a tag from a product", admin, nil, http.StatusOK, models.Product{}},
+	}
+}
+
+func feedbackRoutes() []route {
+	return []route{
+		{http.MethodGet, "/feedback", "Feedback", "List feedback, paginated", public, nil, http.StatusOK, page[models.ProductFeedback]{}},
+		{http.MethodPost, "/feedback", "Feedback", "Create feedback", authenticated, models.CreateProductFeedbackRequest{}, http.StatusCreated, models.ProductFeedback{}},
+		{http.MethodGet, "/feedback/:id", "Feedback", "Get feedback", public, nil, http.StatusOK, models.ProductFeedback{}},
+		{http.MethodPut, "/feedback/:id", "Feedback", "Update feedback", admin, models.UpdateProductFeedbackRequest{}, http.StatusOK, models.ProductFeedback{}},
+		{http.MethodPatch, "/feedback/:id", "Feedback", "Update feedback", admin, models.UpdateProductFeedbackRequest{}, http.StatusOK, models.ProductFeedback{}},
+		{http.MethodDelete, "/feedback/:id", "Feedback", "Delete feedback", admin, nil, http.StatusOK, handlers.SuccessResponse{}},
+		{http.MethodGet, "/products/:id/feedback", "Feedback", "List a product's feedback", public, nil, http.StatusOK, []models.ProductFeedback{}},
+	}
+}
+
+func actionRoutes() []route {
+	return []route{
+		{http.MethodGet, "/actions", "Actions", "List actions, paginated", public, nil, http.StatusOK, page[models.ProductAction]{}},
+		{http.MethodPost, "/actions", "Actions", "Create an action", authenticated, models.CreateProductActionRequest{}, http.StatusCreated, models.ProductAction{}},
+		{http.MethodPatch, "/actions/batch", "Actions", "Change the status of several actions", authenticated, []models.ActionStatusChange{}, http.StatusOK, []handlers.ActionStatusResult{}},
+		{http.MethodPost, "/actions/notify-overdue", "Actions", "Email assignees a digest of overdue and due-soon actions", admin, nil, http.StatusOK, handlers.ActionReminderRun{}},
+		{http.MethodGet, "/actions/:id", "Actions", "Get an action", public, nil, http.StatusOK, models.ProductAction{}},
+		{http.MethodPut, "/actions/:id", "Actions", "Update an action", authenticated, models.UpdateProductActionRequest{}, http.StatusOK, models.ProductAction{}},
+		{http.MethodPatch, "/actions/:id", "Actions", "Update an action", authenticated, models.UpdateProductActionRequest{}, http.StatusOK, models.ProductAction{}},
+		{http.MethodDelete, "/actions/:id", "Actions", "Delete an action", admin, nil, http.StatusOK, handlers.SuccessResponse{}},
+		{http.MethodGet, "/products/:id/actions", "Actions", "List a product's actions", public, nil, http.StatusOK, []models.ProductAction{}},
+		{http.MethodPost, "/actions/:id/comments", "Actions", "Add a progress note", authenticated, models.CreateActionCommentRequest{}, http.StatusCreated, models.ActionComment{}},
+	}
+}
+
+func readinessRoutes() []route {
+	return []route{
+		{http.MethodGet, "/readiness", "Readiness", "List readiness records", public, nil, http.StatusOK, []models.ProductReadiness{}},
+		{http.MethodGet, "/products/:id/readiness", "Readiness", "Get a product's readiness", public, nil, http.StatusOK, models.ProductReadiness{}},
+		{http.MethodPost, "/products/:id/readiness", "Readiness", "Create or update a product's readiness", admin, models.CreateProductReadinessRequest{}, http.StatusOK, models.ProductReadiness{}},
+		{http.MethodPut, "/readiness/:id", "Readiness", "Update readiness", admin, models.UpdateProductReadinessRequest{}, http.StatusOK, models.ProductReadiness{}},
+		{http.MethodPatch, "/readiness/:id", "Readiness", "Update readiness", admin, models.UpdateProductReadinessRequest{}, http.StatusOK, models.ProductReadiness{}},
+		{http.MethodDelete, "/readiness/:id", "Readiness", "Delete readiness", admin, nil, http.StatusOK, handlers.SuccessResponse{}},
+	}
+}
+
+// page is the envelope of paginated lists, typed for the spec
+type page[T any] struct {
+	Data       []T   `json:"data"`
+	Total      int64 `json:"total"`
+	Page       int   `json:"page"`
+	PageSize   int   `json:"page_size"`
+	TotalPages int   `json:"total_pages"`
+}
+
+const basePath = "/api/v1"
+
+func allRoutes() []route {
+	var routes []route
+	for _, group := range [][]route{productRoutes(), feedbackRoutes(), actionRoutes(), readinessRoutes()} {
+		routes = append(routes, group...)
+	}
+	return routes
+}
+
+// Build returns the OpenAPI document for the documented routes
+func Build() *Document {
+	b := newSchemaBuilder()
+	errorResponse := func(description string) Response {
+		return Response{Description: description, Content: jsonContent(b.ref(handlers.ErrorResponse{}))}
+	}
+
+	doc := &Document{
+		OpenAPI: "3.0.3",
+		Info:    Info{Title: "Studio Pilot Vision API", Version: "1.0"},
+		Servers: []Server{{URL: basePath}},
+		Paths:   map[string]PathItem{},
+	}
+
+	for _, r := range allRoutes() {
+		op := &Operation{
+			Summary:   r.summary,
+			Tags:      []string{r.tag},
+			Responses: map[string]Response{},
+		}
+
+		path, params := openAPIPath(r.path)
+		for _, name := range params {
+			schema := &Schema{Type: "string"}
+			if name == "id" || strings.HasSuffix(name, "Id") {
+				schema.Format = "uuid"
+			}
+			op.Parameters = append(op.Parameters, Parameter{Name: name, In: "path", Required: true, Schema: schema})
+		}
+
+		success := Response{Description: http.StatusText(r.status)}
+		if r.result != nil {
+			success.Content = jsonContent(b.ref(r.result))
+		}
+		op.Responses[statusKey(r.status)] = success
+
+		if r.body != nil {
+			op.RequestBody = &RequestBody{Required: true, Content: jsonContent(b.ref(r.body))}
+			op.Responses[statusKey(http.StatusBadRequest)] = errorResponse("Invalid request body")
+		}
+		if len(params) > 0 {
+			op.Responses[statusKey(http.StatusNotFound)] = errorResponse("Not found")
+		}
+		if r.access != public {
+			op.Security = []map[string][]string{{"bearerAuth": {}}}
+			op.Responses[statusKey(http.StatusUnauthorized)] = errorResponse("Missing or invalid token")
+		}
+		if r.access == admin {
+			op.Responses[statusKey(http.StatusForbidden)] = errorResponse("Admin role required")
+		}
+		op.Responses[statusKey(http.StatusInternalServerError)] = errorResponse("Server error")
+
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = PathItem{}
+		}
+		doc.Paths[path][strings.ToLower(r.method)] = op
+	}
+
+	doc.Components = Components{
+		Schemas: b.components,
+		SecuritySchemes: map[string]SecurityScheme{
+			"bearerAuth": {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+		},
+	}
+	return doc
+}
+
+// openAPIPath converts a gin path to OpenAPI syntax, returning its parameters
+func openAPIPath(path string) (string, []string) {
+	var params []string
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if name, ok := strings.CutPrefix(segment, ":"); ok {
+			params = append(params, name)
+			segments[i] = "{" + name + "}"
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+func statusKey(status int) string {
+	return strconv.Itoa(status)
+}
+
+func jsonContent(schema *Schema) map[string]MediaType {
+	return map[string]MediaType{"application/json": {Schema: schema}}
+}
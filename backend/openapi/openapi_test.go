@@ -0,0 +1,101 @@
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestBuild_SchemasFollowBindingTags(t *testing.T) {
+	schemas := Build().Components.Schemas
+
+	required := map[string][]string{
+		"CreateProductRequest":          {"name"},
+		"CloneProductRequest":           {"name", "region"},
+		"TransferOwnershipRequest":      {"owner_email"},
+		"CreateProductFeedbackRequest":  {"product_id", "source", "raw_text"},
+		"CreateProductActionRequest":    {"product_id", "action_type", "title"},
+		"CreateProductReadinessRequest": {"product_id"},
+		"UpdateProductRequest":          nil,
+	}
+	for name, want := range required {
+		schema, ok := schemas[name]
+		if !ok {
+			t.Errorf("%s: missing from components", name)
+			continue
+		}
+		got := slices.Clone(schema.Required)
+		slices.Sort(got)
+		want = slices.Clone(want)
+		slices.Sort(want)
+		if !slices.Equal(got, want) {
+			t.Errorf("%s: required = %v, want %v", name, got, want)
+		}
+	}
+
+	if f := schemas["CreateProductRequest"].Properties["owner_email"].Format; f != "email" {
+		t.Errorf("product owner_email format = %q, want email", f)
+	}
+	if f := schemas["TransferOwnershipRequest"].Properties["owner_email"].Format; f != "email" {
+		t.Errorf("transfer owner_email format = %q, want email", f)
+	}
+	if m := schemas["CreateProductFeedbackRequest"].Properties["volume"].Minimum; m == nil || *m != 1 {
+		t.Errorf("feedback volume minimum = %v, want 1", m)
+	}
+	if enum := schemas["UpdateProductActionRequest"].Properties["status"].Enum; !slices.Contains(enum, "in_progress") {
+		t.Errorf("action status enum = %v", enum)
+	}
+	if _, ok := schemas["Product"].Properties["readiness"]; !ok {
+		t.Error("Product schema lacks its readiness association")
+	}
+}
+
+func TestBuild_CoversCoreResources(t *testing.T) {
+	doc := Build()
+	for path, methods := range map[string][]string{
+		"/products":                       {"get", "post"},
+		"/products/{id}":                  {"get", "put", "patch", "delete"},
+		"/feedback":                       {"get", "post"},
+		"/feedback/{id}":                  {"get", "put", "patch", "delete"},
+		"/actions":                        {"get", "post"},
+		"/actions/{id}":                   {"get", "put", "patch", "delete"},
+		"/products/{id}/readiness": {"get", "post"},
+		"/readiness/{id}":                 {"put", "patch", "delete"},
+	} {
+		for _, method := range methods {
+			op := doc.Paths[path][method]
+			if op == nil {
+				t.Errorf("%s %s: not documented", method, path)
+				continue
+			}
+			if _, ok := op.Responses["500"]; !ok {
+				t.Errorf("%s %s: no error response", method, path)
+			}
+		}
+	}
+
+	put := doc.Paths["/products/{id}"]["put"]
+	if len(put.Parameters) != 1 || put.Parameters[0].Name != "id" || put.Security == nil {
+		t.Errorf("PUT /products/{id}: parameters %v, security %v", put.Parameters, put.Security)
+	}
+}
+
+func TestDocsHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/docs", DocsHandler())
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs", nil))
+
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "/openapi.json") {
+		t.Fatalf("status %d, body %q", w.Code, w.Body.String())
+	}
+	if csp := w.Header().Get("Content-Security-Policy"); !strings.Contains(csp, "'sha256-") {
+		t.Errorf("CSP %q does not allow the start-up script", csp)
+	}
+}
@@ -0,0 +1,174 @@
+package openapi
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+	"gorm.io/gorm"
+)
+
+// Schema is the subset of the OpenAPI schema object the spec uses
+type Schema struct {
+	Ref        string             `json:"$ref,omitempty"`
+	Type       string             `json:"type,omitempty"`
+	Format     string             `json:"format,omitempty"`
+	Enum       []string           `json:"enum,omitempty"`
+	Minimum    *float64           `json:"minimum,omitempty"`
+	MinLength  *int               `json:"minLength,omitempty"`
+	Items      *Schema            `json:"items,omitempty"`
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	Required   []string           `json:"required,omitempty"`
+	Nullable   bool               `json:"nullable,omitempty"`
+
+	// AdditionalProperties describes the values of a map
+	AdditionalProperties *Schema `json:"additionalProperties,omitempty"`
+}
+
+// scalarSchemas are types that marshal to a JSON scalar rather than by field
+var scalarSchemas = map[reflect.Type]Schema{
+	reflect.TypeOf(uuid.UUID{}):        {Type: "string", Format: "uuid"},
+	reflect.TypeOf(time.Time{}):        {Type: "string", Format: "date-time"},
+	reflect.TypeOf(models.Timestamp{}): {Type: "string", Format: "date-time"},
+	reflect.TypeOf(models.Date{}):      {Type: "string", Format: "date"},
+	reflect.TypeOf(gorm.DeletedAt{}):   {Type: "string", Format: "date-time", Nullable: true},
+}
+
+// enumChecker is a models enum; Check on its zero value lists the allowed values
+type enumChecker interface {
+	Check() error
+}
+
+// schemaBuilder turns Go types into schemas, collecting named structs as
+// components so the association graph is described once per type
+type schemaBuilder struct {
+	components map[string]*Schema
+}
+
+func newSchemaBuilder() *schemaBuilder {
+	return &schemaBuilder{components: map[string]*Schema{}}
+}
+
+// ref returns a reference to the component for v's type, building it first
+func (b *schemaBuilder) ref(v interface{}) *Schema {
+	return b.schemaFor(reflect.TypeOf(v))
+}
+
+func (b *schemaBuilder) schemaFor(t reflect.Type) *Schema {
+	if t.Kind() == reflect.Pointer {
+		s := b.schemaFor(t.Elem())
+		if s.Ref == "" {
+			s.Nullable = true
+		}
+		return s
+	}
+	if scalar, ok := scalarSchemas[t]; ok {
+		return &scalar
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		s := &Schema{Type: "string"}
+		if checker, ok := reflect.Zero(t).Interface().(enumChecker); ok {
+			var enumErr *models.EnumError
+			if errors.As(checker.Check(), &enumErr) {
+				s.Enum = enumErr.Allowed
+			}
+		}
+		return s
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: b.schemaFor(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: b.schemaFor(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return b.structSchema(t)
+		}
+		name := componentName(t)
+		if _, ok := b.components[name]; !ok {
+			// Reserve the name first so self-references terminate
+			b.components[name] = &Schema{}
+			*b.components[name] = *b.structSchema(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + name}
+	}
+	return &Schema{}
+}
+
+// componentName names a struct's component. Instances of a generic type are
+// named after their type argument, e.g. page[models.Product] as ProductPage.
+func componentName(t reflect.Type) string {
+	name := t.Name()
+	base, arg, generic := strings.Cut(name, "[")
+	if !generic {
+		return name
+	}
+	arg = strings.TrimSuffix(arg, "]")
+	if i := strings.LastIndex(arg, "."); i >= 0 {
+		arg = arg[i+1:]
+	}
+	return arg + strings.ToUpper(base[:1]) + base[1:]
+}
+
+// structSchema describes a struct by its JSON fields. Binding tags carry
+// over: required fields are listed as such, email sets the format, and min
+// sets the minimum or minimum length.
+func (b *schemaBuilder) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			embedded := b.structSchema(field.Type)
+			for prop, schema := range embedded.Properties {
+				s.Properties[prop] = schema
+			}
+			s.Required = append(s.Required, embedded.Required...)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		prop := b.schemaFor(field.Type)
+		for _, rule := range strings.Split(field.Tag.Get("binding"), ",") {
+			key, value, _ := strings.Cut(rule, "=")
+			switch key {
+			case "required":
+				s.Required = append(s.Required, name)
+			case "email":
+				prop.Format = "email"
+			case "min":
+				n, err := strconv.ParseFloat(value, 64)
+				if err != nil {
+					continue
+				}
+				if prop.Type == "string" {
+					length := int(n)
+					prop.MinLength = &length
+				} else {
+					prop.Minimum = &n
+				}
+			}
+		}
+		s.Properties[name] = prop
+	}
+	return s
+}
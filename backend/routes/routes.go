@@ -3,17 +3,41 @@ package routes
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/pauly7610/studio-pilot-vision/backend/config"
+	"github.com/pauly7610/studio-pilot-vision/backend/database"
 	"github.com/pauly7610/studio-pilot-vision/backend/handlers"
 	"github.com/pauly7610/studio-pilot-vision/backend/middleware"
+	"github.com/pauly7610/studio-pilot-vision/backend/models"
+	"github.com/pauly7610/studio-pilot-vision/backend/notify"
+	"github.com/pauly7610/studio-pilot-vision/backend/openapi"
+	"github.com/pauly7610/studio-pilot-vision/backend/services/email"
+	"github.com/pauly7610/studio-pilot-vision/backend/services/sentiment"
+	"github.com/pauly7610/studio-pilot-vision/backend/startup"
 )
 
-func SetupRouter(cfg *config.Config) *gin.Engine {
+// SetupRouter wires the handlers. Webhook events go to notifier, which the
+// caller waits on at shutdown.
+func SetupRouter(cfg *config.Config, notifier *notify.Dispatcher) *gin.Engine {
 	router := gin.Default()
 
-	// Middleware
-	router.Use(middleware.CORS(cfg.CORSOrigins))
+	// Request counts and latencies, exposed on /metrics
+	requestMetrics := middleware.NewRequestMetrics()
+	router.Use(requestMetrics.Middleware())
 
-	// Rate limiting - 60 requests per minute per IP
+	// Middleware
+	router.Use(middleware.CORS(middleware.CORSOptions{
+		Origins: cfg.CORSOrigins,
+		Headers: cfg.CORSHeaders,
+		Methods: cfg.CORSMethods,
+		MaxAge:  cfg.CORSMaxAge,
+		Strict:  cfg.CORSStrict,
+	}))
+
+	// Identify the caller, if a valid token is sent, so rate limiting can key
+	// on the user; route groups still enforce auth themselves
+	router.Use(middleware.OptionalAuth(cfg.JWTSecret))
+
+	// Rate limiting - 60 requests per minute per user, or per IP when
+	// anonymous; admin roles get a higher ceiling
 	rateLimiter := middleware.DefaultRateLimiter()
 	router.Use(rateLimiter.RateLimit())
 
@@ -27,104 +51,211 @@ func SetupRouter(cfg *config.Config) *gin.Engine {
 	router.Use(middleware.AuditMiddleware())
 
 	// Initialize handlers
-	productHandler := handlers.NewProductHandler()
+	productHandler := handlers.NewProductHandler(cfg.JWTSecret, cfg.StaleProductDays)
 	metricsHandler := handlers.NewMetricsHandler()
 	readinessHandler := handlers.NewReadinessHandler()
 	complianceHandler := handlers.NewComplianceHandler()
 	partnersHandler := handlers.NewPartnersHandler()
-	feedbackHandler := handlers.NewFeedbackHandler()
+	sentimentAnalyzer := sentiment.NewLexicon()
+	themeAliases := models.NewThemeAliases(cfg.FeedbackThemeAliases)
+	feedbackHandler := handlers.NewFeedbackHandler(sentimentAnalyzer, themeAliases)
 	predictionsHandler := handlers.NewPredictionsHandler()
-	actionsHandler := handlers.NewActionsHandler()
+	mailer := email.New(email.Config{
+		Host:     cfg.SMTPHost,
+		Port:     cfg.SMTPPort,
+		Username: cfg.SMTPUsername,
+		Password: cfg.SMTPPassword,
+		From:     cfg.SMTPFrom,
+	})
+	actionsHandler := handlers.NewActionsHandler(mailer)
 	trainingHandler := handlers.NewTrainingHandler()
 	marketEvidenceHandler := handlers.NewMarketEvidenceHandler()
 	profilesHandler := handlers.NewProfilesHandler()
-	dependenciesHandler := handlers.NewDependenciesHandler()
-	escalationsHandler := handlers.NewEscalationsHandler()
-	transitionHandler := handlers.NewTransitionHandler()
+	dependenciesHandler := handlers.NewDependenciesHandler(cfg.Escalation)
+	escalationsHandler := handlers.NewEscalationsHandler(cfg.Escalation, cfg.AutoEscalationActions, notifier)
+	transitionHandler := handlers.NewTransitionHandler(cfg.Escalation.BAUReadyPercent)
 	dataFreshnessHandler := handlers.NewDataFreshnessHandler()
+	portfolioHandler := handlers.NewPortfolioHandler(cfg.Escalation)
+	productHealthHandler := handlers.NewProductHealthHandler(cfg.Escalation)
+	dashboardHandler := handlers.NewDashboardHandler(cfg.Escalation)
+	activityHandler := handlers.NewActivityHandler()
+	auditHandler := handlers.NewAuditHandler()
+	webhooksHandler := handlers.NewWebhooksHandler(sentimentAnalyzer, themeAliases)
+	webhookSubscriptionsHandler := handlers.NewWebhookSubscriptionsHandler()
+	seedHandler := handlers.NewSeedHandler(cfg.IsProduction())
+	authHandler := handlers.NewAuthHandler(cfg.JWTSecret, cfg.AccessTokenTTL, cfg.RefreshTokenTTL)
+
+	// Opt-in per route: limits non-admin callers to their profile's region
+	regionScope := middleware.RegionScope(database.DB)
+	// Follows regionScope on product child routes, refusing other regions'
+	// products
+	productRegion := handlers.RequireProductRegion
 
 	// Health check
 	router.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{"status": "ok", "service": "studio-pilot-vision-api"})
+		c.JSON(200, gin.H{
+			"status":                 "ok",
+			"service":                "studio-pilot-vision-api",
+			"rate_limiter_keys":      rateLimiter.TrackedKeys(),
+			"rate_limiter_evictions": rateLimiter.Evictions(),
+		})
+	})
+
+	// Prometheus scrape endpoint
+	router.GET("/metrics", requestMetrics.Handler())
+
+	// OpenAPI document and Swagger UI
+	router.GET("/openapi.json", openapi.SpecHandler(openapi.Build()))
+	router.GET("/docs", openapi.DocsHandler())
+
+	// Startup self-check report; 503 until the checks have run and passed
+	router.GET("/health/startup", func(c *gin.Context) {
+		report := startup.Latest()
+		if report == nil {
+			c.JSON(503, gin.H{"passed": false, "error": "startup checks have not run"})
+			return
+		}
+		status := 200
+		if !report.Passed {
+			status = 503
+		}
+		c.JSON(status, report)
+	})
+
+	// Readiness for load balancers; 503 while the database is unreachable or
+	// unmigrated, and once shutdown starts so traffic is routed elsewhere
+	// while in-flight requests drain. /health stays the cheap liveness probe.
+	router.GET("/health/ready", func(c *gin.Context) {
+		ready := startup.Ready()
+		status := 200
+		if ready.Status != startup.StatusReady {
+			status = 503
+		}
+		c.JSON(status, ready)
 	})
 
 	// API v1 routes
 	v1 := router.Group("/api/v1")
 	{
+		// Inbound webhooks (HMAC-signed by the sending tool, no JWT)
+		webhooks := v1.Group("/webhooks")
+		webhooks.Use(middleware.WebhookSignature(cfg.WebhookSecret))
+		{
+			webhooks.POST("/feedback", webhooksHandler.ReceiveFeedback)
+			webhooks.POST("/metrics", webhooksHandler.ReceiveMetrics)
+		}
+
+		// Token refresh and logout authenticate with the refresh token itself
+		auth := v1.Group("/auth")
+		{
+			auth.POST("/refresh", authHandler.Refresh)
+			auth.POST("/logout", authHandler.Logout)
+		}
+
 		// Public routes (with optional auth)
 		public := v1.Group("")
 		public.Use(middleware.OptionalAuth(cfg.JWTSecret))
 		{
 			// Products
-			public.GET("/products", productHandler.GetProducts)
-			public.GET("/products/:id", productHandler.GetProduct)
-			public.GET("/products/region/:region", productHandler.GetProductsByRegion)
-			public.GET("/products/lifecycle/:stage", productHandler.GetProductsByLifecycle)
-			public.GET("/products/risk/:riskBand", productHandler.GetProductsByRiskBand)
+			public.GET("/products", regionScope, productHandler.GetProducts)
+			public.GET("/products/stale", productHandler.GetStaleProducts)
+			public.GET("/products/at-risk", portfolioHandler.GetProductsAtRisk)
+			public.GET("/products/:id", regionScope, productHandler.GetProduct)
+			public.GET("/products/:id/ownership/history", regionScope, productRegion, productHandler.GetOwnershipHistory)
+			public.GET("/products/:id/lifecycle-history", regionScope, productRegion, productHandler.GetLifecycleHistory)
+			public.GET("/products/:id/neighbors", regionScope, productRegion, productHandler.GetProductNeighbors)
+			public.GET("/products/region/:region", regionScope, productHandler.GetProductsByRegion)
+			public.GET("/products/lifecycle/:stage", regionScope, productHandler.GetProductsByLifecycle)
+			public.GET("/products/risk/:riskBand", regionScope, productHandler.GetProductsByRiskBand)
 
 			// Metrics
 			public.GET("/metrics", metricsHandler.GetAllMetrics)
 			public.GET("/metrics/:id", metricsHandler.GetMetric)
-			public.GET("/products/:productId/metrics", metricsHandler.GetProductMetrics)
+			public.GET("/products/:id/metrics", regionScope, productRegion, metricsHandler.GetProductMetrics)
+			public.GET("/products/:id/metrics/anomalies", regionScope, productRegion, metricsHandler.GetProductMetricAnomalies)
+			public.GET("/products/:id/metrics/variance", regionScope, productRegion, metricsHandler.GetProductRevenueVariance)
 
 			// Readiness
 			public.GET("/readiness", readinessHandler.GetAllReadiness)
-			public.GET("/products/:productId/readiness", readinessHandler.GetProductReadiness)
+			public.GET("/products/:id/readiness", regionScope, productRegion, readinessHandler.GetProductReadiness)
+			public.GET("/products/:id/full-readiness", regionScope, productRegion, readinessHandler.GetFullReadiness)
+			public.GET("/products/:id/readiness/history", regionScope, productRegion, readinessHandler.GetReadinessHistory)
+			public.GET("/products/:id/readiness/components-history", regionScope, productRegion, readinessHandler.GetReadinessComponentsHistory)
+			public.GET("/products/:id/readiness/weekly", regionScope, productRegion, readinessHandler.GetWeeklyReadiness)
 
 			// Compliance
 			public.GET("/compliance", complianceHandler.GetAllCompliance)
+			public.GET("/compliance/expiring", complianceHandler.GetExpiringCompliance)
 			public.GET("/compliance/:id", complianceHandler.GetCompliance)
-			public.GET("/products/:productId/compliance", complianceHandler.GetProductCompliance)
+			public.GET("/products/:id/compliance", regionScope, productRegion, complianceHandler.GetProductCompliance)
+			public.GET("/products/:id/compliance/summary", regionScope, productRegion, complianceHandler.GetProductComplianceSummary)
 
 			// Partners
 			public.GET("/partners", partnersHandler.GetAllPartners)
+			public.GET("/partners/pipeline", partnersHandler.GetPartnerPipeline)
 			public.GET("/partners/:id", partnersHandler.GetPartner)
-			public.GET("/products/:productId/partners", partnersHandler.GetProductPartners)
+			public.GET("/products/:id/partners", regionScope, productRegion, partnersHandler.GetProductPartners)
+			public.GET("/products/:id/partners/enablement", regionScope, productRegion, partnersHandler.GetPartnerEnablement)
 
 			// Feedback
 			public.GET("/feedback", feedbackHandler.GetAllFeedback)
 			public.GET("/feedback/:id", feedbackHandler.GetFeedback)
 			public.GET("/feedback/summary", feedbackHandler.GetFeedbackSummary)
-			public.GET("/products/:productId/feedback", feedbackHandler.GetProductFeedback)
-			public.GET("/products/:productId/merchant-signal", feedbackHandler.GetMerchantSignal)
+			public.GET("/feedback/facets", feedbackHandler.GetFeedbackFacets)
+			public.GET("/products/:id/feedback", regionScope, productRegion, feedbackHandler.GetProductFeedback)
+			public.GET("/products/:id/merchant-signal", regionScope, productRegion, feedbackHandler.GetMerchantSignal)
 
 			// Predictions
 			public.GET("/predictions", predictionsHandler.GetAllPredictions)
-			public.GET("/products/:productId/predictions", predictionsHandler.GetProductPrediction)
-			public.GET("/products/:productId/predictions/history", predictionsHandler.GetProductPredictionHistory)
+			public.GET("/predictions/stale", predictionsHandler.GetStalePredictions)
+			public.GET("/products/:id/predictions", regionScope, productRegion, predictionsHandler.GetProductPrediction)
+			public.GET("/products/:id/predictions/history", regionScope, productRegion, predictionsHandler.GetProductPredictionHistory)
+			public.GET("/products/:id/prediction-features", regionScope, productRegion, predictionsHandler.GetPredictionFeatures)
 
 			// Actions
 			public.GET("/actions", actionsHandler.GetAllActions)
 			public.GET("/actions/:id", actionsHandler.GetAction)
-			public.GET("/products/:productId/actions", actionsHandler.GetProductActions)
+			public.GET("/actions/:id/comments", actionsHandler.GetActionComments)
+			public.GET("/products/:id/actions", regionScope, productRegion, actionsHandler.GetProductActions)
+			public.GET("/products/:id/actions/burndown", regionScope, productRegion, actionsHandler.GetProductActionBurndown)
+			public.GET("/products/:id/actions/assignee-workload", regionScope, productRegion, actionsHandler.GetAssigneeWorkload)
 
 			// Training
 			public.GET("/training", trainingHandler.GetAllTraining)
-			public.GET("/products/:productId/training", trainingHandler.GetProductTraining)
+			public.GET("/training/gaps", trainingHandler.GetTrainingGaps)
+			public.GET("/products/:id/training", regionScope, productRegion, trainingHandler.GetProductTraining)
 
 			// Market Evidence
 			public.GET("/market-evidence", marketEvidenceHandler.GetAllMarketEvidence)
-			public.GET("/products/:productId/market-evidence", marketEvidenceHandler.GetProductMarketEvidence)
+			public.GET("/products/:id/market-evidence", regionScope, productRegion, marketEvidenceHandler.GetProductMarketEvidence)
 
 			// Dependencies
 			public.GET("/dependencies", dependenciesHandler.GetAllDependencies)
 			public.GET("/dependencies/blocked", dependenciesHandler.GetBlockedDependencies)
+			public.GET("/dependencies/breached", dependenciesHandler.GetBreachedDependencies)
+			public.GET("/dependencies/graph", dependenciesHandler.GetDependencyGraph)
 			public.GET("/dependencies/summary", dependenciesHandler.GetDependencySummary)
-			public.GET("/products/:productId/dependencies", dependenciesHandler.GetProductDependencies)
+			public.GET("/products/:id/dependencies", regionScope, productRegion, dependenciesHandler.GetProductDependencies)
 
 			// Escalations (Governance Triggers)
 			public.GET("/escalations", escalationsHandler.GetAllEscalations)
 			public.GET("/escalations/summary", escalationsHandler.GetEscalationSummary)
-			public.GET("/products/:productId/escalation", escalationsHandler.GetProductEscalation)
+			public.GET("/escalations/:id/actions", escalationsHandler.GetEscalationActions)
+			public.GET("/products/:id/escalation", regionScope, productRegion, escalationsHandler.GetProductEscalation)
 
 			// Transition Readiness (BAU Handover)
-			public.GET("/products/:productId/transition", transitionHandler.GetProductTransitionReadiness)
-			public.GET("/products/:productId/transition/items", transitionHandler.GetTransitionItems)
+			public.GET("/products/:id/transition", regionScope, productRegion, transitionHandler.GetProductTransitionReadiness)
+			public.GET("/products/:id/transition/items", regionScope, productRegion, transitionHandler.GetTransitionItems)
 
 			// Data Freshness (Central Sync Status)
 			public.GET("/data-freshness", dataFreshnessHandler.GetAllDataFreshness)
 			public.GET("/data-freshness/summary", dataFreshnessHandler.GetDataFreshnessSummary)
-			public.GET("/products/:productId/data-freshness", dataFreshnessHandler.GetProductDataFreshness)
+			public.GET("/products/:id/data-freshness", regionScope, productRegion, dataFreshnessHandler.GetProductDataFreshness)
+			public.GET("/products/:id/health", regionScope, productRegion, productHealthHandler.GetProductHealth)
+
+			// Portfolio
+			public.GET("/portfolio/risk-index", portfolioHandler.GetRiskIndex)
+			public.GET("/dashboard", dashboardHandler.GetPortfolioDashboard)
 
 			// Profiles
 			public.GET("/profiles", profilesHandler.GetAllProfiles)
@@ -138,14 +269,20 @@ func SetupRouter(cfg *config.Config) *gin.Engine {
 		{
 			// Current user profile
 			protected.GET("/me", profilesHandler.GetCurrentProfile)
+			protected.POST("/auth/token", middleware.TokenIssueRateLimiter().RateLimit(), authHandler.IssueToken)
+			protected.GET("/me/dashboard", dashboardHandler.GetMyDashboard)
+			protected.GET("/me/attention", dashboardHandler.GetMyAttention)
 
 			// Feedback (users can create)
 			protected.POST("/feedback", feedbackHandler.CreateFeedback)
 
 			// Actions (users can create and update their own)
 			protected.POST("/actions", actionsHandler.CreateAction)
+			protected.PATCH("/actions/batch", actionsHandler.BatchUpdateActionStatus)
 			protected.PUT("/actions/:id", actionsHandler.UpdateAction)
 			protected.PATCH("/actions/:id", actionsHandler.UpdateAction)
+			protected.POST("/actions/:id/comments", actionsHandler.CreateActionComment)
+			protected.DELETE("/actions/:id/comments/:commentId", actionsHandler.DeleteActionComment)
 		}
 
 		// Admin routes (require admin role)
@@ -157,7 +294,15 @@ func SetupRouter(cfg *config.Config) *gin.Engine {
 			admin.POST("/products", productHandler.CreateProduct)
 			admin.PUT("/products/:id", productHandler.UpdateProduct)
 			admin.PATCH("/products/:id", productHandler.UpdateProduct)
+			admin.POST("/products/:id/delete-preview", productHandler.PreviewDeleteProduct)
 			admin.DELETE("/products/:id", productHandler.DeleteProduct)
+			admin.POST("/products/:id/transfer-ownership", productHandler.TransferOwnership)
+			admin.POST("/products/:id/archive", productHandler.ArchiveProduct)
+			admin.POST("/products/:id/unarchive", productHandler.UnarchiveProduct)
+			admin.POST("/products/:id/restore", productHandler.RestoreProduct)
+			admin.POST("/products/:id/clone", productHandler.CloneProduct)
+			admin.POST("/products/:id/tags", productHandler.AddProductTags)
+			admin.DELETE("/products/:id/tags/:tag", productHandler.RemoveProductTag)
 
 			// Metrics management
 			admin.POST("/metrics", metricsHandler.CreateMetric)
@@ -166,7 +311,9 @@ func SetupRouter(cfg *config.Config) *gin.Engine {
 			admin.DELETE("/metrics/:id", metricsHandler.DeleteMetric)
 
 			// Readiness management
-			admin.POST("/products/:productId/readiness", readinessHandler.CreateOrUpdateReadiness)
+			admin.POST("/products/:id/readiness", readinessHandler.CreateOrUpdateReadiness)
+			admin.POST("/readiness/snapshot", readinessHandler.SnapshotReadinessHistory)
+			admin.POST("/readiness/recompute", readinessHandler.RecomputeReadiness)
 			admin.PUT("/readiness/:id", readinessHandler.UpdateReadiness)
 			admin.PATCH("/readiness/:id", readinessHandler.UpdateReadiness)
 			admin.DELETE("/readiness/:id", readinessHandler.DeleteReadiness)
@@ -184,6 +331,7 @@ func SetupRouter(cfg *config.Config) *gin.Engine {
 			admin.DELETE("/partners/:id", partnersHandler.DeletePartner)
 
 			// Feedback management
+			admin.POST("/feedback/normalize-themes", feedbackHandler.NormalizeThemes)
 			admin.PUT("/feedback/:id", feedbackHandler.UpdateFeedback)
 			admin.PATCH("/feedback/:id", feedbackHandler.UpdateFeedback)
 			admin.DELETE("/feedback/:id", feedbackHandler.DeleteFeedback)
@@ -195,10 +343,11 @@ func SetupRouter(cfg *config.Config) *gin.Engine {
 			admin.DELETE("/predictions/:id", predictionsHandler.DeletePrediction)
 
 			// Actions management
+			admin.POST("/actions/notify-overdue", actionsHandler.NotifyOverdueActions)
 			admin.DELETE("/actions/:id", actionsHandler.DeleteAction)
 
 			// Training management
-			admin.POST("/products/:productId/training", trainingHandler.CreateOrUpdateTraining)
+			admin.POST("/products/:id/training", trainingHandler.CreateOrUpdateTraining)
 			admin.DELETE("/training/:id", trainingHandler.DeleteTraining)
 
 			// Market Evidence management
@@ -212,6 +361,18 @@ func SetupRouter(cfg *config.Config) *gin.Engine {
 			admin.PUT("/dependencies/:id", dependenciesHandler.UpdateDependency)
 			admin.PATCH("/dependencies/:id", dependenciesHandler.UpdateDependency)
 			admin.DELETE("/dependencies/:id", dependenciesHandler.DeleteDependency)
+			admin.POST("/dependencies/:id/archive", dependenciesHandler.ArchiveDependency)
+			admin.POST("/dependencies/:id/reactivate", dependenciesHandler.ReactivateDependency)
+			admin.POST("/dependencies/archive-resolved", dependenciesHandler.ArchiveResolvedDependencies)
+
+			// Escalation snapshots and rules
+			admin.POST("/escalations/snapshot", escalationsHandler.SnapshotEscalations)
+			admin.GET("/escalations/config", escalationsHandler.GetEscalationConfig)
+			admin.POST("/products/:id/escalations/acknowledge", escalationsHandler.AcknowledgeEscalation)
+			admin.PUT("/escalations/:id/resolve", escalationsHandler.ResolveEscalation)
+
+			// Portfolio snapshots
+			admin.POST("/portfolio/risk-index/snapshot", portfolioHandler.SnapshotRiskIndex)
 
 			// Transition items management
 			admin.POST("/transition/items", transitionHandler.CreateTransitionItem)
@@ -219,6 +380,23 @@ func SetupRouter(cfg *config.Config) *gin.Engine {
 			admin.PATCH("/transition/items/:id", transitionHandler.UpdateTransitionItem)
 			admin.DELETE("/transition/items/:id", transitionHandler.DeleteTransitionItem)
 
+			// Operational write volume
+			admin.GET("/admin/activity", activityHandler.GetActivity)
+
+			// Demo data for fresh environments; refused in production
+			admin.POST("/admin/seed", seedHandler.SeedDemoData)
+			admin.GET("/audit", auditHandler.GetAuditLogs)
+
+			// Outbound webhook subscriptions (inbound receivers share the
+			// /webhooks prefix but only take POSTs to fixed paths)
+			admin.GET("/webhooks", webhookSubscriptionsHandler.GetSubscriptions)
+			admin.POST("/webhooks", webhookSubscriptionsHandler.CreateSubscription)
+			admin.GET("/webhooks/:id", webhookSubscriptionsHandler.GetSubscription)
+			admin.PUT("/webhooks/:id", webhookSubscriptionsHandler.UpdateSubscription)
+			admin.PATCH("/webhooks/:id", webhookSubscriptionsHandler.UpdateSubscription)
+			admin.DELETE("/webhooks/:id", webhookSubscriptionsHandler.DeleteSubscription)
+			admin.GET("/webhooks/:id/deliveries", webhookSubscriptionsHandler.GetDeliveries)
+
 			// Profiles management
 			admin.POST("/profiles", profilesHandler.CreateProfile)
 			admin.PUT("/profiles/:id", profilesHandler.UpdateProfile)
@@ -0,0 +1,39 @@
+package routes
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pauly7610/studio-pilot-vision/backend/config"
+	"github.com/pauly7610/studio-pilot-vision/backend/startup"
+)
+
+func TestSetupRouter_RegistersRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := SetupRouter(config.Load(), nil)
+
+	registered := make(map[string]bool)
+	for _, route := range router.Routes() {
+		registered[route.Method+" "+route.Path] = true
+	}
+	for _, want := range []string{
+		"GET /api/v1/products/:id",
+		"GET /api/v1/products/:id/lifecycle-history",
+		"GET /api/v1/products/:id/readiness",
+		"POST /api/v1/products/:id/tags",
+	} {
+		if !registered[want] {
+			t.Errorf("route %s not registered", want)
+		}
+	}
+}
+
+func TestSetupRouter_PassesStartupRouteCheck(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	check := startup.CheckRoutes(SetupRouter(config.Load(), nil).Routes())
+	if !check.Passed {
+		t.Errorf("route check failed: %s", check.Detail)
+	}
+}
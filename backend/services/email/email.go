@@ -0,0 +1,104 @@
+// Package email sends plain-text notification emails, such as the digests
+// that remind assignees of overdue actions.
+package email
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Message is one plain-text email to a single recipient
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Notifier delivers email. Implementations should honour ctx cancellation.
+type Notifier interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// Config is the outbound mail server. Username and Password are optional;
+// without them mail is sent unauthenticated.
+type Config struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+// New returns an SMTP notifier, or a Noop one when no host is configured so
+// local development works without a mail server
+func New(cfg Config) Notifier {
+	if cfg.Host == "" {
+		return Noop{}
+	}
+	return NewSMTP(cfg)
+}
+
+// SMTP sends mail through an SMTP relay
+type SMTP struct {
+	cfg  Config
+	send func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error
+	now  func() time.Time
+}
+
+// NewSMTP returns a notifier for the relay in cfg
+func NewSMTP(cfg Config) *SMTP {
+	return &SMTP{cfg: cfg, send: smtp.SendMail, now: time.Now}
+}
+
+// Send delivers msg. net/smtp has no context support, so ctx is only checked
+// before connecting.
+func (s *SMTP) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if s.cfg.Username != "" {
+		auth = smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)
+	}
+	addr := net.JoinHostPort(s.cfg.Host, strconv.Itoa(s.cfg.Port))
+	if err := s.send(addr, auth, s.cfg.From, []string{msg.To}, s.format(msg)); err != nil {
+		return fmt.Errorf("send to %s: %w", msg.To, err)
+	}
+	return nil
+}
+
+// format renders msg as an RFC 5322 message. Line breaks are stripped from
+// header values so a subject or address cannot inject extra headers.
+func (s *SMTP) format(msg Message) []byte {
+	var b bytes.Buffer
+	header := func(name, value string) {
+		value = strings.NewReplacer("\r", "", "\n", " ").Replace(value)
+		fmt.Fprintf(&b, "%s: %s\r\n", name, value)
+	}
+	header("From", s.cfg.From)
+	header("To", msg.To)
+	header("Subject", msg.Subject)
+	header("Date", s.now().Format(time.RFC1123Z))
+	header("MIME-Version", "1.0")
+	header("Content-Type", "text/plain; charset=UTF-8")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(msg.Body, "\r\n", "\n"), "\n", "\r\n"))
+	return b.Bytes()
+}
+
+// Noop logs messages instead of sending them
+type Noop struct{}
+
+// Send logs that msg would have been sent
+func (Noop) Send(ctx context.Context, msg Message) error {
+	log.Printf("SMTP not configured; not sending %q to %s", msg.Subject, msg.To)
+	return nil
+}
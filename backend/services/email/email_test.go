@@ -0,0 +1,81 @@
+package email
+
+import (
+	"context"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNew(t *testing.T) {
+	if _, ok := New(Config{}).(Noop); !ok {
+		t.Error("expected a Noop notifier without an SMTP host")
+	}
+	if _, ok := New(Config{Host: "smtp.example.com", Port: 587}).(*SMTP); !ok {
+		t.Error("expected an SMTP notifier with a host")
+	}
+}
+
+func TestSMTP_Send(t *testing.T) {
+	var gotAddr, gotFrom string
+	var gotTo []string
+	var gotAuth smtp.Auth
+	var gotMsg []byte
+
+	notifier := NewSMTP(Config{Host: "smtp.example.com", Port: 2525, From: "pilot@example.com"})
+	notifier.now = func() time.Time { return time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC) }
+	notifier.send = func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error {
+		gotAddr, gotAuth, gotFrom, gotTo, gotMsg = addr, auth, from, to, msg
+		return nil
+	}
+
+	err := notifier.Send(context.Background(), Message{
+		To:      "ana@example.com",
+		Subject: "2 overdue actions\r\nBcc: evil@example.com",
+		Body:    "First line\nSecond line",
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	if gotAddr != "smtp.example.com:2525" {
+		t.Errorf("addr = %q", gotAddr)
+	}
+	if gotAuth != nil {
+		t.Error("expected no auth without a username")
+	}
+	if gotFrom != "pilot@example.com" || len(gotTo) != 1 || gotTo[0] != "ana@example.com" {
+		t.Errorf("envelope = %q -> %v", gotFrom, gotTo)
+	}
+
+	msg := string(gotMsg)
+	for _, want := range []string{
+		"From: pilot@example.com\r\n",
+		"To: ana@example.com\r\n",
+		"Subject: 2 overdue actions Bcc: evil@example.com\r\n",
+		"Date: Mon, 10 Mar 2025 09:00:00 +0000\r\n",
+		"\r\n\r\nFirst line\r\nSecond line",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("message missing %q:\n%s", want, msg)
+		}
+	}
+	if strings.Contains(msg, "\r\nBcc:") {
+		t.Errorf("subject injected a header:\n%s", msg)
+	}
+}
+
+func TestSMTP_SendCancelled(t *testing.T) {
+	notifier := NewSMTP(Config{Host: "smtp.example.com", Port: 587})
+	notifier.send = func(string, smtp.Auth, string, []string, []byte) error {
+		t.Fatal("send called after cancellation")
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := notifier.Send(ctx, Message{To: "ana@example.com"}); err == nil {
+		t.Error("expected an error for a cancelled context")
+	}
+}
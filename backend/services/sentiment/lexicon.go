@@ -0,0 +1,130 @@
+package sentiment
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Lexicon is the default Analyzer. It counts positive and negative words,
+// flipping a word that directly follows a negator ("not fast"), and picks the
+// theme whose keywords appear most often.
+type Lexicon struct {
+	positive map[string]bool
+	negative map[string]bool
+	themes   map[string][]string
+}
+
+// NewLexicon returns a Lexicon with the built-in word lists
+func NewLexicon() *Lexicon {
+	return &Lexicon{
+		positive: wordSet(positiveWords),
+		negative: wordSet(negativeWords),
+		themes:   themeKeywords,
+	}
+}
+
+var positiveWords = []string{
+	"excellent", "great", "good", "love", "loved", "amazing", "impressive",
+	"satisfied", "happy", "fast", "easy", "smooth", "smoothly", "reliable",
+	"helpful", "actionable", "valuable", "intuitive", "seamless", "improved",
+	"increased", "efficient", "clear", "stable", "accurate", "recommend",
+	"game-changing", "awesome", "perfect", "pleased",
+}
+
+var negativeWords = []string{
+	"bad", "poor", "slow", "broken", "crash", "crashes", "crashed", "bug",
+	"bugs", "error", "errors", "fail", "fails", "failed", "failure",
+	"incomplete", "confusing", "difficult", "hard", "delay", "delayed",
+	"delaying", "delays", "expensive", "frustrating", "frustrated", "hate",
+	"unreliable", "unstable", "outage", "missing", "unhappy", "disappointed",
+	"worse", "worst", "timeout", "timeouts", "complicated", "exceeding",
+}
+
+var negators = wordSet([]string{"not", "no", "never", "isn't", "wasn't", "don't", "doesn't", "didn't", "can't", "won't", "hardly"})
+
+// themeKeywords maps each normalized theme to the words that suggest it
+var themeKeywords = map[string][]string{
+	"performance":   {"performance", "latency", "slow", "fast", "speed", "timeout", "timeouts", "lag"},
+	"integration":   {"integration", "integrate", "api", "sdk", "oauth", "oauth2", "endpoint", "pos"},
+	"onboarding":    {"onboarding", "setup", "activation", "sla", "enrollment"},
+	"stability":     {"crash", "crashes", "crashed", "outage", "downtime", "bug", "bugs", "error", "errors", "unstable"},
+	"accuracy":      {"accuracy", "accurate", "false", "positive", "positives", "precision", "model"},
+	"pricing":       {"price", "pricing", "cost", "costs", "expensive", "fee", "fees"},
+	"usability":     {"ui", "ux", "usability", "intuitive", "confusing", "navigation", "interface"},
+	"documentation": {"documentation", "docs", "guide", "examples"},
+	"value":         {"value", "roi", "revenue", "retention", "insights", "business"},
+}
+
+// Analyze scores text between -1 and 1. Text with no sentiment words scores 0.
+func (l *Lexicon) Analyze(_ context.Context, text string) (Result, error) {
+	words := tokenize(text)
+
+	var positive, negative int
+	themeHits := map[string]int{}
+	for i, word := range words {
+		negated := i > 0 && negators[words[i-1]]
+		switch {
+		case l.positive[word] && !negated, l.negative[word] && negated:
+			positive++
+		case l.negative[word], l.positive[word]:
+			negative++
+		}
+
+		for theme, keywords := range l.themes {
+			for _, keyword := range keywords {
+				if word == keyword {
+					themeHits[theme]++
+				}
+			}
+		}
+	}
+
+	return Result{Score: score(positive, negative), Theme: topTheme(themeHits)}, nil
+}
+
+// score damps the positive/negative balance so one strong word does not read
+// as certainty: one positive word scores 0.5, three score 0.75
+func score(positive, negative int) float64 {
+	if positive == negative {
+		return 0
+	}
+	s := float64(positive-negative) / float64(positive+negative+1)
+	return math.Round(s*100) / 100
+}
+
+// topTheme returns the most-mentioned theme, alphabetically first on ties
+func topTheme(hits map[string]int) string {
+	themes := make([]string, 0, len(hits))
+	for theme := range hits {
+		themes = append(themes, theme)
+	}
+	sort.Slice(themes, func(i, j int) bool {
+		if hits[themes[i]] != hits[themes[j]] {
+			return hits[themes[i]] > hits[themes[j]]
+		}
+		return themes[i] < themes[j]
+	})
+	if len(themes) == 0 {
+		return ""
+	}
+	return themes[0]
+}
+
+// tokenize lowercases text and splits it into words, keeping apostrophes and
+// hyphens inside words
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\'' && r != '-'
+	})
+}
+
+func wordSet(words []string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, word := range words {
+		set[word] = true
+	}
+	return set
+}
@@ -0,0 +1,72 @@
+package sentiment
+
+import (
+	"context"
+	"testing"
+)
+
+func TestLexicon_Analyze(t *testing.T) {
+	lexicon := NewLexicon()
+
+	tests := []struct {
+		text      string
+		wantSign  int
+		wantTheme string
+	}{
+		{"API performance excellent, very satisfied with latency", 1, "performance"},
+		{"Insights are actionable and driving real business value", 1, "value"},
+		{"POS integration went smoothly, great documentation", 1, "integration"},
+		{"App crashes with errors on certain Android devices", -1, "stability"},
+		{"OAuth2 documentation incomplete, delaying integration timeline", -1, "integration"},
+		{"Checkout is not fast enough", -1, "performance"},
+		{"Setup was not difficult at all", 1, "onboarding"},
+		{"We met the team on Tuesday", 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, err := lexicon.Analyze(context.Background(), tt.text)
+			if err != nil {
+				t.Fatalf("Analyze: %v", err)
+			}
+			if got.Score < -1 || got.Score > 1 {
+				t.Errorf("score %v outside [-1, 1]", got.Score)
+			}
+			if sign := signOf(got.Score); sign != tt.wantSign {
+				t.Errorf("score %v has sign %d, want %d", got.Score, sign, tt.wantSign)
+			}
+			if got.Theme != tt.wantTheme {
+				t.Errorf("theme = %q, want %q", got.Theme, tt.wantTheme)
+			}
+		})
+	}
+}
+
+func TestScore_Damped(t *testing.T) {
+	tests := []struct {
+		positive, negative int
+		want               float64
+	}{
+		{0, 0, 0},
+		{1, 0, 0.5},
+		{3, 0, 0.75},
+		{0, 1, -0.5},
+		{2, 2, 0},
+		{2, 1, 0.25},
+	}
+	for _, tt := range tests {
+		if got := score(tt.positive, tt.negative); got != tt.want {
+			t.Errorf("score(%d, %d) = %v, want %v", tt.positive, tt.negative, got, tt.want)
+		}
+	}
+}
+
+func signOf(f float64) int {
+	switch {
+	case f > 0:
+		return 1
+	case f < 0:
+		return -1
+	}
+	return 0
+}
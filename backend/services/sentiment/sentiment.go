@@ -0,0 +1,20 @@
+// Package sentiment scores free-text feedback when callers do not supply a
+// sentiment score of their own.
+package sentiment
+
+import "context"
+
+// Result is the outcome of analyzing one piece of feedback
+type Result struct {
+	// Score runs from -1 (negative) to 1 (positive)
+	Score float64
+
+	// Theme is a coarse, normalized theme guess, or "" when none applies
+	Theme string
+}
+
+// Analyzer scores feedback text. Implementations backed by an external API
+// should honour ctx cancellation.
+type Analyzer interface {
+	Analyze(ctx context.Context, text string) (Result, error)
+}
@@ -0,0 +1,68 @@
+package startup
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCheckRoutes(t *testing.T) {
+	noop := func(*gin.Context) {}
+	routes := gin.RoutesInfo{
+		{Method: "GET", Path: "/health", HandlerFunc: noop},
+		{Method: "GET", Path: "/api/v1/escalations", HandlerFunc: nil},
+	}
+
+	check := checkRoutes(routes, []string{"GET /health", "GET /api/v1/escalations", "GET /api/v1/data-freshness"})
+	if check.Passed {
+		t.Fatal("expected routes check to fail")
+	}
+	want := "no handler for GET /api/v1/escalations; not registered: GET /api/v1/data-freshness"
+	if check.Detail != want {
+		t.Errorf("detail = %q, want %q", check.Detail, want)
+	}
+
+	check = checkRoutes(routes[:1], []string{"GET /health"})
+	if !check.Passed {
+		t.Errorf("expected routes check to pass, got %q", check.Detail)
+	}
+}
+
+func TestSetDraining(t *testing.T) {
+	if Draining() {
+		t.Fatal("expected a fresh server not to be draining")
+	}
+	SetDraining()
+	if !Draining() {
+		t.Error("expected SetDraining to mark the server as draining")
+	}
+}
+
+func TestReadiness(t *testing.T) {
+	ready := readiness([]Check{{Name: "database", Passed: true}, {Name: "migrations", Passed: true}})
+	if ready.Status != StatusReady {
+		t.Errorf("status = %q, want ready", ready.Status)
+	}
+	if ready.Version == "" || ready.Commit == "" {
+		t.Errorf("build info = %+v, want version and commit", ready.BuildInfo)
+	}
+
+	// No database connection in tests, so the live checks fail
+	ready = readiness([]Check{checkDatabase(), checkMigrations()})
+	if ready.Status != StatusUnhealthy {
+		t.Fatalf("status = %q, want unhealthy", ready.Status)
+	}
+	for _, check := range ready.Checks {
+		if check.Passed || check.Detail != "not connected" {
+			t.Errorf("check %s = %+v, want failed as not connected", check.Name, check)
+		}
+	}
+}
+
+func TestBuild(t *testing.T) {
+	defer func(version, commit string) { Version, Commit = version, commit }(Version, Commit)
+	Version, Commit = "v1.2.3", "abc123"
+	if got := Build(); got != (BuildInfo{Version: "v1.2.3", Commit: "abc123"}) {
+		t.Errorf("Build() = %+v", got)
+	}
+}